@@ -31,9 +31,11 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/backint"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/balanceirq"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/checkpermissions"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/configure"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/configurebackint"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/configureinstance"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/diskmapping"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/gcbdr/backup"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/gcbdr/discovery"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/hanachangedisktype"
@@ -42,18 +44,22 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/hanainsights"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/installbackint"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/instancemetadata"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/listsnapshots"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/logusage"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/maintenance"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/migratehanamonitoring"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/migratehmadashboards"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/performancediagnostics"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/processstatus"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/readmetrics"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/reliability"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/reloadsecrets"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/remotevalidation"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/service"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/supportbundle"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/systemdiscovery"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/testrule"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/validate"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime/version"
 	"github.com/GoogleCloudPlatform/sapagent/internal/startdaemon"
@@ -77,29 +83,35 @@ func registerSubCommands() {
 	scs := [...]subcommands.Command{
 		&backint.Backint{},
 		&balanceirq.BalanceIRQ{},
+		&checkpermissions.CheckPermissions{},
 		&configure.Configure{},
 		&configurebackint.ConfigureBackint{},
 		&configureinstance.ConfigureInstance{},
 		&backup.Backup{},
 		&discovery.Discovery{FSH: filesystem.Helper{}},
+		&diskmapping.DiskMapping{},
 		&hanachangedisktype.HanaChangeDiskType{},
 		&hanadiskbackup.Snapshot{},
 		&hanadiskrestore.Restorer{},
 		&hanainsights.HANAInsights{},
 		&installbackint.InstallBackint{},
 		&instancemetadata.InstanceMetadata{},
+		&listsnapshots.ListSnapshots{},
 		&logusage.LogUsage{},
 		&maintenance.Mode{},
 		&migratehanamonitoring.MigrateHANAMonitoring{},
 		&migratehmadashboards.MigrateHMADashboards{},
 		&performancediagnostics.Diagnose{},
+		&processstatus.ProcessStatus{},
 		&readmetrics.ReadMetrics{},
 		&reliability.Reliability{},
+		&reloadsecrets.ReloadSecrets{},
 		&remotevalidation.RemoteValidation{},
 		&service.Service{},
 		&startdaemon.Daemon{},
 		&supportbundle.SupportBundle{},
 		&systemdiscovery.SystemDiscovery{},
+		&testrule.TestRule{},
 		&validate.Validate{},
 		&version.Version{},
 
@@ -129,9 +141,17 @@ func main() {
 		Level:      zapcore.InfoLevel,
 		LogToCloud: true,
 	}
+	// The rotation policy applies to the one time execution log files, startdaemon reads it again
+	// from the configuration file passed in -config.
+	lp = configuration.ApplyLogRotation(lp, configuration.ReadLogRotation("", os.ReadFile))
 
 	cloudProps := &iipb.CloudProperties{}
-	if cp := metadataserver.FetchCloudProperties(); cp != nil {
+	// The retries and the secondary endpoint can be tuned through the environment for instances
+	// whose metadata server is slow to respond at boot, see metadataserver.FetchOptionsFromEnv.
+	// startdaemon retries with its -metadata-* flags when the properties could not be fetched.
+	fetchOpts := metadataserver.FetchOptionsFromEnv(runtime.GOOS, os.Getenv)
+	fetchOpts.BareMetal = configuration.ReadBareMetal("", os.ReadFile)
+	if cp := metadataserver.FetchCloudPropertiesWithOptions(fetchOpts); cp != nil {
 		cloudProps = &iipb.CloudProperties{
 			ProjectId:        cp.ProjectID,
 			InstanceId:       cp.InstanceID,
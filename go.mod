@@ -33,9 +33,11 @@ require (
   golang.org/x/exp v0.0.0-20230321023759-10a507213a29
   golang.org/x/oauth2 v0.17.0
   golang.org/x/sys v0.18.0
+  golang.org/x/time v0.5.0
   google.golang.org/api v0.168.0
   google.golang.org/genproto v0.0.0-20240205150955-31a09d347014
   google.golang.org/genproto/googleapis/api v0.0.0-20240205150955-31a09d347014
+  google.golang.org/grpc v1.62.0
   google.golang.org/protobuf v1.32.0
 )
 
@@ -78,10 +80,8 @@ require (
   golang.org/x/net v0.23.0 // indirect
   golang.org/x/sync v0.6.0 // indirect
   golang.org/x/text v0.14.0 // indirect
-  golang.org/x/time v0.5.0 // indirect
   google.golang.org/appengine v1.6.8 // indirect
   google.golang.org/genproto/googleapis/rpc v0.0.0-20240304161311-37d4d3c04a78 // indirect
-  google.golang.org/grpc v1.62.0 // indirect
   gopkg.in/yaml.v2 v2.4.0 // indirect
   mvdan.cc/sh/v3 v3.7.0 // indirect
 )
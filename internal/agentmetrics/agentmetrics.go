@@ -19,20 +19,28 @@ package agentmetrics
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"time"
 
+	metricpb "google.golang.org/genproto/googleapis/api/metric"
 	mpb "google.golang.org/genproto/googleapis/monitoring/v3"
 	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	"google.golang.org/protobuf/proto"
 	tspb "google.golang.org/protobuf/types/known/timestamppb"
 	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
 	"github.com/shirou/gopsutil/v3/process"
+	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
+	"github.com/GoogleCloudPlatform/sapagent/internal/drain"
 	"github.com/GoogleCloudPlatform/sapagent/internal/heartbeat"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	cfgpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
 	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
+	"github.com/GoogleCloudPlatform/sapagent/shared/gce/metadataserver"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 	"github.com/GoogleCloudPlatform/sapagent/shared/recovery"
 	"github.com/GoogleCloudPlatform/sapagent/shared/timeseries"
@@ -43,6 +51,25 @@ const (
 	agentCPU    = "/sap/agent/cpu/utilization"
 	agentMemory = "/sap/agent/memory/utilization"
 	agentHealth = "/sap/agent/health"
+	agentConfig = "/sap/agent/config"
+	// agentThrottled is the number of cloud monitoring requests waiting for the rate limit.
+	agentThrottled = "/sap/agent/monitoring/throttled_requests"
+	// agentDropped is the number of time series dropped because cloud monitoring rejected them.
+	agentDropped = "/sap/agent/monitoring/dropped_time_series"
+	// agentSendCount is the number of SendTimeSeries calls by subsystem and status.
+	agentSendCount = "/sap/agent/monitoring/send_count"
+	// agentSendLatency is the total time in seconds spent in SendTimeSeries calls by subsystem.
+	agentSendLatency = "/sap/agent/monitoring/send_latency"
+	// agentDrain is true while the agent is in drain mode.
+	agentDrain = "/sap/agent/drain_mode"
+	// agentClockSkew is the difference in seconds between the local clock and the metadata server clock.
+	agentClockSkew = "/sap/agent/clock_skew"
+
+	// clockSkewInterval is the interval at which the clock skew is collected, the clock drifts slowly
+	// so it is collected less often than the other agent metrics.
+	clockSkewInterval = 5 * time.Minute
+	// clockSkewWarnThreshold is the clock skew above which a warning is logged.
+	clockSkewWarnThreshold = 5 * time.Second
 )
 
 type (
@@ -59,7 +86,10 @@ type (
 		timeSeriesSubmitter     timeSeriesSubmitter
 		timeSeriesCreator       cloudmonitoring.TimeSeriesCreator
 		usageReader             usageReader
+		clockSkewReader         clockSkewReader
 		now                     now
+		configHash              string
+		startTime               *tspb.Timestamp
 		collectAndSubmitRoutine *recovery.RecoverableRoutine
 	}
 
@@ -72,6 +102,7 @@ type (
 		timeSeriesCreator   cloudmonitoring.TimeSeriesCreator
 		timeSeriesSubmitter timeSeriesSubmitter
 		usageReader         usageReader
+		clockSkewReader     clockSkewReader
 	}
 
 	// usage represents a snapshot of the agent process resource usage.
@@ -93,6 +124,9 @@ type (
 	// usageReader is a strategy through which agent process metrics can be read.
 	usageReader func(ctx context.Context) (usage, error)
 
+	// clockSkewReader is a strategy through which the skew of the local clock can be read.
+	clockSkewReader func() (time.Duration, error)
+
 	// now is a strategy for getting the current timestamp.
 	now func() *tspb.Timestamp
 )
@@ -109,6 +143,8 @@ func NewService(ctx context.Context, params Parameters) (*Service, error) {
 		timeSeriesCreator:   params.timeSeriesCreator,
 		timeSeriesSubmitter: params.timeSeriesSubmitter,
 		usageReader:         params.usageReader,
+		clockSkewReader:     params.clockSkewReader,
+		configHash:          configHash(params.Config),
 	}
 
 	if service.timeSeriesCreator == nil {
@@ -135,9 +171,14 @@ func NewService(ctx context.Context, params Parameters) (*Service, error) {
 		}
 	}
 
+	if service.clockSkewReader == nil {
+		service.clockSkewReader = metadataserver.FetchClockSkew
+	}
+
 	if service.now == nil {
 		service.now = tspb.Now
 	}
+	service.startTime = service.now()
 	return service, nil
 }
 
@@ -159,7 +200,7 @@ func (s *Service) Start(ctx context.Context) {
 		log.CtxLogger(ctx).Info("Agent process metrics not configured for collection")
 		return
 	}
-	log.CtxLogger(ctx).Info("Agent process metric collection beginning")
+	log.CtxLogger(ctx).Infow("Agent process metric collection beginning", "configHash", s.configHash)
 	s.collectAndSubmitRoutine = &recovery.RecoverableRoutine{
 		Routine:             collectAndSubmitLoop,
 		RoutineArg:          collectAndSubmitArgs{s: s},
@@ -192,6 +233,15 @@ func collectAndSubmitLoop(ctx context.Context, a any) {
 	healthTicker := time.NewTicker(healthInterval)
 	defer healthTicker.Stop()
 
+	// clockSkewTicker will signal when the clock skew is collected and submitted. Bare metal hosts
+	// have no metadata server to compare the clock against.
+	var clockSkewTick <-chan time.Time
+	if !args.s.config.GetBareMetal() {
+		clockSkewTicker := time.NewTicker(clockSkewInterval)
+		defer clockSkewTicker.Stop()
+		clockSkewTick = clockSkewTicker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -207,6 +257,11 @@ func collectAndSubmitLoop(ctx context.Context, a any) {
 			if err := args.s.collectAndSubmitMetrics(ctx); err != nil {
 				log.CtxLogger(ctx).Warnw("Failure during agent metrics collection and submission", "error", err)
 			}
+		case <-clockSkewTick:
+			log.CtxLogger(ctx).Debug("Collecting and submitting clock skew")
+			if err := args.s.collectAndSubmitClockSkew(ctx); err != nil {
+				log.CtxLogger(ctx).Warnw("Failure during clock skew collection and submission", "error", err)
+			}
 		}
 	}
 }
@@ -225,9 +280,10 @@ func (s *Service) collectHealthStatus(ctx context.Context) bool {
 }
 
 // collectAndSubmitHealth will orchestrate the collection of agent health metrics and submit them to cloud monitoring.
+// The configuration hash is submitted along with the health so that config drift is visible at the same cadence.
 func (s *Service) collectAndSubmitHealth(ctx context.Context) error {
 	healthy := s.collectHealthStatus(ctx)
-	timeSeries := s.createHealthTimeSeries(healthy)
+	timeSeries := append(s.createHealthTimeSeries(healthy), s.createConfigTimeSeries()...)
 	request := s.createTimeSeriesRequestFactory(timeSeries)
 	if err := s.timeSeriesSubmitter(ctx, request); err != nil {
 		return fmt.Errorf("failed submitting agent health to cloud monitoring: %v", err)
@@ -242,6 +298,12 @@ func (s *Service) collectAndSubmitMetrics(ctx context.Context) error {
 		return fmt.Errorf("failed collecting agent process metrics: %v", err)
 	}
 	timeSeries := s.createMetricTimeSeries(usage)
+	if s.config.GetCollectionConfiguration().GetMonitoringRequestsPerMinute() > 0 {
+		timeSeries = append(timeSeries, s.createThrottleTimeSeries(cloudmonitoring.ThrottledRequests())...)
+	}
+	timeSeries = append(timeSeries, s.createDroppedTimeSeries(cloudmonitoring.DroppedTimeSeries())...)
+	timeSeries = append(timeSeries, s.createSendStatsTimeSeries(cloudmonitoring.SendStatsBySubsystem())...)
+	timeSeries = append(timeSeries, s.createDrainTimeSeries(drain.Active())...)
 	request := s.createTimeSeriesRequestFactory(timeSeries)
 	if err := s.timeSeriesSubmitter(ctx, request); err != nil {
 		return fmt.Errorf("failed submitting agent metrics to cloud monitoring: %v", err)
@@ -249,6 +311,23 @@ func (s *Service) collectAndSubmitMetrics(ctx context.Context) error {
 	return nil
 }
 
+// collectAndSubmitClockSkew compares the local clock against the metadata server clock and submits
+// the skew to cloud monitoring.
+func (s *Service) collectAndSubmitClockSkew(ctx context.Context) error {
+	skew, err := s.clockSkewReader()
+	if err != nil {
+		return fmt.Errorf("failed collecting clock skew: %v", err)
+	}
+	if skew > clockSkewWarnThreshold || skew < -clockSkewWarnThreshold {
+		log.CtxLogger(ctx).Warnw("The local clock is skewed from the metadata server clock, check the time synchronization of the host", "skew", skew)
+	}
+	request := s.createTimeSeriesRequestFactory(s.createClockSkewTimeSeries(skew))
+	if err := s.timeSeriesSubmitter(ctx, request); err != nil {
+		return fmt.Errorf("failed submitting clock skew to cloud monitoring: %v", err)
+	}
+	return nil
+}
+
 // createTimeSeriesRequestFactory creates a time series request for cloud monitoring from TimeSeries instances.
 func (s *Service) createTimeSeriesRequestFactory(timeSeries []*mrpb.TimeSeries) *mpb.CreateTimeSeriesRequest {
 	projectID := s.config.GetCloudProperties().GetProjectId()
@@ -271,6 +350,130 @@ func (s *Service) createHealthTimeSeries(healthy bool) []*mrpb.TimeSeries {
 	return append(timeSeries, timeseries.BuildBool(params))
 }
 
+// createConfigTimeSeries constructs a gauge carrying the configuration hash and the agent version as labels.
+func (s *Service) createConfigTimeSeries() []*mrpb.TimeSeries {
+	params := timeseries.Params{
+		BareMetal:  s.config.BareMetal,
+		CloudProp:  timeseries.ConvertCloudProperties(s.config.GetCloudProperties()),
+		MetricType: metricURL + agentConfig,
+		MetricLabels: map[string]string{
+			"config_hash":   s.configHash,
+			"agent_version": configuration.AgentVersion,
+		},
+		Int64Value: 1,
+		Timestamp:  s.now(),
+	}
+	return []*mrpb.TimeSeries{timeseries.BuildInt(params)}
+}
+
+// createThrottleTimeSeries constructs a gauge of the cloud monitoring requests waiting for the
+// rate limit, these are sent directly rather than through the rate limited SendTimeSeries.
+func (s *Service) createThrottleTimeSeries(throttled int64) []*mrpb.TimeSeries {
+	params := timeseries.Params{
+		BareMetal:  s.config.BareMetal,
+		CloudProp:  timeseries.ConvertCloudProperties(s.config.GetCloudProperties()),
+		MetricType: metricURL + agentThrottled,
+		Int64Value: throttled,
+		Timestamp:  s.now(),
+	}
+	return []*mrpb.TimeSeries{timeseries.BuildInt(params)}
+}
+
+// createDroppedTimeSeries constructs a counter of the time series dropped since the agent started
+// because cloud monitoring rejected them as invalid.
+func (s *Service) createDroppedTimeSeries(dropped int64) []*mrpb.TimeSeries {
+	params := timeseries.Params{
+		BareMetal:  s.config.BareMetal,
+		CloudProp:  timeseries.ConvertCloudProperties(s.config.GetCloudProperties()),
+		MetricType: metricURL + agentDropped,
+		MetricKind: metricpb.MetricDescriptor_CUMULATIVE,
+		StartTime:  s.startTime,
+		Int64Value: dropped,
+		Timestamp:  s.now(),
+	}
+	return []*mrpb.TimeSeries{timeseries.BuildInt(params)}
+}
+
+// createSendStatsTimeSeries constructs counters of the cloud monitoring send calls and their
+// latency for each subsystem since the agent started.
+func (s *Service) createSendStatsTimeSeries(stats map[string]cloudmonitoring.SendStats) []*mrpb.TimeSeries {
+	subsystems := make([]string, 0, len(stats))
+	for subsystem := range stats {
+		subsystems = append(subsystems, subsystem)
+	}
+	sort.Strings(subsystems)
+
+	var timeSeries []*mrpb.TimeSeries
+	now := s.now()
+	for _, subsystem := range subsystems {
+		st := stats[subsystem]
+		for _, c := range []struct {
+			status string
+			count  int64
+		}{{"success", st.Successes}, {"error", st.Errors}} {
+			timeSeries = append(timeSeries, timeseries.BuildInt(timeseries.Params{
+				BareMetal:    s.config.BareMetal,
+				CloudProp:    timeseries.ConvertCloudProperties(s.config.GetCloudProperties()),
+				MetricType:   metricURL + agentSendCount,
+				MetricLabels: map[string]string{"subsystem": subsystem, "status": c.status},
+				MetricKind:   metricpb.MetricDescriptor_CUMULATIVE,
+				StartTime:    s.startTime,
+				Int64Value:   c.count,
+				Timestamp:    now,
+			}))
+		}
+		timeSeries = append(timeSeries, timeseries.BuildFloat64(timeseries.Params{
+			BareMetal:    s.config.BareMetal,
+			CloudProp:    timeseries.ConvertCloudProperties(s.config.GetCloudProperties()),
+			MetricType:   metricURL + agentSendLatency,
+			MetricLabels: map[string]string{"subsystem": subsystem},
+			MetricKind:   metricpb.MetricDescriptor_CUMULATIVE,
+			StartTime:    s.startTime,
+			Float64Value: st.Latency.Seconds(),
+			Timestamp:    now,
+		}))
+	}
+	return timeSeries
+}
+
+// createDrainTimeSeries constructs a gauge of whether the agent is in drain mode.
+func (s *Service) createDrainTimeSeries(draining bool) []*mrpb.TimeSeries {
+	params := timeseries.Params{
+		BareMetal:  s.config.BareMetal,
+		BoolValue:  draining,
+		CloudProp:  timeseries.ConvertCloudProperties(s.config.GetCloudProperties()),
+		MetricType: metricURL + agentDrain,
+		Timestamp:  s.now(),
+	}
+	return []*mrpb.TimeSeries{timeseries.BuildBool(params)}
+}
+
+// createClockSkewTimeSeries constructs a gauge of the clock skew in seconds.
+func (s *Service) createClockSkewTimeSeries(skew time.Duration) []*mrpb.TimeSeries {
+	params := timeseries.Params{
+		BareMetal:    s.config.BareMetal,
+		CloudProp:    timeseries.ConvertCloudProperties(s.config.GetCloudProperties()),
+		Float64Value: skew.Seconds(),
+		MetricType:   metricURL + agentClockSkew,
+		Timestamp:    s.now(),
+	}
+	return []*mrpb.TimeSeries{timeseries.BuildFloat64(params)}
+}
+
+// configHash returns a stable SHA-256 of the effective configuration. Cloud properties identify
+// the host rather than its configuration and are left out so that hashes can be compared across a fleet.
+func configHash(config *cfgpb.Configuration) string {
+	c := proto.Clone(config).(*cfgpb.Configuration)
+	c.CloudProperties = nil
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(c)
+	if err != nil {
+		log.Logger.Warnw("Could not marshal configuration for hashing", "error", err)
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
 // createMetricTimeSeries constructs TimeSeries instances from usage data.
 func (s *Service) createMetricTimeSeries(u usage) []*mrpb.TimeSeries {
 	timeSeries := make([]*mrpb.TimeSeries, 2)
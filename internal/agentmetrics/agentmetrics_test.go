@@ -33,6 +33,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
 	"github.com/GoogleCloudPlatform/sapagent/internal/heartbeat"
 	cfgpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
 	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
@@ -518,6 +519,170 @@ func TestDefaultTimeSeriesFactory_createsCorrectTimeSeriesForUsage(t *testing.T)
 	}
 }
 
+func TestConfigHash(t *testing.T) {
+	base := &cfgpb.Configuration{
+		CollectionConfiguration: &cfgpb.CollectionConfiguration{CollectProcessMetrics: true},
+		CloudProperties:         &ipb.CloudProperties{InstanceName: "instance-1"},
+	}
+	tests := []struct {
+		name     string
+		config   *cfgpb.Configuration
+		wantSame bool
+	}{
+		{
+			name: "SameConfiguration",
+			config: &cfgpb.Configuration{
+				CollectionConfiguration: &cfgpb.CollectionConfiguration{CollectProcessMetrics: true},
+				CloudProperties:         &ipb.CloudProperties{InstanceName: "instance-1"},
+			},
+			wantSame: true,
+		},
+		{
+			name: "DifferentHost",
+			config: &cfgpb.Configuration{
+				CollectionConfiguration: &cfgpb.CollectionConfiguration{CollectProcessMetrics: true},
+				CloudProperties:         &ipb.CloudProperties{InstanceName: "instance-2"},
+			},
+			wantSame: true,
+		},
+		{
+			name: "DifferentConfiguration",
+			config: &cfgpb.Configuration{
+				CollectionConfiguration: &cfgpb.CollectionConfiguration{CollectProcessMetrics: false},
+				CloudProperties:         &ipb.CloudProperties{InstanceName: "instance-1"},
+			},
+			wantSame: false,
+		},
+	}
+	want := configHash(base)
+	if want == "" {
+		t.Fatalf("configHash(%v) returned an empty hash", base)
+	}
+	for _, d := range tests {
+		t.Run(d.name, func(t *testing.T) {
+			got := configHash(d.config)
+			if (got == want) != d.wantSame {
+				t.Errorf("configHash(%v) = %s, base hash %s, want same: %t", d.config, got, want, d.wantSame)
+			}
+		})
+	}
+}
+
+func TestCreateConfigTimeSeries(t *testing.T) {
+	ctx := context.Background()
+	s := createService(ctx, basicParameters(), t)
+	got := s.createConfigTimeSeries()
+	if len(got) != 1 {
+		t.Fatalf("createConfigTimeSeries() returned %d time series, want 1", len(got))
+	}
+	if gotType := got[0].GetMetric().GetType(); gotType != metricURL+agentConfig {
+		t.Errorf("createConfigTimeSeries() metric type = %s, want %s", gotType, metricURL+agentConfig)
+	}
+	wantLabels := map[string]string{
+		"config_hash":   configHash(basicParameters().Config),
+		"agent_version": configuration.AgentVersion,
+	}
+	if diff := cmp.Diff(wantLabels, got[0].GetMetric().GetLabels()); diff != "" {
+		t.Errorf("createConfigTimeSeries() labels mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestCreateThrottleTimeSeries(t *testing.T) {
+	ctx := context.Background()
+	s := createService(ctx, basicParameters(), t)
+	got := s.createThrottleTimeSeries(3)
+	if len(got) != 1 {
+		t.Fatalf("createThrottleTimeSeries() returned %d time series, want 1", len(got))
+	}
+	if gotType := got[0].GetMetric().GetType(); gotType != metricURL+agentThrottled {
+		t.Errorf("createThrottleTimeSeries() metric type = %s, want %s", gotType, metricURL+agentThrottled)
+	}
+	if gotValue := got[0].GetPoints()[0].GetValue().GetInt64Value(); gotValue != 3 {
+		t.Errorf("createThrottleTimeSeries() value = %d, want 3", gotValue)
+	}
+}
+
+func TestCreateDroppedTimeSeries(t *testing.T) {
+	ctx := context.Background()
+	s := createService(ctx, basicParameters(), t)
+	got := s.createDroppedTimeSeries(2)
+	if len(got) != 1 {
+		t.Fatalf("createDroppedTimeSeries() returned %d time series, want 1", len(got))
+	}
+	if gotType := got[0].GetMetric().GetType(); gotType != metricURL+agentDropped {
+		t.Errorf("createDroppedTimeSeries() metric type = %s, want %s", gotType, metricURL+agentDropped)
+	}
+	if gotKind := got[0].GetMetricKind(); gotKind != metricpb.MetricDescriptor_CUMULATIVE {
+		t.Errorf("createDroppedTimeSeries() metric kind = %v, want %v", gotKind, metricpb.MetricDescriptor_CUMULATIVE)
+	}
+	if gotValue := got[0].GetPoints()[0].GetValue().GetInt64Value(); gotValue != 2 {
+		t.Errorf("createDroppedTimeSeries() value = %d, want 2", gotValue)
+	}
+}
+
+func TestCreateDrainTimeSeries(t *testing.T) {
+	ctx := context.Background()
+	s := createService(ctx, basicParameters(), t)
+	got := s.createDrainTimeSeries(true)
+	if len(got) != 1 {
+		t.Fatalf("createDrainTimeSeries() returned %d time series, want 1", len(got))
+	}
+	if gotType := got[0].GetMetric().GetType(); gotType != metricURL+agentDrain {
+		t.Errorf("createDrainTimeSeries() metric type = %s, want %s", gotType, metricURL+agentDrain)
+	}
+	if gotValue := got[0].GetPoints()[0].GetValue().GetBoolValue(); !gotValue {
+		t.Errorf("createDrainTimeSeries() value = %t, want true", gotValue)
+	}
+}
+
+func TestCreateClockSkewTimeSeries(t *testing.T) {
+	ctx := context.Background()
+	s := createService(ctx, basicParameters(), t)
+	got := s.createClockSkewTimeSeries(-1500 * time.Millisecond)
+	if len(got) != 1 {
+		t.Fatalf("createClockSkewTimeSeries() returned %d time series, want 1", len(got))
+	}
+	if gotType := got[0].GetMetric().GetType(); gotType != metricURL+agentClockSkew {
+		t.Errorf("createClockSkewTimeSeries() metric type = %s, want %s", gotType, metricURL+agentClockSkew)
+	}
+	if gotValue := got[0].GetPoints()[0].GetValue().GetDoubleValue(); gotValue != -1.5 {
+		t.Errorf("createClockSkewTimeSeries() value = %v, want -1.5", gotValue)
+	}
+}
+
+func TestCreateSendStatsTimeSeries(t *testing.T) {
+	ctx := context.Background()
+	s := createService(ctx, basicParameters(), t)
+	got := s.createSendStatsTimeSeries(map[string]cloudmonitoring.SendStats{
+		cloudmonitoring.SubsystemProcess: {Successes: 3, Errors: 1, Latency: 1500 * time.Millisecond},
+	})
+	if len(got) != 3 {
+		t.Fatalf("createSendStatsTimeSeries() returned %d time series, want 3", len(got))
+	}
+	for _, ts := range got {
+		if gotSubsystem := ts.GetMetric().GetLabels()["subsystem"]; gotSubsystem != cloudmonitoring.SubsystemProcess {
+			t.Errorf("createSendStatsTimeSeries() subsystem label = %s, want %s", gotSubsystem, cloudmonitoring.SubsystemProcess)
+		}
+		if gotKind := ts.GetMetricKind(); gotKind != metricpb.MetricDescriptor_CUMULATIVE {
+			t.Errorf("createSendStatsTimeSeries() metric kind = %v, want %v", gotKind, metricpb.MetricDescriptor_CUMULATIVE)
+		}
+		value := ts.GetPoints()[0].GetValue()
+		switch ts.GetMetric().GetType() {
+		case metricURL + agentSendCount:
+			want := map[string]int64{"success": 3, "error": 1}[ts.GetMetric().GetLabels()["status"]]
+			if value.GetInt64Value() != want {
+				t.Errorf("createSendStatsTimeSeries() count for status %s = %d, want %d", ts.GetMetric().GetLabels()["status"], value.GetInt64Value(), want)
+			}
+		case metricURL + agentSendLatency:
+			if value.GetDoubleValue() != 1.5 {
+				t.Errorf("createSendStatsTimeSeries() latency = %f, want 1.5", value.GetDoubleValue())
+			}
+		default:
+			t.Errorf("createSendStatsTimeSeries() unexpected metric type %s", ts.GetMetric().GetType())
+		}
+	}
+}
+
 func TestCollectHealthStatus_shouldIndicateUnhealthyIfAnyServiceIsUnhealthy(t *testing.T) {
 	testData := []struct {
 		name     string
@@ -690,6 +855,59 @@ func TestCollectAndSubmitMetrics_shouldReturnErrorUsageReaderFails(t *testing.T)
 	}
 }
 
+func TestCollectAndSubmitClockSkew(t *testing.T) {
+	testData := []struct {
+		name      string
+		skew      time.Duration
+		readErr   error
+		submitErr error
+		want      error
+	}{
+		{
+			name: "succeeds",
+			skew: time.Second,
+		},
+		{
+			name: "skew above warning threshold",
+			skew: time.Minute,
+		},
+		{
+			name:    "read fails",
+			readErr: errors.New("intentional failure"),
+			want:    cmpopts.AnyError,
+		},
+		{
+			name:      "submit fails",
+			submitErr: errors.New("intentional failure"),
+			want:      cmpopts.AnyError,
+		},
+	}
+	for _, d := range testData {
+		t.Run(d.name, func(t *testing.T) {
+			ctx := context.Background()
+			params := basicParameters()
+			params.clockSkewReader = func() (time.Duration, error) { return d.skew, d.readErr }
+			submitCount := 0
+			params.timeSeriesSubmitter = func(ctx context.Context, request *mpb.CreateTimeSeriesRequest) error {
+				submitCount++
+				return d.submitErr
+			}
+			s := createService(ctx, params, t)
+			got := s.collectAndSubmitClockSkew(ctx)
+			if !cmp.Equal(got, d.want, cmpopts.EquateErrors()) {
+				t.Errorf("collectAndSubmitClockSkew() = %v, want %v", got, d.want)
+			}
+			wantSubmits := 1
+			if d.readErr != nil {
+				wantSubmits = 0
+			}
+			if submitCount != wantSubmits {
+				t.Errorf("collectAndSubmitClockSkew() submitted %d requests, want %d", submitCount, wantSubmits)
+			}
+		})
+	}
+}
+
 func TestCollectAndSubmitMetrics_shouldFailWhenSubmitFails(t *testing.T) {
 	ctx := context.Background()
 	params := basicParameters()
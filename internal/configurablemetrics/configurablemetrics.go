@@ -41,6 +41,19 @@ type Output struct {
 	ExitCode string
 }
 
+// Explanation holds the inputs read to evaluate a metric and the result of the evaluation.
+type Explanation struct {
+	Label string
+	// Source is the command line that was executed or the path of the file that was read.
+	Source string
+	// Input is the command output, or the file line that resolved the evaluation rules as true.
+	Input  Output
+	Value  string
+	Result bool
+	// Skipped holds the reason the metric was not evaluated, it is empty for evaluated metrics.
+	Skipped string
+}
+
 // FileReader abstracts loading and reading files into an io.ReadCloser object.
 type FileReader func(string) (io.ReadCloser, error)
 
@@ -61,14 +74,30 @@ func BuildMetricMap(metrics []*cmpb.EvalMetric) map[string]string {
 // be run if the system is using the same vendor. Otherwise, the metric should
 // be excluded from collection.
 func CollectOSCommandMetric(ctx context.Context, m *cmpb.OSCommandMetric, exec commandlineexecutor.Execute, vendor string) (label, value string) {
+	e := ExplainOSCommandMetric(ctx, m, exec, vendor)
+	if e.Skipped != "" {
+		return "", ""
+	}
+	return e.Label, e.Value
+}
+
+// ExplainOSCommandMetric executes a command, evaluates the output, and returns
+// the command output along with the result of the evaluation.
+func ExplainOSCommandMetric(ctx context.Context, m *cmpb.OSCommandMetric, exec commandlineexecutor.Execute, vendor string) Explanation {
+	e := Explanation{
+		Label:  m.GetMetricInfo().GetLabel(),
+		Source: strings.Join(append([]string{m.GetCommand()}, m.GetArgs()...), " "),
+	}
 	osVendor := m.GetOsVendor()
 	switch {
 	case osVendor == cmpb.OSVendor_RHEL && vendor != "rhel":
 		log.CtxLogger(ctx).Warnw(fmt.Sprintf("Skip metric collection, OS vendor of %q not detected for this system", cmpb.OSVendor_RHEL.String()), "vendor", vendor, "metric", m)
-		return "", ""
+		e.Skipped = fmt.Sprintf("OS vendor of %q not detected for this system", cmpb.OSVendor_RHEL.String())
+		return e
 	case osVendor == cmpb.OSVendor_SLES && vendor != "sles":
 		log.CtxLogger(ctx).Warnw(fmt.Sprintf("Skip metric collection, OS vendor of %q not detected for this system", cmpb.OSVendor_SLES.String()), "vendor", vendor, "metric", m)
-		return "", ""
+		e.Skipped = fmt.Sprintf("OS vendor of %q not detected for this system", cmpb.OSVendor_SLES.String())
+		return e
 	}
 
 	result := exec(ctx, commandlineexecutor.Params{
@@ -76,33 +105,57 @@ func CollectOSCommandMetric(ctx context.Context, m *cmpb.OSCommandMetric, exec c
 		Args:       m.GetArgs(),
 	})
 
-	label = m.GetMetricInfo().GetLabel()
-	value, _ = Evaluate(ctx, m, Output{
+	e.Input = Output{
 		StdOut:   strings.TrimSpace(result.StdOut),
 		StdErr:   strings.TrimSpace(result.StdErr),
 		ExitCode: strconv.Itoa(result.ExitCode),
-	})
-	return label, value
+	}
+	e.Value, e.Result = Evaluate(ctx, m, e.Input)
+	return e
 }
 
 // CollectMetricsFromFile scans a configuration file and returns a map
 // of collected metric values, keyed by metric label.
 func CollectMetricsFromFile(ctx context.Context, reader FileReader, path string, metrics []*cmpb.EvalMetric) map[string]string {
 	labels := BuildMetricMap(metrics)
+	for _, e := range ExplainMetricsFromFile(ctx, reader, path, metrics) {
+		if e.Skipped == "" {
+			labels[e.Label] = e.Value
+		}
+	}
+	return labels
+}
+
+// ExplainMetricsFromFile scans a configuration file and returns, for each metric,
+// the line that resolved its evaluation rules as true along with the result of the evaluation.
+func ExplainMetricsFromFile(ctx context.Context, reader FileReader, path string, metrics []*cmpb.EvalMetric) []Explanation {
+	explanations := make([]Explanation, len(metrics))
+	for i, m := range metrics {
+		explanations[i] = Explanation{Label: m.GetMetricInfo().GetLabel(), Source: path}
+	}
 	if len(metrics) == 0 {
-		return labels
+		return explanations
 	}
 
 	file, err := reader(path)
 	if err != nil {
 		log.CtxLogger(ctx).Warnw("Could not read the file", "path", path, "error", err)
-		return labels
+		for i := range explanations {
+			explanations[i].Skipped = fmt.Sprintf("could not read the file: %v", err)
+		}
+		return explanations
 	}
 	defer file.Close()
 
-	metricsByLabel := make(map[string]*cmpb.EvalMetric, len(metrics))
-	for _, m := range metrics {
-		metricsByLabel[m.GetMetricInfo().GetLabel()] = m
+	// Only the last metric defined for a label is evaluated.
+	metricsByLabel := make(map[string]int, len(metrics))
+	for i, m := range metrics {
+		metricsByLabel[m.GetMetricInfo().GetLabel()] = i
+	}
+	for i, m := range metrics {
+		if metricsByLabel[m.GetMetricInfo().GetLabel()] != i {
+			explanations[i].Skipped = "overridden by a later metric with the same label"
+		}
 	}
 
 	scanner := bufio.NewScanner(file)
@@ -111,12 +164,13 @@ func CollectMetricsFromFile(ctx context.Context, reader FileReader, path string,
 			break
 		}
 		line := strings.TrimSpace(scanner.Text())
-		for l, m := range metricsByLabel {
-			v, ok := Evaluate(ctx, m, Output{StdOut: line})
-			labels[l] = v
+		for l, i := range metricsByLabel {
+			e := &explanations[i]
+			e.Value, e.Result = Evaluate(ctx, metrics[i], Output{StdOut: line})
 			// For a result that evaluates as true, do not attempt to collect this metric again.
 			// This assumes that at most one metric will be collected per line scanned.
-			if ok {
+			if e.Result {
+				e.Input = Output{StdOut: line}
 				delete(metricsByLabel, l)
 				break
 			}
@@ -127,7 +181,7 @@ func CollectMetricsFromFile(ctx context.Context, reader FileReader, path string,
 		log.CtxLogger(ctx).Warnw("Could not read the file", "path", path, "error", err)
 	}
 
-	return labels
+	return explanations
 }
 
 // Evaluate runs a series of evaluation rules against an Output source and
@@ -269,6 +269,138 @@ func TestCollectMetricsFromFile(t *testing.T) {
 	}
 }
 
+func TestExplainOSCommandMetric(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric *cmpb.OSCommandMetric
+		exec   commandlineexecutor.Execute
+		vendor string
+		want   Explanation
+	}{
+		{
+			name:   "OSVendorMismatch",
+			metric: defaultOSCommandMetric(cmpb.OSVendor_RHEL, cmpb.OutputSource_STDOUT, "bar"),
+			exec:   testCommandExecute("bar", "", nil),
+			vendor: "sles",
+			want: Explanation{
+				Label:   "foo",
+				Source:  "foo",
+				Skipped: `OS vendor of "RHEL" not detected for this system`,
+			},
+		},
+		{
+			name: "EvaluatedTrue",
+			metric: func() *cmpb.OSCommandMetric {
+				m := defaultOSCommandMetric(cmpb.OSVendor_ALL, cmpb.OutputSource_STDOUT, "bar")
+				m.Args = []string{"-a", "-b"}
+				return m
+			}(),
+			exec:   testCommandExecute("bar\n", "warning\n", nil),
+			vendor: "rhel",
+			want: Explanation{
+				Label:  "foo",
+				Source: "foo -a -b",
+				Input:  Output{StdOut: "bar", StdErr: "warning", ExitCode: "0"},
+				Value:  "foobar",
+				Result: true,
+			},
+		},
+		{
+			name:   "EvaluatedFalse",
+			metric: defaultOSCommandMetric(cmpb.OSVendor_ALL, cmpb.OutputSource_STDOUT, "bar"),
+			exec:   testCommandExecute("baz", "", nil),
+			vendor: "rhel",
+			want: Explanation{
+				Label:  "foo",
+				Source: "foo",
+				Input:  Output{StdOut: "baz", ExitCode: "0"},
+				Value:  "not foobar",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ExplainOSCommandMetric(context.Background(), test.metric, test.exec, test.vendor)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ExplainOSCommandMetric() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExplainMetricsFromFile(t *testing.T) {
+	literal := &cmpb.EvalResult{
+		EvalResultTypes: &cmpb.EvalResult_ValueFromLiteral{ValueFromLiteral: "Literal Value"},
+	}
+	tests := []struct {
+		name    string
+		reader  FileReader
+		path    string
+		metrics []*cmpb.EvalMetric
+		want    []Explanation
+	}{
+		{
+			name: "EmptyMetrics",
+			want: []Explanation{},
+		},
+		{
+			name:    "FileReadError",
+			metrics: []*cmpb.EvalMetric{andEvalMetricIfTrue(literal)},
+			reader: FileReader(func(data string) (io.ReadCloser, error) {
+				return nil, errors.New("error")
+			}),
+			path: "/etc/file",
+			want: []Explanation{{Label: "foo", Source: "/etc/file", Skipped: "could not read the file: error"}},
+		},
+		{
+			name:    "LineMatched",
+			metrics: []*cmpb.EvalMetric{andEvalMetricIfTrue(literal)},
+			reader: FileReader(func(data string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("first\n  foobar = 1  \nlast")), nil
+			}),
+			path: "/etc/file",
+			want: []Explanation{{
+				Label:  "foo",
+				Source: "/etc/file",
+				Input:  Output{StdOut: "foobar = 1"},
+				Value:  "Literal Value",
+				Result: true,
+			}},
+		},
+		{
+			name:    "NoLineMatched",
+			metrics: []*cmpb.EvalMetric{andEvalMetricIfTrue(literal)},
+			reader: FileReader(func(data string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("first\nlast")), nil
+			}),
+			path: "/etc/file",
+			want: []Explanation{{Label: "foo", Source: "/etc/file", Value: "Value is false"}},
+		},
+		{
+			name:    "DuplicateLabel",
+			metrics: []*cmpb.EvalMetric{andEvalMetricIfTrue(literal), andEvalMetricIfTrue(literal)},
+			reader: FileReader(func(data string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("foobar")), nil
+			}),
+			path: "/etc/file",
+			want: []Explanation{
+				{Label: "foo", Source: "/etc/file", Skipped: "overridden by a later metric with the same label"},
+				{Label: "foo", Source: "/etc/file", Input: Output{StdOut: "foobar"}, Value: "Literal Value", Result: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ExplainMetricsFromFile(context.Background(), test.reader, test.path, test.metrics)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ExplainMetricsFromFile(%v) mismatch (-want, +got):\n%s", test.metrics, diff)
+			}
+		})
+	}
+}
+
 func TestEvaluate(t *testing.T) {
 	tests := []struct {
 		name       string
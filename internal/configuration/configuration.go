@@ -28,8 +28,11 @@ import (
 
 	wpb "google.golang.org/protobuf/types/known/wrapperspb"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"go.uber.org/zap/zapcore"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/sapagent/shared/gce/metadataserver"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 
 	dpb "google.golang.org/protobuf/types/known/durationpb"
@@ -120,6 +123,49 @@ func ReadFromFile(path string, read ReadConfigFile) *cpb.Configuration {
 	return config
 }
 
+// ApplyMetadataOverride merges the configuration overrides read from the instance metadata over
+// the file based configuration. Fields set in the override take precedence over the file, repeated
+// and map fields set in the override replace the ones from the file instead of being appended to
+// them. A malformed override is logged and ignored.
+func ApplyMetadataOverride(config *cpb.Configuration, override string) *cpb.Configuration {
+	if strings.TrimSpace(override) == "" {
+		return config
+	}
+	overrideConfig := &cpb.Configuration{}
+	if err := protojson.Unmarshal([]byte(override), overrideConfig); err != nil {
+		// The override is not logged, it may hold secrets such as hana_db_password.
+		log.Logger.Errorw("Invalid configuration override in instance metadata, ignoring it", "key", metadataserver.AgentConfigKey, "error", err)
+		return config
+	}
+	if config == nil {
+		config = &cpb.Configuration{}
+	}
+
+	var fields []string
+	overrideConfig.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, string(fd.Name()))
+		return true
+	})
+	clearOverriddenLists(config.ProtoReflect(), overrideConfig.ProtoReflect())
+	proto.Merge(config, overrideConfig)
+	log.Logger.Infow("Applied configuration overrides from instance metadata", "fields", fields)
+	return config
+}
+
+// clearOverriddenLists clears the repeated and map fields of dst which are set in src, descending
+// into the nested messages set in both, so that proto.Merge replaces them rather than appending.
+func clearOverriddenLists(dst, src protoreflect.Message) {
+	src.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() || fd.IsMap():
+			dst.Clear(fd)
+		case fd.Message() != nil && dst.Has(fd):
+			clearOverriddenLists(dst.Mutable(fd).Message(), v.Message())
+		}
+		return true
+	})
+}
+
 // LogLevelToZapcore returns the zapcore equivalent of the configuration log level.
 func LogLevelToZapcore(level cpb.Configuration_LogLevel) zapcore.Level {
 	switch level {
@@ -137,6 +183,49 @@ func LogLevelToZapcore(level cpb.Configuration_LogLevel) zapcore.Level {
 	}
 }
 
+// ReadLogRotation returns the log rotation policy from the configuration file at path, or at the
+// default path when path is empty as in ReadFromFile. It is read before logging is set up, so a
+// missing or malformed file is not reported and the default limits are used.
+func ReadLogRotation(path string, read ReadConfigFile) *cpb.LogRotation {
+	return readQuietly(path, read).GetLogRotation()
+}
+
+// ReadBareMetal reports whether the configuration file sets bare_metal, for the cloud properties
+// fetch which happens before the configuration is read. An empty path reads the default
+// configuration file of the OS.
+func ReadBareMetal(path string, read ReadConfigFile) bool {
+	return readQuietly(path, read).GetBareMetal()
+}
+
+// readQuietly reads the configuration file without validating it or logging errors, it returns
+// nil when the file cannot be read or parsed.
+func readQuietly(path string, read ReadConfigFile) *cpb.Configuration {
+	p := path
+	if len(p) == 0 {
+		p = LinuxConfigPath
+		if ros == "windows" {
+			p = WindowsConfigPath
+		}
+	}
+	content, err := read(p)
+	if err != nil || len(content) == 0 {
+		return nil
+	}
+	config := &cpb.Configuration{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(content, config); err != nil {
+		return nil
+	}
+	return config
+}
+
+// ApplyLogRotation sets the rotation policy of the log file in the logging parameters.
+func ApplyLogRotation(lp log.Parameters, rotation *cpb.LogRotation) log.Parameters {
+	lp.MaxSizeMB = int(rotation.GetMaxSizeMb())
+	lp.MaxAgeDays = int(rotation.GetMaxAgeDays())
+	lp.MaxBackups = int(rotation.GetMaxBackups())
+	return lp
+}
+
 // ApplyDefaults will apply the default configuration settings to the configuration passed.
 // The defaults are set only if the values passed are UNDEFINED or invalid.
 func ApplyDefaults(configFromFile *cpb.Configuration, cloudProps *iipb.CloudProperties) *cpb.Configuration {
@@ -174,6 +263,7 @@ func ApplyDefaults(configFromFile *cpb.Configuration, cloudProps *iipb.CloudProp
 		}
 	}
 
+	applyServiceSwitches(config)
 	config.CollectionConfiguration = applyDefaultCollectionConfiguration(config.GetCollectionConfiguration())
 	config.HanaMonitoringConfiguration = applyDefaultHMConfiguration(config.GetHanaMonitoringConfiguration())
 	config.DiscoveryConfiguration = applyDefaultDiscoveryConfiguration(config.GetDiscoveryConfiguration())
@@ -182,6 +272,32 @@ func ApplyDefaults(configFromFile *cpb.Configuration, cloudProps *iipb.CloudProp
 	return config
 }
 
+// applyServiceSwitches copies the master switches which are set to the options of their service,
+// so that a switch takes precedence over the configuration of the service.
+func applyServiceSwitches(config *cpb.Configuration) {
+	if config.GetCollectHostMetrics() != nil {
+		config.ProvideSapHostAgentMetrics = &wpb.BoolValue{Value: config.GetCollectHostMetrics().GetValue()}
+	}
+	if config.GetCollectProcessMetrics() != nil {
+		if config.GetCollectionConfiguration() == nil {
+			config.CollectionConfiguration = &cpb.CollectionConfiguration{}
+		}
+		config.CollectionConfiguration.CollectProcessMetrics = config.GetCollectProcessMetrics().GetValue()
+	}
+	if config.GetCollectHanaMonitoring() != nil {
+		if config.GetHanaMonitoringConfiguration() == nil {
+			config.HanaMonitoringConfiguration = &cpb.HANAMonitoringConfiguration{}
+		}
+		config.HanaMonitoringConfiguration.Enabled = config.GetCollectHanaMonitoring().GetValue()
+	}
+	if config.GetEnableDiscovery() != nil {
+		if config.GetDiscoveryConfiguration() == nil {
+			config.DiscoveryConfiguration = &cpb.DiscoveryConfiguration{}
+		}
+		config.DiscoveryConfiguration.EnableDiscovery = &wpb.BoolValue{Value: config.GetEnableDiscovery().GetValue()}
+	}
+}
+
 func applyDefaultCollectionConfiguration(configFromFile *cpb.CollectionConfiguration) *cpb.CollectionConfiguration {
 	cc := configFromFile
 	if cc == nil {
@@ -371,15 +487,19 @@ func applyOverrides(defaultHMQueriesList, customHMQueriesList []*cpb.Query) []*c
 				break
 			}
 		}
-		if q.GetEnabled() {
-			result = append(result, q)
+		if !q.GetEnabled() {
+			log.Logger.Infow("Skipping disabled HANA Monitoring query", "query", q.GetName())
+			continue
 		}
+		result = append(result, q)
 	}
 	for _, query := range customHMQueriesList {
 		if !strings.HasPrefix(query.GetName(), "default_") {
-			if query.GetEnabled() {
-				result = append(result, query)
+			if !query.GetEnabled() {
+				log.Logger.Infow("Skipping disabled HANA Monitoring query", "query", query.GetName())
+				continue
 			}
+			result = append(result, query)
 		}
 	}
 	return result
@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/testing/protocmp"
 	"go.uber.org/zap/zapcore"
 
@@ -74,6 +75,8 @@ var (
 	testConfigWithHANAMonitoringConfigJSON []byte
 	//go:embed testdata/systemConfig.json
 	testConfigWithSapSystemConfigJSON []byte
+	//go:embed defaultconfigs/hanamonitoring/default_queries.json
+	shippedHANAMonitoringQueriesJSON []byte
 )
 
 func TestReadFromFile(t *testing.T) {
@@ -894,6 +897,180 @@ func TestApplyOverrides(t *testing.T) {
 	}
 }
 
+func TestApplyServiceSwitches(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *cpb.Configuration
+		want   *cpb.Configuration
+	}{
+		{
+			name: "NoSwitches",
+			config: &cpb.Configuration{
+				ProvideSapHostAgentMetrics: &wpb.BoolValue{Value: true},
+				CollectionConfiguration:    &cpb.CollectionConfiguration{CollectProcessMetrics: true},
+			},
+			want: &cpb.Configuration{
+				ProvideSapHostAgentMetrics: &wpb.BoolValue{Value: true},
+				CollectionConfiguration:    &cpb.CollectionConfiguration{CollectProcessMetrics: true},
+			},
+		},
+		{
+			name: "SwitchesTakePrecedence",
+			config: &cpb.Configuration{
+				ProvideSapHostAgentMetrics:  &wpb.BoolValue{Value: true},
+				CollectionConfiguration:     &cpb.CollectionConfiguration{CollectProcessMetrics: true},
+				HanaMonitoringConfiguration: &cpb.HANAMonitoringConfiguration{Enabled: false, SampleIntervalSec: 300},
+				DiscoveryConfiguration:      &cpb.DiscoveryConfiguration{EnableDiscovery: &wpb.BoolValue{Value: true}},
+				CollectHostMetrics:          &wpb.BoolValue{Value: false},
+				CollectProcessMetrics:       &wpb.BoolValue{Value: false},
+				CollectHanaMonitoring:       &wpb.BoolValue{Value: true},
+				EnableDiscovery:             &wpb.BoolValue{Value: false},
+			},
+			want: &cpb.Configuration{
+				ProvideSapHostAgentMetrics:  &wpb.BoolValue{Value: false},
+				CollectionConfiguration:     &cpb.CollectionConfiguration{CollectProcessMetrics: false},
+				HanaMonitoringConfiguration: &cpb.HANAMonitoringConfiguration{Enabled: true, SampleIntervalSec: 300},
+				DiscoveryConfiguration:      &cpb.DiscoveryConfiguration{EnableDiscovery: &wpb.BoolValue{Value: false}},
+				CollectHostMetrics:          &wpb.BoolValue{Value: false},
+				CollectProcessMetrics:       &wpb.BoolValue{Value: false},
+				CollectHanaMonitoring:       &wpb.BoolValue{Value: true},
+				EnableDiscovery:             &wpb.BoolValue{Value: false},
+			},
+		},
+		{
+			name: "SwitchesCreateMissingConfigurations",
+			config: &cpb.Configuration{
+				CollectProcessMetrics: &wpb.BoolValue{Value: true},
+				CollectHanaMonitoring: &wpb.BoolValue{Value: true},
+				EnableDiscovery:       &wpb.BoolValue{Value: true},
+			},
+			want: &cpb.Configuration{
+				CollectionConfiguration:     &cpb.CollectionConfiguration{CollectProcessMetrics: true},
+				HanaMonitoringConfiguration: &cpb.HANAMonitoringConfiguration{Enabled: true},
+				DiscoveryConfiguration:      &cpb.DiscoveryConfiguration{EnableDiscovery: &wpb.BoolValue{Value: true}},
+				CollectProcessMetrics:       &wpb.BoolValue{Value: true},
+				CollectHanaMonitoring:       &wpb.BoolValue{Value: true},
+				EnableDiscovery:             &wpb.BoolValue{Value: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			applyServiceSwitches(test.config)
+			if diff := cmp.Diff(test.want, test.config, protocmp.Transform()); diff != "" {
+				t.Errorf("applyServiceSwitches() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyMetadataOverride(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *cpb.Configuration
+		override string
+		want     *cpb.Configuration
+	}{
+		{
+			name:   "NoOverride",
+			config: &cpb.Configuration{LogLevel: cpb.Configuration_INFO},
+			want:   &cpb.Configuration{LogLevel: cpb.Configuration_INFO},
+		},
+		{
+			name:     "MalformedOverride",
+			config:   &cpb.Configuration{LogLevel: cpb.Configuration_INFO},
+			override: `{"log_level": }`,
+			want:     &cpb.Configuration{LogLevel: cpb.Configuration_INFO},
+		},
+		{
+			name: "OverrideTakesPrecedence",
+			config: &cpb.Configuration{
+				LogLevel:                   cpb.Configuration_INFO,
+				ProvideSapHostAgentMetrics: &wpb.BoolValue{Value: true},
+			},
+			override: `{"log_level": "DEBUG", "collection_configuration": {"collect_process_metrics": true}}`,
+			want: &cpb.Configuration{
+				LogLevel:                   cpb.Configuration_DEBUG,
+				ProvideSapHostAgentMetrics: &wpb.BoolValue{Value: true},
+				CollectionConfiguration:    &cpb.CollectionConfiguration{CollectProcessMetrics: true},
+			},
+		},
+		{
+			name: "RepeatedFieldsReplaced",
+			config: &cpb.Configuration{
+				CollectionConfiguration: &cpb.CollectionConfiguration{
+					CollectProcessMetrics: true,
+					ProcessMetricsToSkip:  []string{"/sap/nw/abap/sessions"},
+				},
+			},
+			override: `{"collection_configuration": {"process_metrics_to_skip": ["/sap/hana/cpu/utilization"]}}`,
+			want: &cpb.Configuration{
+				CollectionConfiguration: &cpb.CollectionConfiguration{
+					CollectProcessMetrics: true,
+					ProcessMetricsToSkip:  []string{"/sap/hana/cpu/utilization"},
+				},
+			},
+		},
+		{
+			name:     "NilConfig",
+			override: `{"log_level": "DEBUG"}`,
+			want:     &cpb.Configuration{LogLevel: cpb.Configuration_DEBUG},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ApplyMetadataOverride(test.config, test.override)
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ApplyMetadataOverride(%v, %q) returned unexpected diff (-want +got):\n%s", test.config, test.override, diff)
+			}
+		})
+	}
+}
+
+func TestShippedHMQueries(t *testing.T) {
+	config := &cpb.HANAMonitoringConfiguration{}
+	if err := protojson.Unmarshal(shippedHANAMonitoringQueriesJSON, config); err != nil {
+		t.Fatalf("protojson.Unmarshal(default_queries.json) returned error: %v", err)
+	}
+	if !ValidateQueries(config.GetQueries()) {
+		t.Error("ValidateQueries(default_queries.json) = false, want: true")
+	}
+
+	wants := []*cpb.Query{
+		{
+			Name: "service_utilization_queries",
+			Sql:  "SELECT M.HOST AS host, M.PORT AS port, M.SERVICE_NAME AS service_name, M.TOTAL_MEMORY_USED_SIZE AS mem_used, S.PROCESS_CPU AS cpu_used FROM M_SERVICE_MEMORY M JOIN M_SERVICE_STATISTICS S ON M.HOST = S.HOST AND M.PORT = S.PORT;",
+			Columns: []*cpb.Column{
+				{Name: "host", MetricType: cpb.MetricType_METRIC_LABEL, ValueType: cpb.ValueType_VALUE_STRING},
+				{Name: "port", MetricType: cpb.MetricType_METRIC_LABEL, ValueType: cpb.ValueType_VALUE_STRING},
+				{Name: "service_name", MetricType: cpb.MetricType_METRIC_LABEL, ValueType: cpb.ValueType_VALUE_STRING},
+				{Name: "mem_used", NameOverride: "service/utilization/memory_used_size", MetricType: cpb.MetricType_METRIC_GAUGE, ValueType: cpb.ValueType_VALUE_INT64},
+				{Name: "cpu_used", NameOverride: "service/utilization/cpu_percent", MetricType: cpb.MetricType_METRIC_GAUGE, ValueType: cpb.ValueType_VALUE_DOUBLE},
+			},
+		},
+		{
+			Name: "license_queries",
+			Sql:  "SELECT (CASE WHEN PERMANENT = 'TRUE' OR EXPIRATION_DATE IS NULL THEN 99999 ELSE DAYS_BETWEEN(CURRENT_UTCDATE, EXPIRATION_DATE) END) AS days_until_expiry FROM M_LICENSE;",
+			Columns: []*cpb.Column{
+				{Name: "days_until_expiry", NameOverride: "license/days_until_expiry", MetricType: cpb.MetricType_METRIC_GAUGE, ValueType: cpb.ValueType_VALUE_INT64},
+			},
+		},
+	}
+	for _, want := range wants {
+		var got *cpb.Query
+		for _, q := range config.GetQueries() {
+			if q.GetName() == want.GetName() {
+				got = q
+			}
+		}
+		if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("default_queries.json returned unexpected diff for %s (-want +got):\n%s", want.GetName(), diff)
+		}
+	}
+}
+
 func TestValidateCustomQueries(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1236,6 +1413,132 @@ func TestLogLevelToZapcore(t *testing.T) {
 	}
 }
 
+func TestReadLogRotation(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		read ReadConfigFile
+		want *cpb.LogRotation
+	}{
+		{
+			name: "Configured",
+			read: func(string) ([]byte, error) {
+				return []byte(`{"log_level": "DEBUG", "log_rotation": {"max_size_mb": 100, "max_age_days": 7, "max_backups": 10}}`), nil
+			},
+			want: &cpb.LogRotation{MaxSizeMb: 100, MaxAgeDays: 7, MaxBackups: 10},
+		},
+		{
+			name: "ConfiguredAtPath",
+			path: "/etc/custom.json",
+			read: func(p string) ([]byte, error) {
+				if p != "/etc/custom.json" {
+					return nil, os.ErrNotExist
+				}
+				return []byte(`{"log_rotation": {"max_backups": 3}}`), nil
+			},
+			want: &cpb.LogRotation{MaxBackups: 3},
+		},
+		{
+			name: "NotConfigured",
+			read: func(string) ([]byte, error) {
+				return []byte(`{"log_level": "DEBUG"}`), nil
+			},
+		},
+		{
+			name: "ReadFailure",
+			read: func(string) ([]byte, error) {
+				return nil, os.ErrNotExist
+			},
+		},
+		{
+			name: "MalformedFile",
+			read: func(string) ([]byte, error) {
+				return []byte(`{"log_rotation": `), nil
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ReadLogRotation(test.path, test.read)
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ReadLogRotation() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadBareMetal(t *testing.T) {
+	tests := []struct {
+		name string
+		read ReadConfigFile
+		want bool
+	}{
+		{
+			name: "BareMetal",
+			read: func(string) ([]byte, error) {
+				return []byte(`{"bare_metal": true}`), nil
+			},
+			want: true,
+		},
+		{
+			name: "NotBareMetal",
+			read: func(string) ([]byte, error) {
+				return []byte(`{"log_level": "DEBUG"}`), nil
+			},
+		},
+		{
+			name: "ReadFailure",
+			read: func(string) ([]byte, error) {
+				return nil, os.ErrNotExist
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ReadBareMetal("", test.read); got != test.want {
+				t.Errorf("ReadBareMetal() = %t, want: %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestApplyLogRotation(t *testing.T) {
+	tests := []struct {
+		name     string
+		lp       log.Parameters
+		rotation *cpb.LogRotation
+		want     log.Parameters
+	}{
+		{
+			name:     "Configured",
+			lp:       log.Parameters{LogFileName: "/var/log/google-cloud-sap-agent.log"},
+			rotation: &cpb.LogRotation{MaxSizeMb: 100, MaxAgeDays: 7, MaxBackups: 10},
+			want: log.Parameters{
+				LogFileName: "/var/log/google-cloud-sap-agent.log",
+				MaxSizeMB:   100,
+				MaxAgeDays:  7,
+				MaxBackups:  10,
+			},
+		},
+		{
+			name: "NilRotationResetsPolicy",
+			lp:   log.Parameters{LogFileName: "/var/log/google-cloud-sap-agent.log", MaxSizeMB: 100},
+			want: log.Parameters{LogFileName: "/var/log/google-cloud-sap-agent.log"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ApplyLogRotation(test.lp, test.rotation)
+			if diff := cmp.Diff(test.want, got, cmpopts.IgnoreUnexported(log.Parameters{})); diff != "" {
+				t.Errorf("ApplyLogRotation(%v) returned unexpected diff (-want +got):\n%s", test.rotation, diff)
+			}
+		})
+	}
+}
+
 func TestValidateAgentConfiguration(t *testing.T) {
 	tests := []struct {
 		name   string
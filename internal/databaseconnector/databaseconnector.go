@@ -185,6 +185,44 @@ func NewCMDDBHandle(p Params) (handle *DBHandle, err error) {
 	}, nil
 }
 
+// CheckHDBUserKey verifies the hdbuserstore key is provisioned for the sidadm user of the SID
+// on this host, so a missing key is reported at connection time rather than on every query.
+func CheckHDBUserKey(ctx context.Context, sid, key string, exec commandlineexecutor.Execute) error {
+	sidadm := fmt.Sprintf("%sadm", strings.ToLower(sid))
+	result := exec(ctx, commandlineexecutor.Params{
+		Executable: "sudo",
+		Args:       []string{"-i", "-u", sidadm, "hdbuserstore", "list", key},
+	})
+	if result.Error != nil && !result.ExitStatusParsed {
+		return fmt.Errorf("could not list the hdbuserstore keys of %s: %v", sidadm, result.Error)
+	}
+	for _, line := range strings.Split(result.StdOut, "\n") {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(line), "KEY "); ok && strings.EqualFold(strings.TrimSpace(name), key) {
+			log.CtxLogger(ctx).Debugw("Found hdbuserstore key", "sidadm", sidadm, "key", key)
+			return nil
+		}
+	}
+	return fmt.Errorf("hdbuserstore key %q is not present for %s on this host, create it with 'hdbuserstore set' as %s", key, sidadm, sidadm)
+}
+
+// Stats returns the connection pool statistics of a handle using the go-hdb driver. The
+// boolean is false for handles querying with hdbsql, which do not keep connections open.
+func (db *DBHandle) Stats() (sql.DBStats, bool) {
+	if db.useCMD || db.goHDBHandle == nil {
+		return sql.DBStats{}, false
+	}
+	return db.goHDBHandle.Stats(), true
+}
+
+// Close closes the connection pool of a handle using the go-hdb driver. The queries in progress
+// finish and new queries fail. Closing a handle querying with hdbsql is a no-op.
+func (db *DBHandle) Close() error {
+	if db.useCMD || db.goHDBHandle == nil {
+		return nil
+	}
+	return db.goHDBHandle.Close()
+}
+
 // Query queries the database via the goHDB driver or command-line accordingly.
 func (db *DBHandle) Query(ctx context.Context, query string, exec commandlineexecutor.Execute) (*QueryResults, error) {
 	if !db.useCMD {
@@ -250,6 +250,68 @@ func TestQueryInvalid(t *testing.T) {
 	})
 }
 
+func TestClose(t *testing.T) {
+	goHandle, err := NewGoDBHandle(context.Background(), Params{Username: "fakeUser", Password: "fakePass", Host: "fakeHost", Port: "30015"})
+	if err != nil {
+		t.Fatalf("NewGoDBHandle() returned error: %v", err)
+	}
+	if err := goHandle.Close(); err != nil {
+		t.Errorf("Close() returned error: %v", err)
+	}
+	if _, err := goHandle.Query(context.Background(), "SELECT 1 FROM DUMMY", nil); err == nil {
+		t.Errorf("Query() after Close() returned nil error, want error")
+	}
+
+	cmdHandle, err := NewCMDDBHandle(Params{SID: "testSID", HDBUserKey: "testHDBUserKey"})
+	if err != nil {
+		t.Fatalf("NewCMDDBHandle() returned error: %v", err)
+	}
+	if err := cmdHandle.Close(); err != nil {
+		t.Errorf("Close() of an hdbsql handle returned error: %v", err)
+	}
+}
+
+func TestCheckHDBUserKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		fakeExec commandlineexecutor.Execute
+		wantErr  error
+	}{
+		{
+			name: "KeyPresent",
+			key:  "monitoringKey",
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "DATA FILE       : /usr/sap/HDB/home/.hdb/SSFS_HDB.DAT\n\nKEY MONITORINGKEY\n  ENV : hdbhost:30015\n  USER: MONITOR\n"}
+			},
+		},
+		{
+			name: "KeyMissing",
+			key:  "monitoringKey",
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "DATA FILE       : /usr/sap/HDB/home/.hdb/SSFS_HDB.DAT\n", ExitCode: 1, ExitStatusParsed: true}
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "CommandFails",
+			key:  "monitoringKey",
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: errors.New("sudo not found")}
+			},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotErr := CheckHDBUserKey(context.Background(), "HDB", test.key, test.fakeExec)
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("CheckHDBUserKey(%s)=%v, want %v", test.key, gotErr, test.wantErr)
+			}
+		})
+	}
+}
+
 func TestReadRow(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drain tracks the drain mode of the agent. While draining the services do not start
+// new collection cycles or discovery passes, the work already in progress is completed and the
+// agent idles until drain mode is exited.
+package drain
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+var (
+	mu     sync.Mutex
+	active bool
+	since  time.Time
+	// resumed is closed when drain mode is exited.
+	resumed = make(chan struct{})
+)
+
+// Active reports whether the agent is in drain mode.
+func Active() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return active
+}
+
+// Set enters drain mode when on is true and exits it otherwise.
+func Set(ctx context.Context, on bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	if on == active {
+		return
+	}
+	active = on
+	if on {
+		since = time.Now()
+		resumed = make(chan struct{})
+		log.CtxLogger(ctx).Info("Entering drain mode, in progress work is completed and no new collection cycles or discovery passes are started")
+		return
+	}
+	close(resumed)
+	log.CtxLogger(ctx).Infow("Exiting drain mode, resuming collection and discovery", "drained", time.Since(since).Round(time.Second))
+}
+
+// Toggle switches the drain mode and returns whether the agent is now draining.
+func Toggle(ctx context.Context) bool {
+	on := !Active()
+	Set(ctx, on)
+	return on
+}
+
+// Wait blocks while the agent is in drain mode. Returns false if the context is done first.
+func Wait(ctx context.Context) bool {
+	mu.Lock()
+	if !active {
+		mu.Unlock()
+		return true
+	}
+	ch := resumed
+	mu.Unlock()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-ch:
+		return true
+	}
+}
+
+// Start toggles drain mode each time the agent receives the drain signal, until the context is
+// done. Drain mode cannot be signaled on platforms without a drain signal.
+func Start(ctx context.Context) {
+	if len(signals) == 0 {
+		return
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+	go handleSignals(ctx, ch)
+}
+
+// handleSignals toggles drain mode for every signal received on the channel.
+func handleSignals(ctx context.Context, ch <-chan os.Signal) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-ch:
+			log.CtxLogger(ctx).Infow("Drain signal received", "signal", sig)
+			Toggle(ctx)
+		}
+	}
+}
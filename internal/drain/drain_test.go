@@ -0,0 +1,110 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+func TestMain(m *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(m.Run())
+}
+
+func TestSetAndToggle(t *testing.T) {
+	ctx := context.Background()
+	defer Set(ctx, false)
+
+	if Active() {
+		t.Fatalf("Active() = true before drain mode was entered, want false")
+	}
+	Set(ctx, true)
+	if !Active() {
+		t.Errorf("Active() after Set(true) = false, want true")
+	}
+	Set(ctx, true)
+	if !Active() {
+		t.Errorf("Active() after setting drain mode twice = false, want true")
+	}
+	if got := Toggle(ctx); got {
+		t.Errorf("Toggle() in drain mode = true, want false")
+	}
+	if got := Toggle(ctx); !got {
+		t.Errorf("Toggle() out of drain mode = false, want true")
+	}
+}
+
+func TestWait(t *testing.T) {
+	ctx := context.Background()
+	defer Set(ctx, false)
+
+	if !Wait(ctx) {
+		t.Errorf("Wait() out of drain mode = false, want true")
+	}
+
+	Set(ctx, true)
+	done := make(chan bool)
+	go func() { done <- Wait(ctx) }()
+	select {
+	case <-done:
+		t.Fatalf("Wait() returned in drain mode")
+	case <-time.After(50 * time.Millisecond):
+	}
+	Set(ctx, false)
+	select {
+	case got := <-done:
+		if !got {
+			t.Errorf("Wait() after drain mode was exited = false, want true")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Wait() did not return after drain mode was exited")
+	}
+}
+
+func TestWaitCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer Set(context.Background(), false)
+
+	Set(ctx, true)
+	cancel()
+	if Wait(ctx) {
+		t.Errorf("Wait() with cancelled context in drain mode = true, want false")
+	}
+}
+
+func TestHandleSignals(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	defer Set(context.Background(), false)
+
+	ch := make(chan os.Signal)
+	go handleSignals(ctx, ch)
+	ch <- os.Interrupt
+	// The drain mode is toggled after the signal is received.
+	deadline := time.Now().Add(time.Second)
+	for !Active() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !Active() {
+		t.Errorf("Active() after the drain signal = false, want true")
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	logging "cloud.google.com/go/logging"
+	"cloud.google.com/go/logging/logadmin"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+// maxCloudLoggingEntries bounds the entries listed for a query, counts of
+// entries matching a query are capped at this value.
+const maxCloudLoggingEntries = 1000
+
+// LogEntryLister returns up to limit entries matching the Logging filter, newest first.
+type LogEntryLister func(ctx context.Context, filter string, limit int) ([]*logging.Entry, error)
+
+// CloudLoggingReader evaluates CloudLogging event sources.
+type CloudLoggingReader struct {
+	List LogEntryLister
+	Now  func() time.Time
+}
+
+// NewCloudLoggingReader creates a CloudLoggingReader which lists the entries with the client.
+func NewCloudLoggingReader(client *logadmin.Client) *CloudLoggingReader {
+	return &CloudLoggingReader{
+		List: func(ctx context.Context, filter string, limit int) ([]*logging.Entry, error) {
+			return listLogEntries(ctx, client, filter, limit)
+		},
+		Now: time.Now,
+	}
+}
+
+// Read runs the log query of the source restricted to its min_severity and
+// window_minutes. Sources with a field_path return that field of the newest
+// matching entry coerced to the value_type, INT64 sources without one return
+// the number of matching entries.
+func (r *CloudLoggingReader) Read(ctx context.Context, source *evpb.EventSource_CloudLogging) (any, error) {
+	filter, err := CloudLoggingFilter(source, r.Now())
+	if err != nil {
+		return nil, err
+	}
+	if source.GetFieldPath() == "" {
+		if source.GetValueType() != evpb.EventSource_INT64 {
+			return nil, fmt.Errorf("cloud logging source without a field_path must have value_type INT64, got %s", source.GetValueType())
+		}
+		entries, err := r.List(ctx, filter, maxCloudLoggingEntries)
+		if err != nil {
+			return nil, fmt.Errorf("listing log entries for %q: %w", filter, err)
+		}
+		return int64(len(entries)), nil
+	}
+
+	entries, err := r.List(ctx, filter, 1)
+	if err != nil {
+		return nil, fmt.Errorf("listing log entries for %q: %w", filter, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no log entries match %q", filter)
+	}
+	body, err := entryJSON(entries[0])
+	if err != nil {
+		return nil, err
+	}
+	value, err := extractJSONPath(body, source.GetFieldPath())
+	if err != nil {
+		return nil, err
+	}
+	if value, err = coerceValue(value, source.GetValueType()); err != nil {
+		return nil, fmt.Errorf("converting log entry field %q: %w", source.GetFieldPath(), err)
+	}
+	return value, nil
+}
+
+// CloudLoggingFilter returns the log query of the source combined with the
+// severity and timestamp restrictions of its min_severity and window_minutes.
+func CloudLoggingFilter(source *evpb.EventSource_CloudLogging, now time.Time) (string, error) {
+	var clauses []string
+	if q := strings.TrimSpace(source.GetLogQuery()); q != "" {
+		clauses = append(clauses, "("+q+")")
+	}
+	if s := source.GetMinSeverity(); s != "" {
+		severity := logging.ParseSeverity(s)
+		if severity == logging.Default && !strings.EqualFold(s, "DEFAULT") {
+			return "", fmt.Errorf("invalid min_severity %q", s)
+		}
+		clauses = append(clauses, "severity>="+strings.ToUpper(severity.String()))
+	}
+	if w := source.GetWindowMinutes(); w < 0 {
+		return "", fmt.Errorf("window_minutes must not be negative, got %d", w)
+	} else if w > 0 {
+		start := now.Add(-time.Duration(w) * time.Minute).UTC()
+		clauses = append(clauses, fmt.Sprintf("timestamp>=%q", start.Format(time.RFC3339)))
+	}
+	if len(clauses) == 0 {
+		return "", errors.New("cloud logging source does not have a log query")
+	}
+	return strings.Join(clauses, " AND "), nil
+}
+
+// listLogEntries returns up to limit entries matching the filter, newest first.
+func listLogEntries(ctx context.Context, client *logadmin.Client, filter string, limit int) ([]*logging.Entry, error) {
+	if client == nil {
+		return nil, errors.New("cloud logging admin client is not available")
+	}
+	it := client.Entries(ctx, logadmin.Filter(filter), logadmin.NewestFirst())
+	var entries []*logging.Entry
+	for len(entries) < limit {
+		e, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// entryJSON returns the entry as a JSON document using the field names of the
+// Logging query language, so field paths read like the queries which match them.
+func entryJSON(e *logging.Entry) ([]byte, error) {
+	doc := map[string]any{
+		"severity":  strings.ToUpper(e.Severity.String()),
+		"timestamp": e.Timestamp.UTC().Format(time.RFC3339Nano),
+		"logName":   e.LogName,
+		"labels":    e.Labels,
+	}
+	switch p := e.Payload.(type) {
+	case string:
+		doc["textPayload"] = p
+	case *structpb.Struct:
+		b, err := protojson.Marshal(p)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling log entry payload: %w", err)
+		}
+		doc["jsonPayload"] = json.RawMessage(b)
+	case nil:
+	default:
+		doc["jsonPayload"] = p
+	}
+	return json.Marshal(doc)
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logging "cloud.google.com/go/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+var testLoggingNow = time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+func TestCloudLoggingFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  *evpb.EventSource_CloudLogging
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "QueryOnly",
+			source: &evpb.EventSource_CloudLogging{LogQuery: `logName:"hana"`},
+			want:   `(logName:"hana")`,
+		},
+		{
+			name:   "SeverityAndWindow",
+			source: &evpb.EventSource_CloudLogging{LogQuery: `logName:"hana" OR logName:"pacemaker"`, MinSeverity: "error", WindowMinutes: 15},
+			want:   `(logName:"hana" OR logName:"pacemaker") AND severity>=ERROR AND timestamp>="2024-05-01T12:15:00Z"`,
+		},
+		{
+			name:   "SeverityWithoutQuery",
+			source: &evpb.EventSource_CloudLogging{MinSeverity: "Warning"},
+			want:   `severity>=WARNING`,
+		},
+		{
+			name:    "InvalidSeverity",
+			source:  &evpb.EventSource_CloudLogging{LogQuery: "x", MinSeverity: "FATAL"},
+			wantErr: true,
+		},
+		{
+			name:    "NegativeWindow",
+			source:  &evpb.EventSource_CloudLogging{LogQuery: "x", WindowMinutes: -1},
+			wantErr: true,
+		},
+		{
+			name:    "Empty",
+			source:  &evpb.EventSource_CloudLogging{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := CloudLoggingFilter(tc.source, testLoggingNow)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("CloudLoggingFilter(%v) returned error: %v, wantErr: %t", tc.source, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("CloudLoggingFilter(%v) = %q, want: %q", tc.source, got, tc.want)
+			}
+		})
+	}
+}
+
+func fakeLister(entries []*logging.Entry, err error) LogEntryLister {
+	return func(_ context.Context, _ string, limit int) ([]*logging.Entry, error) {
+		if len(entries) > limit {
+			return entries[:limit], err
+		}
+		return entries, err
+	}
+}
+
+func TestCloudLoggingReaderRead(t *testing.T) {
+	payload, err := structpb.NewStruct(map[string]any{"status": "FAILED", "duration": 42})
+	if err != nil {
+		t.Fatalf("structpb.NewStruct() returned error: %v", err)
+	}
+	entries := []*logging.Entry{
+		{Severity: logging.Error, Payload: payload, Labels: map[string]string{"sid": "DEH"}},
+		{Severity: logging.Critical, Payload: "backup failed"},
+	}
+	tests := []struct {
+		name    string
+		list    LogEntryLister
+		source  *evpb.EventSource_CloudLogging
+		want    any
+		wantErr bool
+	}{
+		{
+			name:   "Count",
+			list:   fakeLister(entries, nil),
+			source: &evpb.EventSource_CloudLogging{LogQuery: "x", MinSeverity: "ERROR", WindowMinutes: 5, ValueType: evpb.EventSource_INT64},
+			want:   int64(2),
+		},
+		{
+			name:   "CountNoEntries",
+			list:   fakeLister(nil, nil),
+			source: &evpb.EventSource_CloudLogging{LogQuery: "x", ValueType: evpb.EventSource_INT64},
+			want:   int64(0),
+		},
+		{
+			name:   "JSONPayloadField",
+			list:   fakeLister(entries, nil),
+			source: &evpb.EventSource_CloudLogging{LogQuery: "x", FieldPath: "jsonPayload/status", ValueType: evpb.EventSource_STRING},
+			want:   "FAILED",
+		},
+		{
+			name:   "JSONPayloadFieldAsInt",
+			list:   fakeLister(entries, nil),
+			source: &evpb.EventSource_CloudLogging{LogQuery: "x", FieldPath: "jsonPayload/duration", ValueType: evpb.EventSource_INT64},
+			want:   int64(42),
+		},
+		{
+			name:   "Label",
+			list:   fakeLister(entries, nil),
+			source: &evpb.EventSource_CloudLogging{LogQuery: "x", FieldPath: "labels/sid", ValueType: evpb.EventSource_STRING},
+			want:   "DEH",
+		},
+		{
+			name:   "TextPayload",
+			list:   fakeLister(entries[1:], nil),
+			source: &evpb.EventSource_CloudLogging{LogQuery: "x", FieldPath: "textPayload", ValueType: evpb.EventSource_STRING},
+			want:   "backup failed",
+		},
+		{
+			name:   "Severity",
+			list:   fakeLister(entries[1:], nil),
+			source: &evpb.EventSource_CloudLogging{LogQuery: "x", FieldPath: "severity", ValueType: evpb.EventSource_STRING},
+			want:   "CRITICAL",
+		},
+		{
+			name:    "CountNotInt",
+			list:    fakeLister(entries, nil),
+			source:  &evpb.EventSource_CloudLogging{LogQuery: "x", ValueType: evpb.EventSource_STRING},
+			wantErr: true,
+		},
+		{
+			name:    "FieldNoEntries",
+			list:    fakeLister(nil, nil),
+			source:  &evpb.EventSource_CloudLogging{LogQuery: "x", FieldPath: "textPayload", ValueType: evpb.EventSource_STRING},
+			wantErr: true,
+		},
+		{
+			name:    "MissingField",
+			list:    fakeLister(entries, nil),
+			source:  &evpb.EventSource_CloudLogging{LogQuery: "x", FieldPath: "jsonPayload/missing", ValueType: evpb.EventSource_STRING},
+			wantErr: true,
+		},
+		{
+			name:    "ListError",
+			list:    fakeLister(nil, cmpopts.AnyError),
+			source:  &evpb.EventSource_CloudLogging{LogQuery: "x", ValueType: evpb.EventSource_INT64},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidSeverity",
+			list:    fakeLister(entries, nil),
+			source:  &evpb.EventSource_CloudLogging{LogQuery: "x", MinSeverity: "LOUD", ValueType: evpb.EventSource_INT64},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &CloudLoggingReader{List: tc.list, Now: func() time.Time { return testLoggingNow }}
+			got, err := r.Read(context.Background(), tc.source)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Read(%v) returned error: %v, wantErr: %t", tc.source, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Read(%v) returned unexpected diff (-want +got):\n%s", tc.source, diff)
+			}
+		})
+	}
+}
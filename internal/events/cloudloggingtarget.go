@@ -0,0 +1,128 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	logging "cloud.google.com/go/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+const (
+	// Entries are sent once this many are buffered or after the delay, whichever is first.
+	cloudLoggingBatchSize  = 100
+	cloudLoggingBatchDelay = 5 * time.Second
+	// cloudLoggingBufferLimit bounds the memory held by entries waiting to be sent, entries
+	// logged beyond it are dropped.
+	cloudLoggingBufferLimit = 10 * 1024 * 1024
+
+	defaultEventSeverity = logging.Notice
+)
+
+// cloudLogger is the subset of logging.Logger used by the CloudLoggingTarget.
+type cloudLogger interface {
+	Log(e logging.Entry)
+	Flush() error
+}
+
+// CloudLoggingTarget writes event payloads as structured entries to the log_name
+// of a cloud_logging EventTarget. Entries are buffered and sent in batches, the
+// severity of each entry is read from a "severity=<level>" label of its rule.
+type CloudLoggingTarget struct {
+	logger cloudLogger
+}
+
+// NewCloudLoggingTarget creates a CloudLoggingTarget for the cloud_logging target
+// using the client.
+func NewCloudLoggingTarget(target *evpb.EventTarget, client *logging.Client) (*CloudLoggingTarget, error) {
+	logName := target.GetCloudLogging().GetLogName()
+	if logName == "" {
+		return nil, fmt.Errorf("event target does not have a cloud logging log name: %v", target)
+	}
+	if client == nil {
+		return nil, errors.New("cloud logging client is not available")
+	}
+	logger := client.Logger(logName,
+		logging.EntryCountThreshold(cloudLoggingBatchSize),
+		logging.DelayThreshold(cloudLoggingBatchDelay),
+		logging.BufferedByteLimit(cloudLoggingBufferLimit))
+	return &CloudLoggingTarget{logger: logger}, nil
+}
+
+// Write marshals the payload to JSON and buffers it as an entry of the log. The
+// entry is labeled with the ID of the rule which triggered the event.
+func (c *CloudLoggingTarget) Write(rule *evpb.Rule, payload any) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshalling event payload: %w", err)
+	}
+	c.logger.Log(logging.Entry{
+		Timestamp: time.Now(),
+		Severity:  eventSeverity(rule.GetLabels()),
+		Labels:    map[string]string{"rule_id": rule.GetId()},
+		Payload:   json.RawMessage(b),
+	})
+	return nil
+}
+
+// Flush sends the buffered entries. Entries dropped because the logging quota
+// was exhausted or the buffer overflowed are logged rather than returned as an
+// error, as retrying them would only add to the load.
+func (c *CloudLoggingTarget) Flush(ctx context.Context) error {
+	err := c.logger.Flush()
+	if err == nil {
+		return nil
+	}
+	if status.Code(err) == codes.ResourceExhausted || errors.Is(err, logging.ErrOverflow) {
+		log.CtxLogger(ctx).Warnw("Dropped events which could not be written to Cloud Logging", "error", err)
+		return nil
+	}
+	return fmt.Errorf("writing events to Cloud Logging: %w", err)
+}
+
+// Close sends any buffered entries.
+func (c *CloudLoggingTarget) Close() error {
+	return c.Flush(context.Background())
+}
+
+// eventSeverity returns the severity from a "severity=<level>" or "severity:<level>"
+// rule label, events are logged at notice severity by default.
+func eventSeverity(labels []string) logging.Severity {
+	for _, l := range labels {
+		key, value, ok := strings.Cut(l, "=")
+		if !ok {
+			key, value, ok = strings.Cut(l, ":")
+		}
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "severity") {
+			continue
+		}
+		if severity := logging.ParseSeverity(strings.TrimSpace(value)); severity != logging.Default {
+			return severity
+		}
+	}
+	return defaultEventSeverity
+}
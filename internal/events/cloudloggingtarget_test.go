@@ -0,0 +1,177 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	logging "cloud.google.com/go/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+type fakeCloudLogger struct {
+	entries  []logging.Entry
+	flushErr error
+}
+
+func (f *fakeCloudLogger) Log(e logging.Entry) { f.entries = append(f.entries, e) }
+
+func (f *fakeCloudLogger) Flush() error { return f.flushErr }
+
+func TestNewCloudLoggingTarget(t *testing.T) {
+	client, err := logging.NewClient(context.Background(), "test-project", option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("logging.NewClient() returned error: %v", err)
+	}
+	defer client.Close()
+	cloudLogging := &evpb.EventTarget{Target: &evpb.EventTarget_CloudLogging{CloudLogging: &evpb.CloudLoggingTarget{LogName: "sap-agent-events"}}}
+
+	tests := []struct {
+		name    string
+		target  *evpb.EventTarget
+		client  *logging.Client
+		wantErr bool
+	}{
+		{
+			name:    "NoLogName",
+			target:  &evpb.EventTarget{Target: &evpb.EventTarget_FileEndpoint{FileEndpoint: "/tmp/events.json"}},
+			client:  client,
+			wantErr: true,
+		},
+		{
+			name:    "NoClient",
+			target:  cloudLogging,
+			wantErr: true,
+		},
+		{
+			name:   "CloudLogging",
+			target: cloudLogging,
+			client: client,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewCloudLoggingTarget(tc.target, tc.client)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("NewCloudLoggingTarget(%v) returned error: %v, wantErr: %t", tc.target, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCloudLoggingTargetWrite(t *testing.T) {
+	logger := &fakeCloudLogger{}
+	target := &CloudLoggingTarget{logger: logger}
+	rule := &evpb.Rule{Id: "hana-down", Labels: []string{"hana", "severity=error"}}
+
+	if err := target.Write(rule, map[string]string{"value": "0"}); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	if err := target.Write(rule, make(chan int)); err == nil {
+		t.Errorf("Write() succeeded for a payload which cannot be marshalled, want error")
+	}
+
+	want := []logging.Entry{{
+		Severity: logging.Error,
+		Labels:   map[string]string{"rule_id": "hana-down"},
+		Payload:  json.RawMessage(`{"value":"0"}`),
+	}}
+	if diff := cmp.Diff(want, logger.entries, cmpopts.IgnoreFields(logging.Entry{}, "Timestamp")); diff != "" {
+		t.Errorf("Write() logged unexpected entries (-want +got):\n%s", diff)
+	}
+}
+
+func TestCloudLoggingTargetFlush(t *testing.T) {
+	tests := []struct {
+		name     string
+		flushErr error
+		wantErr  error
+	}{
+		{
+			name: "Success",
+		},
+		{
+			name:     "QuotaExhausted",
+			flushErr: fmt.Errorf("saw 1 errors; last: %w", status.Error(codes.ResourceExhausted, "quota exceeded")),
+		},
+		{
+			name:     "BufferOverflow",
+			flushErr: logging.ErrOverflow,
+		},
+		{
+			name:     "OtherError",
+			flushErr: errors.New("permission denied"),
+			wantErr:  cmpopts.AnyError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			target := &CloudLoggingTarget{logger: &fakeCloudLogger{flushErr: tc.flushErr}}
+			if err := target.Flush(context.Background()); !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("Flush() returned error: %v, want: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventSeverity(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []string
+		want   logging.Severity
+	}{
+		{
+			name: "NoLabels",
+			want: logging.Notice,
+		},
+		{
+			name:   "EqualsSeparator",
+			labels: []string{"hana", "severity=WARNING"},
+			want:   logging.Warning,
+		},
+		{
+			name:   "ColonSeparator",
+			labels: []string{"Severity: critical"},
+			want:   logging.Critical,
+		},
+		{
+			name:   "UnknownSeverity",
+			labels: []string{"severity=urgent"},
+			want:   logging.Notice,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := eventSeverity(tc.labels); got != tc.want {
+				t.Errorf("eventSeverity(%v) = %v, want: %v", tc.labels, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	logging "cloud.google.com/go/logging"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+const (
+	// defaultRuleFrequency is how often a rule without a frequency_sec is evaluated.
+	defaultRuleFrequency = time.Minute
+	// evaluationTick is how often the engine checks for the rules due to be evaluated, rules are
+	// not evaluated more often than this.
+	evaluationTick = time.Second
+	// statsInterval is how often the evaluation and delivery counters are sent.
+	statsInterval = time.Minute
+)
+
+// Event is the payload delivered to the targets of a triggered rule.
+type Event struct {
+	RuleID     string    `json:"ruleId"`
+	RuleName   string    `json:"ruleName,omitempty"`
+	Labels     []string  `json:"labels,omitempty"`
+	Value      any       `json:"value"`
+	Operation  string    `json:"operation"`
+	RHS        string    `json:"rhs"`
+	ProjectID  string    `json:"projectId,omitempty"`
+	InstanceID string    `json:"instanceId,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// Target delivers the events of the rules to one EventTarget.
+type Target interface {
+	Deliver(ctx context.Context, rule *evpb.Rule, event *Event) error
+	Close() error
+}
+
+// NewTargetFunc creates the Target of an EventTarget.
+type NewTargetFunc func(ctx context.Context, target *evpb.EventTarget) (Target, error)
+
+// TargetClients hold what the targets created by NewTarget need to deliver events.
+type TargetClients struct {
+	HTTPAuth HTTPAuth
+	// CloudLogging is the client of the cloud_logging targets, they cannot be created when it is nil.
+	CloudLogging *logging.Client
+}
+
+// NewTarget creates a FileTarget, an HTTPTarget or a CloudLoggingTarget for the target.
+func (c TargetClients) NewTarget(ctx context.Context, target *evpb.EventTarget) (Target, error) {
+	switch target.GetTarget().(type) {
+	case *evpb.EventTarget_FileEndpoint:
+		f, err := NewFileTarget(target)
+		if err != nil {
+			return nil, err
+		}
+		return fileDelivery{f}, nil
+	case *evpb.EventTarget_HttpEndpoint:
+		h, err := NewHTTPTarget(ctx, target, c.HTTPAuth)
+		if err != nil {
+			return nil, err
+		}
+		return httpDelivery{h}, nil
+	case *evpb.EventTarget_CloudLogging:
+		l, err := NewCloudLoggingTarget(target, c.CloudLogging)
+		if err != nil {
+			return nil, err
+		}
+		return cloudLoggingDelivery{l}, nil
+	}
+	return nil, fmt.Errorf("event target %v is not supported", target)
+}
+
+type fileDelivery struct{ *FileTarget }
+
+func (f fileDelivery) Deliver(_ context.Context, _ *evpb.Rule, event *Event) error {
+	return f.Write(event)
+}
+
+type httpDelivery struct{ *HTTPTarget }
+
+func (h httpDelivery) Deliver(ctx context.Context, _ *evpb.Rule, event *Event) error {
+	return h.Write(ctx, event)
+}
+
+func (httpDelivery) Close() error { return nil }
+
+type cloudLoggingDelivery struct{ *CloudLoggingTarget }
+
+// Deliver flushes the entry right away, so that a failed write is reported with the delivery.
+func (c cloudLoggingDelivery) Deliver(ctx context.Context, rule *evpb.Rule, event *Event) error {
+	if err := c.Write(rule, event); err != nil {
+		return err
+	}
+	return c.Flush(ctx)
+}
+
+// Parameters hold the parameters necessary to invoke Start().
+type Parameters struct {
+	Config *cpb.Configuration
+	// Read reads the sources of the rules and of their triggers.
+	Read      SourceReader
+	NewTarget NewTargetFunc
+	ReadFile  ReadFileFunc
+	ReadDir   ReadDirFunc
+	Stat      StatFunc
+	// TimeSeriesCreator sends the metrics of the engine, no metric is sent when it is nil.
+	TimeSeriesCreator cloudmonitoring.TimeSeriesCreator
+	BackOffs          *cloudmonitoring.BackOffIntervals
+	// ReadyFunc is called after each evaluation of the due rules, it may be nil.
+	ReadyFunc func()
+}
+
+// Start loads the rules of the events_rules_file or the events_rules_dir of the configuration and
+// evaluates them in a goroutine until ctx is done. The rules are reloaded when their files change,
+// and the rule count is sent after each successful load. Returns false if the events are not
+// enabled or the rules cannot be loaded.
+func Start(ctx context.Context, params Parameters) bool {
+	if !params.Config.GetEnableEvents().GetValue() {
+		log.CtxLogger(ctx).Info("Events disabled, not starting the events engine.")
+		return false
+	}
+	w, err := NewRuleWatcher(RuleWatcherOptions{
+		RulesFile: params.Config.GetEventsRulesFile(),
+		RulesDir:  params.Config.GetEventsRulesDir(),
+		ReadFile:  params.ReadFile,
+		ReadDir:   params.ReadDir,
+		Stat:      params.Stat,
+		OnReload: func(rules []*evpb.Rule) {
+			sendTimeSeries(ctx, params, []*mrpb.TimeSeries{RuleCountTimeSeries(params.Config.GetCloudProperties(), len(rules))})
+		},
+	})
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Failed to load the event rules, not starting the events engine", "rulesFile", params.Config.GetEventsRulesFile(), "rulesDir", params.Config.GetEventsRulesDir(), "error", err)
+		return false
+	}
+	log.CtxLogger(ctx).Infow("Starting the events engine", "rules", len(w.Rules()))
+	go w.Watch(ctx)
+	go newEngine(params, w.Rules).run(ctx)
+	return true
+}
+
+// sendTimeSeries sends the metrics of the engine, errors are logged.
+func sendTimeSeries(ctx context.Context, params Parameters, timeSeries []*mrpb.TimeSeries) {
+	if params.TimeSeriesCreator == nil || len(timeSeries) == 0 {
+		return
+	}
+	if _, _, err := cloudmonitoring.SendTimeSeries(ctx, timeSeries, params.TimeSeriesCreator, params.BackOffs, params.Config.GetCloudProperties().GetProjectId()); err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to send the events metrics", "error", err)
+	}
+}
+
+// engine evaluates the active rules at their frequency, and delivers an event to the targets of
+// each rule whose trigger fires. The targets are created on their first delivery and shared by the
+// rules with the same target. The evaluations and deliveries are counted in stats, which are sent
+// at most once per statsInterval.
+type engine struct {
+	params     Parameters
+	rules      func() []*evpb.Rule
+	read       SourceReader
+	newTarget  NewTargetFunc
+	ready      func()
+	projectID  string
+	instanceID string
+	stats      *Stats
+
+	active    []*evpb.Rule
+	nextRun   map[string]time.Time // By rule ID.
+	targets   map[string]Target    // By targetKey.
+	statsSent time.Time
+}
+
+func newEngine(params Parameters, rules func() []*evpb.Rule) *engine {
+	return &engine{
+		params:     params,
+		rules:      rules,
+		read:       params.Read,
+		newTarget:  params.NewTarget,
+		ready:      params.ReadyFunc,
+		projectID:  params.Config.GetCloudProperties().GetProjectId(),
+		instanceID: params.Config.GetCloudProperties().GetInstanceId(),
+		stats:      NewStats(),
+		nextRun:    make(map[string]time.Time),
+		targets:    make(map[string]Target),
+	}
+}
+
+// run evaluates the due rules every evaluation tick until ctx is done, then closes the targets.
+func (e *engine) run(ctx context.Context) {
+	ticker := time.NewTicker(evaluationTick)
+	defer ticker.Stop()
+	for {
+		e.evaluateDue(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			e.retire(nil)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// evaluateDue evaluates the rules which were not evaluated within their frequency.
+func (e *engine) evaluateDue(ctx context.Context, now time.Time) {
+	rules := e.rules()
+	e.retire(rules)
+	evaluated := false
+	for _, rule := range rules {
+		if next, ok := e.nextRun[rule.GetId()]; ok && now.Before(next) {
+			continue
+		}
+		e.nextRun[rule.GetId()] = now.Add(ruleFrequency(rule))
+		e.evaluate(ctx, rule, now)
+		evaluated = true
+	}
+	if !evaluated {
+		return
+	}
+	if now.Sub(e.statsSent) >= statsInterval {
+		sendTimeSeries(ctx, e.params, e.stats.TimeSeries(e.params.Config.GetCloudProperties()))
+		e.statsSent = now
+	}
+	if e.ready != nil {
+		e.ready()
+	}
+}
+
+// evaluate reads the source of the rule and delivers an event to each of its targets when its
+// trigger fires. Errors are logged, the rule is evaluated again at its next run.
+func (e *engine) evaluate(ctx context.Context, rule *evpb.Rule, now time.Time) {
+	value, err := e.read(ctx, rule.GetSource())
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to read the event source", "rule", rule.GetId(), "error", err)
+		return
+	}
+	rhs, err := ResolveRHS(ctx, rule.GetTrigger(), e.read)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to read the trigger rhs source", "rule", rule.GetId(), "error", err)
+		return
+	}
+	fires := rule.GetForceTrigger()
+	if !fires {
+		if fires, err = evaluate(rule.GetTrigger().GetOperation(), value, rhs); err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to evaluate the rule trigger", "rule", rule.GetId(), "value", value, "error", err)
+			return
+		}
+	}
+	e.stats.RuleEvaluated(rule, fires)
+	if !fires {
+		return
+	}
+
+	log.CtxLogger(ctx).Debugw("Event rule triggered", "rule", rule.GetId(), "value", value)
+	event := &Event{
+		RuleID:     rule.GetId(),
+		RuleName:   rule.GetName(),
+		Labels:     rule.GetLabels(),
+		Value:      value,
+		Operation:  rule.GetTrigger().GetOperation().String(),
+		RHS:        rhs,
+		ProjectID:  e.projectID,
+		InstanceID: e.instanceID,
+		Timestamp:  now,
+	}
+	for _, target := range rule.GetTarget() {
+		err := e.deliver(ctx, rule, target, event)
+		e.stats.TargetDelivered(rule, err)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to deliver the event", "rule", rule.GetId(), "target", target, "error", err)
+		}
+	}
+}
+
+// deliver delivers the event to the target, creating the target on its first delivery. A target
+// which cannot be created is created again on the next delivery.
+func (e *engine) deliver(ctx context.Context, rule *evpb.Rule, target *evpb.EventTarget, event *Event) error {
+	key, err := targetKey(target)
+	if err != nil {
+		return err
+	}
+	t, ok := e.targets[key]
+	if !ok {
+		if t, err = e.newTarget(ctx, target); err != nil {
+			return fmt.Errorf("creating event target: %w", err)
+		}
+		e.targets[key] = t
+	}
+	return t.Deliver(ctx, rule, event)
+}
+
+// retire closes the targets and forgets the schedule of the rules which are no longer active, once
+// the active rules are replaced.
+func (e *engine) retire(rules []*evpb.Rule) {
+	if sameRules(rules, e.active) {
+		return
+	}
+	e.active = rules
+	ids := make(map[string]bool)
+	keys := make(map[string]bool)
+	for _, rule := range rules {
+		ids[rule.GetId()] = true
+		for _, target := range rule.GetTarget() {
+			if key, err := targetKey(target); err == nil {
+				keys[key] = true
+			}
+		}
+	}
+	for id := range e.nextRun {
+		if !ids[id] {
+			delete(e.nextRun, id)
+		}
+	}
+	for key, t := range e.targets {
+		if !keys[key] {
+			t.Close()
+			delete(e.targets, key)
+		}
+	}
+}
+
+// sameRules reports whether a and b hold the same rule messages, the rules are replaced and not
+// modified when they are reloaded.
+func sameRules(a, b []*evpb.Rule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// targetKey identifies the targets which deliver to the same destination with the same options.
+func targetKey(target *evpb.EventTarget) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(target)
+	if err != nil {
+		return "", fmt.Errorf("marshalling event target: %w", err)
+	}
+	return string(b), nil
+}
+
+// ruleFrequency returns how often the rule is evaluated.
+func ruleFrequency(rule *evpb.Rule) time.Duration {
+	if rule.GetFrequencySec() <= 0 {
+		return defaultRuleFrequency
+	}
+	return time.Duration(rule.GetFrequencySec()) * time.Second
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
+	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring/fake"
+
+	wpb "google.golang.org/protobuf/types/known/wrapperspb"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+// fakeTarget records the IDs of the rules of the events delivered to it.
+type fakeTarget struct {
+	delivered []string
+	closed    bool
+	err       error
+}
+
+func (f *fakeTarget) Deliver(_ context.Context, rule *evpb.Rule, _ *Event) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.delivered = append(f.delivered, rule.GetId())
+	return nil
+}
+
+func (f *fakeTarget) Close() error {
+	f.closed = true
+	return nil
+}
+
+// fakeTargets creates a fakeTarget by file endpoint, failing for the endpoints in failCreate.
+type fakeTargets struct {
+	targets    map[string]*fakeTarget
+	created    int
+	failCreate map[string]bool
+}
+
+func (f *fakeTargets) newTarget(_ context.Context, target *evpb.EventTarget) (Target, error) {
+	endpoint := target.GetFileEndpoint()
+	if f.failCreate[endpoint] {
+		return nil, errors.New("target unavailable")
+	}
+	f.created++
+	t := &fakeTarget{}
+	f.targets[endpoint] = t
+	return t, nil
+}
+
+// readMetadataValues returns a SourceReader reading the value of a Metadata source from values.
+func readMetadataValues(values map[string]any) SourceReader {
+	return func(_ context.Context, source *evpb.EventSource) (any, error) {
+		v, ok := values[source.GetMetadata().GetUrl()]
+		if !ok {
+			return nil, fmt.Errorf("no value for %v", source)
+		}
+		return v, nil
+	}
+}
+
+func testRule(id, url string, operation evpb.EvalNode_EvalType, rhs string, endpoints ...string) *evpb.Rule {
+	rule := &evpb.Rule{
+		Id:      id,
+		Source:  &evpb.EventSource{Source: &evpb.EventSource_Metadata_{Metadata: &evpb.EventSource_Metadata{Url: url}}},
+		Trigger: &evpb.EvalNode{Operation: operation, Rhs: rhs},
+	}
+	for _, endpoint := range endpoints {
+		rule.Target = append(rule.Target, &evpb.EventTarget{Target: &evpb.EventTarget_FileEndpoint{FileEndpoint: endpoint}})
+	}
+	return rule
+}
+
+func TestEngineEvaluateDue(t *testing.T) {
+	values := map[string]any{"hana/status": int64(0), "nw/status": int64(1)}
+	rules := []*evpb.Rule{
+		testRule("hana-down", "hana/status", evpb.EvalNode_EQ, "0", "/tmp/a.log", "/tmp/b.log"),
+		testRule("nw-down", "nw/status", evpb.EvalNode_EQ, "0", "/tmp/a.log"),
+		testRule("missing-source", "missing", evpb.EvalNode_EQ, "0", "/tmp/a.log"),
+		testRule("failing-target", "hana/status", evpb.EvalNode_EQ, "0", "/tmp/failing.log"),
+	}
+	forced := testRule("forced", "nw/status", evpb.EvalNode_EQ, "0", "/tmp/b.log")
+	forced.ForceTrigger = true
+	rules = append(rules, forced)
+
+	targets := &fakeTargets{targets: make(map[string]*fakeTarget), failCreate: map[string]bool{"/tmp/failing.log": true}}
+	readyCount := 0
+	e := newEngine(Parameters{
+		Config:    &cpb.Configuration{},
+		Read:      readMetadataValues(values),
+		NewTarget: targets.newTarget,
+		ReadyFunc: func() { readyCount++ },
+	}, func() []*evpb.Rule { return rules })
+	e.evaluateDue(context.Background(), time.Now())
+
+	got := make(map[string][]string)
+	for endpoint, target := range targets.targets {
+		got[endpoint] = target.delivered
+	}
+	want := map[string][]string{
+		"/tmp/a.log": {"hana-down"},
+		"/tmp/b.log": {"hana-down", "forced"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("evaluateDue() delivered unexpected events (-want +got):\n%s", diff)
+	}
+	if targets.created != 2 {
+		t.Errorf("evaluateDue() created %d targets, want: 2", targets.created)
+	}
+	if readyCount != 1 {
+		t.Errorf("evaluateDue() called ReadyFunc %d times, want: 1", readyCount)
+	}
+}
+
+func TestEngineEvaluateDueFrequency(t *testing.T) {
+	rule := testRule("hana-down", "hana/status", evpb.EvalNode_EQ, "0", "/tmp/a.log")
+	rule.FrequencySec = 30
+	targets := &fakeTargets{targets: make(map[string]*fakeTarget)}
+	e := newEngine(Parameters{
+		Config:    &cpb.Configuration{},
+		Read:      readMetadataValues(map[string]any{"hana/status": int64(0)}),
+		NewTarget: targets.newTarget,
+	}, func() []*evpb.Rule { return []*evpb.Rule{rule} })
+
+	start := time.Now()
+	for _, elapsed := range []time.Duration{0, 10 * time.Second, 29 * time.Second, 30 * time.Second, 45 * time.Second, time.Minute} {
+		e.evaluateDue(context.Background(), start.Add(elapsed))
+	}
+	if got := len(targets.targets["/tmp/a.log"].delivered); got != 3 {
+		t.Errorf("evaluateDue() every 30 seconds delivered %d events, want: 3", got)
+	}
+	if targets.created != 1 {
+		t.Errorf("evaluateDue() created %d targets, want: 1", targets.created)
+	}
+}
+
+func TestEngineRetire(t *testing.T) {
+	values := map[string]any{"hana/status": int64(0)}
+	rules := []*evpb.Rule{
+		testRule("hana-down", "hana/status", evpb.EvalNode_EQ, "0", "/tmp/a.log"),
+		testRule("hana-down-copy", "hana/status", evpb.EvalNode_EQ, "0", "/tmp/b.log"),
+	}
+	targets := &fakeTargets{targets: make(map[string]*fakeTarget)}
+	e := newEngine(Parameters{
+		Config:    &cpb.Configuration{},
+		Read:      readMetadataValues(values),
+		NewTarget: targets.newTarget,
+	}, func() []*evpb.Rule { return rules })
+	e.evaluateDue(context.Background(), time.Now())
+
+	rules = []*evpb.Rule{testRule("hana-down", "hana/status", evpb.EvalNode_EQ, "0", "/tmp/a.log")}
+	e.evaluateDue(context.Background(), time.Now())
+	if targets.targets["/tmp/a.log"].closed {
+		t.Errorf("evaluateDue() after a reload closed the target of an active rule")
+	}
+	if !targets.targets["/tmp/b.log"].closed {
+		t.Errorf("evaluateDue() after a reload did not close the target of a removed rule")
+	}
+	if _, ok := e.nextRun["hana-down-copy"]; ok {
+		t.Errorf("evaluateDue() after a reload kept the schedule of a removed rule")
+	}
+}
+
+func TestEngineStats(t *testing.T) {
+	hana := testRule("hana-down", "hana/status", evpb.EvalNode_EQ, "0", "/tmp/a.log", "/tmp/failing.log")
+	hana.Labels = []string{"hana"}
+	hana.FrequencySec = 30
+	nw := testRule("nw-down", "nw/status", evpb.EvalNode_EQ, "0", "/tmp/a.log")
+	nw.FrequencySec = 30
+	targets := &fakeTargets{targets: make(map[string]*fakeTarget), failCreate: map[string]bool{"/tmp/failing.log": true}}
+	creator := &fake.TimeSeriesCreator{}
+	e := newEngine(Parameters{
+		Config:            &cpb.Configuration{},
+		Read:              readMetadataValues(map[string]any{"hana/status": int64(0), "nw/status": int64(1)}),
+		NewTarget:         targets.newTarget,
+		TimeSeriesCreator: creator,
+		BackOffs:          cloudmonitoring.NewBackOffIntervals(time.Millisecond, time.Millisecond),
+	}, func() []*evpb.Rule { return []*evpb.Rule{hana, nw} })
+
+	start := time.Now()
+	e.evaluateDue(context.Background(), start)
+	if len(creator.Calls) != 1 {
+		t.Errorf("evaluateDue() sent %d time series requests, want: 1", len(creator.Calls))
+	}
+	// The rules are evaluated again, but the counters were sent less than a minute ago.
+	e.evaluateDue(context.Background(), start.Add(30*time.Second))
+	if len(creator.Calls) != 1 {
+		t.Errorf("evaluateDue() after 30 seconds sent %d time series requests, want: 1", len(creator.Calls))
+	}
+	e.evaluateDue(context.Background(), start.Add(time.Minute))
+	if len(creator.Calls) != 2 {
+		t.Errorf("evaluateDue() after a minute sent %d time series requests, want: 2", len(creator.Calls))
+	}
+
+	want := map[string]*labelCounts{
+		"hana":      {evaluated: 3, triggered: 3, delivered: 3, failed: 3},
+		"unlabeled": {evaluated: 3},
+	}
+	if diff := cmp.Diff(want, e.stats.counts, cmp.AllowUnexported(labelCounts{})); diff != "" {
+		t.Errorf("evaluateDue() counted unexpected stats (-want +got):\n%s", diff)
+	}
+	// 4 time series are sent for each label.
+	if got := len(creator.Calls[1].GetTimeSeries()); got != 8 {
+		t.Errorf("evaluateDue() sent %d time series, want: 8", got)
+	}
+}
+
+func TestStart(t *testing.T) {
+	dir := t.TempDir()
+	rulesFile := filepath.Join(dir, "rules.json")
+	if err := os.WriteFile(rulesFile, []byte("["+validRuleJSON("a")+"]"), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%s) failed: %v", rulesFile, err)
+	}
+	tests := []struct {
+		name      string
+		config    *cpb.Configuration
+		want      bool
+		wantCalls int
+	}{
+		{
+			name:   "Disabled",
+			config: &cpb.Configuration{EnableEvents: wpb.Bool(false), EventsRulesFile: rulesFile},
+		},
+		{
+			name:   "NoRulesSource",
+			config: &cpb.Configuration{EnableEvents: wpb.Bool(true)},
+		},
+		{
+			name:   "MissingRulesFile",
+			config: &cpb.Configuration{EnableEvents: wpb.Bool(true), EventsRulesFile: filepath.Join(dir, "missing.json")},
+		},
+		{
+			name:      "Started",
+			config:    &cpb.Configuration{EnableEvents: wpb.Bool(true), EventsRulesFile: rulesFile},
+			want:      true,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			targets := &fakeTargets{targets: make(map[string]*fakeTarget)}
+			creator := &fake.TimeSeriesCreator{}
+			got := Start(ctx, Parameters{
+				Config:            tc.config,
+				Read:              readMetadataValues(nil),
+				NewTarget:         targets.newTarget,
+				ReadFile:          os.ReadFile,
+				ReadDir:           os.ReadDir,
+				Stat:              os.Stat,
+				TimeSeriesCreator: creator,
+				BackOffs:          cloudmonitoring.NewBackOffIntervals(time.Millisecond, time.Millisecond),
+			})
+			if got != tc.want {
+				t.Errorf("Start() = %t, want: %t", got, tc.want)
+			}
+			// The rule count is sent by Start, before the engine goroutine runs.
+			if len(creator.Calls) != tc.wantCalls {
+				t.Errorf("Start() sent %d time series requests, want: %d", len(creator.Calls), tc.wantCalls)
+			}
+		})
+	}
+}
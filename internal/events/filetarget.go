@@ -0,0 +1,94 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events provides the building blocks for evaluating event rules and
+// delivering the triggered events to their targets.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/natefinch/lumberjack"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+const (
+	defaultMaxSizeMB  = 25
+	defaultMaxBackups = 3
+)
+
+// FileTarget writes event payloads as newline-delimited JSON to the
+// file_endpoint of an EventTarget. The file is rotated once it exceeds the
+// configured size and rotated files are optionally compressed using gzip.
+type FileTarget struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+// NewFileTarget creates a FileTarget for the file_endpoint of the target.
+func NewFileTarget(target *evpb.EventTarget) (*FileTarget, error) {
+	if target.GetFileEndpoint() == "" {
+		return nil, fmt.Errorf("event target does not have a file endpoint: %v", target)
+	}
+	return &FileTarget{w: newFileLogger(target)}, nil
+}
+
+// newFileLogger builds the rotating writer for the file endpoint, applying
+// defaults for any unset rotation settings.
+func newFileLogger(target *evpb.EventTarget) *lumberjack.Logger {
+	rotation := target.GetFileRotation()
+	l := &lumberjack.Logger{
+		Filename:   target.GetFileEndpoint(),
+		MaxSize:    defaultMaxSizeMB,
+		MaxBackups: defaultMaxBackups,
+		Compress:   rotation.GetCompress(),
+	}
+	if rotation.GetMaxSizeMb() > 0 {
+		l.MaxSize = int(rotation.GetMaxSizeMb())
+	}
+	if rotation.GetMaxBackups() > 0 {
+		l.MaxBackups = int(rotation.GetMaxBackups())
+	}
+	return l
+}
+
+// Write marshals the payload to JSON and appends it to the file as a single line.
+// Writes are serialized so that concurrent callers never interleave their lines.
+func (f *FileTarget) Write(payload any) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshalling event payload: %w", err)
+	}
+	b = append(b, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.w.Write(b); err != nil {
+		return fmt.Errorf("writing event payload: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying file.
+func (f *FileTarget) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.w.Close()
+}
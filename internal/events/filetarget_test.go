@@ -0,0 +1,150 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/natefinch/lumberjack"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+func TestNewFileTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  *evpb.EventTarget
+		wantErr bool
+	}{
+		{
+			name:    "NoFileEndpoint",
+			target:  &evpb.EventTarget{Target: &evpb.EventTarget_HttpEndpoint{HttpEndpoint: "http://localhost"}},
+			wantErr: true,
+		},
+		{
+			name:   "FileEndpoint",
+			target: &evpb.EventTarget{Target: &evpb.EventTarget_FileEndpoint{FileEndpoint: "/tmp/events.json"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewFileTarget(tc.target)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("NewFileTarget(%v) returned error: %v, wantErr: %t", tc.target, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewFileLogger(t *testing.T) {
+	tests := []struct {
+		name   string
+		target *evpb.EventTarget
+		want   *lumberjack.Logger
+	}{
+		{
+			name:   "Defaults",
+			target: &evpb.EventTarget{Target: &evpb.EventTarget_FileEndpoint{FileEndpoint: "/tmp/events.json"}},
+			want: &lumberjack.Logger{
+				Filename:   "/tmp/events.json",
+				MaxSize:    defaultMaxSizeMB,
+				MaxBackups: defaultMaxBackups,
+			},
+		},
+		{
+			name: "CustomRotation",
+			target: &evpb.EventTarget{
+				Target: &evpb.EventTarget_FileEndpoint{FileEndpoint: "/tmp/events.json"},
+				FileRotation: &evpb.FileRotation{
+					MaxSizeMb:  100,
+					MaxBackups: 10,
+					Compress:   true,
+				},
+			},
+			want: &lumberjack.Logger{
+				Filename:   "/tmp/events.json",
+				MaxSize:    100,
+				MaxBackups: 10,
+				Compress:   true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := newFileLogger(tc.target)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreUnexported(lumberjack.Logger{})); diff != "" {
+				t.Errorf("newFileLogger(%v) returned unexpected diff (-want +got):\n%s", tc.target, diff)
+			}
+		})
+	}
+}
+
+func TestFileTargetWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	f, err := NewFileTarget(&evpb.EventTarget{Target: &evpb.EventTarget_FileEndpoint{FileEndpoint: path}})
+	if err != nil {
+		t.Fatalf("NewFileTarget() returned error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := f.Write(map[string]int{"value": i}); err != nil {
+				t.Errorf("Write() returned error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile(%s) returned error: %v", path, err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) != 50 {
+		t.Errorf("Write() wrote %d lines, want: 50", len(lines))
+	}
+	for _, l := range lines {
+		if !strings.HasPrefix(l, `{"value":`) || !strings.HasSuffix(l, "}") {
+			t.Errorf("Write() wrote malformed line: %q", l)
+		}
+	}
+}
+
+func TestFileTargetWriteError(t *testing.T) {
+	f, err := NewFileTarget(&evpb.EventTarget{Target: &evpb.EventTarget_FileEndpoint{FileEndpoint: filepath.Join(t.TempDir(), "events.json")}})
+	if err != nil {
+		t.Fatalf("NewFileTarget() returned error: %v", err)
+	}
+	defer f.Close()
+	if err := f.Write(make(chan int)); err == nil {
+		t.Error("Write(chan) succeeded, want error")
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+// GuestLogReader evaluates GuestLog event sources by reading their log files
+// incrementally. The byte offset reached in each file is remembered between
+// polls so that only newly appended content is scanned.
+type GuestLogReader struct {
+	mu      sync.Mutex
+	offsets map[string]int64
+}
+
+// NewGuestLogReader creates a GuestLogReader with no files read yet.
+func NewGuestLogReader() *GuestLogReader {
+	return &GuestLogReader{offsets: make(map[string]int64)}
+}
+
+// Read returns the number of lines matching the match_pattern of the source
+// which were appended to its log file since the previous call. The first read
+// of a file scans it in full, as does a read after the file was rotated, which
+// is detected by the file having shrunk below the stored offset.
+//
+// A trailing line without a newline is not counted until it is complete.
+func (r *GuestLogReader) Read(source *evpb.EventSource_GuestLog) (int64, error) {
+	path := source.GetLogFilePath()
+	if path == "" {
+		return 0, fmt.Errorf("guest log source does not have a log file path: %v", source)
+	}
+	re, err := regexp.Compile(source.GetMatchPattern())
+	if err != nil {
+		return 0, fmt.Errorf("compiling match pattern %q: %w", source.GetMatchPattern(), err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("opening log file: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("reading log file info: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	offset := r.offsets[path]
+	if info.Size() < offset {
+		// The file was rotated or truncated, scan it from the beginning.
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("seeking log file to offset %d: %w", offset, err)
+	}
+
+	var count int64
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("reading log file: %w", err)
+		}
+		offset += int64(len(line))
+		if re.Match(line) {
+			count++
+		}
+	}
+	r.offsets[path] = offset
+	return count, nil
+}
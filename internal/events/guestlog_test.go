@@ -0,0 +1,126 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+func appendToFile(t *testing.T, path, content string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("os.OpenFile(%s) returned error: %v", path, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatalf("WriteString(%q) returned error: %v", content, err)
+	}
+}
+
+func TestGuestLogReaderRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.log")
+	source := &evpb.EventSource_GuestLog{LogFilePath: path, MatchPattern: "ERROR"}
+	r := NewGuestLogReader()
+
+	// Each step appends to (or replaces) the log file and then polls it.
+	steps := []struct {
+		name    string
+		append  string
+		replace bool
+		want    int64
+	}{
+		{
+			name:   "InitialFullScan",
+			append: "INFO start\nERROR one\nERROR two\n",
+			want:   2,
+		},
+		{
+			name: "NothingAppended",
+			want: 0,
+		},
+		{
+			name:   "OnlyAppendedContentScanned",
+			append: "INFO ok\nERROR three\n",
+			want:   1,
+		},
+		{
+			name:   "PartialLineNotCounted",
+			append: "ERROR fo",
+			want:   0,
+		},
+		{
+			name:   "PartialLineCountedOnceComplete",
+			append: "ur\n",
+			want:   1,
+		},
+		{
+			name:    "RotatedFileScannedInFull",
+			append:  "ERROR after rotation\n",
+			replace: true,
+			want:    1,
+		},
+	}
+
+	for _, step := range steps {
+		if step.replace {
+			if err := os.Remove(path); err != nil {
+				t.Fatalf("%s: os.Remove(%s) returned error: %v", step.name, path, err)
+			}
+		}
+		appendToFile(t, path, step.append)
+		got, err := r.Read(source)
+		if err != nil {
+			t.Fatalf("%s: Read(%v) returned error: %v", step.name, source, err)
+		}
+		if got != step.want {
+			t.Errorf("%s: Read(%v) = %d, want: %d", step.name, source, got, step.want)
+		}
+	}
+}
+
+func TestGuestLogReaderReadError(t *testing.T) {
+	tests := []struct {
+		name   string
+		source *evpb.EventSource_GuestLog
+	}{
+		{
+			name:   "NoLogFilePath",
+			source: &evpb.EventSource_GuestLog{MatchPattern: "ERROR"},
+		},
+		{
+			name:   "InvalidPattern",
+			source: &evpb.EventSource_GuestLog{LogFilePath: "/tmp/agent.log", MatchPattern: "ERROR("},
+		},
+		{
+			name:   "MissingFile",
+			source: &evpb.EventSource_GuestLog{LogFilePath: filepath.Join(t.TempDir(), "missing.log"), MatchPattern: "ERROR"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewGuestLogReader().Read(tc.source); err == nil {
+				t.Errorf("Read(%v) succeeded, want error", tc.source)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/idtoken"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+const httpTargetTimeout = 10 * time.Second
+
+// SecretGetter reads the latest version of a Secret Manager secret, as gce.GCE.GetSecret does.
+type SecretGetter func(ctx context.Context, projectID, secretName string) (string, error)
+
+// IDTokenSourceGetter creates a token source of OIDC identity tokens minted for the audience.
+type IDTokenSourceGetter func(ctx context.Context, audience string) (oauth2.TokenSource, error)
+
+// HTTPAuth holds what is needed to authenticate the requests of an http_endpoint target.
+// Only the fields used by the authentication configured on the target need to be set.
+type HTTPAuth struct {
+	ProjectID string
+	GetSecret SecretGetter
+	// IDTokenSource defaults to idtoken.NewTokenSource, which mints the tokens with the
+	// default credentials of the instance.
+	IDTokenSource IDTokenSourceGetter
+}
+
+// HTTPTarget POSTs event payloads as JSON to the http_endpoint of an EventTarget.
+// Requests carry an Authorization header when the target configures a bearer
+// token, a bearer token stored in Secret Manager, or an OIDC audience.
+type HTTPTarget struct {
+	endpoint    string
+	client      *http.Client
+	tokenSource oauth2.TokenSource
+}
+
+// NewHTTPTarget creates an HTTPTarget for the http_endpoint of the target.
+func NewHTTPTarget(ctx context.Context, target *evpb.EventTarget, auth HTTPAuth) (*HTTPTarget, error) {
+	if target.GetHttpEndpoint() == "" {
+		return nil, fmt.Errorf("event target does not have an http endpoint: %v", target)
+	}
+	ts, err := httpTokenSource(ctx, target, auth)
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPTarget{
+		endpoint:    target.GetHttpEndpoint(),
+		client:      &http.Client{Timeout: httpTargetTimeout},
+		tokenSource: ts,
+	}, nil
+}
+
+// httpTokenSource returns the source of the tokens authenticating the requests of the
+// target, nil when the target is not authenticated.
+func httpTokenSource(ctx context.Context, target *evpb.EventTarget, auth HTTPAuth) (oauth2.TokenSource, error) {
+	configured := 0
+	for _, v := range []string{target.GetHttpBearerToken(), target.GetHttpBearerTokenSecret(), target.GetHttpOidcAudience()} {
+		if v != "" {
+			configured++
+		}
+	}
+	if configured > 1 {
+		return nil, errors.New("only one of http_bearer_token, http_bearer_token_secret and http_oidc_audience can be set")
+	}
+
+	switch {
+	case target.GetHttpBearerToken() != "":
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: target.GetHttpBearerToken(), TokenType: "Bearer"}), nil
+	case target.GetHttpBearerTokenSecret() != "":
+		if auth.GetSecret == nil {
+			return nil, errors.New("secret manager is not available to read http_bearer_token_secret")
+		}
+		token, err := auth.GetSecret(ctx, auth.ProjectID, target.GetHttpBearerTokenSecret())
+		if err != nil {
+			return nil, fmt.Errorf("reading http_bearer_token_secret %q: %w", target.GetHttpBearerTokenSecret(), err)
+		}
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token, TokenType: "Bearer"}), nil
+	case target.GetHttpOidcAudience() != "":
+		newTokenSource := auth.IDTokenSource
+		if newTokenSource == nil {
+			newTokenSource = func(ctx context.Context, audience string) (oauth2.TokenSource, error) {
+				return idtoken.NewTokenSource(ctx, audience)
+			}
+		}
+		ts, err := newTokenSource(ctx, target.GetHttpOidcAudience())
+		if err != nil {
+			return nil, fmt.Errorf("creating identity token source for audience %q: %w", target.GetHttpOidcAudience(), err)
+		}
+		return ts, nil
+	}
+	return nil, nil
+}
+
+// Write marshals the payload to JSON and POSTs it to the endpoint. Responses
+// other than 2xx are returned as an error.
+func (h *HTTPTarget) Write(ctx context.Context, payload any) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshalling event payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.endpoint, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("creating request to %s: %w", h.endpoint, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.tokenSource != nil {
+		token, err := h.tokenSource.Token()
+		if err != nil {
+			return fmt.Errorf("getting token for %s: %w", h.endpoint, err)
+		}
+		token.SetAuthHeader(req)
+	}
+	res, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending event to %s: %w", h.endpoint, err)
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("sending event to %s: unexpected status %s", h.endpoint, res.Status)
+	}
+	return nil
+}
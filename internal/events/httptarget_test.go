@@ -0,0 +1,173 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+func httpTarget(endpoint string) *evpb.EventTarget {
+	return &evpb.EventTarget{Target: &evpb.EventTarget_HttpEndpoint{HttpEndpoint: endpoint}}
+}
+
+func TestHTTPTargetWrite(t *testing.T) {
+	fakeIDTokenSource := func(ctx context.Context, audience string) (oauth2.TokenSource, error) {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "id-token-for-" + audience}), nil
+	}
+	tests := []struct {
+		name       string
+		configure  func(*evpb.EventTarget)
+		auth       HTTPAuth
+		status     int
+		wantHeader string
+		wantErr    bool
+	}{
+		{
+			name:   "NoAuthentication",
+			status: http.StatusOK,
+		},
+		{
+			name:       "BearerToken",
+			configure:  func(t *evpb.EventTarget) { t.HttpBearerToken = "static-token" },
+			status:     http.StatusAccepted,
+			wantHeader: "Bearer static-token",
+		},
+		{
+			name:      "BearerTokenSecret",
+			configure: func(t *evpb.EventTarget) { t.HttpBearerTokenSecret = "events-token" },
+			auth: HTTPAuth{
+				ProjectID: "my-project",
+				GetSecret: func(ctx context.Context, projectID, secretName string) (string, error) {
+					return projectID + "/" + secretName, nil
+				},
+			},
+			status:     http.StatusOK,
+			wantHeader: "Bearer my-project/events-token",
+		},
+		{
+			name:       "OIDCAudience",
+			configure:  func(t *evpb.EventTarget) { t.HttpOidcAudience = "https://events.run.app" },
+			auth:       HTTPAuth{IDTokenSource: fakeIDTokenSource},
+			status:     http.StatusOK,
+			wantHeader: "Bearer id-token-for-https://events.run.app",
+		},
+		{
+			name:       "UnsuccessfulStatus",
+			configure:  func(t *evpb.EventTarget) { t.HttpBearerToken = "static-token" },
+			status:     http.StatusUnauthorized,
+			wantHeader: "Bearer static-token",
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotHeader, gotBody string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header.Get("Authorization")
+				b, _ := io.ReadAll(r.Body)
+				gotBody = string(b)
+				w.WriteHeader(tc.status)
+			}))
+			defer ts.Close()
+
+			target := httpTarget(ts.URL)
+			if tc.configure != nil {
+				tc.configure(target)
+			}
+			h, err := NewHTTPTarget(context.Background(), target, tc.auth)
+			if err != nil {
+				t.Fatalf("NewHTTPTarget(%v) returned error: %v", target, err)
+			}
+			err = h.Write(context.Background(), map[string]string{"rule_id": "r1"})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Write() returned error: %v, wantErr: %t", err, tc.wantErr)
+			}
+			if gotHeader != tc.wantHeader {
+				t.Errorf("Write() sent Authorization header %q, want: %q", gotHeader, tc.wantHeader)
+			}
+			if want := `{"rule_id":"r1"}`; gotBody != want {
+				t.Errorf("Write() sent body %q, want: %q", gotBody, want)
+			}
+		})
+	}
+}
+
+func TestNewHTTPTargetErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		target *evpb.EventTarget
+		auth   HTTPAuth
+	}{
+		{
+			name:   "NoHTTPEndpoint",
+			target: &evpb.EventTarget{Target: &evpb.EventTarget_FileEndpoint{FileEndpoint: "/tmp/events.json"}},
+		},
+		{
+			name: "MultipleAuthentications",
+			target: &evpb.EventTarget{
+				Target:           &evpb.EventTarget_HttpEndpoint{HttpEndpoint: "https://localhost"},
+				HttpBearerToken:  "static-token",
+				HttpOidcAudience: "https://localhost",
+			},
+		},
+		{
+			name: "NoSecretGetter",
+			target: &evpb.EventTarget{
+				Target:                &evpb.EventTarget_HttpEndpoint{HttpEndpoint: "https://localhost"},
+				HttpBearerTokenSecret: "events-token",
+			},
+		},
+		{
+			name: "SecretError",
+			target: &evpb.EventTarget{
+				Target:                &evpb.EventTarget_HttpEndpoint{HttpEndpoint: "https://localhost"},
+				HttpBearerTokenSecret: "events-token",
+			},
+			auth: HTTPAuth{
+				GetSecret: func(context.Context, string, string) (string, error) { return "", errors.New("permission denied") },
+			},
+		},
+		{
+			name: "IDTokenSourceError",
+			target: &evpb.EventTarget{
+				Target:           &evpb.EventTarget_HttpEndpoint{HttpEndpoint: "https://localhost"},
+				HttpOidcAudience: "https://localhost",
+			},
+			auth: HTTPAuth{
+				IDTokenSource: func(context.Context, string) (oauth2.TokenSource, error) { return nil, errors.New("no credentials") },
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewHTTPTarget(context.Background(), tc.target, tc.auth); err == nil {
+				t.Errorf("NewHTTPTarget(%v) returned nil error, want error", tc.target)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+const metadataServerURL = "http://metadata.google.internal/computeMetadata/v1/"
+
+// MetadataFetcher returns the response body for a GCE metadata server URL.
+type MetadataFetcher func(ctx context.Context, url string) ([]byte, error)
+
+// MetadataReader evaluates Metadata event sources.
+type MetadataReader struct {
+	Fetch MetadataFetcher
+}
+
+// NewMetadataReader creates a MetadataReader which queries the GCE metadata server.
+func NewMetadataReader() *MetadataReader {
+	return &MetadataReader{Fetch: fetchMetadata}
+}
+
+// Read fetches the url of the source and returns its value coerced to the
+// value_type of the source. When a json_path is set the response is parsed as
+// JSON and the value at the path is used instead of the whole response.
+func (r *MetadataReader) Read(ctx context.Context, source *evpb.EventSource_Metadata) (any, error) {
+	body, err := r.Fetch(ctx, source.GetUrl())
+	if err != nil {
+		return nil, fmt.Errorf("fetching metadata %q: %w", source.GetUrl(), err)
+	}
+	var value any = strings.TrimSpace(string(body))
+	if source.GetJsonPath() != "" {
+		if value, err = extractJSONPath(body, source.GetJsonPath()); err != nil {
+			return nil, err
+		}
+	}
+	if value, err = coerceValue(value, source.GetValueType()); err != nil {
+		return nil, fmt.Errorf("converting metadata %q: %w", source.GetUrl(), err)
+	}
+	return value, nil
+}
+
+// fetchMetadata performs a GET request to the metadata server. URLs which are
+// not absolute are resolved relative to the computeMetadata/v1 endpoint.
+func fetchMetadata(ctx context.Context, url string) ([]byte, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		url = metadataServerURL + strings.TrimPrefix(url, "/")
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Metadata-Flavor", "Google")
+	client := &http.Client{Timeout: 2 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("unsuccessful response from metadata server: %s", res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// extractJSONPath returns the value at the slash separated path of the JSON
+// document. Object members are selected by key and array elements by index.
+func extractJSONPath(body []byte, path string) (any, error) {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, fmt.Errorf("parsing metadata response as JSON: %w", err)
+	}
+	for _, key := range strings.Split(strings.Trim(path, "/"), "/") {
+		switch v := value.(type) {
+		case map[string]any:
+			next, ok := v[key]
+			if !ok {
+				return nil, fmt.Errorf("json path %q does not exist: no key %q", path, key)
+			}
+			value = next
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("json path %q does not exist: no index %q in array of length %d", path, key, len(v))
+			}
+			value = v[i]
+		default:
+			return nil, fmt.Errorf("json path %q does not exist: cannot select %q from a %T value", path, key, value)
+		}
+	}
+	return value, nil
+}
+
+// coerceValue converts a value decoded from a metadata response to the Go
+// type of the value type: bool, int64, float64 or string.
+func coerceValue(value any, valueType evpb.EventSource_ValueType) (any, error) {
+	switch valueType {
+	case evpb.EventSource_BOOL:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+	case evpb.EventSource_INT64:
+		switch v := value.(type) {
+		case float64:
+			if v == float64(int64(v)) {
+				return int64(v), nil
+			}
+		case string:
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case evpb.EventSource_DOUBLE:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+	default:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	return nil, fmt.Errorf("cannot convert metadata value %v of type %T to %s", value, value, valueType)
+}
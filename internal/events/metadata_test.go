@@ -0,0 +1,181 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+const testMetadataJSON = `{
+	"attributes": {"some-key": "some-value", "enabled": "true", "count": "7"},
+	"disks": [{"index": 0, "sizeGb": 100.5}, {"index": 1, "sizeGb": 200}],
+	"preemptible": false
+}`
+
+func fakeFetch(body string, err error) MetadataFetcher {
+	return func(context.Context, string) ([]byte, error) {
+		return []byte(body), err
+	}
+}
+
+func TestMetadataReaderRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		fetch   MetadataFetcher
+		source  *evpb.EventSource_Metadata
+		want    any
+		wantErr bool
+	}{
+		{
+			name:   "PlainString",
+			fetch:  fakeFetch("TRUE\n", nil),
+			source: &evpb.EventSource_Metadata{ValueType: evpb.EventSource_STRING},
+			want:   "TRUE",
+		},
+		{
+			name:   "PlainBool",
+			fetch:  fakeFetch("TRUE", nil),
+			source: &evpb.EventSource_Metadata{ValueType: evpb.EventSource_BOOL},
+			want:   true,
+		},
+		{
+			name:   "NestedString",
+			fetch:  fakeFetch(testMetadataJSON, nil),
+			source: &evpb.EventSource_Metadata{JsonPath: "attributes/some-key", ValueType: evpb.EventSource_STRING},
+			want:   "some-value",
+		},
+		{
+			name:   "NestedStringCoercedToInt",
+			fetch:  fakeFetch(testMetadataJSON, nil),
+			source: &evpb.EventSource_Metadata{JsonPath: "/attributes/count/", ValueType: evpb.EventSource_INT64},
+			want:   int64(7),
+		},
+		{
+			name:   "ArrayIndex",
+			fetch:  fakeFetch(testMetadataJSON, nil),
+			source: &evpb.EventSource_Metadata{JsonPath: "disks/1/sizeGb", ValueType: evpb.EventSource_INT64},
+			want:   int64(200),
+		},
+		{
+			name:   "Double",
+			fetch:  fakeFetch(testMetadataJSON, nil),
+			source: &evpb.EventSource_Metadata{JsonPath: "disks/0/sizeGb", ValueType: evpb.EventSource_DOUBLE},
+			want:   100.5,
+		},
+		{
+			name:   "Bool",
+			fetch:  fakeFetch(testMetadataJSON, nil),
+			source: &evpb.EventSource_Metadata{JsonPath: "preemptible", ValueType: evpb.EventSource_BOOL},
+			want:   false,
+		},
+		{
+			name:   "ObjectAsString",
+			fetch:  fakeFetch(testMetadataJSON, nil),
+			source: &evpb.EventSource_Metadata{JsonPath: "disks/0", ValueType: evpb.EventSource_STRING},
+			want:   `{"index":0,"sizeGb":100.5}`,
+		},
+		{
+			name:    "FetchError",
+			fetch:   fakeFetch("", cmpopts.AnyError),
+			source:  &evpb.EventSource_Metadata{ValueType: evpb.EventSource_STRING},
+			wantErr: true,
+		},
+		{
+			name:    "NotJSON",
+			fetch:   fakeFetch("plain text", nil),
+			source:  &evpb.EventSource_Metadata{JsonPath: "attributes", ValueType: evpb.EventSource_STRING},
+			wantErr: true,
+		},
+		{
+			name:    "MissingKey",
+			fetch:   fakeFetch(testMetadataJSON, nil),
+			source:  &evpb.EventSource_Metadata{JsonPath: "attributes/missing", ValueType: evpb.EventSource_STRING},
+			wantErr: true,
+		},
+		{
+			name:    "IndexOutOfRange",
+			fetch:   fakeFetch(testMetadataJSON, nil),
+			source:  &evpb.EventSource_Metadata{JsonPath: "disks/2", ValueType: evpb.EventSource_STRING},
+			wantErr: true,
+		},
+		{
+			name:    "PathThroughScalar",
+			fetch:   fakeFetch(testMetadataJSON, nil),
+			source:  &evpb.EventSource_Metadata{JsonPath: "preemptible/value", ValueType: evpb.EventSource_STRING},
+			wantErr: true,
+		},
+		{
+			name:    "FractionalNumberAsInt",
+			fetch:   fakeFetch(testMetadataJSON, nil),
+			source:  &evpb.EventSource_Metadata{JsonPath: "disks/0/sizeGb", ValueType: evpb.EventSource_INT64},
+			wantErr: true,
+		},
+		{
+			name:    "StringNotBool",
+			fetch:   fakeFetch(testMetadataJSON, nil),
+			source:  &evpb.EventSource_Metadata{JsonPath: "attributes/some-key", ValueType: evpb.EventSource_BOOL},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &MetadataReader{Fetch: tc.fetch}
+			got, err := r.Read(context.Background(), tc.source)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Read(%v) returned error: %v, wantErr: %t", tc.source, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Read(%v) returned unexpected diff (-want +got):\n%s", tc.source, diff)
+			}
+		})
+	}
+}
+
+func TestFetchMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("value"))
+	}))
+	defer server.Close()
+
+	got, err := fetchMetadata(context.Background(), server.URL+"/attribute")
+	if err != nil {
+		t.Fatalf("fetchMetadata() returned error: %v", err)
+	}
+	if string(got) != "value" {
+		t.Errorf("fetchMetadata() = %q, want: %q", got, "value")
+	}
+	if _, err := fetchMetadata(context.Background(), server.URL+"/missing"); err == nil {
+		t.Error("fetchMetadata() for a missing url succeeded, want error")
+	}
+}
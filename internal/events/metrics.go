@@ -0,0 +1,148 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"sort"
+	"sync"
+
+	metricpb "google.golang.org/genproto/googleapis/api/metric"
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/GoogleCloudPlatform/sapagent/shared/timeseries"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+)
+
+const (
+	metricPrefix         = "workload.googleapis.com/sap/agent/events/"
+	ruleCountMetric      = metricPrefix + "rules"
+	rulesEvaluatedMetric = metricPrefix + "rules/evaluated"
+	rulesTriggeredMetric = metricPrefix + "rules/triggered"
+	targetDeliveryMetric = metricPrefix + "deliveries"
+	unlabeledRuleLabel   = "unlabeled"
+)
+
+// RuleCountTimeSeries returns the number of active event rules, to be sent after each
+// successful reload of the rules.
+func RuleCountTimeSeries(cp *ipb.CloudProperties, count int) *mrpb.TimeSeries {
+	return timeseries.BuildInt(timeseries.Params{
+		CloudProp:  timeseries.ConvertCloudProperties(cp),
+		MetricType: ruleCountMetric,
+		Timestamp:  tspb.Now(),
+		Int64Value: int64(count),
+	})
+}
+
+// labelCounts are the cumulative counters of the rules having a label.
+type labelCounts struct {
+	evaluated, triggered, delivered, failed int64
+}
+
+// Stats counts, per rule label, the rules evaluated and triggered and the target deliveries which
+// succeeded or failed. A rule is counted under each of its labels, rules without a label are
+// counted under "unlabeled". The counters are cumulative from the creation of the Stats, so they
+// only reset when the agent restarts. Stats is safe for concurrent use.
+type Stats struct {
+	mu     sync.Mutex
+	start  *tspb.Timestamp
+	counts map[string]*labelCounts
+}
+
+// NewStats creates a Stats with all of its counters at zero.
+func NewStats() *Stats {
+	return &Stats{start: tspb.Now(), counts: make(map[string]*labelCounts)}
+}
+
+// RuleEvaluated counts an evaluation of the rule, and whether its trigger fired.
+func (s *Stats) RuleEvaluated(rule *evpb.Rule, triggered bool) {
+	s.add(rule, func(c *labelCounts) {
+		c.evaluated++
+		if triggered {
+			c.triggered++
+		}
+	})
+}
+
+// TargetDelivered counts a delivery of an event of the rule to one of its targets, err is the
+// error returned by the target.
+func (s *Stats) TargetDelivered(rule *evpb.Rule, err error) {
+	s.add(rule, func(c *labelCounts) {
+		if err != nil {
+			c.failed++
+			return
+		}
+		c.delivered++
+	})
+}
+
+func (s *Stats) add(rule *evpb.Rule, inc func(*labelCounts)) {
+	labels := rule.GetLabels()
+	if len(labels) == 0 {
+		labels = []string{unlabeledRuleLabel}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, label := range labels {
+		c, ok := s.counts[label]
+		if !ok {
+			c = &labelCounts{}
+			s.counts[label] = c
+		}
+		inc(c)
+	}
+}
+
+// TimeSeries returns the cumulative counters of each rule label, the engine sends them at most
+// once per minute.
+func (s *Stats) TimeSeries(cp *ipb.CloudProperties) []*mrpb.TimeSeries {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	labels := make([]string, 0, len(s.counts))
+	for label := range s.counts {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	var timeSeries []*mrpb.TimeSeries
+	now := tspb.Now()
+	for _, label := range labels {
+		c := s.counts[label]
+		for _, m := range []struct {
+			metricType string
+			labels     map[string]string
+			count      int64
+		}{
+			{rulesEvaluatedMetric, map[string]string{"label": label}, c.evaluated},
+			{rulesTriggeredMetric, map[string]string{"label": label}, c.triggered},
+			{targetDeliveryMetric, map[string]string{"label": label, "status": "success"}, c.delivered},
+			{targetDeliveryMetric, map[string]string{"label": label, "status": "error"}, c.failed},
+		} {
+			timeSeries = append(timeSeries, timeseries.BuildInt(timeseries.Params{
+				CloudProp:    timeseries.ConvertCloudProperties(cp),
+				MetricType:   m.metricType,
+				MetricLabels: m.labels,
+				MetricKind:   metricpb.MetricDescriptor_CUMULATIVE,
+				StartTime:    s.start,
+				Int64Value:   m.count,
+				Timestamp:    now,
+			}))
+		}
+	}
+	return timeSeries
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+)
+
+func TestRuleCountTimeSeries(t *testing.T) {
+	got := RuleCountTimeSeries(&ipb.CloudProperties{ProjectId: "test-project", InstanceId: "123", Zone: "us-central1-a"}, 3)
+	if got.GetMetric().GetType() != "workload.googleapis.com/sap/agent/events/rules" {
+		t.Errorf("RuleCountTimeSeries() metric type = %s, want: workload.googleapis.com/sap/agent/events/rules", got.GetMetric().GetType())
+	}
+	if gotValue := got.GetPoints()[0].GetValue().GetInt64Value(); gotValue != 3 {
+		t.Errorf("RuleCountTimeSeries() value = %d, want: 3", gotValue)
+	}
+}
+
+func TestStatsTimeSeries(t *testing.T) {
+	hana := &evpb.Rule{Id: "hana-down", Labels: []string{"hana", "critical"}}
+	unlabeled := &evpb.Rule{Id: "other"}
+	s := NewStats()
+	s.RuleEvaluated(hana, true)
+	s.TargetDelivered(hana, nil)
+	s.TargetDelivered(hana, errors.New("target unavailable"))
+	s.RuleEvaluated(unlabeled, false)
+	s.RuleEvaluated(hana, false)
+
+	type point struct {
+		MetricType string
+		Labels     map[string]string
+		Value      int64
+	}
+	var got []point
+	for _, ts := range s.TimeSeries(&ipb.CloudProperties{ProjectId: "test-project", InstanceId: "123", Zone: "us-central1-a"}) {
+		if ts.GetMetricKind().String() != "CUMULATIVE" {
+			t.Errorf("TimeSeries() metric kind of %s = %s, want: CUMULATIVE", ts.GetMetric().GetType(), ts.GetMetricKind())
+		}
+		got = append(got, point{ts.GetMetric().GetType(), ts.GetMetric().GetLabels(), ts.GetPoints()[0].GetValue().GetInt64Value()})
+	}
+	counts := func(label string, evaluated, triggered, delivered, failed int64) []point {
+		return []point{
+			{"workload.googleapis.com/sap/agent/events/rules/evaluated", map[string]string{"label": label}, evaluated},
+			{"workload.googleapis.com/sap/agent/events/rules/triggered", map[string]string{"label": label}, triggered},
+			{"workload.googleapis.com/sap/agent/events/deliveries", map[string]string{"label": label, "status": "success"}, delivered},
+			{"workload.googleapis.com/sap/agent/events/deliveries", map[string]string{"label": label, "status": "error"}, failed},
+		}
+	}
+	var want []point
+	want = append(want, counts("critical", 2, 1, 1, 1)...)
+	want = append(want, counts("hana", 2, 1, 1, 1)...)
+	want = append(want, counts("unlabeled", 1, 0, 0, 0)...)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("TimeSeries() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
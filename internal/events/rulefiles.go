@@ -0,0 +1,100 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/prototext"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+// Extensions of the rule files loaded from a rules directory. A .json file holds a JSON array
+// of rules in the protojson format, a .textproto file holds a single rule in the text format.
+const (
+	jsonRuleFileExt      = ".json"
+	textprotoRuleFileExt = ".textproto"
+)
+
+// ReadFileFunc provides a testable replacement for os.ReadFile.
+type ReadFileFunc func(string) ([]byte, error)
+
+// ReadDirFunc provides a testable replacement for os.ReadDir.
+type ReadDirFunc func(string) ([]os.DirEntry, error)
+
+// RuleFilesInDir returns the paths of the .json and .textproto files of the directory, sorted by
+// name. Subdirectories are not read.
+func RuleFilesInDir(dir string, readDir ReadDirFunc) ([]string, error) {
+	entries, err := readDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading rules directory %s: %w", dir, err)
+	}
+	var paths []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		switch filepath.Ext(e.Name()) {
+		case jsonRuleFileExt, textprotoRuleFileExt:
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// LoadRuleFiles parses the rule files and merges their rules in the order of the files. Rule IDs
+// must be unique across all of the files, a duplicate ID is an error naming both files defining it.
+func LoadRuleFiles(paths []string, readFile ReadFileFunc) ([]*evpb.Rule, error) {
+	var rules []*evpb.Rule
+	definedIn := make(map[string]string)
+	for _, path := range paths {
+		data, err := readFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading rule file %s: %w", path, err)
+		}
+		fileRules, err := parseRuleFile(path, data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing rule file %s: %w", path, err)
+		}
+		for _, r := range fileRules {
+			if first, ok := definedIn[r.GetId()]; ok {
+				return nil, fmt.Errorf("duplicate rule id %q defined in %s and %s", r.GetId(), first, path)
+			}
+			definedIn[r.GetId()] = path
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+// parseRuleFile parses the rules of a file in the format of its extension, files which are not
+// .textproto are parsed as JSON.
+func parseRuleFile(path string, data []byte) ([]*evpb.Rule, error) {
+	if filepath.Ext(path) != textprotoRuleFileExt {
+		return ParseRules(data)
+	}
+	rule := &evpb.Rule{}
+	if err := prototext.Unmarshal(data, rule); err != nil {
+		return nil, err
+	}
+	return []*evpb.Rule{rule}, nil
+}
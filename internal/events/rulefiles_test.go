@@ -0,0 +1,134 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func writeRuleFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%s) failed: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestRuleFilesInDir(t *testing.T) {
+	dir := writeRuleFiles(t, map[string]string{
+		"b.json":      "[]",
+		"a.textproto": "",
+		"notes.txt":   "",
+	})
+	if err := os.Mkdir(filepath.Join(dir, "sub.json"), 0755); err != nil {
+		t.Fatalf("os.Mkdir() failed: %v", err)
+	}
+
+	got, err := RuleFilesInDir(dir, os.ReadDir)
+	if err != nil {
+		t.Fatalf("RuleFilesInDir(%s) returned error: %v", dir, err)
+	}
+	want := []string{filepath.Join(dir, "a.textproto"), filepath.Join(dir, "b.json")}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("RuleFilesInDir(%s) returned unexpected diff (-want +got):\n%s", dir, diff)
+	}
+
+	if _, err := RuleFilesInDir(filepath.Join(dir, "missing"), os.ReadDir); err == nil {
+		t.Errorf("RuleFilesInDir(missing) returned nil error, want error")
+	}
+}
+
+func TestLoadRuleFiles(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        map[string]string
+		wantIDs      []string
+		wantErrFiles []string
+	}{
+		{
+			name: "Merged",
+			files: map[string]string{
+				"hana.json":           `[{"id": "hana-down"}, {"id": "hana-memory"}]`,
+				"pacemaker.textproto": `id: "pacemaker-offline" frequency_sec: 60`,
+			},
+			wantIDs: []string{"hana-down", "hana-memory", "pacemaker-offline"},
+		},
+		{
+			name: "DuplicateAcrossFiles",
+			files: map[string]string{
+				"hana.json":   `[{"id": "hana-down"}]`,
+				"hana-2.json": `[{"id": "hana-down"}]`,
+				"other.json":  `[{"id": "other"}]`,
+			},
+			wantErrFiles: []string{"hana-2.json", "hana.json"},
+		},
+		{
+			name: "DuplicateInFile",
+			files: map[string]string{
+				"hana.json": `[{"id": "hana-down"}, {"id": "hana-down"}]`,
+			},
+			wantErrFiles: []string{"hana.json"},
+		},
+		{
+			name: "InvalidTextproto",
+			files: map[string]string{
+				"bad.textproto": `id: `,
+			},
+			wantErrFiles: []string{"bad.textproto"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeRuleFiles(t, tc.files)
+			paths, err := RuleFilesInDir(dir, os.ReadDir)
+			if err != nil {
+				t.Fatalf("RuleFilesInDir(%s) returned error: %v", dir, err)
+			}
+			rules, err := LoadRuleFiles(paths, os.ReadFile)
+			if len(tc.wantErrFiles) > 0 {
+				if err == nil {
+					t.Fatalf("LoadRuleFiles(%v) returned nil error, want error naming %v", paths, tc.wantErrFiles)
+				}
+				for _, f := range tc.wantErrFiles {
+					if !strings.Contains(err.Error(), f) {
+						t.Errorf("LoadRuleFiles(%v) returned error %q, want it to name %s", paths, err, f)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadRuleFiles(%v) returned error: %v", paths, err)
+			}
+			var gotIDs []string
+			for _, r := range rules {
+				gotIDs = append(gotIDs, r.GetId())
+			}
+			if diff := cmp.Diff(tc.wantIDs, gotIDs); diff != "" {
+				t.Errorf("LoadRuleFiles(%v) returned unexpected rule IDs (-want +got):\n%s", paths, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+// ParseRules parses a rule file, a JSON array of rules in the protojson format.
+func ParseRules(data []byte) ([]*evpb.Rule, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing rule file: %w", err)
+	}
+	rules := make([]*evpb.Rule, 0, len(raw))
+	for i, r := range raw {
+		rule := &evpb.Rule{}
+		if err := protojson.Unmarshal(r, rule); err != nil {
+			return nil, fmt.Errorf("parsing rule %d: %w", i, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// SourceValueType returns the type of the values produced by the event source. A cloud
+// monitoring metric read by label name produces the label value as a string.
+func SourceValueType(source *evpb.EventSource) evpb.EventSource_ValueType {
+	switch s := source.GetSource().(type) {
+	case *evpb.EventSource_CloudMonitoringMetric_:
+		if s.CloudMonitoringMetric.GetLabelName() != "" {
+			return evpb.EventSource_STRING
+		}
+		return s.CloudMonitoringMetric.GetMetricValueType()
+	case *evpb.EventSource_CloudLogging_:
+		return s.CloudLogging.GetValueType()
+	case *evpb.EventSource_Metadata_:
+		return s.Metadata.GetValueType()
+	case *evpb.EventSource_GuestLog_:
+		if s.GuestLog.GetLogFilePath() != "" {
+			return evpb.EventSource_INT64
+		}
+		return s.GuestLog.GetValueType()
+	}
+	return evpb.EventSource_UNSPECIFIED
+}
+
+// ParseValue converts a value read as text to the Go type of the value type.
+func ParseValue(value string, valueType evpb.EventSource_ValueType) (any, error) {
+	return coerceValue(strings.TrimSpace(value), valueType)
+}
+
+// SourceReader reads the current value of an event source.
+type SourceReader func(ctx context.Context, source *evpb.EventSource) (any, error)
+
+// NewSourceReader returns a SourceReader for the Metadata sources, the GuestLog sources
+// read incrementally from a log file and, when cloudLogging is not nil, the CloudLogging
+// sources. Other sources are not supported.
+func NewSourceReader(metadata *MetadataReader, guestLog *GuestLogReader, cloudLogging *CloudLoggingReader) SourceReader {
+	return func(ctx context.Context, source *evpb.EventSource) (any, error) {
+		switch s := source.GetSource().(type) {
+		case *evpb.EventSource_Metadata_:
+			return metadata.Read(ctx, s.Metadata)
+		case *evpb.EventSource_CloudLogging_:
+			if cloudLogging != nil {
+				return cloudLogging.Read(ctx, s.CloudLogging)
+			}
+		case *evpb.EventSource_GuestLog_:
+			if s.GuestLog.GetLogFilePath() != "" {
+				return guestLog.Read(s.GuestLog)
+			}
+		}
+		return nil, fmt.Errorf("reading event source %v is not supported", source)
+	}
+}
+
+// ResolveRHS returns the value the trigger compares against. When the trigger has an
+// rhs_source it is read with read, otherwise the literal rhs is returned.
+func ResolveRHS(ctx context.Context, trigger *evpb.EvalNode, read SourceReader) (string, error) {
+	if trigger.GetRhsSource() == nil {
+		return trigger.GetRhs(), nil
+	}
+	value, err := read(ctx, trigger.GetRhsSource())
+	if err != nil {
+		return "", fmt.Errorf("reading rhs source: %w", err)
+	}
+	return fmt.Sprint(value), nil
+}
+
+// EvaluateWithSource reports whether the trigger fires for the value, comparing it against
+// the rhs resolved by ResolveRHS.
+func EvaluateWithSource(ctx context.Context, trigger *evpb.EvalNode, value any, read SourceReader) (bool, error) {
+	rhs, err := ResolveRHS(ctx, trigger, read)
+	if err != nil {
+		return false, err
+	}
+	return evaluate(trigger.GetOperation(), value, rhs)
+}
+
+// Evaluate reports whether the trigger fires for the value. The numeric operations compare
+// the value with the rhs parsed as a number, EQ and NEQ also accept boolean values. EQSTR and
+// SUBSTR compare the text of the value with the rhs, EQSTR_CI and SUBSTR_CI do the same ignoring
+// case.
+func Evaluate(trigger *evpb.EvalNode, value any) (bool, error) {
+	return evaluate(trigger.GetOperation(), value, trigger.GetRhs())
+}
+
+func evaluate(operation evpb.EvalNode_EvalType, value any, rhs string) (bool, error) {
+	switch operation {
+	case evpb.EvalNode_EQSTR:
+		return fmt.Sprint(value) == rhs, nil
+	case evpb.EvalNode_SUBSTR:
+		return strings.Contains(fmt.Sprint(value), rhs), nil
+	case evpb.EvalNode_EQSTR_CI:
+		return strings.EqualFold(fmt.Sprint(value), rhs), nil
+	case evpb.EvalNode_SUBSTR_CI:
+		return strings.Contains(strings.ToLower(fmt.Sprint(value)), strings.ToLower(rhs)), nil
+	case evpb.EvalNode_UNDEFINED:
+		return false, fmt.Errorf("trigger operation is not set")
+	}
+
+	if b, ok := value.(bool); ok {
+		want, err := strconv.ParseBool(rhs)
+		if err != nil {
+			return false, fmt.Errorf("parsing rhs %q as bool: %w", rhs, err)
+		}
+		switch operation {
+		case evpb.EvalNode_EQ:
+			return b == want, nil
+		case evpb.EvalNode_NEQ:
+			return b != want, nil
+		}
+		return false, fmt.Errorf("operation %s is not supported for bool values", operation)
+	}
+
+	var lhs float64
+	switch v := value.(type) {
+	case int64:
+		lhs = float64(v)
+	case float64:
+		lhs = v
+	default:
+		return false, fmt.Errorf("operation %s is not supported for value %v of type %T", operation, value, value)
+	}
+	r, err := strconv.ParseFloat(rhs, 64)
+	if err != nil {
+		return false, fmt.Errorf("parsing rhs %q as number: %w", rhs, err)
+	}
+	switch operation {
+	case evpb.EvalNode_EQ:
+		return lhs == r, nil
+	case evpb.EvalNode_NEQ:
+		return lhs != r, nil
+	case evpb.EvalNode_LT:
+		return lhs < r, nil
+	case evpb.EvalNode_LTE:
+		return lhs <= r, nil
+	case evpb.EvalNode_GT:
+		return lhs > r, nil
+	case evpb.EvalNode_GTE:
+		return lhs >= r, nil
+	}
+	return false, fmt.Errorf("unknown trigger operation %s", operation)
+}
@@ -0,0 +1,379 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []*evpb.Rule
+		wantErr bool
+	}{
+		{
+			name: "Rules",
+			data: `[
+				{"id": "hana-down", "trigger": {"rhs": "0", "operation": "EQ"}, "target": [{"fileEndpoint": "/tmp/events.json"}]},
+				{"id": "log-errors", "source": {"guestLog": {"logFilePath": "/var/log/messages", "matchPattern": "ERROR"}}}
+			]`,
+			want: []*evpb.Rule{
+				{
+					Id:      "hana-down",
+					Trigger: &evpb.EvalNode{Rhs: "0", Operation: evpb.EvalNode_EQ},
+					Target:  []*evpb.EventTarget{{Target: &evpb.EventTarget_FileEndpoint{FileEndpoint: "/tmp/events.json"}}},
+				},
+				{
+					Id: "log-errors",
+					Source: &evpb.EventSource{Source: &evpb.EventSource_GuestLog_{GuestLog: &evpb.EventSource_GuestLog{
+						LogFilePath:  "/var/log/messages",
+						MatchPattern: "ERROR",
+					}}},
+				},
+			},
+		},
+		{
+			name:    "NotAnArray",
+			data:    `{"id": "hana-down"}`,
+			wantErr: true,
+		},
+		{
+			name:    "UnknownField",
+			data:    `[{"id": "hana-down", "unknown": true}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseRules([]byte(tc.data))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ParseRules() returned error: %v, wantErr: %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); !tc.wantErr && diff != "" {
+				t.Errorf("ParseRules() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSourceValueType(t *testing.T) {
+	tests := []struct {
+		name   string
+		source *evpb.EventSource
+		want   evpb.EventSource_ValueType
+	}{
+		{
+			name: "MetricLabel",
+			source: &evpb.EventSource{Source: &evpb.EventSource_CloudMonitoringMetric_{CloudMonitoringMetric: &evpb.EventSource_CloudMonitoringMetric{
+				Metric: &evpb.EventSource_CloudMonitoringMetric_LabelName{LabelName: "state"},
+			}}},
+			want: evpb.EventSource_STRING,
+		},
+		{
+			name: "MetricValue",
+			source: &evpb.EventSource{Source: &evpb.EventSource_CloudMonitoringMetric_{CloudMonitoringMetric: &evpb.EventSource_CloudMonitoringMetric{
+				Metric: &evpb.EventSource_CloudMonitoringMetric_MetricValueType{MetricValueType: evpb.EventSource_DOUBLE},
+			}}},
+			want: evpb.EventSource_DOUBLE,
+		},
+		{
+			name:   "Metadata",
+			source: &evpb.EventSource{Source: &evpb.EventSource_Metadata_{Metadata: &evpb.EventSource_Metadata{ValueType: evpb.EventSource_BOOL}}},
+			want:   evpb.EventSource_BOOL,
+		},
+		{
+			name:   "GuestLogFile",
+			source: &evpb.EventSource{Source: &evpb.EventSource_GuestLog_{GuestLog: &evpb.EventSource_GuestLog{LogFilePath: "/var/log/messages"}}},
+			want:   evpb.EventSource_INT64,
+		},
+		{
+			name: "NoSource",
+			want: evpb.EventSource_UNSPECIFIED,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SourceValueType(tc.source); got != tc.want {
+				t.Errorf("SourceValueType(%v) = %v, want: %v", tc.source, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name    string
+		trigger *evpb.EvalNode
+		value   any
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "IntEQ",
+			trigger: &evpb.EvalNode{Rhs: "0", Operation: evpb.EvalNode_EQ},
+			value:   int64(0),
+			want:    true,
+		},
+		{
+			name:    "IntNEQ",
+			trigger: &evpb.EvalNode{Rhs: "0", Operation: evpb.EvalNode_NEQ},
+			value:   int64(0),
+			want:    false,
+		},
+		{
+			name:    "DoubleGT",
+			trigger: &evpb.EvalNode{Rhs: "90", Operation: evpb.EvalNode_GT},
+			value:   95.5,
+			want:    true,
+		},
+		{
+			name:    "DoubleLTE",
+			trigger: &evpb.EvalNode{Rhs: "90", Operation: evpb.EvalNode_LTE},
+			value:   95.5,
+			want:    false,
+		},
+		{
+			name:    "IntLT",
+			trigger: &evpb.EvalNode{Rhs: "10", Operation: evpb.EvalNode_LT},
+			value:   int64(3),
+			want:    true,
+		},
+		{
+			name:    "IntGTE",
+			trigger: &evpb.EvalNode{Rhs: "10", Operation: evpb.EvalNode_GTE},
+			value:   int64(10),
+			want:    true,
+		},
+		{
+			name:    "BoolEQ",
+			trigger: &evpb.EvalNode{Rhs: "true", Operation: evpb.EvalNode_EQ},
+			value:   true,
+			want:    true,
+		},
+		{
+			name:    "BoolGT",
+			trigger: &evpb.EvalNode{Rhs: "true", Operation: evpb.EvalNode_GT},
+			value:   true,
+			wantErr: true,
+		},
+		{
+			name:    "EQSTR",
+			trigger: &evpb.EvalNode{Rhs: "PRIMARY", Operation: evpb.EvalNode_EQSTR},
+			value:   "PRIMARY",
+			want:    true,
+		},
+		{
+			name:    "SUBSTR",
+			trigger: &evpb.EvalNode{Rhs: "ERROR", Operation: evpb.EvalNode_SUBSTR},
+			value:   "2024-01-01 ERROR disk full",
+			want:    true,
+		},
+		{
+			name:    "SUBSTRCaseSensitive",
+			trigger: &evpb.EvalNode{Rhs: "error", Operation: evpb.EvalNode_SUBSTR},
+			value:   "2024-01-01 ERROR disk full",
+			want:    false,
+		},
+		{
+			name:    "EQSTRCaseSensitive",
+			trigger: &evpb.EvalNode{Rhs: "primary", Operation: evpb.EvalNode_EQSTR},
+			value:   "PRIMARY",
+			want:    false,
+		},
+		{
+			name:    "EQSTR_CI",
+			trigger: &evpb.EvalNode{Rhs: "primary", Operation: evpb.EvalNode_EQSTR_CI},
+			value:   "PRIMARY",
+			want:    true,
+		},
+		{
+			name:    "EQSTR_CINoMatch",
+			trigger: &evpb.EvalNode{Rhs: "primary", Operation: evpb.EvalNode_EQSTR_CI},
+			value:   "SECONDARY",
+			want:    false,
+		},
+		{
+			name:    "SUBSTR_CI",
+			trigger: &evpb.EvalNode{Rhs: "error", Operation: evpb.EvalNode_SUBSTR_CI},
+			value:   "2024-01-01 Error disk full",
+			want:    true,
+		},
+		{
+			name:    "SUBSTR_CINonString",
+			trigger: &evpb.EvalNode{Rhs: "TRUE", Operation: evpb.EvalNode_SUBSTR_CI},
+			value:   true,
+			want:    true,
+		},
+		{
+			name:    "StringNumericOperation",
+			trigger: &evpb.EvalNode{Rhs: "1", Operation: evpb.EvalNode_GT},
+			value:   "2",
+			wantErr: true,
+		},
+		{
+			name:    "InvalidRHS",
+			trigger: &evpb.EvalNode{Rhs: "high", Operation: evpb.EvalNode_GT},
+			value:   int64(2),
+			wantErr: true,
+		},
+		{
+			name:    "Undefined",
+			trigger: &evpb.EvalNode{Rhs: "1"},
+			value:   int64(1),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Evaluate(tc.trigger, tc.value)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Evaluate(%v, %v) returned error: %v, wantErr: %t", tc.trigger, tc.value, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Evaluate(%v, %v) = %t, want: %t", tc.trigger, tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateWithSource(t *testing.T) {
+	thresholdSource := &evpb.EventSource{
+		Source: &evpb.EventSource_Metadata_{Metadata: &evpb.EventSource_Metadata{
+			Url:       "instance/attributes/threshold",
+			ValueType: evpb.EventSource_DOUBLE,
+		}},
+	}
+	tests := []struct {
+		name    string
+		trigger *evpb.EvalNode
+		value   any
+		read    SourceReader
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "LiteralRHS",
+			trigger: &evpb.EvalNode{Rhs: "90", Operation: evpb.EvalNode_GT},
+			value:   95.5,
+			read: func(context.Context, *evpb.EventSource) (any, error) {
+				return nil, errors.New("unexpected read")
+			},
+			want: true,
+		},
+		{
+			name:    "SourceRHS",
+			trigger: &evpb.EvalNode{Rhs: "90", Operation: evpb.EvalNode_GT, RhsSource: thresholdSource},
+			value:   95.5,
+			read: func(context.Context, *evpb.EventSource) (any, error) {
+				return 97.0, nil
+			},
+			want: false,
+		},
+		{
+			name:    "SourceRHSString",
+			trigger: &evpb.EvalNode{Operation: evpb.EvalNode_EQSTR, RhsSource: thresholdSource},
+			value:   "PRIMARY",
+			read: func(context.Context, *evpb.EventSource) (any, error) {
+				return "PRIMARY", nil
+			},
+			want: true,
+		},
+		{
+			name:    "SourceReadError",
+			trigger: &evpb.EvalNode{Rhs: "90", Operation: evpb.EvalNode_GT, RhsSource: thresholdSource},
+			value:   95.5,
+			read: func(context.Context, *evpb.EventSource) (any, error) {
+				return nil, errors.New("metadata unavailable")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := EvaluateWithSource(context.Background(), tc.trigger, tc.value, tc.read)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("EvaluateWithSource(%v, %v) returned error: %v, wantErr: %t", tc.trigger, tc.value, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("EvaluateWithSource(%v, %v) = %t, want: %t", tc.trigger, tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewSourceReader(t *testing.T) {
+	metadata := &MetadataReader{Fetch: func(context.Context, string) ([]byte, error) {
+		return []byte("80\n"), nil
+	}}
+	read := NewSourceReader(metadata, NewGuestLogReader(), nil)
+	tests := []struct {
+		name    string
+		source  *evpb.EventSource
+		want    any
+		wantErr bool
+	}{
+		{
+			name: "Metadata",
+			source: &evpb.EventSource{
+				Source: &evpb.EventSource_Metadata_{Metadata: &evpb.EventSource_Metadata{
+					Url:       "instance/attributes/threshold",
+					ValueType: evpb.EventSource_INT64,
+				}},
+			},
+			want: int64(80),
+		},
+		{
+			name: "CloudLoggingNotSupported",
+			source: &evpb.EventSource{
+				Source: &evpb.EventSource_CloudLogging_{CloudLogging: &evpb.EventSource_CloudLogging{LogQuery: "severity=ERROR"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GuestLogCommandNotSupported",
+			source: &evpb.EventSource{
+				Source: &evpb.EventSource_GuestLog_{GuestLog: &evpb.EventSource_GuestLog{Command: "cat /tmp/threshold"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := read(context.Background(), tc.source)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("read(%v) returned error: %v, wantErr: %t", tc.source, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("read(%v) returned unexpected diff (-want +got):\n%s", tc.source, diff)
+			}
+		})
+	}
+}
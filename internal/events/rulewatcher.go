@@ -0,0 +1,170 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+// StatFunc provides a testable replacement for os.Stat.
+type StatFunc func(string) (os.FileInfo, error)
+
+// ValidateRules reports the first rule which cannot be evaluated: a rule without an id, a source,
+// a trigger operation or a target, or with a negative frequency.
+func ValidateRules(rules []*evpb.Rule) error {
+	for i, r := range rules {
+		switch {
+		case r.GetId() == "":
+			return fmt.Errorf("rule %d does not have an id", i)
+		case r.GetSource().GetSource() == nil:
+			return fmt.Errorf("rule %q does not have a source", r.GetId())
+		case r.GetTrigger().GetOperation() == evpb.EvalNode_UNDEFINED:
+			return fmt.Errorf("rule %q does not have a trigger operation", r.GetId())
+		case len(r.GetTarget()) == 0:
+			return fmt.Errorf("rule %q does not have a target", r.GetId())
+		case r.GetFrequencySec() < 0:
+			return fmt.Errorf("rule %q has a negative frequency_sec %d", r.GetId(), r.GetFrequencySec())
+		}
+	}
+	return nil
+}
+
+// RuleWatcherOptions configure a RuleWatcher, exactly one of RulesFile and RulesDir is set.
+type RuleWatcherOptions struct {
+	RulesFile string
+	RulesDir  string
+	ReadFile  ReadFileFunc
+	ReadDir   ReadDirFunc
+	Stat      StatFunc
+	// OnReload is called with the new rules after each successful reload, ex: to send the rule
+	// count metric.
+	OnReload func(rules []*evpb.Rule)
+}
+
+// RuleWatcher holds the active rules loaded from a rule file or a rules directory. Once Watch is
+// called, the rules are reloaded when a rule file is modified, added or removed, and replace the
+// active rules only if they are valid.
+type RuleWatcher struct {
+	opts RuleWatcherOptions
+
+	mu      sync.RWMutex
+	rules   []*evpb.Rule
+	version string // Modification stamp of the rule files the active rules were loaded from.
+}
+
+// NewRuleWatcher loads and validates the rules, returning an error if they cannot be loaded or
+// are not valid.
+func NewRuleWatcher(opts RuleWatcherOptions) (*RuleWatcher, error) {
+	if (opts.RulesFile == "") == (opts.RulesDir == "") {
+		return nil, fmt.Errorf("exactly one of the rules file and the rules directory must be set")
+	}
+	w := &RuleWatcher{opts: opts}
+	if _, err := w.Reload(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Rules returns the active rules. The slice is replaced and not modified on reload, callers must
+// not modify it.
+func (w *RuleWatcher) Rules() []*evpb.Rule {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.rules
+}
+
+// Reload loads the rules if the rule files changed since the active rules were loaded, and swaps
+// them in if they are valid. Returns true if the active rules were replaced. The active rules are
+// kept when an error is returned.
+func (w *RuleWatcher) Reload() (bool, error) {
+	paths, version, err := w.stamp()
+	if err != nil {
+		return false, err
+	}
+	w.mu.RLock()
+	unchanged := w.rules != nil && version == w.version
+	w.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+	rules, err := LoadRuleFiles(paths, w.opts.ReadFile)
+	if err != nil {
+		return false, err
+	}
+	if err := ValidateRules(rules); err != nil {
+		return false, fmt.Errorf("validating rules from %s: %w", w.source(), err)
+	}
+	if rules == nil {
+		rules = []*evpb.Rule{}
+	}
+	w.mu.Lock()
+	w.rules, w.version = rules, version
+	w.mu.Unlock()
+	if w.opts.OnReload != nil {
+		w.opts.OnReload(rules)
+	}
+	return true, nil
+}
+
+// reloadAndLog reloads the rules, logging the new rule count or the error. A failed reload keeps
+// the active rules until the next change.
+func (w *RuleWatcher) reloadAndLog(ctx context.Context) {
+	reloaded, err := w.Reload()
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Failed to reload the event rules, keeping the active rules", "source", w.source(), "activeRules", len(w.Rules()), "error", err)
+		return
+	}
+	if reloaded {
+		log.CtxLogger(ctx).Infow("Reloaded the event rules", "source", w.source(), "rules", len(w.Rules()))
+	}
+}
+
+// stamp returns the rule files and a stamp of their modification times and sizes, which changes
+// when a file is modified, added or removed.
+func (w *RuleWatcher) stamp() ([]string, string, error) {
+	paths := []string{w.opts.RulesFile}
+	if w.opts.RulesDir != "" {
+		var err error
+		if paths, err = RuleFilesInDir(w.opts.RulesDir, w.opts.ReadDir); err != nil {
+			return nil, "", err
+		}
+	}
+	var b strings.Builder
+	for _, path := range paths {
+		info, err := w.opts.Stat(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("reading rule file info %s: %w", path, err)
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+	}
+	return paths, b.String(), nil
+}
+
+func (w *RuleWatcher) source() string {
+	if w.opts.RulesDir != "" {
+		return w.opts.RulesDir
+	}
+	return w.opts.RulesFile
+}
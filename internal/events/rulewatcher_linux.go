@@ -0,0 +1,71 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+// ruleWatchMask selects the inotify events of a rule file being written, replaced or removed.
+// Creations are left out, a new file is reloaded once it is closed after being written.
+const ruleWatchMask = unix.IN_CLOSE_WRITE | unix.IN_MOVED_TO | unix.IN_MOVED_FROM | unix.IN_DELETE
+
+// Watch reloads the rules each time inotify reports a rule file written, moved or removed in the
+// rules directory, or in the directory of the rules file, until ctx is done. The directory is
+// watched rather than the file, so that a file replaced by an editor is still watched. A failed
+// reload is logged and the active rules are kept until the next change.
+func (w *RuleWatcher) Watch(ctx context.Context) {
+	dir := w.opts.RulesDir
+	if dir == "" {
+		dir = filepath.Dir(w.opts.RulesFile)
+	}
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Failed to create the inotify instance, the event rules will not be reloaded", "error", err)
+		return
+	}
+	// The non-blocking descriptor is read through the runtime poller, closing the file unblocks Read.
+	f := os.NewFile(uintptr(fd), "inotify")
+	stop := context.AfterFunc(ctx, func() { f.Close() })
+	defer func() {
+		if stop() {
+			f.Close()
+		}
+	}()
+	if _, err := unix.InotifyAddWatch(fd, dir, ruleWatchMask); err != nil {
+		log.CtxLogger(ctx).Errorw("Failed to watch the event rules, the event rules will not be reloaded", "dir", dir, "error", err)
+		return
+	}
+
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		// The events are not parsed, a read returns once at least one change happened and Reload
+		// leaves the rules as they are when their files did not change.
+		if _, err := f.Read(buf); err != nil {
+			if ctx.Err() == nil {
+				log.CtxLogger(ctx).Errorw("Failed to read the inotify events, the event rules will not be reloaded", "dir", dir, "error", err)
+			}
+			return
+		}
+		w.reloadAndLog(ctx)
+	}
+}
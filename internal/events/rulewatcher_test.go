@@ -0,0 +1,264 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+func validRuleJSON(id string) string {
+	return fmt.Sprintf(`{"id": %q, "source": {"metadata": {"url": "instance/attributes/x", "valueType": "INT64"}}, "trigger": {"operation": "GT", "rhs": "1"}, "target": [{"fileEndpoint": "/tmp/events.log"}]}`, id)
+}
+
+// writeRuleFile writes the rule file with a modification time after the previous one, so that the
+// change is detected on file systems with a coarse time resolution.
+func writeRuleFile(t *testing.T, path, data string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%s) failed: %v", path, err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("os.Chtimes(%s) failed: %v", path, err)
+	}
+}
+
+func ruleIDs(rules []*evpb.Rule) []string {
+	var ids []string
+	for _, r := range rules {
+		ids = append(ids, r.GetId())
+	}
+	return ids
+}
+
+func TestValidateRules(t *testing.T) {
+	valid := func() *evpb.Rule {
+		return &evpb.Rule{
+			Id:      "hana-down",
+			Source:  &evpb.EventSource{Source: &evpb.EventSource_Metadata_{Metadata: &evpb.EventSource_Metadata{Url: "x"}}},
+			Trigger: &evpb.EvalNode{Operation: evpb.EvalNode_EQ, Rhs: "0"},
+			Target:  []*evpb.EventTarget{{Target: &evpb.EventTarget_FileEndpoint{FileEndpoint: "/tmp/events.log"}}},
+		}
+	}
+	tests := []struct {
+		name    string
+		modify  func(*evpb.Rule)
+		wantErr bool
+	}{
+		{
+			name:   "Valid",
+			modify: func(*evpb.Rule) {},
+		},
+		{
+			name:    "NoID",
+			modify:  func(r *evpb.Rule) { r.Id = "" },
+			wantErr: true,
+		},
+		{
+			name:    "NoSource",
+			modify:  func(r *evpb.Rule) { r.Source = nil },
+			wantErr: true,
+		},
+		{
+			name:    "NoTriggerOperation",
+			modify:  func(r *evpb.Rule) { r.Trigger.Operation = evpb.EvalNode_UNDEFINED },
+			wantErr: true,
+		},
+		{
+			name:    "NoTarget",
+			modify:  func(r *evpb.Rule) { r.Target = nil },
+			wantErr: true,
+		},
+		{
+			name:    "NegativeFrequency",
+			modify:  func(r *evpb.Rule) { r.FrequencySec = -1 },
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := valid()
+			tc.modify(r)
+			err := ValidateRules([]*evpb.Rule{valid(), r})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("ValidateRules() returned error: %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewRuleWatcherErrors(t *testing.T) {
+	dir := writeRuleFiles(t, map[string]string{
+		"invalid.json": `[{"id": "no-target"}]`,
+	})
+	tests := []struct {
+		name string
+		opts RuleWatcherOptions
+	}{
+		{
+			name: "NoSource",
+			opts: RuleWatcherOptions{ReadFile: os.ReadFile, ReadDir: os.ReadDir, Stat: os.Stat},
+		},
+		{
+			name: "FileAndDir",
+			opts: RuleWatcherOptions{RulesFile: filepath.Join(dir, "invalid.json"), RulesDir: dir, ReadFile: os.ReadFile, ReadDir: os.ReadDir, Stat: os.Stat},
+		},
+		{
+			name: "MissingFile",
+			opts: RuleWatcherOptions{RulesFile: filepath.Join(dir, "missing.json"), ReadFile: os.ReadFile, Stat: os.Stat},
+		},
+		{
+			name: "InvalidRules",
+			opts: RuleWatcherOptions{RulesFile: filepath.Join(dir, "invalid.json"), ReadFile: os.ReadFile, Stat: os.Stat},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewRuleWatcher(tc.opts); err == nil {
+				t.Errorf("NewRuleWatcher(%+v) returned nil error, want error", tc.opts)
+			}
+		})
+	}
+}
+
+func TestRuleWatcherReloadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	modTime := time.Now().Add(-time.Hour)
+	writeRuleFile(t, path, "["+validRuleJSON("a")+"]", modTime)
+	var reloadedCounts []int
+	w, err := NewRuleWatcher(RuleWatcherOptions{
+		RulesFile: path,
+		ReadFile:  os.ReadFile,
+		Stat:      os.Stat,
+		OnReload:  func(rules []*evpb.Rule) { reloadedCounts = append(reloadedCounts, len(rules)) },
+	})
+	if err != nil {
+		t.Fatalf("NewRuleWatcher() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"a"}, ruleIDs(w.Rules())); diff != "" {
+		t.Errorf("Rules() after NewRuleWatcher() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if reloaded, err := w.Reload(); reloaded || err != nil {
+		t.Errorf("Reload() of an unchanged file = (%t, %v), want: (false, nil)", reloaded, err)
+	}
+
+	writeRuleFile(t, path, "["+validRuleJSON("a")+", "+validRuleJSON("b")+"]", modTime.Add(time.Minute))
+	if reloaded, err := w.Reload(); !reloaded || err != nil {
+		t.Errorf("Reload() of a modified file = (%t, %v), want: (true, nil)", reloaded, err)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, ruleIDs(w.Rules())); diff != "" {
+		t.Errorf("Rules() after a reload returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	writeRuleFile(t, path, `[{"id": "no-target"}]`, modTime.Add(2*time.Minute))
+	if reloaded, err := w.Reload(); reloaded || err == nil {
+		t.Errorf("Reload() of invalid rules = (%t, %v), want: (false, error)", reloaded, err)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, ruleIDs(w.Rules())); diff != "" {
+		t.Errorf("Rules() after a failed reload returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]int{1, 2}, reloadedCounts); diff != "" {
+		t.Errorf("OnReload() rule counts returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestRuleWatcherReloadDir(t *testing.T) {
+	dir := writeRuleFiles(t, map[string]string{
+		"hana.json": "[" + validRuleJSON("hana") + "]",
+	})
+	w, err := NewRuleWatcher(RuleWatcherOptions{RulesDir: dir, ReadFile: os.ReadFile, ReadDir: os.ReadDir, Stat: os.Stat})
+	if err != nil {
+		t.Fatalf("NewRuleWatcher() returned error: %v", err)
+	}
+
+	writeRuleFile(t, filepath.Join(dir, "nw.json"), "["+validRuleJSON("nw")+"]", time.Now())
+	if reloaded, err := w.Reload(); !reloaded || err != nil {
+		t.Errorf("Reload() after adding a file = (%t, %v), want: (true, nil)", reloaded, err)
+	}
+	if diff := cmp.Diff([]string{"hana", "nw"}, ruleIDs(w.Rules())); diff != "" {
+		t.Errorf("Rules() after adding a file returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "hana.json")); err != nil {
+		t.Fatalf("os.Remove() failed: %v", err)
+	}
+	if reloaded, err := w.Reload(); !reloaded || err != nil {
+		t.Errorf("Reload() after removing a file = (%t, %v), want: (true, nil)", reloaded, err)
+	}
+	if diff := cmp.Diff([]string{"nw"}, ruleIDs(w.Rules())); diff != "" {
+		t.Errorf("Rules() after removing a file returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestRuleWatcherWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	writeRuleFile(t, path, "["+validRuleJSON("a")+"]", time.Now().Add(-time.Hour))
+	reloadedCounts := make(chan int, 10)
+	w, err := NewRuleWatcher(RuleWatcherOptions{
+		RulesFile: path,
+		ReadFile:  os.ReadFile,
+		Stat:      os.Stat,
+		OnReload:  func(rules []*evpb.Rule) { reloadedCounts <- len(rules) },
+	})
+	if err != nil {
+		t.Fatalf("NewRuleWatcher() returned error: %v", err)
+	}
+	if got := <-reloadedCounts; got != 1 {
+		t.Errorf("OnReload() rule count after NewRuleWatcher() = %d, want: 1", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		w.Watch(ctx)
+		close(stopped)
+	}()
+	// The file is written until a reload is seen, as Watch may not be watching the directory yet.
+	timeout := time.After(5 * time.Second)
+	for reloaded := false; !reloaded; {
+		writeRuleFile(t, path, "["+validRuleJSON("a")+", "+validRuleJSON("b")+"]", time.Now())
+		select {
+		case got := <-reloadedCounts:
+			if got != 2 {
+				t.Errorf("OnReload() rule count after the file was written = %d, want: 2", got)
+			}
+			reloaded = true
+		case <-time.After(100 * time.Millisecond):
+		case <-timeout:
+			t.Fatalf("Watch() did not reload the rules after the file was written")
+		}
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Watch() did not return after the context was canceled")
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+// Watch is not supported for windows platforms, the rules loaded by NewRuleWatcher stay active
+// until the agent restarts.
+func (w *RuleWatcher) Watch(ctx context.Context) {
+	log.CtxLogger(ctx).Infow("Reloading the event rules is not supported for windows platforms, restart the agent to apply rule changes.", "source", w.source())
+}
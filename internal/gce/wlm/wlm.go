@@ -19,11 +19,14 @@ package wlm
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"runtime"
+	"sync/atomic"
 
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option/internaloption"
@@ -45,6 +48,9 @@ const (
 type DataWarehouseService struct {
 	c        *http.Client
 	BasePath string
+
+	compress            bool
+	compressionRejected atomic.Bool
 }
 
 // WriteInsightResponse is a wrapper for the response from a WriteInsight request.
@@ -81,18 +87,71 @@ func NewService(ctx context.Context, opts ...option.ClientOption) (*DataWarehous
 	return s, nil
 }
 
+// WithCompression returns a service sending the WriteInsight request bodies gzip compressed
+// through the same client. When the server rejects a compressed request it is resent
+// uncompressed, and compression is not used for the following requests.
+func (d *DataWarehouseService) WithCompression() *DataWarehouseService {
+	return &DataWarehouseService{c: d.c, BasePath: d.BasePath, compress: true}
+}
+
 // WriteInsight sends the WriteInsightRequest to the Data Warehouse.
 func (d *DataWarehouseService) WriteInsight(project, location string, req *dwpb.WriteInsightRequest) (*WriteInsightResponse, error) {
-	reqHeaders := make(http.Header)
-	reqHeaders.Set("x-goog-api-client", "gl-go/"+runtime.Version())
-	reqHeaders.Set("User-Agent", googleapi.UserAgent)
 	b, err := protojson.Marshal(req)
 	if err != nil {
 		return nil, err
 	}
 	log.Logger.Debugw("Body bytes", "bytes", b, "byteStr", string(b))
+	if d.compress && !d.compressionRejected.Load() {
+		compressed, err := gzipBody(b)
+		if err != nil {
+			return nil, err
+		}
+		log.Logger.Debugw("Compressed WriteInsight request", "uncompressedBytes", len(b), "compressedBytes", len(compressed))
+		res, err := d.writeInsight(project, location, compressed, true)
+		if !compressionRejected(err) {
+			return res, err
+		}
+		log.Logger.Infow("Compressed WriteInsight request rejected, sending uncompressed", "error", err)
+		if res, err = d.writeInsight(project, location, b, false); err == nil {
+			d.compressionRejected.Store(true)
+		}
+		return res, err
+	}
+	return d.writeInsight(project, location, b, false)
+}
+
+// gzipBody returns the gzip compression of the request body.
+func gzipBody(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// compressionRejected reports whether the error is a response of the server refusing the
+// encoding of a compressed request.
+func compressionRejected(err error) bool {
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) {
+		return false
+	}
+	return gerr.Code == http.StatusBadRequest || gerr.Code == http.StatusUnsupportedMediaType
+}
+
+func (d *DataWarehouseService) writeInsight(project, location string, b []byte, gzipped bool) (*WriteInsightResponse, error) {
+	reqHeaders := make(http.Header)
+	reqHeaders.Set("x-goog-api-client", "gl-go/"+runtime.Version())
+	reqHeaders.Set("User-Agent", googleapi.UserAgent)
 	body := bytes.NewReader(b)
 	reqHeaders.Set("Content-Type", "application/json")
+	if gzipped {
+		reqHeaders.Set("Content-Encoding", "gzip")
+	}
 	url := googleapi.ResolveRelative(d.BasePath, "v1/projects/{+project}/locations/{+location}/insights:writeInsight")
 	url += "?alt=json&prettyPrint=false"
 	httpReq, err := http.NewRequest("POST", url, body)
@@ -104,12 +163,7 @@ func (d *DataWarehouseService) WriteInsight(project, location string, req *dwpb.
 		"project":  project,
 		"location": location,
 	})
-	buf := new(bytes.Buffer)
-	bCopy, _ := httpReq.GetBody()
-	buf.ReadFrom(bCopy)
-	bodyBytes := buf.String()
-	bodyStr := string(bodyBytes)
-	log.Logger.Debugw("Sending request", "url", httpReq.URL, "body", bodyStr)
+	log.Logger.Debugw("Sending request", "url", httpReq.URL, "bytes", len(b), "gzip", gzipped)
 	httpRes, err := d.c.Do(httpReq)
 	if err != nil {
 		return nil, err
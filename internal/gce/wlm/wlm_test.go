@@ -0,0 +1,108 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wlm
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	dwpb "github.com/GoogleCloudPlatform/sapagent/protos/datawarehouse"
+)
+
+// fakeServer records the encoding of each request and, when rejectGzip is set, responds
+// 400 to gzip encoded requests.
+type fakeServer struct {
+	rejectGzip bool
+	encodings  []string
+}
+
+func (f *fakeServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		encoding := r.Header.Get("Content-Encoding")
+		f.encodings = append(f.encodings, encoding)
+		var body io.Reader = r.Body
+		if encoding == "gzip" {
+			if f.rejectGzip {
+				http.Error(w, `{"error": {"code": 400, "message": "invalid JSON payload"}}`, http.StatusBadRequest)
+				return
+			}
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("gzip.NewReader() returned error: %v", err)
+				return
+			}
+			body = zr
+		}
+		b, err := io.ReadAll(body)
+		if err != nil || !strings.Contains(string(b), "test-instance") {
+			t.Errorf("request body = %q, %v, want the insight", b, err)
+		}
+		w.Write([]byte("{}"))
+	}
+}
+
+func TestWriteInsightCompression(t *testing.T) {
+	tests := []struct {
+		name          string
+		compress      bool
+		rejectGzip    bool
+		wantEncodings []string
+	}{
+		{
+			name:          "Uncompressed",
+			wantEncodings: []string{"", ""},
+		},
+		{
+			name:          "Compressed",
+			compress:      true,
+			wantEncodings: []string{"gzip", "gzip"},
+		},
+		{
+			name:          "FallBackToUncompressed",
+			compress:      true,
+			rejectGzip:    true,
+			wantEncodings: []string{"gzip", "", ""},
+		},
+	}
+
+	req := &dwpb.WriteInsightRequest{Insight: &dwpb.Insight{InstanceId: "test-instance"}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &fakeServer{rejectGzip: tc.rejectGzip}
+			srv := httptest.NewServer(f.handler(t))
+			defer srv.Close()
+			d := &DataWarehouseService{c: srv.Client(), BasePath: srv.URL + "/"}
+			if tc.compress {
+				d = d.WithCompression()
+			}
+			for i := 0; i < 2; i++ {
+				if _, err := d.WriteInsight("test-project", "us-central1", req); err != nil {
+					t.Fatalf("WriteInsight() returned error: %v", err)
+				}
+			}
+			if diff := cmp.Diff(tc.wantEncodings, f.encodings); diff != "" {
+				t.Errorf("WriteInsight() sent unexpected encodings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
 
 	gpb "github.com/GoogleCloudPlatform/sapagent/protos/guestactions"
 )
@@ -57,7 +58,7 @@ func TestHANADiskBackupHandler(t *testing.T) {
 					},
 				},
 			},
-			wantExitStatus: subcommands.ExitFailure,
+			wantExitStatus: onetime.ExitGCEAPIError,
 		},
 	}
 	for _, tc := range tests {
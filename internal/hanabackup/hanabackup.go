@@ -137,16 +137,39 @@ func CheckDataDir(ctx context.Context, exec commandlineexecutor.Execute) (dataPa
 		return "", "", "", err
 	}
 	log.CtxLogger(ctx).Infow("Data volume base path", "path", dataPath)
+	logicalDataPath, physicalDataPath, err = CheckDataPath(ctx, dataPath, exec)
+	return dataPath, logicalDataPath, physicalDataPath, err
+}
+
+// CheckDataPath checks if the given data path has a valid physical volume.
+func CheckDataPath(ctx context.Context, dataPath string, exec commandlineexecutor.Execute) (logicalDataPath, physicalDataPath string, err error) {
 	if logicalDataPath, err = ParseLogicalPath(ctx, dataPath, exec); err != nil {
-		return dataPath, "", "", err
+		return "", "", err
 	}
 	if !strings.Contains(logicalDataPath, "/dev/mapper") {
-		return dataPath, "", "", fmt.Errorf("only data disks using LVM are supported, exiting")
+		return "", "", fmt.Errorf("only data disks using LVM are supported, exiting")
 	}
 	if physicalDataPath, err = ParsePhysicalPath(ctx, logicalDataPath, exec); err != nil {
-		return dataPath, logicalDataPath, "", err
+		return logicalDataPath, "", err
+	}
+	return logicalDataPath, physicalDataPath, nil
+}
+
+// CheckMountPoint checks if the given path is the mount point of a file system.
+func CheckMountPoint(ctx context.Context, path string, exec commandlineexecutor.Execute) error {
+	result := exec(ctx, commandlineexecutor.Params{
+		Executable:  "findmnt",
+		ArgsToSplit: "-rn -o TARGET --mountpoint " + path,
+	})
+	log.CtxLogger(ctx).Debugf("CheckMountPoint", "stdout", result.StdOut, "stderr", result.StdErr)
+	// findmnt exits with 1 when nothing is mounted at the path.
+	if result.ExitCode == 1 && result.StdOut == "" {
+		return fmt.Errorf("%s is not a mount point", path)
+	}
+	if result.Error != nil {
+		return fmt.Errorf("failure checking mount point %s, stderr: %s, err: %s", path, result.StdErr, result.Error)
 	}
-	return dataPath, logicalDataPath, physicalDataPath, nil
+	return nil
 }
 
 // CheckLogDir checks if the log directory is valid and has a valid physical volume.
@@ -182,6 +205,29 @@ func CheckDataDeviceForStripes(ctx context.Context, logicalDataPath string, exec
 	return false, nil
 }
 
+// TmpfsMountsUnderPath returns the tmpfs mount points at or below the given path,
+// such as those used by HANA fast restart, whose content is not on any disk.
+func TmpfsMountsUnderPath(ctx context.Context, path string, exec commandlineexecutor.Execute) ([]string, error) {
+	result := exec(ctx, commandlineexecutor.Params{
+		Executable:  "findmnt",
+		ArgsToSplit: "-rn -t tmpfs -o TARGET",
+	})
+	log.CtxLogger(ctx).Debugf("TmpfsMountsUnderPath", "stdout", result.StdOut, "stderr", result.StdErr)
+	// findmnt exits with 1 when no tmpfs file systems are mounted.
+	if result.Error != nil && !(result.ExitCode == 1 && result.StdOut == "") {
+		return nil, fmt.Errorf("failure listing tmpfs mounts, stderr: %s, err: %s", result.StdErr, result.Error)
+	}
+
+	path = strings.TrimSuffix(path, "/")
+	var mounts []string
+	for _, target := range strings.Split(result.StdOut, "\n") {
+		if target == path || strings.HasPrefix(target, path+"/") {
+			mounts = append(mounts, target)
+		}
+	}
+	return mounts, nil
+}
+
 // ReadDataDirMountPath reads the data directory mount path.
 func ReadDataDirMountPath(ctx context.Context, baseDataPath string, exec commandlineexecutor.Execute) (string, error) {
 	result := exec(ctx, commandlineexecutor.Params{
@@ -365,3 +365,114 @@ func TestReadDataDirMountPath(t *testing.T) {
 		})
 	}
 }
+
+func TestTmpfsMountsUnderPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		fakeExec commandlineexecutor.Execute
+		want     []string
+		wantErr  error
+	}{
+		{
+			name:     "Failure",
+			fakeExec: fakeCommandExecuteWithExitCode("", "", 2, &exec.ExitError{}),
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "NoTmpfsMounts",
+			fakeExec: fakeCommandExecuteWithExitCode("", "", 1, cmpopts.AnyError),
+		},
+		{
+			name:     "NoTmpfsMountsUnderPath",
+			fakeExec: fakeCommandExecuteWithExitCode("/dev/shm\n/run\n/hana/tmpfs0/ABC\n/hana/datadir\n", "", 0, nil),
+		},
+		{
+			name:     "TmpfsMountsUnderPath",
+			fakeExec: fakeCommandExecuteWithExitCode("/dev/shm\n/hana/data/ABC/mnt00001/fastrestart\n/hana/data/ABC\n", "", 0, nil),
+			want:     []string{"/hana/data/ABC/mnt00001/fastrestart", "/hana/data/ABC"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, gotErr := TmpfsMountsUnderPath(context.Background(), "/hana/data/ABC/", test.fakeExec)
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("TmpfsMountsUnderPath() = %v, want %v", gotErr, test.wantErr)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("TmpfsMountsUnderPath() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckMountPoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		fakeExec commandlineexecutor.Execute
+		wantErr  error
+	}{
+		{
+			name:     "MountPoint",
+			fakeExec: fakeCommandExecuteWithExitCode("/hana/data\n", "", 0, nil),
+		},
+		{
+			name:     "NotAMountPoint",
+			fakeExec: fakeCommandExecuteWithExitCode("", "", 1, cmpopts.AnyError),
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "Failure",
+			fakeExec: fakeCommandExecuteWithExitCode("", "", 2, cmpopts.AnyError),
+			wantErr:  cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotErr := CheckMountPoint(context.Background(), "/hana/data", test.fakeExec)
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("CheckMountPoint() = %v, want %v", gotErr, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckDataPath(t *testing.T) {
+	tests := []struct {
+		name             string
+		fakeExec         commandlineexecutor.Execute
+		wantLogicalPath  string
+		wantPhysicalPath string
+		wantErr          error
+	}{
+		{
+			name:     "LogicalPathFailure",
+			fakeExec: fakeCommandExecute("", "", cmpopts.AnyError),
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "NotLVM",
+			fakeExec: fakeCommandExecute("/dev/sdb\n", "", nil),
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:             "Success",
+			fakeExec:         fakeCommandExecute("/dev/mapper/vg-data\n", "", nil),
+			wantLogicalPath:  "/dev/mapper/vg-data",
+			wantPhysicalPath: "/dev/mapper/vg-data",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotLogicalPath, gotPhysicalPath, gotErr := CheckDataPath(context.Background(), "/hana/data", test.fakeExec)
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("CheckDataPath() = %v, want %v", gotErr, test.wantErr)
+			}
+			if gotLogicalPath != test.wantLogicalPath || gotPhysicalPath != test.wantPhysicalPath {
+				t.Errorf("CheckDataPath() = (%q, %q), want (%q, %q)", gotLogicalPath, gotPhysicalPath, test.wantLogicalPath, test.wantPhysicalPath)
+			}
+		})
+	}
+}
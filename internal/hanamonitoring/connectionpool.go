@@ -0,0 +1,111 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanamonitoring
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/gammazero/workerpool"
+	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+	"github.com/GoogleCloudPlatform/sapagent/shared/timeseries"
+
+	mpb "google.golang.org/genproto/googleapis/api/metric"
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const connectionPoolMetricURL = metricURL + "/connection_pool"
+
+// poolStatsOptions holds parameters for the poolStatsAndSend workflow.
+type poolStatsOptions struct {
+	db             *database
+	sampleInterval int64
+	params         Parameters
+	wp             *workerpool.WorkerPool
+}
+
+// isConnectionError reports whether the error is a failure to reach the database, as opposed to
+// an error returned by the database for the query.
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// poolStatsAndSend perpetually sends the connection pool metrics of the database once every
+// sample interval. Returns true if the job is queued back to the workerpool, false if it is canceled.
+func poolStatsAndSend(ctx context.Context, opts poolStatsOptions) bool {
+	select {
+	case <-ctx.Done():
+		log.CtxLogger(ctx).Debugw("Context cancelled, stopping poolStatsAndSend worker", "err", ctx.Err())
+		return false
+	default:
+	}
+	metrics := connectionPoolMetrics(opts.db, opts.params, tspb.Now())
+	if _, _, err := cloudmonitoring.SendTimeSeriesForSubsystem(ctx, cloudmonitoring.SubsystemHANAMonitoring, metrics, opts.params.TimeSeriesCreator, opts.params.BackOffs, opts.params.Config.GetCloudProperties().GetProjectId()); err != nil {
+		log.CtxLogger(ctx).Errorw("Error sending connection pool metrics", "name", opts.db.instance.GetName(), "error", err)
+	}
+	time.AfterFunc(time.Duration(opts.sampleInterval)*time.Second, func() {
+		opts.wp.Submit(func() {
+			poolStatsAndSend(ctx, opts)
+		})
+	})
+	return true
+}
+
+// connectionPoolMetrics builds the in use and idle connection gauges of the database, when its
+// DB handle keeps a connection pool, and the cumulative count of its connection errors.
+func connectionPoolMetrics(db *database, params Parameters, timestamp *tspb.Timestamp) []*mrpb.TimeSeries {
+	labels := map[string]string{
+		"instance_name": db.instance.GetName(),
+		"sid":           db.instance.GetSid(),
+	}
+	newParams := func(metric string, value int64) timeseries.Params {
+		return timeseries.Params{
+			CloudProp:    timeseries.ConvertCloudProperties(params.Config.GetCloudProperties()),
+			MetricType:   connectionPoolMetricURL + "/" + metric,
+			MetricLabels: labels,
+			Timestamp:    timestamp,
+			BareMetal:    params.Config.GetBareMetal(),
+			Int64Value:   value,
+		}
+	}
+
+	var metrics []*mrpb.TimeSeries
+	if stats, ok := db.stats(); ok {
+		metrics = append(metrics,
+			timeseries.BuildInt(newParams("in_use", int64(stats.InUse))),
+			timeseries.BuildInt(newParams("idle", int64(stats.Idle))))
+	}
+	errs := newParams("errors", db.connectionErrors.Load())
+	errs.MetricKind = mpb.MetricDescriptor_CUMULATIVE
+	errs.StartTime = db.startTime
+	if errs.StartTime == nil {
+		errs.StartTime = timestamp
+	}
+	return append(metrics, timeseries.BuildInt(errs))
+}
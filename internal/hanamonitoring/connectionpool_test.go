@@ -0,0 +1,171 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanamonitoring
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/gammazero/workerpool"
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+	cmfake "github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring/fake"
+)
+
+func TestIsConnectionError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "NoError",
+			want: false,
+		},
+		{
+			name: "BadConn",
+			err:  fmt.Errorf("query failed: %w", driver.ErrBadConn),
+			want: true,
+		},
+		{
+			name: "NetworkError",
+			err:  &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			want: true,
+		},
+		{
+			name: "QueryError",
+			err:  errors.New("invalid column name"),
+			want: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isConnectionError(tc.err); got != tc.want {
+				t.Errorf("isConnectionError(%v) = %t, want %t", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func metricTypes(db *database) []string {
+	var types []string
+	for _, ts := range connectionPoolMetrics(db, defaultParams, defaultTimestamp) {
+		types = append(types, ts.GetMetric().GetType())
+	}
+	return types
+}
+
+func TestConnectionPoolMetrics(t *testing.T) {
+	tests := []struct {
+		name       string
+		stats      statsFunc
+		wantTypes  []string
+		wantInUse  int64
+		wantIdle   int64
+		wantErrors int64
+	}{
+		{
+			name:  "GoHDBHandle",
+			stats: func() (sql.DBStats, bool) { return sql.DBStats{InUse: 2, Idle: 3}, true },
+			wantTypes: []string{
+				connectionPoolMetricURL + "/in_use",
+				connectionPoolMetricURL + "/idle",
+				connectionPoolMetricURL + "/errors",
+			},
+			wantInUse:  2,
+			wantIdle:   3,
+			wantErrors: 4,
+		},
+		{
+			name:       "HDBSQLHandle",
+			stats:      func() (sql.DBStats, bool) { return sql.DBStats{}, false },
+			wantTypes:  []string{connectionPoolMetricURL + "/errors"},
+			wantErrors: 4,
+		},
+		{
+			name:       "NoStatsFunc",
+			wantTypes:  []string{connectionPoolMetricURL + "/errors"},
+			wantErrors: 4,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db := &database{statsFunc: tc.stats, instance: &configpb.HANAInstance{Name: "testName", Sid: "testSID"}}
+			db.connectionErrors.Add(tc.wantErrors)
+			if diff := cmp.Diff(tc.wantTypes, metricTypes(db)); diff != "" {
+				t.Errorf("connectionPoolMetrics() returned unexpected metric types (-want +got):\n%s", diff)
+			}
+			for _, ts := range connectionPoolMetrics(db, defaultParams, defaultTimestamp) {
+				want := map[string]int64{
+					connectionPoolMetricURL + "/in_use": tc.wantInUse,
+					connectionPoolMetricURL + "/idle":   tc.wantIdle,
+					connectionPoolMetricURL + "/errors": tc.wantErrors,
+				}[ts.GetMetric().GetType()]
+				if got := ts.GetPoints()[0].GetValue().GetInt64Value(); got != want {
+					t.Errorf("connectionPoolMetrics() %s = %d, want %d", ts.GetMetric().GetType(), got, want)
+				}
+				if got := ts.GetMetric().GetLabels()["sid"]; got != "testSID" {
+					t.Errorf("connectionPoolMetrics() %s sid label = %q, want %q", ts.GetMetric().GetType(), got, "testSID")
+				}
+			}
+		})
+	}
+}
+
+func TestPoolStatsAndSend(t *testing.T) {
+	tests := []struct {
+		name        string
+		isCancelled bool
+		want        bool
+	}{
+		{
+			name: "Rescheduled",
+			want: true,
+		},
+		{
+			name:        "Cancelled",
+			isCancelled: true,
+			want:        false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			if tc.isCancelled {
+				cancel()
+			}
+			defer cancel()
+			params := defaultParams
+			params.TimeSeriesCreator = &cmfake.TimeSeriesCreatorThreadSafe{}
+			opts := poolStatsOptions{
+				db:             &database{instance: &configpb.HANAInstance{Name: "testName"}},
+				sampleInterval: 60,
+				params:         params,
+				wp:             workerpool.New(1),
+			}
+			if got := poolStatsAndSend(ctx, opts); got != tc.want {
+				t.Errorf("poolStatsAndSend() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
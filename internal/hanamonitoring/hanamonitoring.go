@@ -19,16 +19,22 @@ package hanamonitoring
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gammazero/workerpool"
 	"github.com/GoogleCloudPlatform/sapagent/internal/databaseconnector"
+	"github.com/GoogleCloudPlatform/sapagent/internal/drain"
+	"github.com/GoogleCloudPlatform/sapagent/internal/secretreload"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/sapagent/internal/utils/jitter"
 	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
@@ -73,6 +79,17 @@ type (
 	// queryFunc provides an easily testable translation to the SQL API.
 	queryFunc func(ctx context.Context, query string, exec commandlineexecutor.Execute) (*databaseconnector.QueryResults, error)
 
+	// statsFunc returns the connection pool statistics of a DB handle, false if the handle does
+	// not keep a connection pool.
+	statsFunc func() (sql.DBStats, bool)
+
+	// closeFunc closes a DB handle.
+	closeFunc func() error
+
+	// connectFunc creates a DB handle for the connection parameters and returns its queryFunc,
+	// statsFunc and closeFunc.
+	connectFunc func(ctx context.Context, p databaseconnector.Params) (queryFunc, statsFunc, closeFunc, error)
+
 	// hanaReplicationConfig provides an easily testable translation to invoking the sapdiscovery package function HANAReplicationConfig.
 	hanaReplicationConfig func(ctx context.Context, user, sid, instID string) (int, []string, int64, *sapb.HANAReplicaSite, error)
 
@@ -85,6 +102,13 @@ type (
 		dailyMetricsRoutine     *recovery.RecoverableRoutine
 		createWorkerPoolRoutine *recovery.RecoverableRoutine
 		HRC                     hanaReplicationConfig
+		// DisabledQueries holds the names of queries muted at startup, these are
+		// skipped in addition to the queries disabled in the configuration.
+		DisabledQueries []string
+		// ReadyFunc is called after each successful query and send, it may be nil.
+		ReadyFunc func()
+		// exec runs the hdbuserstore key checks, defaults to commandlineexecutor.ExecuteCommand.
+		exec commandlineexecutor.Execute
 	}
 
 	// queryOptions holds parameters for the queryAndSend workflows.
@@ -102,14 +126,27 @@ type (
 
 	// database holds the relevant information for querying and debugging the database.
 	database struct {
+		mu        sync.RWMutex
 		queryFunc queryFunc
-		instance  *cpb.HANAInstance
+		statsFunc statsFunc
+		closeFunc closeFunc
+		// inFlight counts the queries in progress on the current DB handle, a handle replaced when
+		// the secrets are reloaded is closed once they are done.
+		inFlight *sync.WaitGroup
+		instance *cpb.HANAInstance
+		// params are the connection parameters, kept to reconnect when the secrets are reloaded.
+		params databaseconnector.Params
+		// connectionErrors counts the queries and reconnects which failed to reach the database
+		// since startTime.
+		connectionErrors atomic.Int64
+		startTime        *tspb.Timestamp
 	}
 
 	// createWorkerPoolArgs holds the parameters necessary to invoke the routine createWorkerPool().
 	createWorkerPoolArgs struct {
 		params    Parameters
 		databases []*database
+		queries   []*cpb.Query
 	}
 )
 
@@ -126,6 +163,11 @@ func Start(ctx context.Context, params Parameters) bool {
 		usagemetrics.Error(usagemetrics.MalformedConfigFile)
 		return false
 	}
+	queries := scheduledQueries(ctx, cfg.GetQueries(), params.DisabledQueries)
+	if len(queries) == 0 {
+		log.CtxLogger(ctx).Info("HANA Monitoring enabled but all queries are disabled, not starting HANA Monitoring.")
+		return false
+	}
 	// Log usagemetric if any one of the HANA instances has hdbuserstore key configured.
 	for _, i := range params.Config.GetHanaMonitoringConfiguration().GetHanaInstances() {
 		if i.GetHdbuserstoreKey() != "" {
@@ -154,6 +196,7 @@ func Start(ctx context.Context, params Parameters) bool {
 	createWorkerPoolArgs := createWorkerPoolArgs{
 		params:    params,
 		databases: databases,
+		queries:   queries,
 	}
 	params.createWorkerPoolRoutine = &recovery.RecoverableRoutine{
 		Routine:             createWorkerPool,
@@ -163,9 +206,102 @@ func Start(ctx context.Context, params Parameters) bool {
 		ExpectedMinDuration: time.Minute,
 	}
 	params.createWorkerPoolRoutine.StartRoutine(ctx)
+	go reloadSecretsOnRequest(ctx, databases)
 	return true
 }
 
+// reloadSecretsOnRequest reconnects the databases each time a secret reload is requested, until
+// the context is done.
+func reloadSecretsOnRequest(ctx context.Context, databases []*database) {
+	reload, unsubscribe := secretreload.Subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reload:
+			reloadSecrets(ctx, databases, connect)
+		}
+	}
+}
+
+// reloadSecrets re-reads the Secret Manager password of each database configured with a secret
+// and replaces its DB handle, the replaced handle is closed once its queries in progress are done.
+// Databases which fail to reconnect keep their current handle.
+// Returns the names of the databases which were refreshed.
+func reloadSecrets(ctx context.Context, databases []*database, connect connectFunc) []string {
+	var refreshed, failed []string
+	for _, db := range databases {
+		if db.params.PasswordSecret == "" {
+			continue
+		}
+		q, stats, closeDB, err := connect(ctx, db.params)
+		if err != nil {
+			log.CtxLogger(ctx).Errorw("Error reconnecting to database with the reloaded secret, keeping the current connection", "name", db.instance.GetName(), "secret", db.params.PasswordSecret, "error", err)
+			db.connectionErrors.Add(1)
+			failed = append(failed, db.instance.GetName())
+			continue
+		}
+		db.mu.Lock()
+		oldClose, oldInFlight := db.closeFunc, db.inFlight
+		db.queryFunc = q
+		db.statsFunc = stats
+		db.closeFunc = closeDB
+		db.inFlight = nil
+		db.mu.Unlock()
+		go closeReplacedHandle(ctx, db.instance.GetName(), oldClose, oldInFlight)
+		refreshed = append(refreshed, db.instance.GetName())
+	}
+	log.CtxLogger(ctx).Infow("Reloaded HANA Monitoring secrets", "refreshed", refreshed, "failed", failed)
+	return refreshed
+}
+
+// closeReplacedHandle closes a DB handle replaced by reloadSecrets after waiting for the queries
+// in progress on it.
+func closeReplacedHandle(ctx context.Context, name string, closeDB closeFunc, inFlight *sync.WaitGroup) {
+	if inFlight != nil {
+		inFlight.Wait()
+	}
+	if closeDB == nil {
+		return
+	}
+	if err := closeDB(); err != nil {
+		log.CtxLogger(ctx).Warnw("Error closing the replaced database connection", "name", name, "error", err)
+	}
+}
+
+// connect creates a DB handle for the connection parameters.
+func connect(ctx context.Context, p databaseconnector.Params) (queryFunc, statsFunc, closeFunc, error) {
+	handle, err := databaseconnector.CreateDBHandle(ctx, p)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return handle.Query, handle.Stats, handle.Close, nil
+}
+
+// query returns the queryFunc of the current DB handle of the database, and a done function to
+// call once the query results are read. The handle is not closed before done is called.
+func (db *database) query() (queryFunc, func()) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.inFlight == nil {
+		db.inFlight = &sync.WaitGroup{}
+	}
+	db.inFlight.Add(1)
+	return db.queryFunc, db.inFlight.Done
+}
+
+// stats returns the connection pool statistics of the current DB handle of the database.
+func (db *database) stats() (sql.DBStats, bool) {
+	db.mu.RLock()
+	statsFunc := db.statsFunc
+	db.mu.RUnlock()
+	if statsFunc == nil {
+		return sql.DBStats{}, false
+	}
+	return statsFunc()
+}
+
 // createWorkerPool creates a job for each query on each database. If the SID
 // is not present in the config, the database will be queried to populate it.
 func createWorkerPool(ctx context.Context, a any) {
@@ -180,8 +316,17 @@ func createWorkerPool(ctx context.Context, a any) {
 	}
 
 	cfg := args.params.Config.GetHanaMonitoringConfiguration()
-	wp := workerpool.New(int(cfg.GetExecutionThreads()))
-	queryNamesMap := queryMap(cfg.GetQueries())
+	// Offset the start of the queries so that hosts deployed together do not send at the same time.
+	delay := jitter.Delay(args.params.Config.GetCloudProperties().GetInstanceId(), time.Duration(cfg.GetSampleIntervalSec())*time.Second, args.params.Config.GetCollectionConfiguration().GetCollectionJitterFraction())
+	log.CtxLogger(ctx).Infow("Delaying start of HANA Monitoring queries", "delay", delay)
+	if !jitter.Wait(ctx, delay) {
+		log.CtxLogger(ctx).Info("Context cancelled, not starting HANA Monitoring queries")
+		return
+	}
+	poolSize := workerPoolSize(ctx, cfg)
+	log.CtxLogger(ctx).Infow("Starting HANA Monitoring worker pool", "executionthreads", poolSize)
+	wp := workerpool.New(poolSize)
+	queryNamesMap := queryMap(args.queries)
 	var queryNames []string
 	for qn := range queryNamesMap {
 		queryNames = append(queryNames, qn)
@@ -232,7 +377,50 @@ func createWorkerPool(ctx context.Context, a any) {
 				})
 			})
 		}
+		dbCopy := db
+		wp.Submit(func() {
+			poolStatsAndSend(ctx, poolStatsOptions{
+				db:             dbCopy,
+				sampleInterval: cfg.GetSampleIntervalSec(),
+				params:         args.params,
+				wp:             wp,
+			})
+		})
+	}
+}
+
+// workerPoolSize returns the number of workers running the queries concurrently, taken from
+// execution_threads. At least one worker is used.
+func workerPoolSize(ctx context.Context, cfg *cpb.HANAMonitoringConfiguration) int {
+	threads := cfg.GetExecutionThreads()
+	if threads < 1 {
+		log.CtxLogger(ctx).Warnw("Invalid execution_threads for HANA Monitoring, using a single worker", "executionthreads", threads)
+		return 1
+	}
+	return int(threads)
+}
+
+// scheduledQueries returns the queries which should be scheduled. Queries disabled
+// in the configuration or muted using the -disable-query flag are skipped.
+func scheduledQueries(ctx context.Context, queries []*cpb.Query, disabledQueries []string) []*cpb.Query {
+	muted := make(map[string]bool)
+	for _, name := range disabledQueries {
+		if name = strings.TrimSpace(name); name != "" {
+			muted[name] = true
+		}
+	}
+	var res []*cpb.Query
+	for _, q := range queries {
+		switch {
+		case !q.GetEnabled():
+			log.CtxLogger(ctx).Infow("Skipping HANA Monitoring query", "query", q.GetName(), "reason", "query is disabled in the configuration")
+		case muted[q.GetName()] || muted["default_"+q.GetName()]:
+			log.CtxLogger(ctx).Infow("Skipping HANA Monitoring query", "query", q.GetName(), "reason", "query is disabled using the -disable-query flag")
+		default:
+			res = append(res, q)
+		}
 	}
+	return res
 }
 
 // queryMap prepares a queryName to *cpb.Query Map data structure.
@@ -260,14 +448,26 @@ func queryAndSend(ctx context.Context, opts queryOptions) (bool, error) {
 		cancel()
 		return false, ctx.Err()
 	default:
-		sent, batchCount, err := queryAndSendOnce(ctxTimeout, opts.db, opts.query, opts.params, opts.runningSum)
+		var sent, batchCount int
+		var err error
+		if drain.Active() {
+			log.CtxLogger(ctx).Debugw("Agent is in drain mode, skipping query", "query", queryName)
+		} else {
+			sent, batchCount, err = queryAndSendOnce(ctxTimeout, opts.db, opts.query, opts.params, opts.runningSum)
+		}
 		cancel()
 		if err != nil {
 			opts.failCount++
+			if isConnectionError(err) {
+				opts.db.connectionErrors.Add(1)
+			}
 			log.CtxLogger(ctx).Errorw("Error querying database or sending metrics", "user", user, "host", host, "port", port, "query", queryName, "failCount", opts.failCount, "error", err)
 			usagemetrics.Error(usagemetrics.HANAMonitoringCollectionFailure)
 		} else {
 			opts.failCount = 0
+			if opts.params.ReadyFunc != nil {
+				opts.params.ReadyFunc()
+			}
 			log.CtxLogger(ctx).Debugw("Sent metrics from queryAndSend.", "user", user, "host", host, "port", port, "query", queryName, "sent", sent, "batches", batchCount, "sleeping", opts.sampleInterval)
 		}
 
@@ -332,8 +532,10 @@ func queryAndSendOnce(ctx context.Context, db *database, query *cpb.Query, param
 		log.CtxLogger(ctx).Infow("Query should not run on this instance type in this cycle ", "query", query.GetName(), "host", db.instance.GetHost(), "user", db.instance.GetUser(), "port", db.instance.GetPort())
 		return 0, 0, nil
 	}
+	q, done := db.query()
+	defer done()
 	queryStartTime := time.Now()
-	rows, cols, err := queryDatabase(ctx, db.queryFunc, query)
+	rows, cols, err := queryDatabase(ctx, q, query)
 	responseTime := time.Since(queryStartTime).Milliseconds()
 	if err != nil {
 		return 0, 0, err
@@ -349,7 +551,7 @@ func queryAndSendOnce(ctx context.Context, db *database, query *cpb.Query, param
 		}
 		metrics = append(metrics, createMetricsForRow(ctx, db.instance.GetName(), db.instance.GetSid(), query, cols, params, runningSum)...)
 	}
-	return cloudmonitoring.SendTimeSeries(ctx, metrics, params.TimeSeriesCreator, params.BackOffs, params.Config.GetCloudProperties().GetProjectId())
+	return cloudmonitoring.SendTimeSeriesForSubsystem(ctx, cloudmonitoring.SubsystemHANAMonitoring, metrics, params.TimeSeriesCreator, params.BackOffs, params.Config.GetCloudProperties().GetProjectId())
 }
 
 // createColumns creates pointers to the types defined in the configuration for each column in a query.
@@ -407,7 +609,7 @@ func connectToDatabases(ctx context.Context, params Parameters) []*database {
 			Host:           i.GetHost(),
 			Password:       i.GetPassword(),
 			PasswordSecret: i.GetSecretName(),
-			Port:           i.GetPort(),
+			Port:           portValue(ctx, i),
 			EnableSSL:      i.GetEnableSsl(),
 			HostNameInCert: i.GetHostNameInCertificate(),
 			RootCAFile:     i.GetTlsRootCaFile(),
@@ -425,16 +627,38 @@ func connectToDatabases(ctx context.Context, params Parameters) []*database {
 			}
 		}
 
+		if i.GetHdbuserstoreKey() != "" && i.GetSid() != "" {
+			exec := params.exec
+			if exec == nil {
+				exec = commandlineexecutor.ExecuteCommand
+			}
+			if err := databaseconnector.CheckHDBUserKey(ctx, i.GetSid(), i.GetHdbuserstoreKey(), exec); err != nil {
+				log.CtxLogger(ctx).Errorw("Error connecting to database", "name", i.GetName(), "error", err.Error())
+				continue
+			}
+		}
+
 		handle, err := databaseconnector.CreateDBHandle(ctx, dbp)
 		if err != nil {
 			log.CtxLogger(ctx).Errorw("Error connecting to database", "name", i.GetName(), "error", err.Error())
 			continue
 		}
-		databases = append(databases, &database{queryFunc: handle.Query, instance: i})
+		databases = append(databases, &database{queryFunc: handle.Query, statsFunc: handle.Stats, closeFunc: handle.Close, instance: i, params: dbp, startTime: tspb.Now()})
 	}
 	return databases
 }
 
+// portValue returns the port of the HANA instance. When no port is configured it is built from
+// the instance number as the port of the system database, 3<instance number>13.
+func portValue(ctx context.Context, i *cpb.HANAInstance) string {
+	if i.GetPort() != "" || i.GetInstanceNum() == "" {
+		return i.GetPort()
+	}
+	port := fmt.Sprintf("3%s13", i.GetInstanceNum())
+	log.CtxLogger(ctx).Debugw("Building port number of the system database from instance number", "name", i.GetName(), "instanceNumber", i.GetInstanceNum(), "port", port)
+	return port
+}
+
 // createQueryResponseTimeMetric builds a cloud monitoring time series with an int point value for the time taken by query.
 func createQueryResponseTimeMetric(ctx context.Context, dbName, sid string, query *cpb.Query, params Parameters, timeTaken int64, timestamp *tspb.Timestamp) *mrpb.TimeSeries {
 	labels := map[string]string{
@@ -585,7 +809,9 @@ func createCumulativeMetric(ctx context.Context, c *cpb.Column, val any, labels
 // fetchSID is responsible for fetching the SID for a HANA instance if it not
 // already set by executing a query on the M_DATABASE table.
 func fetchSID(ctx context.Context, db *database) (string, error) {
-	rows, err := db.queryFunc(ctx, "SELECT SYSTEM_ID AS sid FROM M_DATABASE LIMIT 1;", commandlineexecutor.ExecuteCommand)
+	q, done := db.query()
+	defer done()
+	rows, err := q(ctx, "SELECT SYSTEM_ID AS sid FROM M_DATABASE LIMIT 1;", commandlineexecutor.ExecuteCommand)
 	if err != nil {
 		return "", err
 	}
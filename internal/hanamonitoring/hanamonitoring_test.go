@@ -211,6 +211,25 @@ func TestStart(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "FailsWithAllQueriesDisabled",
+			params: Parameters{
+				Config: &configpb.Configuration{
+					HanaMonitoringConfiguration: &configpb.HANAMonitoringConfiguration{
+						Enabled: true,
+						Queries: []*configpb.Query{
+							&configpb.Query{Name: "disabledQuery", SampleIntervalSec: 5},
+							&configpb.Query{Name: "mutedQuery", SampleIntervalSec: 5, Enabled: true},
+						},
+						HanaInstances: []*configpb.HANAInstance{
+							&configpb.HANAInstance{Password: "fakePassword", Sid: "fakeSID"},
+						},
+					},
+				},
+				DisabledQueries: []string{"mutedQuery"},
+			},
+			want: false,
+		},
 		{
 			name: "FailsWithEmptyDatabases",
 			params: Parameters{
@@ -218,7 +237,7 @@ func TestStart(t *testing.T) {
 					HanaMonitoringConfiguration: &configpb.HANAMonitoringConfiguration{
 						Enabled: true,
 						Queries: []*configpb.Query{
-							&configpb.Query{},
+							&configpb.Query{Enabled: true},
 						},
 					},
 				},
@@ -232,7 +251,7 @@ func TestStart(t *testing.T) {
 					HanaMonitoringConfiguration: &configpb.HANAMonitoringConfiguration{
 						Enabled: true,
 						Queries: []*configpb.Query{
-							&configpb.Query{SampleIntervalSec: 5},
+							&configpb.Query{SampleIntervalSec: 5, Enabled: true},
 						},
 						HanaInstances: []*configpb.HANAInstance{
 							&configpb.HANAInstance{Password: "fakePassword"},
@@ -249,7 +268,7 @@ func TestStart(t *testing.T) {
 					HanaMonitoringConfiguration: &configpb.HANAMonitoringConfiguration{
 						Enabled: true,
 						Queries: []*configpb.Query{
-							&configpb.Query{SampleIntervalSec: 5},
+							&configpb.Query{SampleIntervalSec: 5, Enabled: true},
 						},
 						HanaInstances: []*configpb.HANAInstance{
 							&configpb.HANAInstance{Password: "fakePassword", Sid: "fakeSID"},
@@ -265,7 +284,7 @@ func TestStart(t *testing.T) {
 					HanaMonitoringConfiguration: &configpb.HANAMonitoringConfiguration{
 						Enabled: true,
 						Queries: []*configpb.Query{
-							&configpb.Query{SampleIntervalSec: 5},
+							&configpb.Query{SampleIntervalSec: 5, Enabled: true},
 						},
 						HanaInstances: []*configpb.HANAInstance{
 							&configpb.HANAInstance{
@@ -288,8 +307,8 @@ func TestStart(t *testing.T) {
 					HanaMonitoringConfiguration: &configpb.HANAMonitoringConfiguration{
 						Enabled: true,
 						Queries: []*configpb.Query{
-							&configpb.Query{SampleIntervalSec: 5, Name: "fakeQueryName"},
-							&configpb.Query{SampleIntervalSec: 5, Name: "fakeQueryName2"},
+							&configpb.Query{SampleIntervalSec: 5, Name: "fakeQueryName", Enabled: true},
+							&configpb.Query{SampleIntervalSec: 5, Name: "fakeQueryName2", Enabled: true},
 						},
 						HanaInstances: []*configpb.HANAInstance{
 							&configpb.HANAInstance{
@@ -320,6 +339,97 @@ func TestStart(t *testing.T) {
 	}
 }
 
+func TestWorkerPoolSize(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *configpb.HANAMonitoringConfiguration
+		want int
+	}{
+		{
+			name: "Configured",
+			cfg:  &configpb.HANAMonitoringConfiguration{ExecutionThreads: 4},
+			want: 4,
+		},
+		{
+			name: "Unset",
+			cfg:  &configpb.HANAMonitoringConfiguration{},
+			want: 1,
+		},
+		{
+			name: "Negative",
+			cfg:  &configpb.HANAMonitoringConfiguration{ExecutionThreads: -2},
+			want: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := workerPoolSize(context.Background(), tc.cfg); got != tc.want {
+				t.Errorf("workerPoolSize(%v) = %d, want %d", tc.cfg, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestScheduledQueries(t *testing.T) {
+	tests := []struct {
+		name            string
+		queries         []*configpb.Query
+		disabledQueries []string
+		want            []*configpb.Query
+	}{
+		{
+			name: "AllEnabled",
+			queries: []*configpb.Query{
+				&configpb.Query{Name: "query1", Enabled: true},
+				&configpb.Query{Name: "query2", Enabled: true},
+			},
+			want: []*configpb.Query{
+				&configpb.Query{Name: "query1", Enabled: true},
+				&configpb.Query{Name: "query2", Enabled: true},
+			},
+		},
+		{
+			name: "SkipsDisabledInConfig",
+			queries: []*configpb.Query{
+				&configpb.Query{Name: "query1", Enabled: true},
+				&configpb.Query{Name: "query2", Enabled: false},
+			},
+			want: []*configpb.Query{
+				&configpb.Query{Name: "query1", Enabled: true},
+			},
+		},
+		{
+			name: "SkipsDisabledByFlag",
+			queries: []*configpb.Query{
+				&configpb.Query{Name: "query1", Enabled: true},
+				&configpb.Query{Name: "query2", Enabled: true},
+				&configpb.Query{Name: "query3", Enabled: true},
+			},
+			disabledQueries: []string{"query1", " query3", ""},
+			want: []*configpb.Query{
+				&configpb.Query{Name: "query2", Enabled: true},
+			},
+		},
+		{
+			name: "SkipsDefaultQueryByOverrideName",
+			queries: []*configpb.Query{
+				&configpb.Query{Name: "cpu_queries", Enabled: true},
+			},
+			disabledQueries: []string{"default_cpu_queries"},
+			want:            nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := scheduledQueries(context.Background(), tc.queries, tc.disabledQueries)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("scheduledQueries(%v, %v) returned unexpected diff (-want +got):\n%s", tc.queries, tc.disabledQueries, diff)
+			}
+		})
+	}
+}
+
 func TestQueryAndSend(t *testing.T) {
 	// We test that the queryAndSend() workflow returns an error and retries or cancels
 	// the query based on the if the query results in an authentication error.
@@ -633,9 +743,27 @@ func TestConnectToDatabases(t *testing.T) {
 							&configpb.HANAInstance{Sid: "fakeSID", HdbuserstoreKey: "fakeKey"}},
 					},
 				},
+				exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+					return commandlineexecutor.Result{StdOut: "KEY FAKEKEY\n  ENV : fakehost:30015\n  USER: SYSTEM\n"}
+				},
 			},
 			want: 3,
 		},
+		{
+			name: "ConnectViaHDBUserstoreKeyFailsKeyNotOnHost",
+			params: Parameters{
+				Config: &configpb.Configuration{
+					HanaMonitoringConfiguration: &configpb.HANAMonitoringConfiguration{
+						HanaInstances: []*configpb.HANAInstance{
+							&configpb.HANAInstance{Sid: "fakeSID", HdbuserstoreKey: "fakeKey"}},
+					},
+				},
+				exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+					return commandlineexecutor.Result{StdOut: "KEY OTHERKEY\n  ENV : fakehost:30015\n"}
+				},
+			},
+			want: 0,
+		},
 		{
 			name: "ConnectViaHDBUserstoreKeyFailsNoSID",
 			params: Parameters{
@@ -905,6 +1033,38 @@ func TestCreateQueryTimeTakenMetric(t *testing.T) {
 	createQueryResponseTimeMetric(ctx, dbName, sid, query, defaultParams, int64(timeTaken), ts)
 }
 
+func TestPortValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance *configpb.HANAInstance
+		want     string
+	}{
+		{
+			name:     "PortConfigured",
+			instance: &configpb.HANAInstance{Port: "30015", InstanceNum: "00"},
+			want:     "30015",
+		},
+		{
+			name:     "PortFromInstanceNumber",
+			instance: &configpb.HANAInstance{InstanceNum: "02"},
+			want:     "30213",
+		},
+		{
+			name:     "NoPortOrInstanceNumber",
+			instance: &configpb.HANAInstance{},
+			want:     "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := portValue(context.Background(), tc.instance); got != tc.want {
+				t.Errorf("portValue(%v) = %q, want: %q", tc.instance, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestMatchQyeryAndInstanceType(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1092,3 +1252,89 @@ func TestCollectExpiementalMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestReloadSecrets(t *testing.T) {
+	connect := func(ctx context.Context, p databaseconnector.Params) (queryFunc, statsFunc, closeFunc, error) {
+		if p.PasswordSecret == "badSecret" {
+			return nil, nil, nil, cmpopts.AnyError
+		}
+		return fakeQueryFunc, nil, nil, nil
+	}
+	withSecret := &database{
+		queryFunc: fakeQueryFuncError,
+		instance:  &configpb.HANAInstance{Name: "withSecret"},
+		params:    databaseconnector.Params{PasswordSecret: "secret"},
+	}
+	withPassword := &database{
+		queryFunc: fakeQueryFuncError,
+		instance:  &configpb.HANAInstance{Name: "withPassword"},
+		params:    databaseconnector.Params{Password: "password"},
+	}
+	failsToConnect := &database{
+		queryFunc: fakeQueryFuncError,
+		instance:  &configpb.HANAInstance{Name: "failsToConnect"},
+		params:    databaseconnector.Params{PasswordSecret: "badSecret"},
+	}
+
+	got := reloadSecrets(context.Background(), []*database{withSecret, withPassword, failsToConnect}, connect)
+	if want := []string{"withSecret"}; !cmp.Equal(got, want) {
+		t.Errorf("reloadSecrets() = %v, want %v", got, want)
+	}
+	// Only the refreshed database queries with the new handle.
+	for _, tc := range []struct {
+		db      *database
+		wantErr bool
+	}{
+		{db: withSecret, wantErr: false},
+		{db: withPassword, wantErr: true},
+		{db: failsToConnect, wantErr: true},
+	} {
+		q, done := tc.db.query()
+		_, err := q(context.Background(), "", nil)
+		done()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("query() for %s after reloadSecrets() returned error: %v, want error: %t", tc.db.instance.GetName(), err, tc.wantErr)
+		}
+	}
+	if got := failsToConnect.connectionErrors.Load(); got != 1 {
+		t.Errorf("reloadSecrets() counted %d connection errors for failsToConnect, want 1", got)
+	}
+}
+
+func TestReloadSecretsClosesReplacedHandle(t *testing.T) {
+	connect := func(ctx context.Context, p databaseconnector.Params) (queryFunc, statsFunc, closeFunc, error) {
+		return fakeQueryFunc, nil, func() error { return nil }, nil
+	}
+	closed := make(chan struct{})
+	db := &database{
+		queryFunc: fakeQueryFuncError,
+		closeFunc: func() error {
+			close(closed)
+			return nil
+		},
+		instance: &configpb.HANAInstance{Name: "withSecret"},
+		params:   databaseconnector.Params{PasswordSecret: "secret"},
+	}
+	// A query in progress on the old handle delays its close.
+	_, done := db.query()
+
+	reloadSecrets(context.Background(), []*database{db}, connect)
+	select {
+	case <-closed:
+		t.Fatal("reloadSecrets() closed the replaced handle before the query in progress was done")
+	case <-time.After(50 * time.Millisecond):
+	}
+	done()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reloadSecrets() did not close the replaced handle after the query in progress was done")
+	}
+
+	// Queries use the new handle.
+	q, done := db.query()
+	if _, err := q(context.Background(), "", nil); err != nil {
+		t.Errorf("query() after reloadSecrets() returned error: %v", err)
+	}
+	done()
+}
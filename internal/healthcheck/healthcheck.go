@@ -0,0 +1,120 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package healthcheck serves the liveness and readiness endpoints of the running agent.
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+// Status tracks whether the collectors enabled in the agent have completed a successful cycle.
+// The zero value has no collectors registered and is ready.
+type Status struct {
+	mu         sync.RWMutex
+	collectors map[string]bool
+}
+
+// Register adds a collector which has to complete a successful cycle before the agent is ready.
+// Registering a collector which is already registered keeps its readiness.
+func (s *Status) Register(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.collectors == nil {
+		s.collectors = map[string]bool{}
+	}
+	if _, ok := s.collectors[name]; !ok {
+		s.collectors[name] = false
+	}
+}
+
+// Deregister removes a collector, used when a registered collector is not started.
+func (s *Status) Deregister(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.collectors, name)
+}
+
+// MarkReady records a successful cycle of the collector, unregistered collectors are ignored.
+func (s *Status) MarkReady(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.collectors[name]; ok {
+		s.collectors[name] = true
+	}
+}
+
+// ReadyFunc returns a function marking the collector ready.
+func (s *Status) ReadyFunc(name string) func() {
+	return func() { s.MarkReady(name) }
+}
+
+// Pending returns the sorted names of the registered collectors without a successful cycle.
+func (s *Status) Pending() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var pending []string
+	for name, ready := range s.collectors {
+		if !ready {
+			pending = append(pending, name)
+		}
+	}
+	sort.Strings(pending)
+	return pending
+}
+
+// handleHealthz reports that the agent process is alive.
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprint(w, "ok\n")
+}
+
+// handleReadyz reports whether every registered collector has completed a successful cycle.
+func (s *Status) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if pending := s.Pending(); len(pending) > 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, "waiting for: %s\n", strings.Join(pending, ","))
+		return
+	}
+	fmt.Fprint(w, "ok\n")
+}
+
+// ListenAndServe serves /healthz and /readyz on the port until the context is cancelled.
+func (s *Status) ListenAndServe(ctx context.Context, port int64) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", handleHealthz)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+	server := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+	log.CtxLogger(ctx).Infow("Serving health check endpoints", "port", port)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
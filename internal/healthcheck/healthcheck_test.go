@@ -0,0 +1,159 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPending(t *testing.T) {
+	tests := []struct {
+		name       string
+		register   []string
+		deregister []string
+		ready      []string
+		want       []string
+	}{
+		{
+			name: "NoCollectors",
+		},
+		{
+			name:     "NoneReady",
+			register: []string{"processmetrics", "hostmetrics"},
+			want:     []string{"hostmetrics", "processmetrics"},
+		},
+		{
+			name:     "SomeReady",
+			register: []string{"processmetrics", "hostmetrics"},
+			ready:    []string{"hostmetrics"},
+			want:     []string{"processmetrics"},
+		},
+		{
+			name:     "AllReady",
+			register: []string{"processmetrics", "hostmetrics"},
+			ready:    []string{"hostmetrics", "processmetrics"},
+		},
+		{
+			name:     "UnregisteredReadyIgnored",
+			register: []string{"hostmetrics"},
+			ready:    []string{"hanamonitoring"},
+			want:     []string{"hostmetrics"},
+		},
+		{
+			name:       "Deregistered",
+			register:   []string{"processmetrics", "hostmetrics"},
+			deregister: []string{"processmetrics"},
+			want:       []string{"hostmetrics"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Status{}
+			for _, n := range tc.register {
+				s.Register(n)
+			}
+			for _, n := range tc.deregister {
+				s.Deregister(n)
+			}
+			for _, n := range tc.ready {
+				s.ReadyFunc(n)()
+			}
+			if got := s.Pending(); !cmp.Equal(got, tc.want) {
+				t.Errorf("Pending() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegisterKeepsReadiness(t *testing.T) {
+	s := &Status{}
+	s.Register("hostmetrics")
+	s.MarkReady("hostmetrics")
+	s.Register("hostmetrics")
+	if got := s.Pending(); len(got) != 0 {
+		t.Errorf("Pending() = %v, want none", got)
+	}
+}
+
+func TestHandlers(t *testing.T) {
+	tests := []struct {
+		name     string
+		ready    bool
+		handler  func(s *Status) http.HandlerFunc
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "HealthzNotReady",
+			handler:  func(*Status) http.HandlerFunc { return handleHealthz },
+			wantCode: http.StatusOK,
+			wantBody: "ok\n",
+		},
+		{
+			name:     "ReadyzNotReady",
+			handler:  func(s *Status) http.HandlerFunc { return s.handleReadyz },
+			wantCode: http.StatusServiceUnavailable,
+			wantBody: "waiting for: hostmetrics\n",
+		},
+		{
+			name:     "ReadyzReady",
+			ready:    true,
+			handler:  func(s *Status) http.HandlerFunc { return s.handleReadyz },
+			wantCode: http.StatusOK,
+			wantBody: "ok\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Status{}
+			s.Register("hostmetrics")
+			if tc.ready {
+				s.MarkReady("hostmetrics")
+			}
+			rec := httptest.NewRecorder()
+			tc.handler(s)(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+			if rec.Code != tc.wantCode {
+				t.Errorf("status code = %d, want %d", rec.Code, tc.wantCode)
+			}
+			if got := rec.Body.String(); got != tc.wantBody {
+				t.Errorf("body = %q, want %q", got, tc.wantBody)
+			}
+		})
+	}
+}
+
+func TestListenAndServeStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Status{}
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.ListenAndServe(ctx, 0) }()
+	cancel()
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Errorf("ListenAndServe() = %v, want nil", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Error("ListenAndServe() did not return after the context was cancelled")
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/GoogleCloudPlatform/sapagent/internal/drain"
 	"github.com/GoogleCloudPlatform/sapagent/internal/heartbeat"
 	"github.com/GoogleCloudPlatform/sapagent/internal/hostmetrics/agenttime"
 	"github.com/GoogleCloudPlatform/sapagent/internal/hostmetrics/cloudmetricreader"
@@ -36,6 +37,7 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/internal/hostmetrics/osmetricreader"
 	"github.com/GoogleCloudPlatform/sapagent/internal/instanceinfo"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/sapagent/internal/utils/jitter"
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 	"github.com/GoogleCloudPlatform/sapagent/shared/recovery"
@@ -51,6 +53,8 @@ type Parameters struct {
 	CloudMetricReader  cloudmetricreader.CloudMetricReader
 	AgentTime          agenttime.AgentTime
 	HeartbeatSpec      *heartbeat.Spec
+	// ReadyFunc is called after each completed collection, it may be nil.
+	ReadyFunc func()
 }
 
 type hostMetricsReaders struct {
@@ -144,6 +148,14 @@ func collectHostMetrics(ctx context.Context, a any) {
 		dsr:      diskstatsreader.New(runtime.GOOS, os.ReadFile, commandlineexecutor.ExecuteCommand),
 	}
 
+	// Offset the start of collection so that hosts deployed together do not send at the same time.
+	delay := jitter.Delay(params.Config.GetCloudProperties().GetInstanceId(), 60*time.Second, params.Config.GetCollectionConfiguration().GetCollectionJitterFraction())
+	log.CtxLogger(ctx).Infow("Delaying start of host metrics collection", "delay", delay)
+	if !jitter.Wait(ctx, delay) {
+		log.CtxLogger(ctx).Info("Host metrics cancellation requested")
+		return
+	}
+
 	collectTicker := time.NewTicker(60 * time.Second)
 	heartbeatTicker := params.HeartbeatSpec.CreateTicker()
 	defer collectTicker.Stop()
@@ -172,6 +184,10 @@ func collectHostMetrics(ctx context.Context, a any) {
 		case <-heartbeatTicker.C:
 			params.HeartbeatSpec.Beat()
 		case <-collectTicker.C:
+			if drain.Active() {
+				log.CtxLogger(ctx).Debug("Agent is in drain mode, skipping host metrics collection")
+				continue
+			}
 			collectHostMetricsOnce(ctx, params, readers)
 		}
 	}
@@ -202,4 +218,7 @@ func collectHostMetricsOnce(ctx context.Context, params Parameters, readers host
 	metricsXML = GenerateXML(metricsCollection)
 
 	log.CtxLogger(ctx).Infow("Metrics collection complete", "metricscollected", len(metricsCollection.GetMetrics()))
+	if params.ReadyFunc != nil {
+		params.ReadyFunc()
+	}
 }
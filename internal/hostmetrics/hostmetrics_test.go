@@ -146,3 +146,26 @@ func TestCollectHostMetrics_shouldBeatAccordingToHeartbeatSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectHostMetrics_callsReadyFunc(t *testing.T) {
+	defer func(s string) { metricsXML = s }(metricsXML)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	ready := 0
+	lock := sync.Mutex{}
+	at := agenttime.New(clockwork.NewFakeClock())
+	params := Parameters{
+		AgentTime: *at,
+		ReadyFunc: func() {
+			lock.Lock()
+			defer lock.Unlock()
+			ready++
+		},
+	}
+	collectHostMetrics(ctx, params)
+	lock.Lock()
+	defer lock.Unlock()
+	if ready != 1 {
+		t.Errorf("collectHostMetrics() ReadyFunc calls got %d, want 1", ready)
+	}
+}
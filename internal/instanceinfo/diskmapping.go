@@ -18,11 +18,15 @@ package instanceinfo
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	instancepb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
 )
 
 /*
@@ -39,6 +43,13 @@ type PhysicalPathReader struct {
 	OS string
 }
 
+var (
+	// lvsDeviceSuffix matches the extent suffix lvs appends to each device, e.g. "/dev/sdb(0)".
+	lvsDeviceSuffix = regexp.MustCompile(`\(\d+\)$`)
+	partitionSuffix = regexp.MustCompile(`^\d+$`)
+	nvmePartSuffix  = regexp.MustCompile(`^p\d+$`)
+)
+
 const winPsPath = `C:\\Program Files\Google\google-cloud-sap-agent\google-cloud-sap-agent-diskmapping.ps1`
 
 /*
@@ -89,3 +100,71 @@ func forLinux(deviceName string) (string, error) {
 	log.Logger.Debugw("Mapping for device", "name", deviceName, "mapping", path)
 	return path, nil
 }
+
+/*
+PhysicalDevicesForLogicalVolume returns the names of the physical devices (sdb, sdc1, etc...)
+backing the LVM logical volume at "logicalPath". Striped or multi-segment volumes return one
+entry for each distinct physical volume in the order reported by lvs.
+*/
+func PhysicalDevicesForLogicalVolume(ctx context.Context, logicalPath string, exec commandlineexecutor.Execute) ([]string, error) {
+	result := exec(ctx, commandlineexecutor.Params{
+		Executable: "/sbin/lvs",
+		Args:       []string{"--noheadings", "-o", "devices", logicalPath},
+	})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failure reading physical volumes for logical volume %s, stderr: %s, err: %s", logicalPath, result.StdErr, result.Error)
+	}
+	log.CtxLogger(ctx).Debugw("PhysicalDevicesForLogicalVolume", "stdout", result.StdOut, "stderr", result.StdErr)
+
+	var devices []string
+	seen := make(map[string]bool)
+	for _, field := range strings.FieldsFunc(result.StdOut, func(r rune) bool {
+		return r == ',' || r == '\n' || r == ' ' || r == '\t'
+	}) {
+		device := filepath.Base(lvsDeviceSuffix.ReplaceAllString(field, ""))
+		if device == "" || seen[device] {
+			continue
+		}
+		seen[device] = true
+		devices = append(devices, device)
+	}
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no physical volumes found for logical volume %s", logicalPath)
+	}
+	log.CtxLogger(ctx).Infow("Logical volume to physical devices mapping", "logicalVolume", logicalPath, "devices", devices)
+	return devices, nil
+}
+
+/*
+DisksForDevices returns the disks whose mapping backs any of the given physical devices. A device
+matches a disk when it is the mapped device itself or one of its partitions (sdb1 for sdb,
+nvme0n2p1 for nvme0n2).
+*/
+func DisksForDevices(disks []*instancepb.Disk, devices []string) []*instancepb.Disk {
+	var matched []*instancepb.Disk
+	for _, d := range disks {
+		for _, device := range devices {
+			if isDeviceOrPartition(device, d.GetMapping()) {
+				matched = append(matched, d)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// isDeviceOrPartition reports whether device is the disk named by mapping or one of its partitions.
+func isDeviceOrPartition(device, mapping string) bool {
+	if mapping == "" || !strings.HasPrefix(device, mapping) {
+		return false
+	}
+	suffix := strings.TrimPrefix(device, mapping)
+	if suffix == "" {
+		return true
+	}
+	// Devices whose names end in a digit (nvme0n1) separate partitions with a "p".
+	if last := mapping[len(mapping)-1]; last >= '0' && last <= '9' {
+		return nvmePartSuffix.MatchString(suffix)
+	}
+	return partitionSuffix.MatchString(suffix)
+}
@@ -25,6 +25,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	instancepb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
 )
 
 func TestMain(t *testing.M) {
@@ -143,3 +146,118 @@ func TestForWindowsError(t *testing.T) {
 		t.Errorf("%#v.ForDeviceName(\"C:\") did not return an error", d)
 	}
 }
+
+func TestPhysicalDevicesForLogicalVolume(t *testing.T) {
+	tests := []struct {
+		name    string
+		exec    commandlineexecutor.Execute
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "SingleDevice",
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "  /dev/sdb(0)\n"}
+			},
+			want: []string{"sdb"},
+		},
+		{
+			name: "StripedDevices",
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "  /dev/sdb(0),/dev/sdc(0),/dev/sdd(0)\n"}
+			},
+			want: []string{"sdb", "sdc", "sdd"},
+		},
+		{
+			name: "MultipleSegmentsDeduplicated",
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "  /dev/nvme0n2p1(0)\n  /dev/nvme0n3(0)\n  /dev/nvme0n2p1(2560)\n"}
+			},
+			want: []string{"nvme0n2p1", "nvme0n3"},
+		},
+		{
+			name: "NoDevices",
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "\n"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "CommandError",
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: errors.New("test error")}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := PhysicalDevicesForLogicalVolume(context.Background(), "/dev/mapper/vg-data", tc.exec)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("PhysicalDevicesForLogicalVolume() returned error: %v, wantErr: %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("PhysicalDevicesForLogicalVolume() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDisksForDevices(t *testing.T) {
+	disks := []*instancepb.Disk{
+		{DiskName: "boot", Mapping: "sda"},
+		{DiskName: "data-1", Mapping: "sdb"},
+		{DiskName: "data-2", Mapping: "sdc"},
+		{DiskName: "nvme-1", Mapping: "nvme0n1"},
+		{DiskName: "nvme-12", Mapping: "nvme0n12"},
+		{DiskName: "unmapped", Mapping: "unknown"},
+	}
+	tests := []struct {
+		name    string
+		devices []string
+		want    []*instancepb.Disk
+	}{
+		{
+			name:    "SingleDisk",
+			devices: []string{"sdb"},
+			want:    []*instancepb.Disk{{DiskName: "data-1", Mapping: "sdb"}},
+		},
+		{
+			name:    "StripedDisks",
+			devices: []string{"sdb", "sdc"},
+			want: []*instancepb.Disk{
+				{DiskName: "data-1", Mapping: "sdb"},
+				{DiskName: "data-2", Mapping: "sdc"},
+			},
+		},
+		{
+			name:    "Partition",
+			devices: []string{"sdc1"},
+			want:    []*instancepb.Disk{{DiskName: "data-2", Mapping: "sdc"}},
+		},
+		{
+			name:    "NVMePartition",
+			devices: []string{"nvme0n1p2"},
+			want:    []*instancepb.Disk{{DiskName: "nvme-1", Mapping: "nvme0n1"}},
+		},
+		{
+			name:    "NVMeNamespaceIsNotPartition",
+			devices: []string{"nvme0n12"},
+			want:    []*instancepb.Disk{{DiskName: "nvme-12", Mapping: "nvme0n12"}},
+		},
+		{
+			name:    "NoMatch",
+			devices: []string{"sdz"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DisksForDevices(disks, tc.devices)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("DisksForDevices(%v) returned unexpected diff (-want +got):\n%s", tc.devices, diff)
+			}
+		})
+	}
+}
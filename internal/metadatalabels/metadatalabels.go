@@ -0,0 +1,101 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metadatalabels adds instance metadata attributes, such as a cost center or landscape
+// tag, as labels on all the metrics sent by the agent. The attributes are read from the metadata
+// server at startup and again each time a reload is requested with SIGHUP.
+package metadatalabels
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/sapagent/internal/secretreload"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+	"github.com/GoogleCloudPlatform/sapagent/shared/timeseries"
+)
+
+// Limits of the metric labels accepted by Cloud Monitoring.
+const (
+	maxKeyLength   = 100
+	maxValueLength = 1024
+)
+
+// labelKeyRegex matches the metric label keys accepted by Cloud Monitoring.
+var labelKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// FetchAttribute returns the value of an instance metadata attribute, empty if it is not set.
+type FetchAttribute func(key string) (string, error)
+
+// Start sets the metric labels from the instance metadata attributes and refreshes them each
+// time a reload is requested, until the context is done.
+func Start(ctx context.Context, attributes []string, fetch FetchAttribute) {
+	timeseries.SetCommonLabels(load(ctx, attributes, fetch))
+	if len(attributes) == 0 {
+		return
+	}
+	reload, unsubscribe := secretreload.Subscribe()
+	go func() {
+		defer unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-reload:
+				timeseries.SetCommonLabels(load(ctx, attributes, fetch))
+			}
+		}
+	}()
+}
+
+// load reads the attributes from the instance metadata and returns them as metric labels.
+// Attributes which are not set, cannot be read or do not map to a valid label key are skipped.
+func load(ctx context.Context, attributes []string, fetch FetchAttribute) map[string]string {
+	if len(attributes) == 0 {
+		return nil
+	}
+	labels := make(map[string]string)
+	for _, attribute := range attributes {
+		key := labelKey(attribute)
+		if !labelKeyRegex.MatchString(key) || len(key) > maxKeyLength {
+			log.CtxLogger(ctx).Warnw("Instance metadata attribute is not a valid metric label key, skipping", "attribute", attribute)
+			continue
+		}
+		value, err := fetch(attribute)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Could not read instance metadata attribute for the metric labels", "attribute", attribute, "error", err)
+			continue
+		}
+		if value == "" {
+			log.CtxLogger(ctx).Debugw("Instance metadata attribute is not set, skipping", "attribute", attribute)
+			continue
+		}
+		if len(value) > maxValueLength {
+			log.CtxLogger(ctx).Warnw("Instance metadata attribute value is too long for a metric label, truncating", "attribute", attribute, "length", len(value), "maxLength", maxValueLength)
+			value = value[:maxValueLength]
+		}
+		labels[key] = value
+	}
+	log.CtxLogger(ctx).Infow("Loaded metric labels from the instance metadata", "labels", labels)
+	return labels
+}
+
+// labelKey returns the metric label key for an attribute, metadata keys commonly use hyphens
+// which are not allowed in label keys.
+func labelKey(attribute string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(attribute)), "-", "_")
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadatalabels
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+func TestMain(m *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(m.Run())
+}
+
+func fakeFetch(attributes map[string]string) FetchAttribute {
+	return func(key string) (string, error) {
+		if key == "broken" {
+			return "", errors.New("metadata server unavailable")
+		}
+		return attributes[key], nil
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes []string
+		metadata   map[string]string
+		want       map[string]string
+	}{
+		{
+			name: "NoAttributes",
+		},
+		{
+			name:       "AttributesLoaded",
+			attributes: []string{"cost-center", "Landscape"},
+			metadata:   map[string]string{"cost-center": "finance", "Landscape": "production"},
+			want:       map[string]string{"cost_center": "finance", "landscape": "production"},
+		},
+		{
+			name:       "AttributeNotSet",
+			attributes: []string{"cost-center", "landscape"},
+			metadata:   map[string]string{"cost-center": "finance"},
+			want:       map[string]string{"cost_center": "finance"},
+		},
+		{
+			name:       "FetchError",
+			attributes: []string{"cost-center", "broken"},
+			metadata:   map[string]string{"cost-center": "finance"},
+			want:       map[string]string{"cost_center": "finance"},
+		},
+		{
+			name:       "InvalidKeys",
+			attributes: []string{"1st-tier", "team.name", strings.Repeat("a", maxKeyLength+1)},
+			metadata:   map[string]string{"1st-tier": "a", "team.name": "b", strings.Repeat("a", maxKeyLength+1): "c"},
+			want:       map[string]string{},
+		},
+		{
+			name:       "ValueTruncated",
+			attributes: []string{"description"},
+			metadata:   map[string]string{"description": strings.Repeat("x", maxValueLength+10)},
+			want:       map[string]string{"description": strings.Repeat("x", maxValueLength)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := load(context.Background(), test.attributes, fakeFetch(test.metadata))
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("load(%v) returned unexpected labels (-want +got):\n%s", test.attributes, diff)
+			}
+		})
+	}
+}
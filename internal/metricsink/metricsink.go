@@ -0,0 +1,241 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metricsink provides the destinations to which collected metrics are sent.
+package metricsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	metricpb "google.golang.org/genproto/googleapis/api/metric"
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+// DefaultPrometheusPort is the port serving /metrics when prometheus_port is not configured.
+const DefaultPrometheusPort = 9464
+
+// staleCycles is the number of collection cycles after which a time series which was not sent
+// again, such as one of a removed SAP instance, is no longer served.
+const staleCycles = 3
+
+var (
+	invalidNameChars  = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+)
+
+type (
+	// MetricSink is a destination for collected time series.
+	MetricSink interface {
+		Send(ctx context.Context, timeSeries []*mrpb.TimeSeries, bo *cloudmonitoring.BackOffIntervals) (sent, batchCount int, err error)
+	}
+
+	// CloudMonitoring sends time series to Cloud Monitoring.
+	CloudMonitoring struct {
+		Client    cloudmonitoring.TimeSeriesCreator
+		ProjectID string
+		// Subsystem labels the send latency and error self-metrics of the agent.
+		Subsystem string
+	}
+
+	// Prometheus keeps the latest point of every time series sent to it and exposes them in
+	// the Prometheus text exposition format. A time series which is not sent again within
+	// staleAfter expires.
+	Prometheus struct {
+		mu         sync.RWMutex
+		series     map[string]sample
+		staleAfter time.Duration
+		now        func() time.Time
+	}
+
+	// sample is the latest value of a single time series.
+	sample struct {
+		name, labels, kind string
+		value              float64
+		updated            time.Time
+	}
+)
+
+// New returns the sink selected by metric_sink in the collection configuration. Cloud
+// Monitoring is used unless Prometheus is selected.
+func New(config *cpb.Configuration, client cloudmonitoring.TimeSeriesCreator) MetricSink {
+	if cc := config.GetCollectionConfiguration(); cc.GetMetricSink() == cpb.MetricSink_PROMETHEUS {
+		// The slow moving collectors send their time series at the longest interval.
+		cycle := time.Duration(max(cc.GetProcessMetricsFrequency(), cc.GetSlowProcessMetricsFrequency())) * time.Second
+		return NewPrometheus(staleCycles * cycle)
+	}
+	return &CloudMonitoring{Client: client, ProjectID: config.GetCloudProperties().GetProjectId(), Subsystem: cloudmonitoring.SubsystemProcess}
+}
+
+// Send sends the time series to Cloud Monitoring in batches.
+func (c *CloudMonitoring) Send(ctx context.Context, timeSeries []*mrpb.TimeSeries, bo *cloudmonitoring.BackOffIntervals) (sent, batchCount int, err error) {
+	return cloudmonitoring.SendTimeSeriesForSubsystem(ctx, c.Subsystem, timeSeries, c.Client, bo, c.ProjectID)
+}
+
+// NewPrometheus creates a Prometheus sink without any time series. The time series which are not
+// sent again within staleAfter expire, a zero staleAfter keeps them until the agent restarts.
+func NewPrometheus(staleAfter time.Duration) *Prometheus {
+	return &Prometheus{series: make(map[string]sample), staleAfter: staleAfter, now: time.Now}
+}
+
+// Send records the latest point of each time series to be served on the next scrape, and removes
+// the expired time series. Distribution values are not supported and are dropped.
+func (p *Prometheus) Send(ctx context.Context, timeSeries []*mrpb.TimeSeries, bo *cloudmonitoring.BackOffIntervals) (sent, batchCount int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	for _, ts := range timeSeries {
+		if len(ts.GetPoints()) == 0 {
+			continue
+		}
+		value, ok := pointValue(ts.GetPoints()[0])
+		if !ok {
+			log.CtxLogger(ctx).Debugw("Dropping time series with unsupported value type", "metric", ts.GetMetric().GetType())
+			continue
+		}
+		s := sample{
+			name:    metricName(ts.GetMetric().GetType()),
+			labels:  formatLabels(ts),
+			kind:    "gauge",
+			value:   value,
+			updated: now,
+		}
+		if ts.GetMetricKind() == metricpb.MetricDescriptor_CUMULATIVE {
+			s.kind = "counter"
+		}
+		p.series[s.name+s.labels] = s
+		sent++
+	}
+	for key, s := range p.series {
+		if p.stale(s, now) {
+			delete(p.series, key)
+		}
+	}
+	return sent, 1, nil
+}
+
+// stale reports whether the time series expired, it was not sent again within staleAfter.
+func (p *Prometheus) stale(s sample, now time.Time) bool {
+	return p.staleAfter > 0 && now.Sub(s.updated) > p.staleAfter
+}
+
+// ServeHTTP writes all unexpired time series in the Prometheus text exposition format.
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.RLock()
+	now := p.now()
+	samples := make([]sample, 0, len(p.series))
+	for _, s := range p.series {
+		// The expired time series are removed on the next Send, which does not happen when
+		// nothing is collected anymore.
+		if !p.stale(s, now) {
+			samples = append(samples, s)
+		}
+	}
+	p.mu.RUnlock()
+	sort.Slice(samples, func(i, j int) bool {
+		if samples[i].name != samples[j].name {
+			return samples[i].name < samples[j].name
+		}
+		return samples[i].labels < samples[j].labels
+	})
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	var b strings.Builder
+	for i, s := range samples {
+		if i == 0 || samples[i-1].name != s.name {
+			fmt.Fprintf(&b, "# TYPE %s %s\n", s.name, s.kind)
+		}
+		fmt.Fprintf(&b, "%s%s %s\n", s.name, s.labels, strconv.FormatFloat(s.value, 'g', -1, 64))
+	}
+	w.Write([]byte(b.String()))
+}
+
+// ListenAndServe serves /metrics on the port until the context is cancelled.
+func (p *Prometheus) ListenAndServe(ctx context.Context, port int64) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", p)
+	server := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	log.CtxLogger(ctx).Infow("Serving metrics for Prometheus", "port", port)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// pointValue returns the numeric value of the point, bool values are reported as 0 or 1.
+func pointValue(point *mrpb.Point) (float64, bool) {
+	switch v := point.GetValue().GetValue().(type) {
+	case *mrpb.TypedValue_BoolValue:
+		if v.BoolValue {
+			return 1, true
+		}
+		return 0, true
+	case *mrpb.TypedValue_Int64Value:
+		return float64(v.Int64Value), true
+	case *mrpb.TypedValue_DoubleValue:
+		return v.DoubleValue, true
+	}
+	return 0, false
+}
+
+// metricName converts a Cloud Monitoring metric type such as
+// workload.googleapis.com/sap/hana/cpu/utilization to sap_hana_cpu_utilization.
+func metricName(metricType string) string {
+	if i := strings.Index(metricType, "/"); i >= 0 {
+		metricType = metricType[i+1:]
+	}
+	return invalidNameChars.ReplaceAllString(metricType, "_")
+}
+
+// formatLabels merges the metric and monitored resource labels of the time series into a
+// Prometheus label set, metric labels take precedence on conflicts.
+func formatLabels(ts *mrpb.TimeSeries) string {
+	labels := make(map[string]string)
+	for k, v := range ts.GetResource().GetLabels() {
+		labels[invalidNameChars.ReplaceAllString(k, "_")] = v
+	}
+	for k, v := range ts.GetMetric().GetLabels() {
+		labels[invalidNameChars.ReplaceAllString(k, "_")] = v
+	}
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, k, labelValueEscaper.Replace(labels[k]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsink
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metricpb "google.golang.org/genproto/googleapis/api/metric"
+	mrespb "google.golang.org/genproto/googleapis/api/monitoredres"
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	"github.com/google/go-cmp/cmp"
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
+	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring/fake"
+)
+
+func timeSeries(metricType string, labels map[string]string, kind metricpb.MetricDescriptor_MetricKind, value *mrpb.TypedValue) *mrpb.TimeSeries {
+	return &mrpb.TimeSeries{
+		Metric:     &metricpb.Metric{Type: metricType, Labels: labels},
+		Resource:   &mrespb.MonitoredResource{Type: "gce_instance", Labels: map[string]string{"instance_id": "123"}},
+		MetricKind: kind,
+		Points:     []*mrpb.Point{{Value: value}},
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         *cpb.Configuration
+		wantPrometheus bool
+	}{
+		{
+			name:   "Default",
+			config: &cpb.Configuration{},
+		},
+		{
+			name: "CloudMonitoring",
+			config: &cpb.Configuration{
+				CollectionConfiguration: &cpb.CollectionConfiguration{MetricSink: cpb.MetricSink_CLOUD_MONITORING},
+			},
+		},
+		{
+			name: "Prometheus",
+			config: &cpb.Configuration{
+				CollectionConfiguration: &cpb.CollectionConfiguration{MetricSink: cpb.MetricSink_PROMETHEUS},
+			},
+			wantPrometheus: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, gotPrometheus := New(tc.config, &fake.TimeSeriesCreator{}).(*Prometheus)
+			if gotPrometheus != tc.wantPrometheus {
+				t.Errorf("New(%v) returned a Prometheus sink: %t, want: %t", tc.config, gotPrometheus, tc.wantPrometheus)
+			}
+		})
+	}
+}
+
+func TestCloudMonitoringSend(t *testing.T) {
+	sink := &CloudMonitoring{Client: &fake.TimeSeriesCreator{}, ProjectID: "test-project", Subsystem: cloudmonitoring.SubsystemProcess}
+	ts := []*mrpb.TimeSeries{
+		timeSeries("workload.googleapis.com/sap/hana/cpu", nil, metricpb.MetricDescriptor_GAUGE, &mrpb.TypedValue{Value: &mrpb.TypedValue_Int64Value{Int64Value: 1}}),
+	}
+	sent, batchCount, err := sink.Send(context.Background(), ts, cloudmonitoring.NoBackOff())
+	if sent != 1 || batchCount != 1 || err != nil {
+		t.Errorf("Send() = %d, %d, %v, want: 1, 1, nil", sent, batchCount, err)
+	}
+}
+
+func TestPrometheusServeHTTP(t *testing.T) {
+	sink := NewPrometheus(0)
+	ts := []*mrpb.TimeSeries{
+		timeSeries("workload.googleapis.com/sap/nw/service", map[string]string{"service_name": "msg_server"}, metricpb.MetricDescriptor_GAUGE, &mrpb.TypedValue{Value: &mrpb.TypedValue_Int64Value{Int64Value: 0}}),
+		timeSeries("workload.googleapis.com/sap/hana/cpu/utilization", map[string]string{"process": "hdb\"nameserver"}, metricpb.MetricDescriptor_GAUGE, &mrpb.TypedValue{Value: &mrpb.TypedValue_DoubleValue{DoubleValue: 0.25}}),
+		timeSeries("workload.googleapis.com/sap/hana/query/state", nil, metricpb.MetricDescriptor_GAUGE, &mrpb.TypedValue{Value: &mrpb.TypedValue_BoolValue{BoolValue: true}}),
+		timeSeries("workload.googleapis.com/sap/hana/rfc/count", nil, metricpb.MetricDescriptor_CUMULATIVE, &mrpb.TypedValue{Value: &mrpb.TypedValue_Int64Value{Int64Value: 42}}),
+		timeSeries("workload.googleapis.com/sap/hana/distribution", nil, metricpb.MetricDescriptor_GAUGE, &mrpb.TypedValue{Value: &mrpb.TypedValue_DistributionValue{}}),
+	}
+	if sent, _, err := sink.Send(context.Background(), ts, nil); sent != 4 || err != nil {
+		t.Fatalf("Send() = %d, %v, want: 4, nil", sent, err)
+	}
+	// A later point replaces the value of the same time series.
+	update := timeSeries("workload.googleapis.com/sap/nw/service", map[string]string{"service_name": "msg_server"}, metricpb.MetricDescriptor_GAUGE, &mrpb.TypedValue{Value: &mrpb.TypedValue_Int64Value{Int64Value: 1}})
+	sink.Send(context.Background(), []*mrpb.TimeSeries{update}, nil)
+
+	rec := httptest.NewRecorder()
+	sink.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	want := `# TYPE sap_hana_cpu_utilization gauge
+sap_hana_cpu_utilization{instance_id="123",process="hdb\"nameserver"} 0.25
+# TYPE sap_hana_query_state gauge
+sap_hana_query_state{instance_id="123"} 1
+# TYPE sap_hana_rfc_count counter
+sap_hana_rfc_count{instance_id="123"} 42
+# TYPE sap_nw_service gauge
+sap_nw_service{instance_id="123",service_name="msg_server"} 1
+`
+	if diff := cmp.Diff(want, rec.Body.String()); diff != "" {
+		t.Errorf("ServeHTTP() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestPrometheusExpiry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	sink := NewPrometheus(time.Minute)
+	sink.now = func() time.Time { return now }
+	removed := timeSeries("workload.googleapis.com/sap/nw/service", map[string]string{"service_name": "msg_server"}, metricpb.MetricDescriptor_GAUGE, &mrpb.TypedValue{Value: &mrpb.TypedValue_Int64Value{Int64Value: 1}})
+	kept := timeSeries("workload.googleapis.com/sap/hana/cpu/utilization", nil, metricpb.MetricDescriptor_GAUGE, &mrpb.TypedValue{Value: &mrpb.TypedValue_DoubleValue{DoubleValue: 0.25}})
+	sink.Send(context.Background(), []*mrpb.TimeSeries{removed, kept}, nil)
+
+	// Only kept is sent again, removed expires once it was not sent for longer than a minute.
+	now = now.Add(45 * time.Second)
+	sink.Send(context.Background(), []*mrpb.TimeSeries{kept}, nil)
+	now = now.Add(45 * time.Second)
+	sink.Send(context.Background(), []*mrpb.TimeSeries{kept}, nil)
+
+	rec := httptest.NewRecorder()
+	sink.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	want := `# TYPE sap_hana_cpu_utilization gauge
+sap_hana_cpu_utilization{instance_id="123"} 0.25
+`
+	if diff := cmp.Diff(want, rec.Body.String()); diff != "" {
+		t.Errorf("ServeHTTP() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if got := len(sink.series); got != 1 {
+		t.Errorf("Send() kept %d time series, want: 1", got)
+	}
+
+	// Nothing is served once no time series is sent anymore.
+	now = now.Add(2 * time.Minute)
+	rec = httptest.NewRecorder()
+	sink.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if got := rec.Body.String(); got != "" {
+		t.Errorf("ServeHTTP() = %q, want an empty body", got)
+	}
+}
+
+func TestMetricName(t *testing.T) {
+	tests := []struct {
+		metricType string
+		want       string
+	}{
+		{metricType: "workload.googleapis.com/sap/hana/cpu/utilization", want: "sap_hana_cpu_utilization"},
+		{metricType: "workload.googleapis.com/sap/nw/abap/queue-peak", want: "sap_nw_abap_queue_peak"},
+		{metricType: "availability", want: "availability"},
+	}
+	for _, tc := range tests {
+		if got := metricName(tc.metricType); got != tc.want {
+			t.Errorf("metricName(%q) = %q, want: %q", tc.metricType, got, tc.want)
+		}
+	}
+}
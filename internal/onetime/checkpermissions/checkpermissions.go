@@ -0,0 +1,257 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package checkpermissions implements the one time execution mode which probes each of the
+// Google Cloud APIs used by the agent with the credentials of the instance, reporting the APIs
+// for which the service account is missing permissions.
+package checkpermissions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"flag"
+	"golang.org/x/oauth2/google"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	iipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+)
+
+const (
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+	probeTimeout       = 30 * time.Second
+)
+
+// Outcomes of a probe.
+const (
+	resultGranted = "OK"
+	resultDenied  = "PERMISSION DENIED"
+	resultError   = "ERROR"
+)
+
+type (
+	// httpDoer is the subset of http.Client used to send the probes.
+	httpDoer interface {
+		Do(req *http.Request) (*http.Response, error)
+	}
+
+	// newClientFunc provides a testable replacement for google.DefaultClient.
+	newClientFunc func(ctx context.Context) (httpDoer, error)
+
+	// probe is a lightweight request to one of the APIs used by the agent.
+	probe struct {
+		api        string
+		permission string
+		method     string
+		url        string
+		body       string
+	}
+
+	// probeResult is the outcome of a probe.
+	probeResult struct {
+		probe  probe
+		result string
+		detail string
+	}
+)
+
+// CheckPermissions stores the arguments for the checkpermissions subcommand.
+type CheckPermissions struct {
+	LogLevel  string `json:"loglevel"`
+	LogPath   string `json:"log-path"`
+	help      bool
+	oteLogger *onetime.OTELogger
+}
+
+// Name implements the subcommand interface for checkpermissions.
+func (*CheckPermissions) Name() string { return "checkpermissions" }
+
+// Synopsis implements the subcommand interface for checkpermissions.
+func (*CheckPermissions) Synopsis() string {
+	return "check that the service account can call the Google Cloud APIs used by the agent"
+}
+
+// Usage implements the subcommand interface for checkpermissions.
+func (*CheckPermissions) Usage() string {
+	return `Usage: checkpermissions [-h] [-loglevel=<debug|info|warn|error>] [-log-path=<log-path>]
+	Sends a lightweight request to each of the Google Cloud APIs used by the agent (Compute Engine,
+	Cloud Monitoring, Secret Manager, Cloud Logging, Filestore and Workload Manager) with the
+	credentials of this instance, and reports the APIs which return 403 Permission Denied.
+	No resources are created or modified.` + "\n"
+}
+
+// SetFlags implements the subcommand interface for checkpermissions.
+func (c *CheckPermissions) SetFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&c.help, "h", false, "Displays help")
+	fs.StringVar(&c.LogLevel, "loglevel", "info", "Sets the logging level")
+	fs.StringVar(&c.LogPath, "log-path", "", "The log path to write the log file (optional), default value is /var/log/google-cloud-sap-agent/checkpermissions.log")
+}
+
+// Execute implements the subcommand interface for checkpermissions.
+func (c *CheckPermissions) Execute(ctx context.Context, f *flag.FlagSet, args ...any) subcommands.ExitStatus {
+	_, cp, exitStatus, completed := onetime.Init(ctx, onetime.InitOptions{
+		Name:     c.Name(),
+		Help:     c.help,
+		LogLevel: c.LogLevel,
+		LogPath:  c.LogPath,
+		Fs:       f,
+	}, args...)
+	if !completed {
+		return exitStatus
+	}
+
+	_, status := c.Run(ctx, onetime.CreateRunOptions(cp, false))
+	return status
+}
+
+// Run probes the APIs and prints a table with the outcome of each probe.
+func (c *CheckPermissions) Run(ctx context.Context, opts *onetime.RunOptions) (string, subcommands.ExitStatus) {
+	c.oteLogger = onetime.CreateOTELogger(opts.DaemonMode)
+	return c.checkPermissionsHandler(ctx, opts.CloudProperties, defaultClient, os.Stdout)
+}
+
+func defaultClient(ctx context.Context) (httpDoer, error) {
+	return google.DefaultClient(ctx, cloudPlatformScope)
+}
+
+func (c *CheckPermissions) checkPermissionsHandler(ctx context.Context, cp *iipb.CloudProperties, newClient newClientFunc, w io.Writer) (string, subcommands.ExitStatus) {
+	if cp.GetProjectId() == "" || cp.GetZone() == "" {
+		errMessage := "ERROR: Could not read the project and zone of the instance from the metadata server"
+		c.oteLogger.LogMessageToConsole(errMessage)
+		return errMessage, subcommands.ExitFailure
+	}
+	client, err := newClient(ctx)
+	if err != nil {
+		errMessage := fmt.Sprintf("ERROR: Could not create a client with the default credentials: %v", err)
+		c.oteLogger.LogMessageToConsole(errMessage)
+		return errMessage, subcommands.ExitFailure
+	}
+
+	var results []probeResult
+	status := subcommands.ExitSuccess
+	for _, p := range probes(cp) {
+		r := runProbe(ctx, client, p)
+		log.CtxLogger(ctx).Debugw("Probed API", "api", p.api, "url", p.url, "result", r.result, "detail", r.detail)
+		if r.result != resultGranted {
+			status = subcommands.ExitFailure
+		}
+		results = append(results, r)
+	}
+	table := resultTable(results)
+	fmt.Fprint(w, table)
+	return table, status
+}
+
+// probes returns the probes of the APIs used by the agent. Each probe is the cheapest read, or a
+// write without any content, which requires the same permission as the calls made by the agent.
+func probes(cp *iipb.CloudProperties) []probe {
+	project := cp.GetProjectId()
+	region := cp.GetRegion()
+	if region == "" {
+		region = cp.GetZone()[:max(strings.LastIndex(cp.GetZone(), "-"), 0)]
+	}
+	return []probe{
+		{
+			api:        "Compute Engine",
+			permission: "compute.instances.get",
+			method:     http.MethodGet,
+			url:        fmt.Sprintf("https://compute.googleapis.com/compute/v1/projects/%s/zones/%s/instances/%s", project, cp.GetZone(), cp.GetInstanceName()),
+		},
+		{
+			api:        "Cloud Monitoring",
+			permission: "monitoring.timeSeries.create",
+			method:     http.MethodPost,
+			url:        fmt.Sprintf("https://monitoring.googleapis.com/v3/projects/%s/timeSeries", project),
+			body:       `{"timeSeries":[]}`,
+		},
+		{
+			api:        "Secret Manager",
+			permission: "secretmanager.secrets.list",
+			method:     http.MethodGet,
+			url:        fmt.Sprintf("https://secretmanager.googleapis.com/v1/projects/%s/secrets?pageSize=1", project),
+		},
+		{
+			api:        "Cloud Logging",
+			permission: "logging.logEntries.create",
+			method:     http.MethodPost,
+			url:        "https://logging.googleapis.com/v2/entries:write",
+			body:       fmt.Sprintf(`{"logName":"projects/%s/logs/google-cloud-sap-agent","entries":[]}`, project),
+		},
+		{
+			api:        "Filestore",
+			permission: "file.instances.list",
+			method:     http.MethodGet,
+			url:        fmt.Sprintf("https://file.googleapis.com/v1/projects/%s/locations/-/instances?pageSize=1", project),
+		},
+		{
+			api:        "Workload Manager",
+			permission: "workloadmanager.insights.write",
+			method:     http.MethodPost,
+			url:        fmt.Sprintf("https://workloadmanager-datawarehouse.googleapis.com/v1/projects/%s/locations/%s/insights:writeInsight", project, region),
+			body:       `{}`,
+		},
+	}
+}
+
+// runProbe sends the probe. Only 401 and 403 responses are reported as denied, any other
+// response, including a rejection of the empty content of a write, shows that the
+// credentials are allowed to call the API.
+func runProbe(ctx context.Context, client httpDoer, p probe) probeResult {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	var body io.Reader
+	if p.body != "" {
+		body = strings.NewReader(p.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, p.method, p.url, body)
+	if err != nil {
+		return probeResult{probe: p, result: resultError, detail: err.Error()}
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return probeResult{probe: p, result: resultError, detail: err.Error()}
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+	switch res.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return probeResult{probe: p, result: resultDenied, detail: res.Status}
+	}
+	return probeResult{probe: p, result: resultGranted, detail: res.Status}
+}
+
+// resultTable returns a table with a row for each probe.
+func resultTable(results []probeResult) string {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "API\tPERMISSION\tRESULT\tDETAIL")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.probe.api, r.probe.permission, r.result, r.detail)
+	}
+	tw.Flush()
+	return b.String()
+}
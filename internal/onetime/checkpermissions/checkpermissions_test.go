@@ -0,0 +1,214 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpermissions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"flag"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	iipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+)
+
+func TestMain(m *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(m.Run())
+}
+
+var defaultCloudProperties = &iipb.CloudProperties{
+	ProjectId:    "test-project",
+	Zone:         "us-central1-a",
+	InstanceName: "test-instance",
+}
+
+// fakeDoer responds with the status of the first host which is a key of statuses, 200 otherwise.
+type fakeDoer struct {
+	statuses map[string]int
+	err      error
+	urls     []string
+}
+
+func (f *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	f.urls = append(f.urls, req.URL.String())
+	if f.err != nil {
+		return nil, f.err
+	}
+	status := http.StatusOK
+	if s, ok := f.statuses[req.URL.Host]; ok {
+		status = s
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Body:       io.NopCloser(strings.NewReader("{}")),
+	}, nil
+}
+
+func fakeNewClient(d httpDoer, err error) newClientFunc {
+	return func(context.Context) (httpDoer, error) { return d, err }
+}
+
+func TestExecuteCheckPermissions(t *testing.T) {
+	tests := []struct {
+		name string
+		c    CheckPermissions
+		want subcommands.ExitStatus
+		args []any
+	}{
+		{
+			name: "FailLengthArgs",
+			want: subcommands.ExitUsageError,
+			args: []any{},
+		},
+		{
+			name: "FailAssertFirstArgs",
+			want: subcommands.ExitUsageError,
+			args: []any{
+				"test",
+				"test2",
+				"test3",
+			},
+		},
+		{
+			name: "SuccessForHelp",
+			c:    CheckPermissions{help: true},
+			want: subcommands.ExitSuccess,
+			args: []any{
+				"test",
+				log.Parameters{},
+				"test3",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.c.Execute(context.Background(), &flag.FlagSet{Usage: func() { return }}, test.args...)
+			if got != test.want {
+				t.Errorf("Execute(%v)=%v, want %v", test.args, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCheckPermissionsHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		cp           *iipb.CloudProperties
+		newClient    newClientFunc
+		wantStatus   subcommands.ExitStatus
+		wantContains []string
+	}{
+		{
+			name:       "NoCloudProperties",
+			newClient:  fakeNewClient(&fakeDoer{}, nil),
+			wantStatus: subcommands.ExitFailure,
+		},
+		{
+			name:       "ClientError",
+			cp:         defaultCloudProperties,
+			newClient:  fakeNewClient(nil, errors.New("no credentials")),
+			wantStatus: subcommands.ExitFailure,
+		},
+		{
+			name:       "AllGranted",
+			cp:         defaultCloudProperties,
+			newClient:  fakeNewClient(&fakeDoer{statuses: map[string]int{"monitoring.googleapis.com": http.StatusBadRequest}}, nil),
+			wantStatus: subcommands.ExitSuccess,
+			wantContains: []string{
+				"Compute Engine    compute.instances.get",
+				"Cloud Monitoring  monitoring.timeSeries.create    OK",
+				"Workload Manager  workloadmanager.insights.write  OK",
+			},
+		},
+		{
+			name: "SomeDenied",
+			cp:   defaultCloudProperties,
+			newClient: fakeNewClient(&fakeDoer{statuses: map[string]int{
+				"secretmanager.googleapis.com": http.StatusForbidden,
+				"file.googleapis.com":          http.StatusUnauthorized,
+			}}, nil),
+			wantStatus: subcommands.ExitFailure,
+			wantContains: []string{
+				"Secret Manager    secretmanager.secrets.list      PERMISSION DENIED  Forbidden",
+				"Filestore         file.instances.list             PERMISSION DENIED  Unauthorized",
+				"Cloud Logging     logging.logEntries.create       OK",
+			},
+		},
+		{
+			name:       "RequestError",
+			cp:         defaultCloudProperties,
+			newClient:  fakeNewClient(&fakeDoer{err: errors.New("connection refused")}, nil),
+			wantStatus: subcommands.ExitFailure,
+			wantContains: []string{
+				"ERROR",
+				"connection refused",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &CheckPermissions{oteLogger: onetime.CreateOTELogger(false)}
+			var w bytes.Buffer
+			got, status := c.checkPermissionsHandler(context.Background(), test.cp, test.newClient, &w)
+			if status != test.wantStatus {
+				t.Errorf("checkPermissionsHandler() returned status %v, want %v", status, test.wantStatus)
+			}
+			for _, want := range test.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("checkPermissionsHandler() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestProbesRegion(t *testing.T) {
+	tests := []struct {
+		name string
+		cp   *iipb.CloudProperties
+		want string
+	}{
+		{
+			name: "FromZone",
+			cp:   defaultCloudProperties,
+			want: "/locations/us-central1/insights:writeInsight",
+		},
+		{
+			name: "FromRegion",
+			cp:   &iipb.CloudProperties{ProjectId: "test-project", Zone: "us-central1-a", Region: "europe-west4"},
+			want: "/locations/europe-west4/insights:writeInsight",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := probes(test.cp)
+			if got := p[len(p)-1].url; !strings.HasSuffix(got, test.want) {
+				t.Errorf("probes(%v) Workload Manager url = %q, want suffix %q", test.cp, got, test.want)
+			}
+		})
+	}
+}
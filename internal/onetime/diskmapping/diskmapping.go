@@ -0,0 +1,178 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diskmapping implements the one time execution mode for showing the disks which back a
+// directory, as resolved by hanadiskbackup before snapshotting the HANA data volume.
+package diskmapping
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"flag"
+	compute "google.golang.org/api/compute/v1"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/hanabackup"
+	"github.com/GoogleCloudPlatform/sapagent/internal/instanceinfo"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/sapagent/shared/gce"
+
+	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+)
+
+type (
+	// DiskMapping stores the arguments for the diskmapping subcommand.
+	DiskMapping struct {
+		Path      string `json:"path"`
+		LogLevel  string `json:"loglevel"`
+		LogPath   string `json:"log-path"`
+		help      bool
+		oteLogger *onetime.OTELogger
+	}
+
+	// gceInterface is the testable equivalent of gce.GCE for reading the instance disks.
+	gceInterface interface {
+		GetInstance(project, zone, instance string) (*compute.Instance, error)
+		ListZoneOperations(project, zone, filter string, maxResults int64) (*compute.OperationList, error)
+		GetDisk(project, zone, name string) (*compute.Disk, error)
+		ListDisks(project, zone, filter string) (*compute.DiskList, error)
+	}
+
+	// mapping holds the resolved path and the disks backing it.
+	mapping struct {
+		logicalPath string
+		devices     []string
+		disks       []*ipb.Disk
+	}
+)
+
+// Name implements the subcommand interface for diskmapping.
+func (*DiskMapping) Name() string { return "diskmapping" }
+
+// Synopsis implements the subcommand interface for diskmapping.
+func (*DiskMapping) Synopsis() string { return "show the disks backing a directory" }
+
+// Usage implements the subcommand interface for diskmapping.
+func (*DiskMapping) Usage() string {
+	return `Usage: diskmapping [-path=<directory>] [-h] [-loglevel=<debug|info|warn|error>] [-log-path=<log-path>]
+	Shows the logical and physical devices and the disks backing the directory, for
+	/hana/data these are the disks hanadiskbackup snapshots.` + "\n"
+}
+
+// SetFlags implements the subcommand interface for diskmapping.
+func (d *DiskMapping) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&d.Path, "path", "/hana/data", "Directory for which the backing disks are shown. (optional) Default: /hana/data")
+	fs.BoolVar(&d.help, "h", false, "Displays help")
+	fs.StringVar(&d.LogLevel, "loglevel", "info", "Sets the logging level")
+	fs.StringVar(&d.LogPath, "log-path", "", "The log path to write the log file (optional), default value is /var/log/google-cloud-sap-agent/diskmapping.log")
+}
+
+// Execute implements the subcommand interface for diskmapping.
+func (d *DiskMapping) Execute(ctx context.Context, f *flag.FlagSet, args ...any) subcommands.ExitStatus {
+	_, cp, exitStatus, completed := onetime.Init(ctx, onetime.InitOptions{
+		Name:     d.Name(),
+		Help:     d.help,
+		LogLevel: d.LogLevel,
+		LogPath:  d.LogPath,
+		Fs:       f,
+	}, args...)
+	if !completed {
+		return exitStatus
+	}
+
+	_, status := d.Run(ctx, onetime.CreateRunOptions(cp, false))
+	return status
+}
+
+// Run resolves the disks backing the path and returns the formatted mapping.
+func (d *DiskMapping) Run(ctx context.Context, opts *onetime.RunOptions) (string, subcommands.ExitStatus) {
+	d.oteLogger = onetime.CreateOTELogger(opts.DaemonMode)
+	if d.Path == "" {
+		d.Path = "/hana/data"
+	}
+	gceService, err := gce.NewGCEClient(ctx)
+	if err != nil {
+		errMessage := "ERROR: Failed to create GCE service"
+		d.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
+		return errMessage, subcommands.ExitFailure
+	}
+	return d.diskMappingHandler(ctx, gceService, commandlineexecutor.ExecuteCommand, opts.CloudProperties)
+}
+
+func (d *DiskMapping) diskMappingHandler(ctx context.Context, gceService gceInterface, exec commandlineexecutor.Execute, cp *ipb.CloudProperties) (string, subcommands.ExitStatus) {
+	m, err := d.readMapping(ctx, gceService, exec, cp)
+	if err != nil {
+		errMessage := fmt.Sprintf("ERROR: Failed to read the disk mapping of %s", d.Path)
+		d.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
+		return errMessage, subcommands.ExitFailure
+	}
+	message := d.format(m, cp)
+	d.oteLogger.LogMessageToConsole(message)
+	return message, subcommands.ExitSuccess
+}
+
+// readMapping resolves the path the same way as hanadiskbackup: the logical device is read from
+// df, its physical volumes from LVM and the disks are matched against the instance disk mapping.
+func (d *DiskMapping) readMapping(ctx context.Context, gceService gceInterface, exec commandlineexecutor.Execute, cp *ipb.CloudProperties) (*mapping, error) {
+	logicalPath, err := hanabackup.ParseLogicalPath(ctx, d.Path, exec)
+	if err != nil {
+		return nil, err
+	}
+	if logicalPath == "" {
+		return nil, fmt.Errorf("no device found for %s", d.Path)
+	}
+	m := &mapping{logicalPath: logicalPath}
+	if strings.HasPrefix(logicalPath, "/dev/mapper") {
+		if m.devices, err = instanceinfo.PhysicalDevicesForLogicalVolume(ctx, logicalPath, exec); err != nil {
+			return nil, err
+		}
+	} else {
+		m.devices = []string{filepath.Base(logicalPath)}
+	}
+
+	reader := instanceinfo.New(&instanceinfo.PhysicalPathReader{OS: runtime.GOOS}, gceService)
+	_, instanceProperties, err := reader.ReadDiskMapping(ctx, &cpb.Configuration{CloudProperties: cp})
+	if err != nil {
+		return nil, err
+	}
+	m.disks = instanceinfo.DisksForDevices(instanceProperties.GetDisks(), m.devices)
+	if len(m.disks) == 0 {
+		return nil, fmt.Errorf("no instance disks found backing the physical devices %v of %s", m.devices, logicalPath)
+	}
+	return m, nil
+}
+
+// format renders the mapping for the console.
+func (d *DiskMapping) format(m *mapping, cp *ipb.CloudProperties) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Path: %s\n", d.Path)
+	fmt.Fprintf(&b, "Logical path: %s\n", m.logicalPath)
+	fmt.Fprintf(&b, "Physical devices: %s\n", strings.Join(m.devices, ", "))
+	for _, disk := range m.disks {
+		fmt.Fprintf(&b, "Disk: %s\n", disk.GetDiskName())
+		fmt.Fprintf(&b, "  Zone: %s\n", cp.GetZone())
+		fmt.Fprintf(&b, "  Device: %s\n", disk.GetMapping())
+		fmt.Fprintf(&b, "  Type: %s\n", disk.GetDeviceType())
+		fmt.Fprintf(&b, "  Provisioned IOPS: %d\n", disk.GetProvisionedIops())
+		fmt.Fprintf(&b, "  Provisioned throughput: %d\n", disk.GetProvisionedThroughput())
+	}
+	return b.String()
+}
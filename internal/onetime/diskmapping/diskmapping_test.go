@@ -0,0 +1,173 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diskmapping
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"flag"
+	compute "google.golang.org/api/compute/v1"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/sapagent/shared/gce/fake"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+)
+
+var defaultCloudProperties = &ipb.CloudProperties{
+	ProjectId:    "test-project",
+	Zone:         "us-central1-a",
+	InstanceName: "test-instance",
+}
+
+func TestMain(m *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(m.Run())
+}
+
+func defaultGCE() *fake.TestGCE {
+	return &fake.TestGCE{
+		GetInstanceResp: []*compute.Instance{{
+			Disks: []*compute.AttachedDisk{{
+				Source:     "/some/path/hana-data",
+				DeviceName: "hana-data",
+				Type:       "PERSISTENT",
+			}},
+		}},
+		GetInstanceErr: []error{nil},
+		ListDisksResp: []*compute.DiskList{{
+			Items: []*compute.Disk{{
+				Name:                  "hana-data",
+				Type:                  "/some/path/hyperdisk-extreme",
+				ProvisionedIops:       3000,
+				ProvisionedThroughput: 140,
+			}},
+		}},
+		ListDisksErr: []error{nil},
+	}
+}
+
+// fakeExec returns the df and lvs output for the devices. The disk mapping reads "unknown" as the
+// device of every disk when the /dev/disk/by-id links are missing.
+func fakeExec(logicalPath, lvsOut string) commandlineexecutor.Execute {
+	return func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+		switch params.Executable {
+		case "/bin/sh":
+			return commandlineexecutor.Result{StdOut: logicalPath + "\n"}
+		case "/sbin/lvs":
+			return commandlineexecutor.Result{StdOut: lvsOut}
+		}
+		return commandlineexecutor.Result{StdOut: "unknown"}
+	}
+}
+
+func TestSetFlags(t *testing.T) {
+	d := &DiskMapping{}
+	fs := flag.NewFlagSet("flags", flag.ExitOnError)
+	d.SetFlags(fs)
+	for _, f := range []string{"path", "h", "loglevel", "log-path"} {
+		if fs.Lookup(f) == nil {
+			t.Errorf("SetFlags(%#v) flag not found: %s", fs, f)
+		}
+	}
+	if got := fs.Lookup("path").DefValue; got != "/hana/data" {
+		t.Errorf("SetFlags() path default = %q, want: /hana/data", got)
+	}
+}
+
+func TestExecute(t *testing.T) {
+	d := &DiskMapping{}
+	if got := d.Execute(context.Background(), &flag.FlagSet{Usage: func() {}}); got != subcommands.ExitUsageError {
+		t.Errorf("Execute() with no arguments = %v, want: %v", got, subcommands.ExitUsageError)
+	}
+}
+
+func TestDiskMappingHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		gceService   *fake.TestGCE
+		exec         commandlineexecutor.Execute
+		want         subcommands.ExitStatus
+		wantContains []string
+	}{
+		{
+			name:       "LVM",
+			gceService: defaultGCE(),
+			exec:       fakeExec("/dev/mapper/vg_hana-data", "  /dev/unknown(0)\n"),
+			want:       subcommands.ExitSuccess,
+			wantContains: []string{
+				"Logical path: /dev/mapper/vg_hana-data",
+				"Physical devices: unknown",
+				"Disk: hana-data",
+				"Zone: us-central1-a",
+				"Provisioned IOPS: 3000",
+				"Provisioned throughput: 140",
+			},
+		},
+		{
+			name:       "NonLVM",
+			gceService: defaultGCE(),
+			exec:       fakeExec("/dev/unknown", ""),
+			want:       subcommands.ExitSuccess,
+			wantContains: []string{
+				"Logical path: /dev/unknown",
+				"Disk: hana-data",
+			},
+		},
+		{
+			name:       "NoDevice",
+			gceService: defaultGCE(),
+			exec:       fakeExec("", ""),
+			want:       subcommands.ExitFailure,
+		},
+		{
+			name:       "NoMatchingDisk",
+			gceService: defaultGCE(),
+			exec:       fakeExec("/dev/mapper/vg_hana-data", "  /dev/sdz(0)\n"),
+			want:       subcommands.ExitFailure,
+		},
+		{
+			name: "ReadInstanceFailure",
+			gceService: &fake.TestGCE{
+				GetInstanceResp: []*compute.Instance{nil},
+				GetInstanceErr:  []error{os.ErrPermission},
+			},
+			exec: fakeExec("/dev/mapper/vg_hana-data", "  /dev/unknown(0)\n"),
+			want: subcommands.ExitFailure,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &DiskMapping{Path: "/hana/data", oteLogger: onetime.CreateOTELogger(false)}
+			got, status := d.diskMappingHandler(context.Background(), tc.gceService, tc.exec, defaultCloudProperties)
+			if status != tc.want {
+				t.Errorf("diskMappingHandler() = %v, want: %v, message: %s", status, tc.want, got)
+			}
+			for _, s := range tc.wantContains {
+				if !strings.Contains(got, s) {
+					t.Errorf("diskMappingHandler() = %q, want it to contain %q", got, s)
+				}
+			}
+		})
+	}
+}
@@ -89,7 +89,7 @@ func TestExecute(t *testing.T) {
 		{
 			name: "SuccessfullyParseArgs",
 			cdt:  *defaultChangeDiskType,
-			want: subcommands.ExitFailure,
+			want: onetime.ExitGCEAPIError,
 			args: []any{
 				"test",
 				log.Parameters{},
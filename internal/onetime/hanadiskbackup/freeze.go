@@ -0,0 +1,92 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanadiskbackup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/internal/hanabackup"
+	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+// errFreezeTimeout is the cause of the backup context being cancelled by the freeze watchdog.
+var errFreezeTimeout = errors.New("file system was frozen for longer than -max-freeze-seconds, the backup is aborted")
+
+// freezeWatchdog unfreezes the file system once it has been frozen for too long.
+type freezeWatchdog struct {
+	timer  *time.Timer
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+}
+
+// freezeXFS freezes the HANA data file system. When -max-freeze-seconds is set a watchdog is
+// started which unfreezes the file system and cancels the returned context with errFreezeTimeout
+// once the file system has been frozen for that long, so that a hanging snapshot creation does
+// not leave /hana/data unresponsive. The watchdog is stopped by unfreezeXFS.
+func (s *Snapshot) freezeXFS(ctx context.Context, exec commandlineexecutor.Execute) (context.Context, error) {
+	if err := hanabackup.FreezeXFS(ctx, s.hanaDataPath, exec); err != nil {
+		return ctx, err
+	}
+	if s.MaxFreezeSeconds <= 0 {
+		return ctx, nil
+	}
+	maxFreeze := time.Duration(s.MaxFreezeSeconds) * time.Second
+	watchdogCtx, cancel := context.WithCancelCause(ctx)
+	w := &freezeWatchdog{cancel: cancel, done: make(chan struct{})}
+	w.timer = time.AfterFunc(maxFreeze, func() {
+		defer close(w.done)
+		s.oteLogger.LogUsageError(usagemetrics.XFSFreezeTimeoutFailure)
+		s.oteLogger.LogMessageToFileAndConsole(ctx, fmt.Sprintf("File system %s has been frozen for %v, unfreezing it and aborting the backup.", s.hanaDataPath, maxFreeze))
+		if err := hanabackup.UnFreezeXFS(ctx, s.hanaDataPath, exec); err != nil {
+			s.oteLogger.LogErrorToFileAndConsole(ctx, "Error unfreezing XFS after the maximum freeze duration", err)
+		}
+		cancel(errFreezeTimeout)
+	})
+	s.freezeWatchdog = w
+	return watchdogCtx, nil
+}
+
+// unfreezeXFS stops the freeze watchdog and unfreezes the HANA data file system. Nothing is left
+// to unfreeze when the watchdog has already fired, the backup has been aborted by then.
+func (s *Snapshot) unfreezeXFS(ctx context.Context, exec commandlineexecutor.Execute) error {
+	if w := s.freezeWatchdog; w != nil {
+		s.freezeWatchdog = nil
+		stopped := w.timer.Stop()
+		if !stopped {
+			<-w.done
+		}
+		w.cancel(nil)
+		if !stopped {
+			log.CtxLogger(ctx).Info("File system was already unfrozen by the freeze watchdog")
+			return nil
+		}
+	}
+	return hanabackup.UnFreezeXFS(ctx, s.hanaDataPath, exec)
+}
+
+// freezeTimedOut returns errFreezeTimeout if the freeze watchdog has cancelled the context.
+func freezeTimedOut(ctx context.Context) error {
+	if cause := context.Cause(ctx); errors.Is(cause, errFreezeTimeout) {
+		return cause
+	}
+	return nil
+}
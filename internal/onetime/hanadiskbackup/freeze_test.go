@@ -0,0 +1,118 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanadiskbackup
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
+)
+
+// xfsFreezeRecorder records the xfs_freeze arguments it is called with.
+type xfsFreezeRecorder struct {
+	mu   sync.Mutex
+	args []string
+	err  error
+}
+
+func (r *xfsFreezeRecorder) exec(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.args = append(r.args, strings.Fields(params.ArgsToSplit)[0])
+	return commandlineexecutor.Result{Error: r.err}
+}
+
+func (r *xfsFreezeRecorder) calls() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.args
+}
+
+func TestFreezeAndUnfreezeXFS(t *testing.T) {
+	tests := []struct {
+		name             string
+		maxFreezeSeconds int64
+		wantCalls        []string
+	}{
+		{
+			name:      "NoWatchdog",
+			wantCalls: []string{"-f", "-u"},
+		},
+		{
+			name:             "UnfrozenBeforeWatchdog",
+			maxFreezeSeconds: 300,
+			wantCalls:        []string{"-f", "-u"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &xfsFreezeRecorder{}
+			s := &Snapshot{hanaDataPath: "/hana/data", MaxFreezeSeconds: tc.maxFreezeSeconds, oteLogger: defaultOTELogger}
+			ctx, err := s.freezeXFS(context.Background(), r.exec)
+			if err != nil {
+				t.Fatalf("freezeXFS() returned error: %v", err)
+			}
+			if err := s.unfreezeXFS(context.Background(), r.exec); err != nil {
+				t.Errorf("unfreezeXFS() returned error: %v", err)
+			}
+			if err := freezeTimedOut(ctx); err != nil {
+				t.Errorf("freezeTimedOut() = %v, want nil", err)
+			}
+			if diff := cmp.Diff(tc.wantCalls, r.calls()); diff != "" {
+				t.Errorf("xfs_freeze calls mismatch (-want +got):\n%s", diff)
+			}
+			if s.freezeWatchdog != nil {
+				t.Error("unfreezeXFS() did not stop the freeze watchdog")
+			}
+		})
+	}
+}
+
+func TestFreezeWatchdogFires(t *testing.T) {
+	r := &xfsFreezeRecorder{}
+	s := &Snapshot{hanaDataPath: "/hana/data", MaxFreezeSeconds: 1, oteLogger: defaultOTELogger}
+	ctx, err := s.freezeXFS(context.Background(), r.exec)
+	if err != nil {
+		t.Fatalf("freezeXFS() returned error: %v", err)
+	}
+	<-ctx.Done()
+	if err := freezeTimedOut(ctx); !errors.Is(err, errFreezeTimeout) {
+		t.Errorf("freezeTimedOut() = %v, want: %v", err, errFreezeTimeout)
+	}
+	if err := s.unfreezeXFS(context.Background(), r.exec); err != nil {
+		t.Errorf("unfreezeXFS() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"-f", "-u"}, r.calls()); diff != "" {
+		t.Errorf("xfs_freeze calls mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFreezeXFSFailure(t *testing.T) {
+	r := &xfsFreezeRecorder{err: errors.New("xfs_freeze failed")}
+	s := &Snapshot{hanaDataPath: "/hana/data", MaxFreezeSeconds: 300, oteLogger: defaultOTELogger}
+	if _, err := s.freezeXFS(context.Background(), r.exec); err == nil {
+		t.Error("freezeXFS() succeeded when xfs_freeze failed, want error")
+	}
+	if s.freezeWatchdog != nil {
+		t.Error("freezeXFS() started the freeze watchdog when xfs_freeze failed")
+	}
+}
@@ -19,10 +19,16 @@ package hanadiskbackup
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"path"
+	"path/filepath"
+	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 	"time"
 
@@ -30,7 +36,9 @@ import (
 	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
 	compute "google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/encoding/protojson"
 	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
 	"github.com/GoogleCloudPlatform/sapagent/internal/databaseconnector"
 	"github.com/GoogleCloudPlatform/sapagent/internal/hanabackup"
 	"github.com/GoogleCloudPlatform/sapagent/internal/instanceinfo"
@@ -41,9 +49,11 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/gce"
+	"github.com/GoogleCloudPlatform/sapagent/shared/gce/metadataserver"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 	"github.com/GoogleCloudPlatform/sapagent/shared/timeseries"
 
+	metricpb "google.golang.org/genproto/googleapis/api/metric"
 	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
 	tspb "google.golang.org/protobuf/types/known/timestamppb"
 	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
@@ -78,12 +88,16 @@ type (
 		GetDisk(project, zone, name string) (*compute.Disk, error)
 		ListDisks(project, zone, filter string) (*compute.DiskList, error)
 		ListSnapshots(ctx context.Context, project string) (*compute.SnapshotList, error)
+		GetSnapshot(ctx context.Context, project, name string) (*compute.Snapshot, error)
 
 		DiskAttachedToInstance(projectID, zone, instanceName, diskName string) (string, bool, error)
-		WaitForSnapshotCreationCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string) error
-		WaitForSnapshotUploadCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string) error
+		WaitForSnapshotCreationCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string, interval, maxWait time.Duration) error
+		WaitForSnapshotUploadCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string, interval, maxWait time.Duration) error
 		WaitForInstantSnapshotConversionCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string) error
 		CreateSnapshot(ctx context.Context, project string, snapshotReq *compute.Snapshot) (*compute.Operation, error)
+		CreateDisk(ctx context.Context, project, zone string, disk *compute.Disk) (*compute.Operation, error)
+		DeleteDisk(ctx context.Context, project, zone, name string) (*compute.Operation, error)
+		WaitForDiskOpCompletionWithRetry(ctx context.Context, op *compute.Operation, project, dataDiskZone string) error
 	}
 
 	// ISGInterface is the testable equivalent for ISGService for ISG operations.
@@ -105,8 +119,33 @@ const (
 	metricPrefix = "workload.googleapis.com/sap/agent/"
 )
 
+// Bounds of the wait for the storage size of a snapshot to be computed after its upload.
+const (
+	defaultSnapshotSizeMaxWait      = 5 * time.Minute
+	defaultSnapshotSizePollInterval = 10 * time.Second
+)
+
+// sidLabel holds the lowercase HANA SID of the database backed up by the snapshot.
+const sidLabel = "goog-sapagent-sid"
+
+// Stages of the workflow at which the HANA data snapshot is confirmed.
+const (
+	confirmAfterCreate = "AFTER_CREATE"
+	confirmAfterUpload = "AFTER_UPLOAD"
+	confirmNone        = "NONE"
+)
+
+// Upper bounds of -snapshot-poll-interval-seconds and -snapshot-max-wait-seconds.
+const (
+	maxSnapshotPollInterval = 10 * time.Minute
+	maxSnapshotWait         = 24 * time.Hour
+)
+
 var (
 	dbFreezeStartTime, workflowStartTime time.Time
+
+	// snapshotNameRegex matches the resource names accepted by Compute Engine.
+	snapshotNameRegex = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
 )
 
 // ISG is a placeholder struct defining fields potentially required
@@ -119,28 +158,43 @@ type ISG struct {
 
 // Snapshot has args for snapshot subcommands.
 type Snapshot struct {
-	Project                                string `json:"project"`
-	Host                                   string `json:"host"`
-	Port                                   string `json:"port"`
-	Sid                                    string `json:"sid"`
-	HanaSidAdm                             string `json:"-"`
-	InstanceID                             string `json:"instance-id"`
-	HanaDBUser                             string `json:"hana-db-user"`
-	Password                               string `json:"password"`
-	PasswordSecret                         string `json:"password-secret"`
-	HDBUserstoreKey                        string `json:"hdbuserstore-key"`
-	Disk                                   string `json:"source-disk"`
-	DiskZone                               string `json:"source-disk-zone"`
-	DiskKeyFile                            string `json:"source-disk-key-file"`
-	StorageLocation                        string `json:"storage-location"`
-	SnapshotName                           string `json:"snapshot-name"`
-	SnapshotType                           string `json:"snapshot-type"`
-	Description                            string `json:"snapshot-description"`
-	AbandonPrepared                        bool   `json:"abandon-prepared,string"`
-	SendToMonitoring                       bool   `json:"send-metrics-to-monitoring,string"`
-	FreezeFileSystem                       bool   `json:"freeze-file-system,string"`
-	ConfirmDataSnapshotAfterCreate         bool   `json:"confirm-data-snapshot-after-create,string"`
+	Project                                string  `json:"project"`
+	Host                                   string  `json:"host"`
+	Port                                   string  `json:"port"`
+	Sid                                    string  `json:"sid"`
+	HanaSidAdm                             string  `json:"-"`
+	InstanceID                             string  `json:"instance-id"`
+	HanaDBUser                             string  `json:"hana-db-user"`
+	Password                               string  `json:"password"`
+	PasswordSecret                         string  `json:"password-secret"`
+	HDBUserstoreKey                        string  `json:"hdbuserstore-key"`
+	Disk                                   string  `json:"source-disk"`
+	SourceDisks                            string  `json:"source-disks"`
+	DiskZone                               string  `json:"source-disk-zone"`
+	DiskProject                            string  `json:"source-disk-project"`
+	DiskKeyFile                            string  `json:"source-disk-key-file"`
+	StorageLocation                        string  `json:"storage-location"`
+	ReplicaLocations                       string  `json:"replica-locations"`
+	SnapshotName                           string  `json:"snapshot-name"`
+	SnapshotNameTemplate                   string  `json:"snapshot-name-template"`
+	SnapshotType                           string  `json:"snapshot-type"`
+	Description                            string  `json:"snapshot-description"`
+	AbandonPrepared                        bool    `json:"abandon-prepared,string"`
+	SendToMonitoring                       bool    `json:"send-metrics-to-monitoring,string"`
+	FreezeFileSystem                       bool    `json:"freeze-file-system,string"`
+	MaxFreezeSeconds                       int64   `json:"max-freeze-seconds,string"`
+	GuestFlush                             bool    `json:"guest-flush,string"`
+	SnapshotPollIntervalSeconds            int64   `json:"snapshot-poll-interval-seconds,string"`
+	SnapshotMaxWaitSeconds                 int64   `json:"snapshot-max-wait-seconds,string"`
+	ConfirmDataSnapshotAfterCreate         bool    `json:"confirm-data-snapshot-after-create,string"`
+	ConfirmDataSnapshotMode                string  `json:"confirm-data-snapshot-mode"`
+	IgnoreTmpfsData                        bool    `json:"ignore-tmpfs-data,string"`
+	HANADataPath                           string  `json:"hana-data-path"`
+	AdditionalMountPoints                  string  `json:"additional-mount-points"`
+	SnapshotAdditionalMountPoints          bool    `json:"snapshot-additional-mount-points,string"`
+	StorageCostPerGB                       float64 `json:"storage-cost-per-gb,string"`
 	groupSnapshotName                      string
+	snapshotNameGenerated                  bool
 	disks                                  []string
 	db                                     *databaseconnector.DBHandle
 	gceService                             gceInterface
@@ -148,12 +202,14 @@ type Snapshot struct {
 	isgService                             ISGInterface
 	status                                 bool
 	timeSeriesCreator                      cloudmonitoring.TimeSeriesCreator
+	snapshotSizeMaxWait                    time.Duration
 	help                                   bool
 	SkipDBSnapshotForChangeDiskType        bool   `json:"skip-db-snapshot-for-change-disk-type,string"`
 	HANAChangeDiskTypeOTEName              string `json:"-"`
 	ForceStopHANA                          bool   `json:"-"`
 	LogLevel                               string `json:"loglevel"`
 	LogPath                                string `json:"log-path"`
+	ParamsFile                             string `json:"-"`
 	hanaDataPath                           string
 	logicalDataPath, physicalDataPath      string
 	Labels                                 string                        `json:"labels"`
@@ -161,8 +217,20 @@ type Snapshot struct {
 	instanceProperties                     *ipb.InstanceProperties
 	cgName                                 string
 	groupSnapshot                          bool
+	diskMappingRead                        bool
 	provisionedIops, provisionedThroughput int64
 	oteLogger                              *onetime.OTELogger
+	freezeWatchdog                         *freezeWatchdog
+	historyPath                            string
+	history                                *snapshotHistory
+	errorCategory                          onetime.ErrorCategory
+	snapshotCreationTime                   time.Duration
+	replicaOps                             []*snapshotOp
+	replicaSnapshots                       []string
+	// readConfigFile and fetchCloudProperties resolve the project and zone when they are not
+	// passed in the flags, a nil function skips its source.
+	readConfigFile       configuration.ReadConfigFile
+	fetchCloudProperties func() *metadataserver.CloudProperties
 }
 
 // Name implements the subcommand interface for hanadiskbackup.
@@ -174,15 +242,19 @@ func (*Snapshot) Synopsis() string { return "invoke HANA backup using disk snaps
 // Usage implements the subcommand interface for hanadiskbackup.
 func (*Snapshot) Usage() string {
 	return `Usage: hanadiskbackup -port=<port-number> -sid=<HANA-sid> -hana-db-user=<HANA DB User>
-	[-source-disk=<disk-name>] [-source-disk-zone=<disk-zone>] [-host=<hostname>]
-	[-project=<project-name>] [-password=<passwd> | -password-secret=<secret-name>]
+	[-source-disk=<disk-name> | -source-disks=<disk-name1,disk-name2>] [-source-disk-zone=<disk-zone>] [-host=<hostname>]
+	[-project=<project-name>] [-source-disk-project=<project-name>] [-password=<passwd> | -password-secret=<secret-name>]
 	[-hdbuserstore-key=<userstore-key>] [-abandon-prepared=<true|false>]
-	[-send-metrics-to-monitoring]=<true|false>] [-source-disk-key-file=<path-to-key-file>]
-	[-storage-location=<storage-location>] [-snapshot-description=<description>]
-	[-snapshot-name=<snapshot-name>] [-snapshot-type=<snapshot-type>] [-group-snapshot-name=<group-snapshot-name>]
-	[-freeze-file-system=<true|false>] [-labels="label1=value1,label2=value2"]
-	[-confirm-data-snapshot-after-create=<true|false>]
-	[-instance-id=<instance-id>]
+	[-send-metrics-to-monitoring]=<true|false>] [-storage-cost-per-gb=<price>] [-source-disk-key-file=<path-to-key-file>]
+	[-storage-location=<storage-location1,storage-location2>] [-replica-locations=<location1,location2>]
+	[-snapshot-description=<description>]
+	[-snapshot-name=<snapshot-name> | -snapshot-name-template=<template>] [-snapshot-type=<snapshot-type>] [-group-snapshot-name=<group-snapshot-name>]
+	[-freeze-file-system=<true|false>] [-max-freeze-seconds=<seconds>] [-guest-flush=<true|false>] [-labels="label1=value1,label2=value2"]
+	[-confirm-data-snapshot-after-create=<true|false>] [-confirm-data-snapshot-mode=<AFTER_CREATE|AFTER_UPLOAD|NONE>]
+	[-ignore-tmpfs-data=<true|false>] [-hana-data-path=<mount-point>]
+	[-additional-mount-points=<mount-point1,mount-point2>] [-snapshot-additional-mount-points=<true|false>]
+	[-snapshot-poll-interval-seconds=<seconds>] [-snapshot-max-wait-seconds=<seconds>]
+	[-instance-id=<instance-id>] [-params-file=<path-to-json-file>]
 	[-h] [-loglevel=<debug|info|warn|error>] [-log-path=<log-path>]
 
 	Authentication Flag Combinations:
@@ -194,6 +266,12 @@ func (*Snapshot) Usage() string {
 
 	For multi-disk backup:
 	hanadiskbackup -sid=<HANA SID> [Authentication Flags] -group-snapshot-name=<group-snapshot-name> -snapshot-type=<snapshot-type>
+
+	For multi-disk backup of an explicit list of disks:
+	hanadiskbackup -sid=<HANA SID> [Authentication Flags] -source-disks=<disk-name1,disk-name2> -group-snapshot-name=<group-snapshot-name>
+
+	Exit codes: 0 success, 2 usage error, 10 precondition failure, 11 GCE API error,
+	12 database error, 13 snapshot error.
 	` + "\n"
 }
 
@@ -207,24 +285,39 @@ func (s *Snapshot) SetFlags(fs *flag.FlagSet) {
 	fs.StringVar(&s.PasswordSecret, "password-secret", "", "Secret Manager secret name that holds HANA password. (optional - either password-secret or hdbuserstore-key must be provided)")
 	fs.StringVar(&s.HDBUserstoreKey, "hdbuserstore-key", "", "HANA userstore key specific to HANA instance.")
 	fs.StringVar(&s.Disk, "source-disk", "", "name of the disk from which you want to create a snapshot (optional). Default: disk used to store /hana/data/")
+	fs.StringVar(&s.SourceDisks, "source-disks", "", "Comma separated names of the disks to snapshot as a group, bypassing the auto-detection of the disks backing /hana/data/. The disks must belong to the same consistency group. (optional)")
 	fs.StringVar(&s.DiskZone, "source-disk-zone", "", "zone of the disk from which you want to create a snapshot. (optional) Default: Same zone as current instance")
+	fs.StringVar(&s.DiskProject, "source-disk-project", "", "Project of the instance and the disk from which you want to create a snapshot, when the snapshot is created in a different -project. Not supported for group snapshots. (optional) Default: value of -project")
 	fs.BoolVar(&s.FreezeFileSystem, "freeze-file-system", false, "Freeze file system. (optional) Default: false")
+	fs.Int64Var(&s.MaxFreezeSeconds, "max-freeze-seconds", 300, "Maximum number of seconds the file system stays frozen, after which it is unfrozen and the backup is aborted. 0 disables the limit. (optional) Default: 300")
+	fs.BoolVar(&s.GuestFlush, "guest-flush", false, "Request an application consistent snapshot by flushing the guest before the disk snapshot is created, ignored when freeze-file-system is set. (optional) Default: false")
+	fs.Int64Var(&s.SnapshotPollIntervalSeconds, "snapshot-poll-interval-seconds", 0, "Seconds between the polls of the snapshot creation and upload status, at most 600. (optional) Default: 1 for the creation and 30 for the upload")
+	fs.Int64Var(&s.SnapshotMaxWaitSeconds, "snapshot-max-wait-seconds", 0, "Maximum number of seconds to wait for the snapshot creation and for the upload, at most 86400. (optional) Default: 300 for the creation and 14400 for the upload")
 	fs.StringVar(&s.Host, "host", "localhost", "HANA host. (optional) Default: localhost")
 	fs.StringVar(&s.Project, "project", "", "GCP project. (optional) Default: project corresponding to this instance")
 	fs.BoolVar(&s.AbandonPrepared, "abandon-prepared", false, "Abandon any prepared HANA snapshot that is in progress, (optional) Default: false)")
 	fs.BoolVar(&s.SkipDBSnapshotForChangeDiskType, "skip-db-snapshot-for-change-disk-type", false, "Skip DB snapshot for change disk type, (optional) Default: false")
 	fs.BoolVar(&s.ConfirmDataSnapshotAfterCreate, "confirm-data-snapshot-after-create", true, "Confirm HANA data snapshot after disk snapshot create and then wait for upload. (optional) Default: true")
+	fs.StringVar(&s.ConfirmDataSnapshotMode, "confirm-data-snapshot-mode", "", "When to confirm the HANA data snapshot: AFTER_CREATE, AFTER_UPLOAD or NONE to leave it prepared. Takes precedence over confirm-data-snapshot-after-create. (optional) Default: derived from confirm-data-snapshot-after-create")
+	fs.BoolVar(&s.IgnoreTmpfsData, "ignore-tmpfs-data", false, "Create the backup even though tmpfs file systems such as HANA fast restart are mounted under the HANA data path, their content is not captured by the disk snapshot. (optional) Default: false")
+	fs.StringVar(&s.HANADataPath, "hana-data-path", "", "Mount point of the HANA data volumes to freeze and map to disks, overriding the basepath_datavolumes read from global.ini. (optional) Default: read from global.ini")
+	fs.StringVar(&s.AdditionalMountPoints, "additional-mount-points", "", "Comma separated mount points, ex: /hana/log,/hana/shared, whose backing disks are reported along with the disks of /hana/data. Not supported with -source-disk or -source-disks. (optional)")
+	fs.BoolVar(&s.SnapshotAdditionalMountPoints, "snapshot-additional-mount-points", false, "Snapshot the disks of -additional-mount-points in the group snapshot of the /hana/data disks, the disks must belong to the same consistency group. (optional) Default: false")
 	fs.StringVar(&s.SnapshotName, "snapshot-name", "", "Snapshot name override.(Optional - defaults to 'snapshot-diskname-yyyymmdd-hhmmss'.)")
+	fs.StringVar(&s.SnapshotNameTemplate, "snapshot-name-template", "", "Template for the snapshot name, the placeholders {sid}, {disk}, {date}, {time} and {host} are replaced by the lowercase HANA sid, the disk name, yyyymmdd, hhmmss and the instance name.(Optional)")
 	fs.StringVar(&s.SnapshotType, "snapshot-type", "STANDARD", "Snapshot type override.(Optional - defaults to 'STANDARD', use 'ARCHIVE' for archive snapshots.)")
 	fs.StringVar(&s.DiskKeyFile, "source-disk-key-file", "", `Path to the customer-supplied encryption key of the source disk. (optional)\n (required if the source disk is protected by a customer-supplied encryption key.)`)
-	fs.StringVar(&s.StorageLocation, "storage-location", "", "Cloud Storage multi-region or the region where you want to store your snapshot. (optional) Default: nearby regional or multi-regional location automatically chosen.")
+	fs.StringVar(&s.StorageLocation, "storage-location", "", "Cloud Storage multi-region or the region where you want to store your snapshot. A comma separated list is tried in order when creation fails in a location. (optional) Default: nearby regional or multi-regional location automatically chosen.")
+	fs.StringVar(&s.ReplicaLocations, "replica-locations", "", "Comma separated storage locations in which a copy of the disk snapshot is also created, labeled as an agent managed replica. The copy is taken from a temporary disk restored from the uploaded snapshot in the disk zone. A replica failure does not fail the backup. Not supported for group snapshots. (optional)")
 	fs.StringVar(&s.Description, "snapshot-description", "", "Description of the new snapshot(optional)")
 	fs.BoolVar(&s.SendToMonitoring, "send-metrics-to-monitoring", true, "Send backup related metrics to cloud monitoring. (optional) Default: true")
+	fs.Float64Var(&s.StorageCostPerGB, "storage-cost-per-gb", 0, "Monthly snapshot storage price per GB, used to send the estimated monthly storage cost of the snapshots to cloud monitoring. (optional) Default: 0, no cost estimate is sent")
 	fs.StringVar(&s.LogPath, "log-path", "", "The log path to write the log file (optional), default value is /var/log/google-cloud-sap-agent/hanadiskbackup.log")
 	fs.BoolVar(&s.help, "h", false, "Displays help")
 	fs.StringVar(&s.LogLevel, "loglevel", "info", "Sets the logging level")
 	fs.StringVar(&s.Labels, "labels", "", "Labels to be added to the disk snapshot")
 	fs.StringVar(&s.groupSnapshotName, "group-snapshot-name", "", "Group Snapshot name override.(optional - defaults to '<consistency-group-name>-yyyymmdd-hhmmss'.)")
+	fs.StringVar(&s.ParamsFile, "params-file", "", "Path to a JSON file with the parameters keyed by flag name, flags set on the command line take precedence over the file. (optional)")
 }
 
 // Execute implements the subcommand interface for hanadiskbackup.
@@ -241,105 +334,189 @@ func (s *Snapshot) Execute(ctx context.Context, f *flag.FlagSet, args ...any) su
 	if !completed {
 		return exitStatus
 	}
+	if err := s.readParamsFile(f, os.ReadFile); err != nil {
+		onetime.CreateOTELogger(false).LogErrorToFileAndConsole(ctx, "ERROR: Failed to read the params file", err)
+		return subcommands.ExitUsageError
+	}
 
 	_, status := s.Run(ctx, onetime.CreateRunOptions(cp, false))
-	if status == subcommands.ExitFailure {
+	if status != subcommands.ExitSuccess && status != subcommands.ExitUsageError {
 		supportbundle.CollectAgentSupport(ctx, f, lp, cp, s.Name())
 	}
 	return status
 }
 
+// readParamsFile reads the parameters from the JSON params file, keyed by the flag names, into
+// the snapshot. The flags explicitly set on the command line are applied again afterwards so
+// that they take precedence over the file.
+func (s *Snapshot) readParamsFile(fs *flag.FlagSet, readFile func(string) ([]byte, error)) error {
+	if s.ParamsFile == "" {
+		return nil
+	}
+	data, err := readFile(s.ParamsFile)
+	if err != nil {
+		return err
+	}
+	setFlags := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = f.Value.String() })
+	if err := json.Unmarshal(data, s); err != nil {
+		return fmt.Errorf("parsing params file %s: %w", s.ParamsFile, err)
+	}
+	for name, value := range setFlags {
+		if err := fs.Set(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Run executes the command and returns the message and exit status.
 func (s *Snapshot) Run(ctx context.Context, opts *onetime.RunOptions) (string, subcommands.ExitStatus) {
 	s.oteLogger = onetime.CreateOTELogger(opts.DaemonMode)
+	s.readConfigFile = os.ReadFile
+	s.fetchCloudProperties = metadataserver.FetchCloudProperties
 	if err := s.validateParameters(runtime.GOOS, opts.CloudProperties); err != nil {
 		errMessage := err.Error()
 		s.oteLogger.LogMessageToConsole(errMessage)
-		return errMessage, subcommands.ExitUsageError
+		return errMessage, s.fail(onetime.ErrorCategoryUsage)
 	}
 
 	mc, err := monitoring.NewMetricClient(ctx)
 	if err != nil {
 		errMessage := "ERROR: Failed to create Cloud Monitoring metric client"
 		s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-		return errMessage, subcommands.ExitFailure
+		return errMessage, s.fail(onetime.ErrorCategoryGCEAPI)
 	}
 	s.timeSeriesCreator = mc
+	s.historyPath = defaultHistoryPath
 
-	message, exitStatus := s.snapshotHandler(ctx, gce.NewGCEClient, onetime.NewComputeService, hanabackup.CheckDataDir, opts.CloudProperties)
-	if exitStatus != subcommands.ExitSuccess {
-		return message, subcommands.ExitFailure
+	checkDataDir := hanabackup.CheckDataDir
+	if s.HANADataPath != "" {
+		checkDataDir = s.checkDataPathOverride
 	}
-	return message, subcommands.ExitSuccess
+	return s.snapshotHandler(ctx, gce.NewGCEClient, onetime.NewComputeService, checkDataDir, opts.CloudProperties)
 }
 
-func (s *Snapshot) snapshotHandler(ctx context.Context, gceServiceCreator onetime.GCEServiceFunc, computeServiceCreator onetime.ComputeServiceFunc, checkDataDir checkDataDirFunc, cp *ipb.CloudProperties) (string, subcommands.ExitStatus) {
+// checkDataPathOverride checks the HANA data path passed in -hana-data-path, which is used
+// in place of the data path read from global.ini for installations with custom mount points.
+func (s *Snapshot) checkDataPathOverride(ctx context.Context, exec commandlineexecutor.Execute) (dataPath, logicalDataPath, physicalDataPath string, err error) {
+	if _, err := os.Stat(s.HANADataPath); err != nil {
+		return "", "", "", fmt.Errorf("invalid -hana-data-path: %w", err)
+	}
+	if err := hanabackup.CheckMountPoint(ctx, s.HANADataPath, exec); err != nil {
+		return "", "", "", fmt.Errorf("invalid -hana-data-path: %w", err)
+	}
+	log.CtxLogger(ctx).Infow("Using the HANA data path passed in -hana-data-path", "path", s.HANADataPath)
+	logicalDataPath, physicalDataPath, err = hanabackup.CheckDataPath(ctx, s.HANADataPath, exec)
+	return s.HANADataPath, logicalDataPath, physicalDataPath, err
+}
+
+// fail records the category of the failure for the status metric and returns the exit status
+// of the category.
+func (s *Snapshot) fail(category onetime.ErrorCategory) subcommands.ExitStatus {
+	s.errorCategory = category
+	return category.ExitStatus()
+}
+
+func (s *Snapshot) snapshotHandler(ctx context.Context, gceServiceCreator onetime.GCEServiceFunc, computeServiceCreator onetime.ComputeServiceFunc, checkDataDir checkDataDirFunc, cp *ipb.CloudProperties) (message string, exitStatus subcommands.ExitStatus) {
 	var err error
 	s.status = false
+	s.errorCategory = onetime.ErrorCategoryNone
 
-	defer s.sendStatusToMonitoring(ctx, cloudmonitoring.NewDefaultBackOffIntervals(), cp)
+	defer func() {
+		failureReason := ""
+		if !s.status {
+			failureReason = message
+		}
+		s.recordHistory(ctx, failureReason, os.ReadFile, os.WriteFile)
+		s.sendStatusToMonitoring(ctx, cloudmonitoring.NewDefaultBackOffIntervals(), cp)
+	}()
 
 	s.gceService, err = gceServiceCreator(ctx)
 	if err != nil {
 		errMessage := "ERROR: Failed to create GCE service"
 		s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-		return errMessage, subcommands.ExitFailure
+		return errMessage, s.fail(onetime.ErrorCategoryGCEAPI)
 	}
 
 	if s.hanaDataPath, s.logicalDataPath, s.physicalDataPath, err = checkDataDir(ctx, commandlineexecutor.ExecuteCommand); err != nil {
 		errMessage := "ERROR: Failed to check preconditions"
 		s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-		return errMessage, subcommands.ExitFailure
+		return errMessage, s.fail(onetime.ErrorCategoryPrecondition)
+	}
+	if err := s.checkTmpfsDataPath(ctx, commandlineexecutor.ExecuteCommand); err != nil {
+		errMessage := "ERROR: Failed to check preconditions"
+		s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
+		return errMessage, s.fail(onetime.ErrorCategoryPrecondition)
 	}
 
-	if s.Disk == "" {
+	if len(s.disks) > 0 {
+		log.CtxLogger(ctx).Infow("Using the disks passed in -source-disks", "disks", s.disks)
+		for _, d := range s.disks {
+			if err := s.isDiskAttachedToInstance(ctx, d, cp); err != nil {
+				errMessage := "ERROR: Failed to validate the disks passed in -source-disks"
+				s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
+				return errMessage, s.fail(onetime.ErrorCategoryPrecondition)
+			}
+		}
+		s.oteLogger.LogUsageAction(usagemetrics.HANADiskGroupBackupStarted)
+		if errMessage, err := s.setupGroupSnapshot(ctx); err != nil {
+			s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
+			return errMessage, s.fail(onetime.ErrorCategoryPrecondition)
+		}
+	} else if s.Disk == "" {
 		log.CtxLogger(ctx).Info("Reading disk mapping for /hana/data/")
-		if err := s.readDiskMapping(ctx, cp); err != nil {
+		if err := s.readDiskMapping(ctx, cp, commandlineexecutor.ExecuteCommand); err != nil {
 			errMessage := "ERROR: Failed to read disk mapping"
 			s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-			return errMessage, subcommands.ExitFailure
+			return errMessage, s.fail(onetime.ErrorCategoryPrecondition)
 		}
 
 		if len(s.disks) > 1 {
-			s.oteLogger.LogUsageAction(usagemetrics.HANADiskGroupBackupStarted)
 			if ok, err := hanabackup.CheckDataDeviceForStripes(ctx, s.logicalDataPath, commandlineexecutor.ExecuteCommand); err != nil {
 				errMessage := "ERROR: Failed to check if data device is striped"
 				s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-				return errMessage, subcommands.ExitFailure
+				return errMessage, s.fail(onetime.ErrorCategoryPrecondition)
 			} else if !ok {
 				errMessage := "ERROR: Multiple disks are backing up /hana/data but data device is not striped"
 				s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-				return errMessage, subcommands.ExitFailure
+				return errMessage, s.fail(onetime.ErrorCategoryPrecondition)
 			}
-			s.isgService = &instantsnapshotgroup.ISGService{}
-			if err := s.isgService.NewService(); err != nil {
-				errMessage := "ERROR: Failed to create Instant Snapshot Group service"
-				s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-				return errMessage, subcommands.ExitFailure
-			}
-			if err := s.validateDisksBelongToCG(ctx); err != nil {
-				errMessage := "ERROR: Failed to validate whether disks belong to consistency group"
+		}
+		if err := s.readAdditionalMountMappings(ctx, commandlineexecutor.ExecuteCommand); err != nil {
+			errMessage := "ERROR: Failed to read disk mapping of the additional mount points"
+			s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
+			return errMessage, s.fail(onetime.ErrorCategoryPrecondition)
+		}
+		if len(s.disks) > 1 {
+			s.oteLogger.LogUsageAction(usagemetrics.HANADiskGroupBackupStarted)
+			if errMessage, err := s.setupGroupSnapshot(ctx); err != nil {
 				s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-				return errMessage, subcommands.ExitFailure
+				return errMessage, s.fail(onetime.ErrorCategoryPrecondition)
 			}
-			s.groupSnapshot = true
 		}
 		log.CtxLogger(ctx).Infow("Successfully read disk mapping for /hana/data/", "disks", s.disks, "cgPath", s.cgName, "groupSnapshot", s.groupSnapshot)
 	}
 
+	if err := s.checkCrossProjectAccess(ctx); err != nil {
+		errMessage := "ERROR: Failed to check access to the source disk and snapshot projects"
+		s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
+		return errMessage, s.fail(onetime.ErrorCategoryPrecondition)
+	}
+
 	if s.groupSnapshotName != "" {
 		snapshotList, err := s.gceService.ListSnapshots(ctx, s.Project)
 		if err != nil {
 			errMessage := "ERROR: Failed to check if group snapshot exists"
 			s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-			return errMessage, subcommands.ExitFailure
+			return errMessage, s.fail(onetime.ErrorCategoryGCEAPI)
 		}
 
 		for _, snapshot := range snapshotList.Items {
 			if snapshot.Labels["goog-sapagent-isg"] == s.groupSnapshotName {
 				errMessage := "ERROR: Group snapshot with given name already exists"
 				s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, fmt.Errorf("group snapshot with given name already exists"))
-				return errMessage, subcommands.ExitFailure
+				return errMessage, s.fail(onetime.ErrorCategoryPrecondition)
 			}
 		}
 	}
@@ -349,30 +526,19 @@ func (s *Snapshot) snapshotHandler(ctx context.Context, gceServiceCreator onetim
 	if s.HDBUserstoreKey != "" {
 		s.oteLogger.LogUsageAction(usagemetrics.HANADiskSnapshotUserstoreKey)
 	}
-	dbp := databaseconnector.Params{
-		Username:       s.HanaDBUser,
-		Password:       s.Password,
-		PasswordSecret: s.PasswordSecret,
-		Host:           s.Host,
-		Port:           s.Port,
-		HDBUserKey:     s.HDBUserstoreKey,
-		GCEService:     s.gceService,
-		Project:        s.Project,
-		SID:            s.Sid,
-	}
 	if s.SkipDBSnapshotForChangeDiskType {
 		s.oteLogger.LogMessageToFileAndConsole(ctx, "Skipping connecting to HANA Database in case of changedisktype workflow.")
-	} else if s.db, err = databaseconnector.CreateDBHandle(ctx, dbp); err != nil {
+	} else if s.db, err = databaseconnector.CreateDBHandle(ctx, s.dbParams(ctx)); err != nil {
 		errMessage := "ERROR: Failed to connect to database"
 		s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-		return errMessage, subcommands.ExitFailure
+		return errMessage, s.fail(onetime.ErrorCategoryDatabase)
 	}
 
 	s.computeService, err = computeServiceCreator(ctx)
 	if err != nil {
 		errMessage := "ERROR: Failed to create compute service"
 		s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-		return errMessage, subcommands.ExitFailure
+		return errMessage, s.fail(onetime.ErrorCategoryGCEAPI)
 	}
 
 	workflowStartTime := time.Now()
@@ -381,18 +547,21 @@ func (s *Snapshot) snapshotHandler(ctx context.Context, gceServiceCreator onetim
 		if err != nil {
 			errMessage := "ERROR: Failed to run HANA disk snapshot workflow"
 			s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-			return errMessage, subcommands.ExitFailure
+			return errMessage, s.fail(onetime.ErrorCategorySnapshot)
 		}
 	} else if s.groupSnapshot {
+		if s.ReplicaLocations != "" {
+			log.CtxLogger(ctx).Warnw("Replica snapshots are not supported for group snapshots, ignoring -replica-locations", "replicaLocations", s.ReplicaLocations)
+		}
 		if err := s.runWorkflowForInstantSnapshotGroups(ctx, runQuery, cp); err != nil {
 			errMessage := "ERROR: Failed to run HANA disk snapshot workflow"
 			s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-			return errMessage, subcommands.ExitFailure
+			return errMessage, s.fail(onetime.ErrorCategorySnapshot)
 		}
 	} else if err = s.runWorkflowForDiskSnapshot(ctx, runQuery, s.createSnapshot, cp); err != nil {
 		errMessage := "ERROR: Failed to run HANA disk snapshot workflow"
 		s.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
-		return errMessage, subcommands.ExitFailure
+		return errMessage, s.fail(onetime.ErrorCategorySnapshot)
 	}
 	workflowDur := time.Since(workflowStartTime)
 
@@ -405,6 +574,9 @@ func (s *Snapshot) snapshotHandler(ctx context.Context, gceServiceCreator onetim
 		s.oteLogger.LogUsageAction(usagemetrics.HANADiskGroupBackupSucceeded)
 	} else {
 		successMessage = fmt.Sprintf("SUCCESS: HANA backup and disk snapshot creation successful. Snapshot Name: %s", snapshotName)
+		if len(s.replicaSnapshots) > 0 {
+			successMessage += fmt.Sprintf(", Replica Snapshot Names: %s", strings.Join(s.replicaSnapshots, ", "))
+		}
 		s.oteLogger.LogMessageToConsole(successMessage)
 		s.oteLogger.LogUsageAction(usagemetrics.HANADiskBackupSucceeded)
 	}
@@ -414,7 +586,44 @@ func (s *Snapshot) snapshotHandler(ctx context.Context, gceServiceCreator onetim
 	return successMessage, subcommands.ExitSuccess
 }
 
-func (s *Snapshot) readDiskMapping(ctx context.Context, cp *ipb.CloudProperties) error {
+// setupGroupSnapshot creates the Instant Snapshot Group service and validates that the disks
+// belong to the same consistency group. Returns the message to report on failure.
+func (s *Snapshot) setupGroupSnapshot(ctx context.Context) (string, error) {
+	s.isgService = &instantsnapshotgroup.ISGService{}
+	if err := s.isgService.NewService(); err != nil {
+		return "ERROR: Failed to create Instant Snapshot Group service", err
+	}
+	if err := s.validateDisksBelongToCG(ctx); err != nil {
+		return "ERROR: Failed to validate whether disks belong to consistency group", err
+	}
+	s.groupSnapshot = true
+	return "", nil
+}
+
+// dbParams returns the parameters of the HANA connection. With -hdbuserstore-key the host and
+// port are read from the key, so -host and -port are not passed and cannot override them.
+func (s *Snapshot) dbParams(ctx context.Context) databaseconnector.Params {
+	dbp := databaseconnector.Params{
+		Username:       s.HanaDBUser,
+		Password:       s.Password,
+		PasswordSecret: s.PasswordSecret,
+		Host:           s.Host,
+		Port:           s.Port,
+		HDBUserKey:     s.HDBUserstoreKey,
+		GCEService:     s.gceService,
+		Project:        s.Project,
+		SID:            s.Sid,
+	}
+	if s.HDBUserstoreKey != "" {
+		log.CtxLogger(ctx).Infow("Connecting to HANA with the host and port of the hdbuserstore key", "key", s.HDBUserstoreKey)
+		dbp.Host, dbp.Port = "", ""
+	}
+	return dbp
+}
+
+// readDiskMapping resolves the disks backing the HANA data volume. The physical volumes of the
+// logical data volume are read from LVM so that every disk of a striped volume is captured.
+func (s *Snapshot) readDiskMapping(ctx context.Context, cp *ipb.CloudProperties, exec commandlineexecutor.Execute) error {
 	var err error
 
 	instanceInfoReader := instanceinfo.New(&instanceinfo.PhysicalPathReader{OS: runtime.GOOS}, s.gceService)
@@ -422,28 +631,144 @@ func (s *Snapshot) readDiskMapping(ctx context.Context, cp *ipb.CloudProperties)
 		return err
 	}
 
-	log.CtxLogger(ctx).Debugw("Reading disk mapping", "ip", s.instanceProperties)
-	for _, d := range s.instanceProperties.GetDisks() {
-		if strings.Contains(s.physicalDataPath, d.GetMapping()) {
-			log.CtxLogger(ctx).Debugw("Found disk mapping", "physicalPath", s.physicalDataPath, "diskName", d.GetDiskName())
-			s.Disk = d.GetDiskName()
+	devices, err := instanceinfo.PhysicalDevicesForLogicalVolume(ctx, s.logicalDataPath, exec)
+	if err != nil {
+		return err
+	}
+	log.CtxLogger(ctx).Debugw("Reading disk mapping", "ip", s.instanceProperties, "devices", devices)
+	for _, d := range instanceinfo.DisksForDevices(s.instanceProperties.GetDisks(), devices) {
+		log.CtxLogger(ctx).Debugw("Found disk mapping", "physicalPath", s.physicalDataPath, "diskName", d.GetDiskName(), "mapping", d.GetMapping())
+		s.Disk = d.GetDiskName()
+		if cp.GetZone() != "" {
 			s.DiskZone = cp.GetZone()
-			s.disks = append(s.disks, d.GetDiskName())
-			s.provisionedIops = d.GetProvisionedIops()
-			s.provisionedThroughput = d.GetProvisionedThroughput()
 		}
+		s.disks = append(s.disks, d.GetDiskName())
+		s.provisionedIops = d.GetProvisionedIops()
+		s.provisionedThroughput = d.GetProvisionedThroughput()
+	}
+	if len(s.disks) == 0 {
+		return fmt.Errorf("no instance disks found backing the physical devices %v of %s", devices, s.logicalDataPath)
 	}
 
 	if s.SnapshotName == "" {
-		t := time.Now()
 		log.CtxLogger(ctx).Debug("disk: ", s.Disk)
-		s.SnapshotName = fmt.Sprintf("snapshot-%s-%d%02d%02d-%02d%02d%02d",
-			s.Disk, t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second())
+		if s.SnapshotName, err = s.defaultSnapshotName(s.Disk, cp.GetInstanceName(), time.Now()); err != nil {
+			return err
+		}
+		s.snapshotNameGenerated = true
 	}
+	s.diskMappingRead = true
 	return nil
 }
 
+// refreshStaleDiskMapping re-reads the disk mapping when a disk found by readDiskMapping is no
+// longer attached to the instance, e.g. after its disk type was changed, so that a detached disk
+// is not snapshotted. Disks passed in -source-disk or -source-disks are left unchanged. The default
+// snapshot name is regenerated for the refreshed disk, a -snapshot-name passed is kept.
+func (s *Snapshot) refreshStaleDiskMapping(ctx context.Context, cp *ipb.CloudProperties, exec commandlineexecutor.Execute) error {
+	if !s.diskMappingRead {
+		return nil
+	}
+	stale := ""
+	for _, d := range s.disks {
+		_, ok, err := s.gceService.DiskAttachedToInstance(s.sourceDiskProject(), s.DiskZone, cp.GetInstanceName(), d)
+		if err != nil {
+			return fmt.Errorf("failed to check if the disk=%v is attached to the instance: %v", d, err)
+		}
+		if !ok {
+			stale = d
+			break
+		}
+	}
+	if stale == "" {
+		return nil
+	}
+
+	log.CtxLogger(ctx).Infow("Disk mapping is stale, refreshing it", "detachedDisk", stale, "disks", s.disks)
+	oldDisks := s.disks
+	s.disks = nil
+	if s.snapshotNameGenerated {
+		s.SnapshotName = ""
+	}
+	if err := s.readDiskMapping(ctx, cp, exec); err != nil {
+		return fmt.Errorf("failed to refresh the disk mapping: %v", err)
+	}
+	if len(s.disks) != len(oldDisks) {
+		return fmt.Errorf("the number of disks backing %s changed from %d to %d, rerun the backup", s.logicalDataPath, len(oldDisks), len(s.disks))
+	}
+	log.CtxLogger(ctx).Infow("Refreshed disk mapping", "oldDisks", oldDisks, "disks", s.disks)
+	return nil
+}
+
+// defaultSnapshotName returns the name of the snapshot of the disk, which is expanded from
+// -snapshot-name-template when one is passed and is 'snapshot-diskname-yyyymmdd-hhmmss' otherwise.
+func (s *Snapshot) defaultSnapshotName(disk, host string, t time.Time) (string, error) {
+	if s.SnapshotNameTemplate == "" {
+		return fmt.Sprintf("snapshot-%s-%d%02d%02d-%02d%02d%02d",
+			disk, t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second()), nil
+	}
+	name := strings.NewReplacer(
+		"{sid}", strings.ToLower(s.Sid),
+		"{disk}", disk,
+		"{date}", t.Format("20060102"),
+		"{time}", t.Format("150405"),
+		"{host}", host,
+	).Replace(s.SnapshotNameTemplate)
+	if !snapshotNameRegex.MatchString(name) {
+		return "", fmt.Errorf("snapshot name %q expanded from -snapshot-name-template=%q is invalid, it must be 1-63 characters long, start with a lowercase letter, contain only lowercase letters, digits and hyphens and not end with a hyphen", name, s.SnapshotNameTemplate)
+	}
+	return name, nil
+}
+
+// checkTmpfsDataPath fails when tmpfs file systems are mounted under the HANA data path, as a
+// disk snapshot would not capture them consistently, unless -ignore-tmpfs-data is set.
+func (s *Snapshot) checkTmpfsDataPath(ctx context.Context, exec commandlineexecutor.Execute) error {
+	if s.hanaDataPath == "" {
+		return nil
+	}
+	mounts, err := hanabackup.TmpfsMountsUnderPath(ctx, s.hanaDataPath, exec)
+	if err != nil {
+		return err
+	}
+	if len(mounts) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("HANA data path %s includes tmpfs mounts %v which are not captured by the disk snapshot", s.hanaDataPath, mounts)
+	if s.IgnoreTmpfsData {
+		s.oteLogger.LogMessageToFileAndConsole(ctx, "WARNING: "+msg+", continuing as -ignore-tmpfs-data is set.")
+		return nil
+	}
+	return fmt.Errorf("%s, rerun with <-ignore-tmpfs-data=true> to create the backup anyway", msg)
+}
+
 func (s *Snapshot) validateParameters(os string, cp *ipb.CloudProperties) error {
+	if err := s.validateConfirmMode(); err != nil {
+		return err
+	}
+	if err := s.parseSourceDisks(); err != nil {
+		return err
+	}
+	if err := s.validateSnapshotNameTemplate(cp); err != nil {
+		return err
+	}
+	if s.HANADataPath != "" && !filepath.IsAbs(s.HANADataPath) {
+		return fmt.Errorf("-hana-data-path must be an absolute path, got %q", s.HANADataPath)
+	}
+	if s.StorageCostPerGB < 0 {
+		return fmt.Errorf("-storage-cost-per-gb must not be negative, got %v", s.StorageCostPerGB)
+	}
+	if s.GuestFlush && s.FreezeFileSystem {
+		log.Logger.Warn("Both -guest-flush and -freeze-file-system are set, the file system is frozen and the guest flush is not requested")
+	}
+	if err := s.validateSnapshotWait(); err != nil {
+		return err
+	}
+	if err := s.validateReplicaLocations(); err != nil {
+		return err
+	}
+	if err := s.validateAdditionalMountPoints(); err != nil {
+		return err
+	}
 	if s.SkipDBSnapshotForChangeDiskType {
 		log.Logger.Debug("Skipping parameter validation for change disk type workflow.")
 		return nil
@@ -463,30 +788,156 @@ func (s *Snapshot) validateParameters(os string, cp *ipb.CloudProperties) error
 			return fmt.Errorf("either -password, -password-secret or -hdbuserstore-key is required. Usage:" + s.Usage())
 		}
 	}
+	if s.HDBUserstoreKey != "" && s.Host != "" && s.Host != "localhost" {
+		log.Logger.Warnw("-host is ignored with -hdbuserstore-key, the host of the key is used", "host", s.Host, "key", s.HDBUserstoreKey)
+	}
 
 	if s.SnapshotType != "STANDARD" && s.SnapshotType != "ARCHIVE" {
 		return fmt.Errorf("invalid snapshot type, only STANDARD and ARCHIVE are supported")
 	}
-	if s.Project == "" {
-		s.Project = cp.GetProjectId()
-	}
-	if s.DiskZone == "" {
-		s.DiskZone = cp.GetZone()
-	}
+	s.resolveProjectAndZone(cp)
 	if s.Description == "" {
 		s.Description = fmt.Sprintf("Snapshot created by Agent for SAP for HANA sid: %q", s.Sid)
 	}
 	s.Port = s.portValue()
 
 	if s.SnapshotName == "" && s.Disk != "" {
-		t := time.Now()
-		s.SnapshotName = fmt.Sprintf("snapshot-%s-%d%02d%02d-%02d%02d%02d",
-			s.Disk, t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second())
+		var err error
+		if s.SnapshotName, err = s.defaultSnapshotName(s.Disk, cp.GetInstanceName(), time.Now()); err != nil {
+			return err
+		}
 	}
 	log.Logger.Debug("Parameter validation successful.")
 	return nil
 }
 
+// validateSnapshotWait checks the bounds of -snapshot-poll-interval-seconds and
+// -snapshot-max-wait-seconds, 0 keeps the default of the wait.
+func (s *Snapshot) validateSnapshotWait() error {
+	interval, maxWait := s.snapshotPollInterval(), s.snapshotMaxWait()
+	switch {
+	case interval < 0 || interval > maxSnapshotPollInterval:
+		return fmt.Errorf("-snapshot-poll-interval-seconds must be between 1 and %d, got %d", int64(maxSnapshotPollInterval.Seconds()), s.SnapshotPollIntervalSeconds)
+	case maxWait < 0 || maxWait > maxSnapshotWait:
+		return fmt.Errorf("-snapshot-max-wait-seconds must be between 1 and %d, got %d", int64(maxSnapshotWait.Seconds()), s.SnapshotMaxWaitSeconds)
+	case interval > 0 && maxWait > 0 && maxWait < interval:
+		return fmt.Errorf("-snapshot-max-wait-seconds (%d) must not be less than -snapshot-poll-interval-seconds (%d)", s.SnapshotMaxWaitSeconds, s.SnapshotPollIntervalSeconds)
+	}
+	return nil
+}
+
+// snapshotPollInterval returns the interval between the polls of the snapshot status, 0 uses the
+// default of the wait.
+func (s *Snapshot) snapshotPollInterval() time.Duration {
+	return time.Duration(s.SnapshotPollIntervalSeconds) * time.Second
+}
+
+// snapshotMaxWait returns the maximum wait for the snapshot creation or upload, 0 uses the default
+// of the wait.
+func (s *Snapshot) snapshotMaxWait() time.Duration {
+	return time.Duration(s.SnapshotMaxWaitSeconds) * time.Second
+}
+
+// resolveProjectAndZone sets the project and the zone which are not passed in the flags or the
+// params file. The first source providing a value is used, in order of precedence:
+//  1. The -project and -source-disk-zone flags, or the params file.
+//  2. The cloud_properties in the agent configuration file.
+//  3. The cloud properties read by the agent at startup.
+//  4. The metadata server, queried again for hosts where the startup read was incomplete.
+func (s *Snapshot) resolveProjectAndZone(cp *ipb.CloudProperties) {
+	var projectSource, zoneSource string
+	if s.Project != "" {
+		projectSource = "flags"
+	}
+	if s.DiskZone != "" {
+		zoneSource = "flags"
+	}
+	resolve := func(source, project, zone string) {
+		if s.Project == "" && project != "" {
+			s.Project, projectSource = project, source
+		}
+		if s.DiskZone == "" && zone != "" {
+			s.DiskZone, zoneSource = zone, source
+		}
+	}
+	unresolved := func() bool { return s.Project == "" || s.DiskZone == "" }
+
+	if unresolved() && s.readConfigFile != nil {
+		if config := s.configCloudProperties(); config != nil {
+			resolve("configuration file", config.GetProjectId(), config.GetZone())
+		}
+	}
+	if unresolved() {
+		resolve("cloud properties", cp.GetProjectId(), cp.GetZone())
+	}
+	if unresolved() && s.fetchCloudProperties != nil {
+		if metadata := s.fetchCloudProperties(); metadata != nil {
+			resolve("metadata server", metadata.ProjectID, metadata.Zone)
+		}
+	}
+	log.Logger.Infow("Resolved project and zone", "project", s.Project, "projectSource", projectSource, "zone", s.DiskZone, "zoneSource", zoneSource)
+}
+
+// configCloudProperties returns the cloud properties set in the agent configuration file, or nil
+// if the file cannot be read.
+func (s *Snapshot) configCloudProperties() *ipb.CloudProperties {
+	content, err := s.readConfigFile(configuration.LinuxConfigPath)
+	if err != nil || len(content) == 0 {
+		log.Logger.Debugw("Could not read the agent configuration file", "file", configuration.LinuxConfigPath, "error", err)
+		return nil
+	}
+	config := &cpb.Configuration{}
+	if err := protojson.Unmarshal(content, config); err != nil {
+		log.Logger.Debugw("Invalid content in the agent configuration file", "file", configuration.LinuxConfigPath, "error", err)
+		return nil
+	}
+	return config.GetCloudProperties()
+}
+
+// validateSnapshotNameTemplate checks that -snapshot-name-template expands to a valid snapshot
+// name. When the disk is read from the disk mapping it is not known yet, so the name is checked
+// with a placeholder disk here and again once the disk mapping has been read.
+func (s *Snapshot) validateSnapshotNameTemplate(cp *ipb.CloudProperties) error {
+	if s.SnapshotNameTemplate == "" {
+		return nil
+	}
+	if s.SnapshotName != "" {
+		return fmt.Errorf("only one of -snapshot-name and -snapshot-name-template can be passed")
+	}
+	disk := s.Disk
+	if disk == "" {
+		disk = "disk"
+	}
+	if _, err := s.defaultSnapshotName(disk, cp.GetInstanceName(), time.Now()); err != nil {
+		return fmt.Errorf("%v. Usage:%s", err, s.Usage())
+	}
+	return nil
+}
+
+// parseSourceDisks reads the disks passed in -source-disks, which are snapshotted as a group.
+func (s *Snapshot) parseSourceDisks() error {
+	if s.SourceDisks == "" {
+		return nil
+	}
+	switch {
+	case s.Disk != "":
+		return fmt.Errorf("only one of -source-disk and -source-disks can be passed")
+	case s.SkipDBSnapshotForChangeDiskType:
+		return fmt.Errorf("-source-disks is not supported for the change disk type workflow")
+	}
+	s.disks = nil
+	for _, d := range strings.Split(s.SourceDisks, ",") {
+		if d = strings.TrimSpace(d); d != "" && !slices.Contains(s.disks, d) {
+			s.disks = append(s.disks, d)
+		}
+	}
+	if len(s.disks) < 2 {
+		return fmt.Errorf("-source-disks requires at least two disks, use -source-disk to snapshot a single disk")
+	}
+	s.Disk = s.disks[0]
+	return nil
+}
+
 func (s *Snapshot) portValue() string {
 	if s.Port == "" {
 		log.Logger.Debug("Building port number of the system database from instance ID", "instanceID", s.InstanceID)
@@ -509,6 +960,45 @@ func runQuery(ctx context.Context, h *databaseconnector.DBHandle, q string) (str
 	return val, nil
 }
 
+// sourceDiskProject returns the project of the source disks, which defaults to the project the
+// snapshots are created in.
+func (s *Snapshot) sourceDiskProject() string {
+	if s.DiskProject != "" {
+		return s.DiskProject
+	}
+	return s.Project
+}
+
+// crossProject reports whether the snapshots are created in a different project than the disk.
+func (s *Snapshot) crossProject() bool {
+	return s.sourceDiskProject() != s.Project
+}
+
+// sourceDiskURI returns the URI of the source disk.
+func (s *Snapshot) sourceDiskURI() string {
+	return fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/zones/%s/disks/%s", s.sourceDiskProject(), s.DiskZone, s.Disk)
+}
+
+// checkCrossProjectAccess verifies that the source disk can be read in its project and that the
+// snapshots of the target project can be listed, so that missing permissions in either project
+// are reported before the HANA snapshot is prepared.
+func (s *Snapshot) checkCrossProjectAccess(ctx context.Context) error {
+	if !s.crossProject() {
+		return nil
+	}
+	if s.groupSnapshot {
+		return fmt.Errorf("group snapshots are not supported with -source-disk-project")
+	}
+	log.CtxLogger(ctx).Infow("Checking access to the source disk and snapshot projects", "sourceDiskProject", s.sourceDiskProject(), "project", s.Project)
+	if _, err := s.gceService.GetDisk(s.sourceDiskProject(), s.DiskZone, s.Disk); err != nil {
+		return fmt.Errorf("cannot read source-disk=%s in project %s, the service account needs compute.disks.get and compute.disks.useReadOnly in the source disk project: %v", s.Disk, s.sourceDiskProject(), err)
+	}
+	if _, err := s.gceService.ListSnapshots(ctx, s.Project); err != nil {
+		return fmt.Errorf("cannot list the snapshots of project %s, the service account needs compute.snapshots.list and compute.snapshots.create in the snapshot project: %v", s.Project, err)
+	}
+	return nil
+}
+
 func (s *Snapshot) createSnapshot(snapshot *compute.Snapshot) fakeDiskCreateSnapshotCall {
 	return s.computeService.Disks.CreateSnapshot(s.Project, s.DiskZone, s.Disk, snapshot)
 }
@@ -519,7 +1009,7 @@ func (s *Snapshot) runWorkflowForChangeDiskType(ctx context.Context, createSnaps
 		s.oteLogger.LogErrorToFileAndConsole(ctx, "Error preparing for change disk type workflow", err)
 		return err
 	}
-	_, ok, err := s.gceService.DiskAttachedToInstance(s.Project, s.DiskZone, cp.GetInstanceName(), s.Disk)
+	_, ok, err := s.gceService.DiskAttachedToInstance(s.sourceDiskProject(), s.DiskZone, cp.GetInstanceName(), s.Disk)
 	if err != nil {
 		return fmt.Errorf("failed to check if the source-disk=%v is attached to the instance", s.Disk)
 	}
@@ -528,7 +1018,7 @@ func (s *Snapshot) runWorkflowForChangeDiskType(ctx context.Context, createSnaps
 	}
 	op, err := s.createDiskSnapshot(ctx, createSnapshot)
 	if s.FreezeFileSystem {
-		if err := hanabackup.UnFreezeXFS(ctx, s.hanaDataPath, commandlineexecutor.ExecuteCommand); err != nil {
+		if err := s.unfreezeXFS(ctx, commandlineexecutor.ExecuteCommand); err != nil {
 			s.oteLogger.LogErrorToFileAndConsole(ctx, "Error unfreezing XFS", err)
 			return err
 		}
@@ -538,11 +1028,15 @@ func (s *Snapshot) runWorkflowForChangeDiskType(ctx context.Context, createSnaps
 	if err != nil {
 		return err
 	}
+	defer s.sendDurationToCloudMonitoring(ctx, metricPrefix+s.Name()+"/snapshotcreationtime", s.SnapshotName, s.snapshotCreationTime, cloudmonitoring.NewDefaultBackOffIntervals(), cp)
 
 	log.CtxLogger(ctx).Info("Waiting for disk snapshot to complete uploading.")
-	if err := s.gceService.WaitForSnapshotUploadCompletionWithRetry(ctx, op, s.Project, s.DiskZone, s.SnapshotName); err != nil {
+	uploadStartTime := time.Now()
+	if err := s.gceService.WaitForSnapshotUploadCompletionWithRetry(ctx, op, s.Project, s.DiskZone, s.SnapshotName, s.snapshotPollInterval(), s.snapshotMaxWait()); err != nil {
 		return err
 	}
+	uploadTime := time.Since(uploadStartTime)
+	defer s.sendDurationToCloudMonitoring(ctx, metricPrefix+s.Name()+"/snapshotuploadtime", s.SnapshotName, uploadTime, cloudmonitoring.NewDefaultBackOffIntervals(), cp)
 
 	log.CtxLogger(ctx).Info("Disk snapshot created.")
 	return nil
@@ -563,11 +1057,10 @@ func (s *Snapshot) createDiskSnapshot(ctx context.Context, createSnapshot diskSn
 	log.CtxLogger(ctx).Infow("Creating disk snapshot", "sourcedisk", s.Disk, "sourcediskzone", s.DiskZone, "snapshotname", s.SnapshotName)
 
 	snapshot := &compute.Snapshot{
-		Description:      s.Description,
-		Name:             s.SnapshotName,
-		SnapshotType:     s.SnapshotType,
-		StorageLocations: []string{s.StorageLocation},
-		Labels:           s.parseLabels(),
+		Description:  s.Description,
+		Name:         s.SnapshotName,
+		SnapshotType: s.SnapshotType,
+		Labels:       s.parseLabels(),
 	}
 
 	return s.createBackup(ctx, snapshot, createSnapshot)
@@ -582,7 +1075,7 @@ func (s *Snapshot) createBackup(ctx context.Context, snapshot *compute.Snapshot,
 	// snapshot encryption key are the same.
 	if s.DiskKeyFile != "" {
 		s.oteLogger.LogUsageAction(usagemetrics.EncryptedDiskSnapshot)
-		srcDiskURI := fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/zones/%s/disks/%s", s.Project, s.DiskZone, s.Disk)
+		srcDiskURI := s.sourceDiskURI()
 		srcDiskKey, err := hanabackup.ReadKey(s.DiskKeyFile, srcDiskURI, os.ReadFile)
 		if err != nil {
 			s.oteLogger.LogUsageError(usagemetrics.EncryptedDiskSnapshotFailure)
@@ -596,21 +1089,116 @@ func (s *Snapshot) createBackup(ctx context.Context, snapshot *compute.Snapshot,
 	}
 	dbFreezeStartTime = time.Now()
 	if s.FreezeFileSystem {
-		if err := hanabackup.FreezeXFS(ctx, s.hanaDataPath, commandlineexecutor.ExecuteCommand); err != nil {
+		if ctx, err = s.freezeXFS(ctx, commandlineexecutor.ExecuteCommand); err != nil {
 			return nil, err
 		}
 	}
-	if op, err = createSnapshot(snapshot).Do(); err != nil {
-		return nil, err
+	locations := s.storageLocations()
+	for i, location := range locations {
+		snapshot.StorageLocations = []string{location}
+		var inserted bool
+		op, inserted, err = s.createSnapshotInLocation(ctx, snapshot, createSnapshot)
+		if timeoutErr := freezeTimedOut(ctx); timeoutErr != nil {
+			return nil, timeoutErr
+		}
+		if err == nil {
+			log.CtxLogger(ctx).Infow("Disk snapshot created in storage location", "storagelocation", location)
+			return op, nil
+		}
+		if inserted {
+			// The snapshot exists under s.SnapshotName, retrying in the next location would collide with it.
+			return nil, fmt.Errorf("snapshot %s was inserted in storage location %q but its creation did not complete, not retrying in another location: %w", s.SnapshotName, location, err)
+		}
+		if i == len(locations)-1 || !isStorageLocationError(err) {
+			return nil, err
+		}
+		s.oteLogger.LogMessageToFileAndConsole(ctx, fmt.Sprintf("Snapshot creation failed in storage location %q, retrying in %q: %v", location, locations[i+1], err))
+	}
+	return nil, fmt.Errorf("no storage location to create the snapshot in")
+}
+
+// createSnapshotInLocation creates the disk snapshot and waits for its creation. inserted reports
+// whether the snapshot was inserted, which is the case when only the wait failed.
+func (s *Snapshot) createSnapshotInLocation(ctx context.Context, snapshot *compute.Snapshot, createSnapshot diskSnapshotFunc) (op *compute.Operation, inserted bool, err error) {
+	creationStartTime := time.Now()
+	guestFlush := s.GuestFlush && !s.FreezeFileSystem
+	if guestFlush {
+		log.CtxLogger(ctx).Info("Requesting a guest flush for the disk snapshot")
+	}
+	if s.crossProject() {
+		// The disk's createSnapshot method creates the snapshot in the project of the disk, the
+		// snapshot is inserted in the target project instead with the disk as its source. Only the
+		// createSnapshot method can request a guest flush.
+		if guestFlush {
+			log.CtxLogger(ctx).Warn("A guest flush cannot be requested for a snapshot of a disk in another project, creating the snapshot without it")
+		}
+		snapshot.SourceDisk = s.sourceDiskURI()
+		op, err = s.gceService.CreateSnapshot(ctx, s.Project, snapshot)
+	} else {
+		call := createSnapshot(snapshot)
+		if guestFlush {
+			call.GuestFlush(true)
+		}
+		op, err = call.Do()
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if err := s.gceService.WaitForSnapshotCreationCompletionWithRetry(ctx, op, s.Project, s.DiskZone, s.SnapshotName, s.snapshotPollInterval(), s.snapshotMaxWait()); err != nil {
+		return nil, true, err
+	}
+	s.snapshotCreationTime = time.Since(creationStartTime)
+	return op, true, nil
+}
+
+// storageLocations returns the ordered list of storage locations to try from the comma separated
+// -storage-location value. An empty value lets compute engine choose the location.
+func (s *Snapshot) storageLocations() []string {
+	var locations []string
+	for _, l := range strings.Split(s.StorageLocation, ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			locations = append(locations, l)
+		}
 	}
-	if err := s.gceService.WaitForSnapshotCreationCompletionWithRetry(ctx, op, s.Project, s.DiskZone, s.SnapshotName); err != nil {
-		return nil, err
+	if len(locations) == 0 {
+		return []string{s.StorageLocation}
 	}
-	return op, nil
+	return locations
+}
+
+// storageLocationErrorReasons are the reasons of the compute API errors returned when a snapshot
+// cannot be created in its storage location, such as an unknown location or one denied by the
+// resource locations organization policy.
+var storageLocationErrorReasons = map[string]bool{
+	"invalid":          true,
+	"invalidParameter": true,
+	"conditionNotMet":  true,
+}
+
+// isStorageLocationError reports whether the snapshot insertion was rejected because of the storage
+// location it was created in, in which case the next location can be tried.
+func isStorageLocationError(err error) bool {
+	var gErr *googleapi.Error
+	if !errors.As(err, &gErr) {
+		return false
+	}
+	if gErr.Code != http.StatusBadRequest && gErr.Code != http.StatusPreconditionFailed {
+		return false
+	}
+	for _, item := range gErr.Errors {
+		if storageLocationErrorReasons[item.Reason] {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *Snapshot) parseLabels() map[string]string {
 	labels := s.createGroupBackupLabels()
+	if s.Sid != "" {
+		// Label values must be lowercase, the listsnapshots command filters on this label.
+		labels[sidLabel] = strings.ToLower(s.Sid)
+	}
 	if s.Labels != "" {
 		for _, label := range strings.Split(s.Labels, ",") {
 			split := strings.Split(label, "=")
@@ -631,7 +1219,7 @@ func (s *Snapshot) diskSnapshotFailureHandler(ctx context.Context, run queryFunc
 }
 
 func (s *Snapshot) isDiskAttachedToInstance(ctx context.Context, disk string, cp *ipb.CloudProperties) error {
-	_, ok, err := s.gceService.DiskAttachedToInstance(s.Project, s.DiskZone, cp.GetInstanceName(), disk)
+	_, ok, err := s.gceService.DiskAttachedToInstance(s.sourceDiskProject(), s.DiskZone, cp.GetInstanceName(), disk)
 	if err != nil {
 		s.oteLogger.LogErrorToFileAndConsole(ctx, fmt.Sprintf("ERROR: Failed to check if the source-disk=%v is attached to the instance", disk), err)
 		return fmt.Errorf("failed to check if the source-disk=%v is attached to the instance", disk)
@@ -655,12 +1243,14 @@ func (s *Snapshot) sendStatusToMonitoring(ctx context.Context, bo *cloudmonitori
 			Timestamp:  tspb.Now(),
 			BoolValue:  s.status,
 			MetricLabels: map[string]string{
-				"sid":           s.Sid,
-				"disk":          s.Disk,
-				"snapshot_name": s.SnapshotName,
+				"sid":            s.Sid,
+				"disk":           s.Disk,
+				"snapshot_name":  s.SnapshotName,
+				"error_category": s.errorCategory.String(),
 			},
 		}),
 	}
+	ts = append(ts, s.historyTimeSeries(cp)...)
 	if _, _, err := cloudmonitoring.SendTimeSeries(ctx, ts, s.timeSeriesCreator, bo, s.Project); err != nil {
 		log.CtxLogger(ctx).Debugw("Error sending status metric to cloud monitoring", "error", err.Error())
 		return false
@@ -668,6 +1258,113 @@ func (s *Snapshot) sendStatusToMonitoring(ctx context.Context, bo *cloudmonitori
 	return true
 }
 
+// historyTimeSeries returns the cumulative counts of the successful and failed backups of the
+// HANA system, labeled with the reason of the last failure.
+func (s *Snapshot) historyTimeSeries(cp *ipb.CloudProperties) []*mrpb.TimeSeries {
+	if s.history == nil {
+		return nil
+	}
+	var ts []*mrpb.TimeSeries
+	for _, c := range []struct {
+		result string
+		count  int64
+	}{{"success", s.history.Successes}, {"failure", s.history.Failures}} {
+		ts = append(ts, timeseries.BuildInt(timeseries.Params{
+			CloudProp:  timeseries.ConvertCloudProperties(cp),
+			MetricType: metricPrefix + s.Name() + "/snapshot_count",
+			MetricKind: metricpb.MetricDescriptor_CUMULATIVE,
+			StartTime:  tspb.New(s.history.StartTime),
+			Timestamp:  tspb.Now(),
+			Int64Value: c.count,
+			MetricLabels: map[string]string{
+				"sid":                 s.Sid,
+				"result":              c.result,
+				"last_failure_reason": s.history.LastFailureReason,
+			},
+		}))
+	}
+	return ts
+}
+
+// sendSnapshotSizeToMonitoring sends the storage size of each snapshot, and its estimated monthly
+// storage cost when -storage-cost-per-gb is set, to cloud monitoring as GAUGE metrics.
+func (s *Snapshot) sendSnapshotSizeToMonitoring(ctx context.Context, snapshotNames []string, bo *cloudmonitoring.BackOffIntervals, cp *ipb.CloudProperties) bool {
+	if !s.SendToMonitoring {
+		return false
+	}
+	var ts []*mrpb.TimeSeries
+	for _, name := range snapshotNames {
+		snapshot, err := s.waitForSnapshotSize(ctx, name)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Could not read the snapshot size, skipping the size metric", "snapshot", name, "error", err)
+			continue
+		}
+		labels := map[string]string{
+			"sid":           s.Sid,
+			"disk":          path.Base(snapshot.SourceDisk),
+			"snapshot_name": name,
+		}
+		log.CtxLogger(ctx).Infow("Optional: sending HANA disk snapshot size to cloud monitoring", "snapshot", name, "storageBytes", snapshot.StorageBytes)
+		ts = append(ts, timeseries.BuildInt(timeseries.Params{
+			CloudProp:    timeseries.ConvertCloudProperties(cp),
+			MetricType:   metricPrefix + s.Name() + "/snapshot_size",
+			Timestamp:    tspb.Now(),
+			Int64Value:   snapshot.StorageBytes,
+			MetricLabels: labels,
+		}))
+		if s.StorageCostPerGB > 0 {
+			ts = append(ts, timeseries.BuildFloat64(timeseries.Params{
+				CloudProp:    timeseries.ConvertCloudProperties(cp),
+				MetricType:   metricPrefix + s.Name() + "/snapshot_monthly_cost_estimate",
+				Timestamp:    tspb.Now(),
+				Float64Value: float64(snapshot.StorageBytes) / (1 << 30) * s.StorageCostPerGB,
+				MetricLabels: labels,
+			}))
+		}
+	}
+	if len(ts) == 0 {
+		return false
+	}
+	if _, _, err := cloudmonitoring.SendTimeSeries(ctx, ts, s.timeSeriesCreator, bo, s.Project); err != nil {
+		log.CtxLogger(ctx).Debugw("Error sending snapshot size metric to cloud monitoring", "error", err.Error())
+		return false
+	}
+	return true
+}
+
+// waitForSnapshotSize polls the snapshot until its storage size, which is computed asynchronously
+// after the upload completes, is up to date. Gives up after snapshotSizeMaxWait.
+func (s *Snapshot) waitForSnapshotSize(ctx context.Context, name string) (*compute.Snapshot, error) {
+	maxWait := s.snapshotSizeMaxWait
+	if maxWait <= 0 {
+		maxWait = defaultSnapshotSizeMaxWait
+	}
+	interval := s.snapshotPollInterval()
+	if interval <= 0 {
+		interval = defaultSnapshotSizePollInterval
+	}
+	deadline := time.Now().Add(maxWait)
+	for {
+		snapshot, err := s.gceService.GetSnapshot(ctx, s.Project, name)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot.StorageBytesStatus == "UP_TO_DATE" {
+			return snapshot, nil
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("storage size of snapshot %s is not up to date after %v, status: %q", name, maxWait, snapshot.StorageBytesStatus)
+		}
+		log.CtxLogger(ctx).Debugw("Snapshot size is still being computed", "snapshot", name, "status", snapshot.StorageBytesStatus)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(min(interval, remaining)):
+		}
+	}
+}
+
 func (s *Snapshot) sendDurationToCloudMonitoring(ctx context.Context, mtype string, snapshotName string, dur time.Duration, bo *cloudmonitoring.BackOffIntervals, cp *ipb.CloudProperties) bool {
 	if !s.SendToMonitoring {
 		return false
@@ -19,6 +19,7 @@ package hanadiskbackup
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/exec"
@@ -42,7 +43,10 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/gce/fake"
 	"github.com/GoogleCloudPlatform/sapagent/shared/gce"
+	"github.com/GoogleCloudPlatform/sapagent/shared/gce/metadataserver"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
 )
 
 func TestMain(t *testing.M) {
@@ -108,8 +112,9 @@ type fakeSnapshot interface {
 }
 
 type mockDiskCreateSnapshot struct {
-	doErr     error
-	operation *compute.Operation
+	doErr      error
+	operation  *compute.Operation
+	guestFlush bool
 }
 
 func (m *mockDiskCreateSnapshot) Context(ctx context.Context) *compute.DisksCreateSnapshotCall {
@@ -124,7 +129,8 @@ func (m *mockDiskCreateSnapshot) Fields(...googleapi.Field) *compute.DisksCreate
 	return &compute.DisksCreateSnapshotCall{}
 }
 
-func (m *mockDiskCreateSnapshot) GuestFlush(bool) *compute.DisksCreateSnapshotCall {
+func (m *mockDiskCreateSnapshot) GuestFlush(guestFlush bool) *compute.DisksCreateSnapshotCall {
+	m.guestFlush = guestFlush
 	return &compute.DisksCreateSnapshotCall{}
 }
 
@@ -144,6 +150,121 @@ func createDiskSnapshotSuccess(*compute.Snapshot) fakeDiskCreateSnapshotCall {
 	return &mockDiskCreateSnapshot{doErr: nil, operation: &compute.Operation{}}
 }
 
+// createDiskSnapshotFailInLocation fails the snapshot creation when it is created in location.
+func createDiskSnapshotFailInLocation(location string) diskSnapshotFunc {
+	return func(snapshot *compute.Snapshot) fakeDiskCreateSnapshotCall {
+		if snapshot.StorageLocations[0] == location {
+			return &mockDiskCreateSnapshot{doErr: storageLocationError(location)}
+		}
+		return &mockDiskCreateSnapshot{operation: &compute.Operation{}}
+	}
+}
+
+// storageLocationError returns the compute API error for a snapshot rejected in location.
+func storageLocationError(location string) error {
+	return &googleapi.Error{
+		Code:   http.StatusBadRequest,
+		Errors: []googleapi.ErrorItem{{Reason: "invalid", Message: fmt.Sprintf("Invalid value for field 'resource.storageLocations[0]': '%s'.", location)}},
+	}
+}
+
+func TestIsStorageLocationError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "InvalidLocation",
+			err:  storageLocationError("us-east1"),
+			want: true,
+		},
+		{
+			name: "WrappedInvalidLocation",
+			err:  fmt.Errorf("failed to create snapshot: %w", storageLocationError("us-east1")),
+			want: true,
+		},
+		{
+			name: "OrganizationPolicy",
+			err: &googleapi.Error{
+				Code:   http.StatusPreconditionFailed,
+				Errors: []googleapi.ErrorItem{{Reason: "conditionNotMet", Message: "Location us-east1 violates constraint constraints/gcp.resourceLocations"}},
+			},
+			want: true,
+		},
+		{
+			name: "PermissionDenied",
+			err: &googleapi.Error{
+				Code:   http.StatusForbidden,
+				Errors: []googleapi.ErrorItem{{Reason: "forbidden", Message: "Required 'compute.snapshots.create' permission"}},
+			},
+		},
+		{
+			name: "NotAnAPIError",
+			err:  errors.New("storage location us-east1 is unavailable"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isStorageLocationError(tc.err); got != tc.want {
+				t.Errorf("isStorageLocationError(%v) = %t, want: %t", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCreateBackupNoFallbackAfterInsert(t *testing.T) {
+	s := &Snapshot{
+		computeService:  &compute.Service{},
+		gceService:      &fake.TestGCE{CreationCompletionErr: storageLocationError("us-east1")},
+		StorageLocation: "us-east1,us-central1",
+		oteLogger:       defaultOTELogger,
+	}
+	var locations []string
+	createSnapshot := func(snapshot *compute.Snapshot) fakeDiskCreateSnapshotCall {
+		locations = append(locations, snapshot.StorageLocations[0])
+		return &mockDiskCreateSnapshot{operation: &compute.Operation{}}
+	}
+	if _, err := s.createBackup(context.Background(), &compute.Snapshot{}, createSnapshot); err == nil {
+		t.Fatal("createBackup() succeeded, want error")
+	}
+	if diff := cmp.Diff([]string{"us-east1"}, locations); diff != "" {
+		t.Errorf("createBackup() created the snapshot in unexpected locations (-want +got):\n%s", diff)
+	}
+}
+
+func TestStorageLocations(t *testing.T) {
+	tests := []struct {
+		name            string
+		storageLocation string
+		want            []string
+	}{
+		{
+			name: "Empty",
+			want: []string{""},
+		},
+		{
+			name:            "SingleLocation",
+			storageLocation: "us-east1",
+			want:            []string{"us-east1"},
+		},
+		{
+			name:            "OrderedList",
+			storageLocation: "us-east1, us ,,asia",
+			want:            []string{"us-east1", "us", "asia"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Snapshot{StorageLocation: tc.storageLocation}
+			if diff := cmp.Diff(tc.want, s.storageLocations()); diff != "" {
+				t.Errorf("storageLocations() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSnapshotHandler(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -152,6 +273,7 @@ func TestSnapshotHandler(t *testing.T) {
 		fakeComputeService onetime.ComputeServiceFunc
 		checkDataDir       checkDataDirFunc
 		want               subcommands.ExitStatus
+		wantCategory       onetime.ErrorCategory
 	}{
 		{
 			name:       "GCEServiceCreationFailure",
@@ -160,7 +282,8 @@ func TestSnapshotHandler(t *testing.T) {
 			checkDataDir: func(context.Context, commandlineexecutor.Execute) (string, string, string, error) {
 				return "", "", "", cmpopts.AnyError
 			},
-			want: subcommands.ExitFailure,
+			want:         onetime.ExitGCEAPIError,
+			wantCategory: onetime.ErrorCategoryGCEAPI,
 		},
 		{
 			name:               "ComputeServiceCreationFailure",
@@ -170,7 +293,8 @@ func TestSnapshotHandler(t *testing.T) {
 			checkDataDir: func(context.Context, commandlineexecutor.Execute) (string, string, string, error) {
 				return "", "", "", nil
 			},
-			want: subcommands.ExitFailure,
+			want:         onetime.ExitGCEAPIError,
+			wantCategory: onetime.ErrorCategoryGCEAPI,
 		},
 		{
 			name:               "CheckDataDirFailure",
@@ -180,7 +304,8 @@ func TestSnapshotHandler(t *testing.T) {
 			checkDataDir: func(context.Context, commandlineexecutor.Execute) (string, string, string, error) {
 				return "", "", "", cmpopts.AnyError
 			},
-			want: subcommands.ExitFailure,
+			want:         onetime.ExitPreconditionFailure,
+			wantCategory: onetime.ErrorCategoryPrecondition,
 		},
 	}
 	for _, test := range tests {
@@ -190,6 +315,127 @@ func TestSnapshotHandler(t *testing.T) {
 			if got != test.want {
 				t.Errorf("snapshotHandler(%v)=%v want %v", test.name, got, test.want)
 			}
+			if test.snapshot.errorCategory != test.wantCategory {
+				t.Errorf("snapshotHandler(%v) error category=%v want %v", test.name, test.snapshot.errorCategory, test.wantCategory)
+			}
+		})
+	}
+}
+
+func TestCheckTmpfsDataPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot Snapshot
+		exec     commandlineexecutor.Execute
+		wantErr  error
+	}{
+		{
+			name:     "NoDataPath",
+			snapshot: Snapshot{},
+		},
+		{
+			name:     "FindmntFailure",
+			snapshot: Snapshot{hanaDataPath: "/hana/data/ABC"},
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{ExitCode: 2, Error: cmpopts.AnyError}
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:     "NoTmpfsUnderDataPath",
+			snapshot: Snapshot{hanaDataPath: "/hana/data/ABC"},
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "/dev/shm\n/hana/tmpfs0/ABC\n"}
+			},
+		},
+		{
+			name:     "TmpfsUnderDataPath",
+			snapshot: Snapshot{hanaDataPath: "/hana/data/ABC"},
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "/dev/shm\n/hana/data/ABC/fastrestart\n"}
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:     "TmpfsUnderDataPathIgnored",
+			snapshot: Snapshot{hanaDataPath: "/hana/data/ABC", IgnoreTmpfsData: true},
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "/dev/shm\n/hana/data/ABC/fastrestart\n"}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.snapshot.oteLogger = defaultOTELogger
+			gotErr := test.snapshot.checkTmpfsDataPath(context.Background(), test.exec)
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("checkTmpfsDataPath()=%v, want=%v", gotErr, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckDataPathOverride(t *testing.T) {
+	mountPoint := t.TempDir()
+	tests := []struct {
+		name             string
+		snapshot         Snapshot
+		exec             commandlineexecutor.Execute
+		wantDataPath     string
+		wantPhysicalPath string
+		wantErr          error
+	}{
+		{
+			name:     "PathDoesNotExist",
+			snapshot: Snapshot{HANADataPath: mountPoint + "/missing"},
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "NotAMountPoint",
+			snapshot: Snapshot{HANADataPath: mountPoint},
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{ExitCode: 1, Error: cmpopts.AnyError}
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:     "NotLVM",
+			snapshot: Snapshot{HANADataPath: mountPoint},
+			exec: func(_ context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+				if params.Executable == "findmnt" {
+					return commandlineexecutor.Result{StdOut: mountPoint + "\n"}
+				}
+				return commandlineexecutor.Result{StdOut: "/dev/sdb\n"}
+			},
+			wantDataPath: mountPoint,
+			wantErr:      cmpopts.AnyError,
+		},
+		{
+			name:     "Success",
+			snapshot: Snapshot{HANADataPath: mountPoint},
+			exec: func(_ context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+				switch {
+				case params.Executable == "findmnt":
+					return commandlineexecutor.Result{StdOut: mountPoint + "\n"}
+				case strings.Contains(params.ArgsToSplit, "lvdisplay"):
+					return commandlineexecutor.Result{StdOut: "/dev/sdb\n"}
+				default:
+					return commandlineexecutor.Result{StdOut: "/dev/mapper/vg-data\n"}
+				}
+			},
+			wantDataPath:     mountPoint,
+			wantPhysicalPath: "/dev/sdb",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotDataPath, _, gotPhysicalPath, gotErr := test.snapshot.checkDataPathOverride(context.Background(), test.exec)
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("checkDataPathOverride()=%v, want=%v", gotErr, test.wantErr)
+			}
+			if gotDataPath != test.wantDataPath || gotPhysicalPath != test.wantPhysicalPath {
+				t.Errorf("checkDataPathOverride()=(%q, %q), want=(%q, %q)", gotDataPath, gotPhysicalPath, test.wantDataPath, test.wantPhysicalPath)
+			}
 		})
 	}
 }
@@ -198,6 +444,7 @@ func TestReadDiskMapping(t *testing.T) {
 	tests := []struct {
 		name     string
 		snapshot Snapshot
+		exec     commandlineexecutor.Execute
 		want     error
 	}{
 		{
@@ -223,6 +470,54 @@ func TestReadDiskMapping(t *testing.T) {
 			},
 			want: cmpopts.AnyError,
 		},
+		{
+			name: "FailurePhysicalDevices",
+			snapshot: Snapshot{
+				SnapshotName: "snapshot",
+				gceService: &fake.TestGCE{
+					GetInstanceResp: []*compute.Instance{{
+						Disks: []*compute.AttachedDisk{
+							{
+								Source:     "/some/path/disk-name",
+								DeviceName: "disk-device-name",
+								Type:       "PERSISTENT",
+							},
+						},
+					}},
+					GetInstanceErr: []error{nil},
+					ListDisksResp:  []*compute.DiskList{{}},
+					ListDisksErr:   []error{nil},
+				},
+			},
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: cmpopts.AnyError}
+			},
+			want: cmpopts.AnyError,
+		},
+		{
+			name: "FailureNoMatchingDisk",
+			snapshot: Snapshot{
+				SnapshotName: "snapshot",
+				gceService: &fake.TestGCE{
+					GetInstanceResp: []*compute.Instance{{
+						Disks: []*compute.AttachedDisk{
+							{
+								Source:     "/some/path/disk-name",
+								DeviceName: "disk-device-name",
+								Type:       "PERSISTENT",
+							},
+						},
+					}},
+					GetInstanceErr: []error{nil},
+					ListDisksResp:  []*compute.DiskList{{}},
+					ListDisksErr:   []error{nil},
+				},
+			},
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "  /dev/sdz(0)\n"}
+			},
+			want: cmpopts.AnyError,
+		},
 		{
 			name: "Success",
 			snapshot: Snapshot{
@@ -261,6 +556,9 @@ func TestReadDiskMapping(t *testing.T) {
 					ListDisksErr: []error{nil},
 				},
 			},
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "  /dev/unknown(0)\n"}
+			},
 			want: nil,
 		},
 		{
@@ -300,6 +598,9 @@ func TestReadDiskMapping(t *testing.T) {
 					ListDisksErr: []error{nil},
 				},
 			},
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "  /dev/unknown(0)\n"}
+			},
 			want: nil,
 		},
 	}
@@ -307,7 +608,7 @@ func TestReadDiskMapping(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			oldSnapshot := test.snapshot.SnapshotName
 			test.snapshot.oteLogger = defaultOTELogger
-			got := test.snapshot.readDiskMapping(context.Background(), defaultCloudProperties)
+			got := test.snapshot.readDiskMapping(context.Background(), defaultCloudProperties, test.exec)
 			if !cmp.Equal(got, test.want, cmpopts.EquateErrors()) {
 				t.Errorf("readDiskMapping()=%v, want=%v", got, test.want)
 			}
@@ -322,6 +623,138 @@ func TestReadDiskMapping(t *testing.T) {
 	}
 }
 
+func TestRefreshStaleDiskMapping(t *testing.T) {
+	tests := []struct {
+		name      string
+		snapshot  Snapshot
+		wantDisks []string
+		want      error
+	}{
+		{
+			name: "MappingNotRead",
+			snapshot: Snapshot{
+				disks:      []string{"pd-1"},
+				gceService: &fake.TestGCE{DiskAttachedToInstanceErr: cmpopts.AnyError},
+			},
+			wantDisks: []string{"pd-1"},
+		},
+		{
+			name: "DisksAttached",
+			snapshot: Snapshot{
+				disks:           []string{"pd-1", "pd-2"},
+				diskMappingRead: true,
+				gceService:      &fake.TestGCE{IsDiskAttached: true},
+			},
+			wantDisks: []string{"pd-1", "pd-2"},
+		},
+		{
+			name: "AttachedCheckFailure",
+			snapshot: Snapshot{
+				disks:           []string{"pd-1"},
+				diskMappingRead: true,
+				gceService:      &fake.TestGCE{DiskAttachedToInstanceErr: cmpopts.AnyError},
+			},
+			wantDisks: []string{"pd-1"},
+			want:      cmpopts.AnyError,
+		},
+		{
+			name: "StaleRefreshFailure",
+			snapshot: Snapshot{
+				disks:           []string{"pd-1"},
+				diskMappingRead: true,
+				gceService: &fake.TestGCE{
+					IsDiskAttached:  false,
+					GetInstanceResp: []*compute.Instance{nil},
+					GetInstanceErr:  []error{cmpopts.AnyError},
+				},
+			},
+			want: cmpopts.AnyError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.snapshot.oteLogger = defaultOTELogger
+			got := test.snapshot.refreshStaleDiskMapping(context.Background(), defaultCloudProperties, nil)
+			if !cmp.Equal(got, test.want, cmpopts.EquateErrors()) {
+				t.Errorf("refreshStaleDiskMapping()=%v, want=%v", got, test.want)
+			}
+			if diff := cmp.Diff(test.wantDisks, test.snapshot.disks, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("refreshStaleDiskMapping() returned unexpected disks diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRefreshStaleDiskMappingSnapshotName(t *testing.T) {
+	staleGCE := func() *fake.TestGCE {
+		return &fake.TestGCE{
+			IsDiskAttached: false,
+			GetInstanceResp: []*compute.Instance{{
+				Disks: []*compute.AttachedDisk{
+					{
+						Source:     "/some/path/disk-name",
+						DeviceName: "disk-device-name",
+						Type:       "PERSISTENT",
+					},
+				},
+			}},
+			GetInstanceErr: []error{nil},
+			ListDisksResp: []*compute.DiskList{
+				{
+					Items: []*compute.Disk{
+						{
+							Name: "disk-name",
+							Type: "/some/path/device-type",
+						},
+					},
+				},
+			},
+			ListDisksErr: []error{nil},
+		}
+	}
+	exec := func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+		return commandlineexecutor.Result{StdOut: "  /dev/unknown(0)\n"}
+	}
+	tests := []struct {
+		name             string
+		snapshot         Snapshot
+		wantSnapshotName string
+	}{
+		{
+			name: "DefaultNameRegenerated",
+			snapshot: Snapshot{
+				SnapshotName:          "snapshot-old-disk-20240101-000000",
+				snapshotNameGenerated: true,
+				disks:                 []string{"old-disk"},
+				diskMappingRead:       true,
+				gceService:            staleGCE(),
+			},
+			wantSnapshotName: "^snapshot-disk-name-\\d{8}-\\d{6}$",
+		},
+		{
+			name: "UserNameKept",
+			snapshot: Snapshot{
+				SnapshotName:    "user-snapshot",
+				disks:           []string{"old-disk"},
+				diskMappingRead: true,
+				gceService:      staleGCE(),
+			},
+			wantSnapshotName: "^user-snapshot$",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.snapshot.oteLogger = defaultOTELogger
+			if err := test.snapshot.refreshStaleDiskMapping(context.Background(), defaultCloudProperties, exec); err != nil {
+				t.Fatalf("refreshStaleDiskMapping() returned error: %v", err)
+			}
+			if !regexp.MustCompile(test.wantSnapshotName).MatchString(test.snapshot.SnapshotName) {
+				t.Errorf("refreshStaleDiskMapping() snapshot name = %s, want match for %s", test.snapshot.SnapshotName, test.wantSnapshotName)
+			}
+		})
+	}
+}
+
 func TestParseLabels(t *testing.T) {
 	tests := []struct {
 		name string
@@ -342,6 +775,14 @@ func TestParseLabels(t *testing.T) {
 			},
 			want: map[string]string{"label1": "value1", "label2": "value2"},
 		},
+		{
+			name: "SID",
+			s: Snapshot{
+				Sid:    "ABC",
+				Labels: "label1=value1",
+			},
+			want: map[string]string{"goog-sapagent-sid": "abc", "label1": "value1"},
+		},
 		{
 			name: "GroupSnapshot",
 			s: Snapshot{
@@ -535,6 +976,18 @@ func TestValidateParameters(t *testing.T) {
 			},
 			wantErr: cmpopts.AnyError,
 		},
+		{
+			name: "RelativeHANADataPath",
+			snapshot: Snapshot{
+				Port:         "123",
+				Sid:          "HDB",
+				HanaDBUser:   "system",
+				Password:     "password",
+				SnapshotType: "STANDARD",
+				HANADataPath: "hana/data",
+			},
+			wantErr: cmpopts.AnyError,
+		},
 		{
 			name: "EmptyUser",
 			snapshot: Snapshot{
@@ -683,6 +1136,46 @@ func TestValidateParameters(t *testing.T) {
 			},
 			wantErr: cmpopts.AnyError,
 		},
+		{
+			name: "InvalidConfirmMode",
+			snapshot: Snapshot{
+				Sid:                     "HDB",
+				HDBUserstoreKey:         "hdbuserstore-key",
+				SnapshotType:            "STANDARD",
+				ConfirmDataSnapshotMode: "AFTER_RESTORE",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "InvalidSourceDisks",
+			snapshot: Snapshot{
+				Port:            "123",
+				Sid:             "HDB",
+				HDBUserstoreKey: "hdbuserstore-key",
+				SnapshotType:    "STANDARD",
+				SourceDisks:     "pd-1",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "InvalidSnapshotNameTemplate",
+			snapshot: Snapshot{
+				Port:                 "123",
+				Sid:                  "HDB",
+				HDBUserstoreKey:      "hdbuserstore-key",
+				SnapshotType:         "STANDARD",
+				SnapshotNameTemplate: "{sid}_{disk}",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "ConfirmModeForChangeDiskType",
+			snapshot: Snapshot{
+				SkipDBSnapshotForChangeDiskType: true,
+				ConfirmDataSnapshotMode:         confirmAfterUpload,
+			},
+			wantErr: cmpopts.AnyError,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -698,9 +1191,364 @@ func TestValidateParameters(t *testing.T) {
 	}
 }
 
-func TestDefaults(t *testing.T) {
+func TestUserstoreKeyOnlyConnection(t *testing.T) {
 	s := Snapshot{
-		Port:           "123",
+		Host:            "localhost",
+		Sid:             "HDB",
+		HDBUserstoreKey: "REMOTEKEY",
+		Disk:            "pd-1",
+		DiskZone:        "us-east1-a",
+		SnapshotType:    "STANDARD",
+	}
+	if err := s.validateParameters("linux", defaultCloudProperties); err != nil {
+		t.Fatalf("validateParameters() with only -hdbuserstore-key returned unexpected error: %v", err)
+	}
+
+	dbp := s.dbParams(context.Background())
+	if dbp.Host != "" || dbp.Port != "" || dbp.HDBUserKey != "REMOTEKEY" {
+		t.Errorf("dbParams()=(Host: %q, Port: %q, HDBUserKey: %q), want (Host: \"\", Port: \"\", HDBUserKey: \"REMOTEKEY\")", dbp.Host, dbp.Port, dbp.HDBUserKey)
+	}
+	db, err := databaseconnector.CreateDBHandle(context.Background(), dbp)
+	if err != nil {
+		t.Fatalf("CreateDBHandle() returned unexpected error: %v", err)
+	}
+
+	var gotArgs []string
+	exec := func(_ context.Context, p commandlineexecutor.Params) commandlineexecutor.Result {
+		gotArgs = p.Args
+		return commandlineexecutor.Result{StdOut: "1\n"}
+	}
+	if _, err := db.Query(context.Background(), "SELECT 1 FROM DUMMY", exec); err != nil {
+		t.Fatalf("Query() returned unexpected error: %v", err)
+	}
+	want := []string{"-i", "-u", "hdbadm", "hdbsql", "-U", "REMOTEKEY"}
+	if len(gotArgs) < len(want) || !cmp.Equal(gotArgs[:len(want)], want) {
+		t.Errorf("Query() ran sudo with args %v, want prefix %v", gotArgs, want)
+	}
+	for _, a := range gotArgs {
+		if a == "localhost" {
+			t.Errorf("Query() ran sudo with args %v, want no host override of the userstore key", gotArgs)
+		}
+	}
+}
+
+func TestValidateSnapshotWait(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot Snapshot
+		wantErr  error
+	}{
+		{
+			name: "Defaults",
+		},
+		{
+			name:     "ValidBounds",
+			snapshot: Snapshot{SnapshotPollIntervalSeconds: 600, SnapshotMaxWaitSeconds: 86400},
+		},
+		{
+			name:     "OnlyMaxWait",
+			snapshot: Snapshot{SnapshotMaxWaitSeconds: 28800},
+		},
+		{
+			name:     "NegativeInterval",
+			snapshot: Snapshot{SnapshotPollIntervalSeconds: -1},
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "IntervalTooLong",
+			snapshot: Snapshot{SnapshotPollIntervalSeconds: 601},
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "MaxWaitTooLong",
+			snapshot: Snapshot{SnapshotMaxWaitSeconds: 86401},
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "MaxWaitLessThanInterval",
+			snapshot: Snapshot{SnapshotPollIntervalSeconds: 60, SnapshotMaxWaitSeconds: 30},
+			wantErr:  cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.snapshot.validateSnapshotWait(); !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("validateSnapshotWait() = %v, want: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveProjectAndZone(t *testing.T) {
+	configFile := func(content string, err error) configuration.ReadConfigFile {
+		return func(string) ([]byte, error) { return []byte(content), err }
+	}
+	metadata := func() *metadataserver.CloudProperties {
+		return &metadataserver.CloudProperties{ProjectID: "metadata-project", Zone: "metadata-zone"}
+	}
+	tests := []struct {
+		name                 string
+		snapshot             Snapshot
+		cp                   *ipb.CloudProperties
+		readConfigFile       configuration.ReadConfigFile
+		fetchCloudProperties func() *metadataserver.CloudProperties
+		wantProject          string
+		wantZone             string
+	}{
+		{
+			name:                 "Flags",
+			snapshot:             Snapshot{Project: "flag-project", DiskZone: "flag-zone"},
+			cp:                   defaultCloudProperties,
+			readConfigFile:       configFile(`{"cloud_properties": {"project_id": "config-project", "zone": "config-zone"}}`, nil),
+			fetchCloudProperties: metadata,
+			wantProject:          "flag-project",
+			wantZone:             "flag-zone",
+		},
+		{
+			name:                 "ConfigurationFile",
+			snapshot:             Snapshot{Project: "flag-project"},
+			cp:                   defaultCloudProperties,
+			readConfigFile:       configFile(`{"cloud_properties": {"project_id": "config-project", "zone": "config-zone"}}`, nil),
+			fetchCloudProperties: metadata,
+			wantProject:          "flag-project",
+			wantZone:             "config-zone",
+		},
+		{
+			name:                 "CloudProperties",
+			cp:                   defaultCloudProperties,
+			readConfigFile:       configFile("", errors.New("no such file")),
+			fetchCloudProperties: metadata,
+			wantProject:          "default-project",
+			wantZone:             "default-zone",
+		},
+		{
+			name:                 "InvalidConfigurationFile",
+			cp:                   defaultCloudProperties,
+			readConfigFile:       configFile("{", nil),
+			fetchCloudProperties: metadata,
+			wantProject:          "default-project",
+			wantZone:             "default-zone",
+		},
+		{
+			name:                 "MetadataServer",
+			cp:                   &ipb.CloudProperties{ProjectId: "default-project"},
+			readConfigFile:       configFile("", errors.New("no such file")),
+			fetchCloudProperties: metadata,
+			wantProject:          "default-project",
+			wantZone:             "metadata-zone",
+		},
+		{
+			name:                 "MetadataServerUnavailable",
+			cp:                   &ipb.CloudProperties{},
+			fetchCloudProperties: func() *metadataserver.CloudProperties { return nil },
+		},
+		{
+			name:        "NoSourcesSet",
+			cp:          defaultCloudProperties,
+			wantProject: "default-project",
+			wantZone:    "default-zone",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.snapshot.readConfigFile = tc.readConfigFile
+			tc.snapshot.fetchCloudProperties = tc.fetchCloudProperties
+			tc.snapshot.resolveProjectAndZone(tc.cp)
+			if tc.snapshot.Project != tc.wantProject || tc.snapshot.DiskZone != tc.wantZone {
+				t.Errorf("resolveProjectAndZone() set project %q and zone %q, want %q and %q", tc.snapshot.Project, tc.snapshot.DiskZone, tc.wantProject, tc.wantZone)
+			}
+		})
+	}
+}
+
+func TestParseSourceDisks(t *testing.T) {
+	tests := []struct {
+		name      string
+		snapshot  Snapshot
+		wantDisks []string
+		wantDisk  string
+		wantErr   error
+	}{
+		{
+			name: "NotPassed",
+		},
+		{
+			name:      "MultipleDisks",
+			snapshot:  Snapshot{SourceDisks: "pd-1, pd-2,,pd-1,pd-3"},
+			wantDisks: []string{"pd-1", "pd-2", "pd-3"},
+			wantDisk:  "pd-1",
+		},
+		{
+			name:     "SingleDisk",
+			snapshot: Snapshot{SourceDisks: "pd-1,"},
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "SourceDiskAlsoPassed",
+			snapshot: Snapshot{SourceDisks: "pd-1,pd-2", Disk: "pd-3"},
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "ChangeDiskTypeWorkflow",
+			snapshot: Snapshot{SourceDisks: "pd-1,pd-2", SkipDBSnapshotForChangeDiskType: true},
+			wantErr:  cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.snapshot.parseSourceDisks()
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("parseSourceDisks()=%v, want=%v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantDisks, tc.snapshot.disks); diff != "" {
+				t.Errorf("parseSourceDisks() returned diff in disks (-want +got):\n%s", diff)
+			}
+			if tc.snapshot.Disk != tc.wantDisk {
+				t.Errorf("parseSourceDisks() set Disk=%q, want=%q", tc.snapshot.Disk, tc.wantDisk)
+			}
+		})
+	}
+}
+
+func TestDefaultSnapshotName(t *testing.T) {
+	now := time.Date(2024, 3, 7, 9, 5, 1, 0, time.UTC)
+	tests := []struct {
+		name     string
+		snapshot Snapshot
+		disk     string
+		want     string
+		wantErr  error
+	}{
+		{
+			name: "NoTemplate",
+			disk: "pd-1",
+			want: "snapshot-pd-1-20240307-090501",
+		},
+		{
+			name:     "AllPlaceholders",
+			snapshot: Snapshot{Sid: "HDB", SnapshotNameTemplate: "{sid}-{host}-{disk}-{date}{time}"},
+			disk:     "pd-1",
+			want:     "hdb-default-instance-pd-1-20240307090501",
+		},
+		{
+			name:     "UnknownPlaceholderLeftAsIs",
+			snapshot: Snapshot{SnapshotNameTemplate: "backup-{month}"},
+			disk:     "pd-1",
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "StartsWithDigit",
+			snapshot: Snapshot{SnapshotNameTemplate: "{date}-{disk}"},
+			disk:     "pd-1",
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "Uppercase",
+			snapshot: Snapshot{SnapshotNameTemplate: "Backup-{disk}"},
+			disk:     "pd-1",
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "EndsWithHyphen",
+			snapshot: Snapshot{SnapshotNameTemplate: "backup-{disk}-"},
+			disk:     "pd-1",
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "TooLong",
+			snapshot: Snapshot{SnapshotNameTemplate: "backup-{host}-{disk}-{date}-{time}"},
+			disk:     strings.Repeat("d", 40),
+			wantErr:  cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.snapshot.defaultSnapshotName(tc.disk, defaultCloudProperties.GetInstanceName(), now)
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("defaultSnapshotName(%q)=%v, want=%v", tc.disk, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("defaultSnapshotName(%q)=%q, want=%q", tc.disk, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateSnapshotNameTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot Snapshot
+		wantErr  error
+	}{
+		{
+			name: "NoTemplate",
+		},
+		{
+			name:     "DiskReadFromMapping",
+			snapshot: Snapshot{SnapshotNameTemplate: "{host}-{disk}-{date}"},
+		},
+		{
+			name:     "SnapshotNameAlsoPassed",
+			snapshot: Snapshot{SnapshotNameTemplate: "{host}-{disk}", SnapshotName: "snapshot"},
+			wantErr:  cmpopts.AnyError,
+		},
+		{
+			name:     "InvalidWithSourceDisk",
+			snapshot: Snapshot{SnapshotNameTemplate: "{disk}-{date}", Disk: "1-pd"},
+			wantErr:  cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.snapshot.validateSnapshotNameTemplate(defaultCloudProperties); !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("validateSnapshotNameTemplate()=%v, want=%v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfirmMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot Snapshot
+		want     string
+	}{
+		{
+			name:     "ConfirmAfterCreate",
+			snapshot: Snapshot{ConfirmDataSnapshotAfterCreate: true},
+			want:     confirmAfterCreate,
+		},
+		{
+			name:     "ConfirmAfterUpload",
+			snapshot: Snapshot{ConfirmDataSnapshotAfterCreate: false},
+			want:     confirmAfterUpload,
+		},
+		{
+			name:     "ChangeDiskType",
+			snapshot: Snapshot{ConfirmDataSnapshotAfterCreate: true, SkipDBSnapshotForChangeDiskType: true},
+			want:     confirmNone,
+		},
+		{
+			name:     "ExplicitModeTakesPrecedence",
+			snapshot: Snapshot{ConfirmDataSnapshotAfterCreate: true, ConfirmDataSnapshotMode: "after_upload"},
+			want:     confirmAfterUpload,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.snapshot.confirmMode(); got != test.want {
+				t.Errorf("confirmMode()=%s, want=%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	s := Snapshot{
+		Port:           "123",
 		Sid:            "HDB",
 		Project:        "",
 		HanaDBUser:     "system",
@@ -919,6 +1767,25 @@ func TestRunWorkflowForDiskSnapshot(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "ConfirmModeNone",
+			snapshot: Snapshot{
+				AbandonPrepared:         true,
+				ConfirmDataSnapshotMode: confirmNone,
+				gceService: &fake.TestGCE{
+					IsDiskAttached: true,
+				},
+				computeService: &compute.Service{},
+			},
+			createSnapshot: createDiskSnapshotSuccess,
+			run: func(ctx context.Context, h *databaseconnector.DBHandle, q string) (string, error) {
+				if strings.Contains(q, " SUCCESSFUL ") {
+					return "", cmpopts.AnyError
+				}
+				return "1234", nil
+			},
+			want: nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -970,6 +1837,7 @@ func TestCreateBackup(t *testing.T) {
 				computeService: &compute.Service{},
 				gceService:     &fake.TestGCE{CreationCompletionErr: cmpopts.AnyError},
 			},
+			snapshot:       &compute.Snapshot{},
 			createSnapshot: createDiskSnapshotFail,
 			wantOp:         nil,
 			wantErr:        cmpopts.AnyError,
@@ -980,10 +1848,47 @@ func TestCreateBackup(t *testing.T) {
 				computeService: &compute.Service{},
 				gceService:     &fake.TestGCE{CreationCompletionErr: nil},
 			},
+			snapshot:       &compute.Snapshot{},
 			createSnapshot: createDiskSnapshotSuccess,
 			wantOp:         &compute.Operation{},
 			wantErr:        nil,
 		},
+		{
+			name: "FallbackToNextStorageLocation",
+			s: &Snapshot{
+				computeService:  &compute.Service{},
+				gceService:      &fake.TestGCE{CreationCompletionErr: nil},
+				StorageLocation: "us-east1, us-central1",
+			},
+			snapshot:       &compute.Snapshot{},
+			createSnapshot: createDiskSnapshotFailInLocation("us-east1"),
+			wantOp:         &compute.Operation{},
+			wantErr:        nil,
+		},
+		{
+			name: "AllStorageLocationsFail",
+			s: &Snapshot{
+				computeService:  &compute.Service{},
+				gceService:      &fake.TestGCE{CreationCompletionErr: nil},
+				StorageLocation: "us-east1,us-east1",
+			},
+			snapshot:       &compute.Snapshot{},
+			createSnapshot: createDiskSnapshotFailInLocation("us-east1"),
+			wantOp:         nil,
+			wantErr:        cmpopts.AnyError,
+		},
+		{
+			name: "NoFallbackForOtherErrors",
+			s: &Snapshot{
+				computeService:  &compute.Service{},
+				gceService:      &fake.TestGCE{CreationCompletionErr: nil},
+				StorageLocation: "us-east1,us-central1",
+			},
+			snapshot:       &compute.Snapshot{},
+			createSnapshot: createDiskSnapshotFail,
+			wantOp:         nil,
+			wantErr:        cmpopts.AnyError,
+		},
 	}
 
 	ctx := context.Background()
@@ -998,6 +1903,146 @@ func TestCreateBackup(t *testing.T) {
 			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
 				t.Errorf("buildSnapshot() returned diff (-want +got):\n%s", diff)
 			}
+			if gotRecorded, wantRecorded := tc.s.snapshotCreationTime > 0, err == nil; gotRecorded != wantRecorded {
+				t.Errorf("buildSnapshot() recorded snapshot creation time %v, want recorded: %t", tc.s.snapshotCreationTime, wantRecorded)
+			}
+		})
+	}
+}
+
+func TestCreateSnapshotInLocationGuestFlush(t *testing.T) {
+	tests := []struct {
+		name             string
+		guestFlush       bool
+		freezeFileSystem bool
+		want             bool
+	}{
+		{
+			name: "Default",
+		},
+		{
+			name:       "GuestFlush",
+			guestFlush: true,
+			want:       true,
+		},
+		{
+			name:             "FreezeFileSystemTakesPrecedence",
+			guestFlush:       true,
+			freezeFileSystem: true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Snapshot{
+				GuestFlush:       tc.guestFlush,
+				FreezeFileSystem: tc.freezeFileSystem,
+				gceService:       &fake.TestGCE{CreationCompletionErr: nil},
+			}
+			call := &mockDiskCreateSnapshot{operation: &compute.Operation{}}
+			createSnapshot := func(*compute.Snapshot) fakeDiskCreateSnapshotCall { return call }
+			if _, _, err := s.createSnapshotInLocation(ctx, &compute.Snapshot{}, createSnapshot); err != nil {
+				t.Fatalf("createSnapshotInLocation() returned error: %v", err)
+			}
+			if call.guestFlush != tc.want {
+				t.Errorf("createSnapshotInLocation() requested guest flush: %t, want: %t", call.guestFlush, tc.want)
+			}
+		})
+	}
+}
+
+func TestCreateSnapshotInLocationCrossProject(t *testing.T) {
+	s := &Snapshot{
+		Project:     "snapshot-project",
+		DiskProject: "disk-project",
+		DiskZone:    "us-east1-b",
+		Disk:        "pd-1",
+		GuestFlush:  true,
+		gceService:  &fake.TestGCE{CreateSnapshotOp: &compute.Operation{}},
+	}
+	createSnapshot := func(*compute.Snapshot) fakeDiskCreateSnapshotCall {
+		t.Errorf("createSnapshotInLocation() created the snapshot in the project of the disk, want it inserted in the snapshot project")
+		return &mockDiskCreateSnapshot{operation: &compute.Operation{}}
+	}
+	snapshot := &compute.Snapshot{}
+	if _, _, err := s.createSnapshotInLocation(context.Background(), snapshot, createSnapshot); err != nil {
+		t.Fatalf("createSnapshotInLocation() returned error: %v", err)
+	}
+	want := &compute.Snapshot{
+		SourceDisk: "https://www.googleapis.com/compute/v1/projects/disk-project/zones/us-east1-b/disks/pd-1",
+	}
+	if diff := cmp.Diff(want, snapshot); diff != "" {
+		t.Errorf("createSnapshotInLocation() inserted unexpected snapshot (-want +got):\n%s", diff)
+	}
+}
+
+func TestCheckCrossProjectAccess(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       *Snapshot
+		wantErr error
+	}{
+		{
+			name:    "SameProject",
+			s:       &Snapshot{Project: "my-project", DiskProject: "my-project"},
+			wantErr: nil,
+		},
+		{
+			name:    "GroupSnapshot",
+			s:       &Snapshot{Project: "snapshot-project", DiskProject: "disk-project", groupSnapshot: true},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "DiskNotReadable",
+			s: &Snapshot{
+				Project:     "snapshot-project",
+				DiskProject: "disk-project",
+				gceService: &fake.TestGCE{
+					GetDiskResp: []*compute.Disk{nil},
+					GetDiskErr:  []error{cmpopts.AnyError},
+				},
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "SnapshotsNotListable",
+			s: &Snapshot{
+				Project:     "snapshot-project",
+				DiskProject: "disk-project",
+				gceService: &fake.TestGCE{
+					GetDiskResp:     []*compute.Disk{{}},
+					GetDiskErr:      []error{nil},
+					SnapshotListErr: cmpopts.AnyError,
+				},
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "Success",
+			s: &Snapshot{
+				Project:     "snapshot-project",
+				DiskProject: "disk-project",
+				DiskZone:    "us-east1-b",
+				Disk:        "pd-1",
+				gceService: &fake.TestGCE{
+					GetDiskResp: []*compute.Disk{{}},
+					GetDiskArgs: []*fake.GetDiskArguments{{Project: "disk-project", Zone: "us-east1-b", DiskName: "pd-1"}},
+					GetDiskErr:  []error{nil},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if g, ok := tc.s.gceService.(*fake.TestGCE); ok {
+				g.T = t
+			}
+			gotErr := tc.s.checkCrossProjectAccess(context.Background())
+			if !cmp.Equal(gotErr, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("checkCrossProjectAccess() = %v, want %v", gotErr, tc.wantErr)
+			}
 		})
 	}
 }
@@ -1075,12 +2120,88 @@ func TestSynopsisForSnapshot(t *testing.T) {
 	}
 }
 
+func TestReadParamsFile(t *testing.T) {
+	paramsFile := `{"sid": "HDB", "port": "30015", "snapshot-type": "ARCHIVE", "freeze-file-system": "true", "max-freeze-seconds": "60"}`
+	tests := []struct {
+		name     string
+		args     []string
+		readFile func(string) ([]byte, error)
+		want     Snapshot
+		wantErr  error
+	}{
+		{
+			name: "NoParamsFile",
+			args: []string{"-sid=ABC"},
+			readFile: func(string) ([]byte, error) {
+				return nil, os.ErrNotExist
+			},
+			want: Snapshot{Sid: "ABC", Host: "localhost", SnapshotType: "STANDARD", MaxFreezeSeconds: 300},
+		},
+		{
+			name: "ParamsFromFile",
+			args: []string{"-params-file=/etc/hanadiskbackup.json"},
+			readFile: func(string) ([]byte, error) {
+				return []byte(paramsFile), nil
+			},
+			want: Snapshot{Sid: "HDB", Port: "30015", Host: "localhost", SnapshotType: "ARCHIVE", FreezeFileSystem: true, MaxFreezeSeconds: 60},
+		},
+		{
+			name: "CommandLineTakesPrecedence",
+			args: []string{"-params-file=/etc/hanadiskbackup.json", "-sid=ABC", "-max-freeze-seconds=0"},
+			readFile: func(string) ([]byte, error) {
+				return []byte(paramsFile), nil
+			},
+			want: Snapshot{Sid: "ABC", Port: "30015", Host: "localhost", SnapshotType: "ARCHIVE", FreezeFileSystem: true},
+		},
+		{
+			name: "ReadFailure",
+			args: []string{"-params-file=/etc/hanadiskbackup.json"},
+			readFile: func(string) ([]byte, error) {
+				return nil, os.ErrNotExist
+			},
+			wantErr: os.ErrNotExist,
+		},
+		{
+			name: "InvalidJSON",
+			args: []string{"-params-file=/etc/hanadiskbackup.json"},
+			readFile: func(string) ([]byte, error) {
+				return []byte(`{"sid": 1}`), nil
+			},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := Snapshot{}
+			fs := flag.NewFlagSet("flags", flag.ContinueOnError)
+			s.SetFlags(fs)
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatalf("fs.Parse(%v) returned error: %v", tc.args, err)
+			}
+			err := s.readParamsFile(fs, tc.readFile)
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("readParamsFile() returned error: %v, want: %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			opts := cmpopts.IgnoreFields(Snapshot{}, "ParamsFile", "ConfirmDataSnapshotAfterCreate", "SendToMonitoring", "LogLevel")
+			if diff := cmp.Diff(tc.want, s, cmpopts.IgnoreUnexported(Snapshot{}), opts); diff != "" {
+				t.Errorf("readParamsFile() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSetFlagsForSnapshot(t *testing.T) {
 	snapshot := Snapshot{}
 	fs := flag.NewFlagSet("flags", flag.ExitOnError)
 	flags := []string{"project", "host", "port", "sid", "hana-db-user", "password", "password-secret",
 		"hdbuserstore-key", "snapshot-name", "source-disk", "source-disk-zone", "source-disk-key-file", "group-snapshot-name",
-		"snapshot-description", "send-metrics-to-monitoring", "storage-location", "confirm-data-snapshot-after-create"}
+		"snapshot-description", "send-metrics-to-monitoring", "storage-cost-per-gb", "storage-location", "confirm-data-snapshot-after-create",
+		"confirm-data-snapshot-mode", "ignore-tmpfs-data", "source-disks", "snapshot-name-template", "max-freeze-seconds", "params-file", "hana-data-path", "guest-flush",
+		"snapshot-poll-interval-seconds", "snapshot-max-wait-seconds", "additional-mount-points", "snapshot-additional-mount-points"}
 	snapshot.SetFlags(fs)
 	for _, flag := range flags {
 		got := fs.Lookup(flag)
@@ -1197,6 +2318,96 @@ func TestSendStatusToMonitoring(t *testing.T) {
 	}
 }
 
+func TestSendSnapshotSizeToMonitoring(t *testing.T) {
+	snapshot := &compute.Snapshot{
+		SourceDisk:         "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-east1-b/disks/pd-data",
+		StorageBytes:       2 << 30,
+		StorageBytesStatus: "UP_TO_DATE",
+	}
+	tests := []struct {
+		name       string
+		s          *Snapshot
+		want       bool
+		wantSeries int
+		wantCost   float64
+	}{
+		{
+			name: "SizeOnly",
+			s: &Snapshot{
+				SendToMonitoring: true,
+				gceService:       &fake.TestGCE{GetSnapshotResp: snapshot},
+			},
+			want:       true,
+			wantSeries: 1,
+		},
+		{
+			name: "SizeAndCost",
+			s: &Snapshot{
+				SendToMonitoring: true,
+				StorageCostPerGB: 0.05,
+				gceService:       &fake.TestGCE{GetSnapshotResp: snapshot},
+			},
+			want:       true,
+			wantSeries: 2,
+			wantCost:   0.1,
+		},
+		{
+			name: "SizeNotComputed",
+			s: &Snapshot{
+				SendToMonitoring:    true,
+				snapshotSizeMaxWait: time.Millisecond,
+				gceService:          &fake.TestGCE{GetSnapshotResp: &compute.Snapshot{StorageBytesStatus: "UPDATING"}},
+			},
+		},
+		{
+			name: "GetSnapshotFailure",
+			s: &Snapshot{
+				SendToMonitoring: true,
+				gceService:       &fake.TestGCE{GetSnapshotErr: cmpopts.AnyError},
+			},
+		},
+		{
+			name: "SendToMonitoringFalse",
+			s: &Snapshot{
+				gceService: &fake.TestGCE{GetSnapshotResp: snapshot},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			creator := &cmFake.TimeSeriesCreator{}
+			tc.s.timeSeriesCreator = creator
+			tc.s.oteLogger = defaultOTELogger
+			got := tc.s.sendSnapshotSizeToMonitoring(context.Background(), []string{"snapshot-name"}, cloudmonitoring.NewBackOffIntervals(time.Millisecond, time.Millisecond), defaultCloudProperties)
+			if got != tc.want {
+				t.Errorf("sendSnapshotSizeToMonitoring() = %v, want: %v", got, tc.want)
+			}
+			var series []*mrpb.TimeSeries
+			for _, call := range creator.Calls {
+				series = append(series, call.GetTimeSeries()...)
+			}
+			if len(series) != tc.wantSeries {
+				t.Fatalf("sendSnapshotSizeToMonitoring() sent %d time series, want: %d", len(series), tc.wantSeries)
+			}
+			if tc.wantSeries == 0 {
+				return
+			}
+			if got := series[0].GetMetric().GetLabels()["disk"]; got != "pd-data" {
+				t.Errorf("sendSnapshotSizeToMonitoring() disk label = %q, want: %q", got, "pd-data")
+			}
+			if got := series[0].GetPoints()[0].GetValue().GetInt64Value(); got != snapshot.StorageBytes {
+				t.Errorf("sendSnapshotSizeToMonitoring() size = %d, want: %d", got, snapshot.StorageBytes)
+			}
+			if tc.wantSeries > 1 {
+				if got := series[1].GetPoints()[0].GetValue().GetDoubleValue(); got != tc.wantCost {
+					t.Errorf("sendSnapshotSizeToMonitoring() cost = %v, want: %v", got, tc.wantCost)
+				}
+			}
+		})
+	}
+}
+
 func TestSendDurationToCloudMonitoring(t *testing.T) {
 	tests := []struct {
 		name         string
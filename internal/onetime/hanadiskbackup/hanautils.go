@@ -19,11 +19,43 @@ package hanadiskbackup
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 )
 
+// confirmMode returns the stage of the workflow at which the HANA data snapshot
+// is confirmed. An explicit -confirm-data-snapshot-mode takes precedence, otherwise
+// the mode is derived from -confirm-data-snapshot-after-create. No HANA snapshot
+// exists to confirm when the DB snapshot is skipped for changing the disk type.
+func (s *Snapshot) confirmMode() string {
+	switch {
+	case s.ConfirmDataSnapshotMode != "":
+		return strings.ToUpper(s.ConfirmDataSnapshotMode)
+	case s.SkipDBSnapshotForChangeDiskType:
+		return confirmNone
+	case s.ConfirmDataSnapshotAfterCreate:
+		return confirmAfterCreate
+	default:
+		return confirmAfterUpload
+	}
+}
+
+// validateConfirmMode checks the confirm mode is known and can be honoured by the workflow.
+func (s *Snapshot) validateConfirmMode() error {
+	switch mode := s.confirmMode(); mode {
+	case confirmAfterCreate, confirmAfterUpload:
+		if s.SkipDBSnapshotForChangeDiskType {
+			return fmt.Errorf("confirm data snapshot mode %s requires a HANA data snapshot, which is skipped for change disk type", mode)
+		}
+	case confirmNone:
+	default:
+		return fmt.Errorf("invalid confirm data snapshot mode %q, only %s, %s and %s are supported", s.ConfirmDataSnapshotMode, confirmAfterCreate, confirmAfterUpload, confirmNone)
+	}
+	return nil
+}
+
 func (s *Snapshot) markSnapshotAsSuccessful(ctx context.Context, run queryFunc, snapshotID string) error {
 	snapshotName := s.SnapshotName
 	if snapshotName == "" {
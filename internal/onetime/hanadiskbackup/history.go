@@ -0,0 +1,90 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanadiskbackup
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+// defaultHistoryPath is the file persisting the snapshot history across invocations. It is
+// kept in the one time execution log directory, which is writable by the sapsys group.
+const defaultHistoryPath = "/var/log/google-cloud-sap-agent/hanadiskbackup-history.json"
+
+type (
+	// snapshotHistory counts the backups of a HANA system since StartTime.
+	snapshotHistory struct {
+		Successes         int64     `json:"successes"`
+		Failures          int64     `json:"failures"`
+		LastFailureReason string    `json:"last_failure_reason"`
+		StartTime         time.Time `json:"start_time"`
+	}
+
+	// readFileFunc provides a testable replacement for os.ReadFile.
+	readFileFunc func(string) ([]byte, error)
+
+	// writeFileFunc provides a testable replacement for os.WriteFile.
+	writeFileFunc func(string, []byte, os.FileMode) error
+)
+
+// recordHistory adds the result of the backup to the history of the HANA system persisted in
+// s.historyPath and keeps the updated history for the status metrics. An empty failure reason
+// records a success. The history is best effort, failures to persist it are only logged.
+func (s *Snapshot) recordHistory(ctx context.Context, failureReason string, readFile readFileFunc, writeFile writeFileFunc) {
+	if s.historyPath == "" {
+		return
+	}
+	histories := make(map[string]*snapshotHistory)
+	if data, err := readFile(s.historyPath); err == nil {
+		if err := json.Unmarshal(data, &histories); err != nil {
+			log.CtxLogger(ctx).Warnw("Invalid snapshot history file, resetting the counts", "path", s.historyPath, "error", err)
+			histories = make(map[string]*snapshotHistory)
+		}
+	}
+
+	h, ok := histories[s.Sid]
+	if !ok || h == nil {
+		h = &snapshotHistory{StartTime: time.Now()}
+		histories[s.Sid] = h
+	}
+	if failureReason == "" {
+		h.Successes++
+	} else {
+		h.Failures++
+		h.LastFailureReason = strings.TrimPrefix(failureReason, "ERROR: ")
+	}
+	s.history = h
+
+	data, err := json.Marshal(histories)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Could not encode the snapshot history", "error", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(s.historyPath), 0770); err != nil {
+		log.CtxLogger(ctx).Warnw("Could not create the snapshot history directory", "path", s.historyPath, "error", err)
+		return
+	}
+	if err := writeFile(s.historyPath, data, 0660); err != nil {
+		log.CtxLogger(ctx).Warnw("Could not write the snapshot history file", "path", s.historyPath, "error", err)
+	}
+}
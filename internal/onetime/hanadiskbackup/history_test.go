@@ -0,0 +1,165 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanadiskbackup
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestRecordHistory(t *testing.T) {
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		existing      string
+		failureReason string
+		newHistory    bool
+		want          map[string]*snapshotHistory
+	}{
+		{
+			name:       "FirstSuccess",
+			newHistory: true,
+			want: map[string]*snapshotHistory{
+				"DEH": {Successes: 1},
+			},
+		},
+		{
+			name:          "FirstFailure",
+			failureReason: "ERROR: Failed to connect to database",
+			newHistory:    true,
+			want: map[string]*snapshotHistory{
+				"DEH": {Failures: 1, LastFailureReason: "Failed to connect to database"},
+			},
+		},
+		{
+			name:     "SuccessAddedToHistory",
+			existing: `{"DEH": {"successes": 4, "failures": 1, "last_failure_reason": "Failed to connect to database", "start_time": "2024-01-01T00:00:00Z"}, "ABC": {"successes": 2, "start_time": "2024-01-01T00:00:00Z"}}`,
+			want: map[string]*snapshotHistory{
+				"DEH": {Successes: 5, Failures: 1, LastFailureReason: "Failed to connect to database", StartTime: startTime},
+				"ABC": {Successes: 2, StartTime: startTime},
+			},
+		},
+		{
+			name:          "FailureReplacesReason",
+			existing:      `{"DEH": {"successes": 4, "failures": 1, "last_failure_reason": "Failed to connect to database", "start_time": "2024-01-01T00:00:00Z"}}`,
+			failureReason: "ERROR: Failed to run HANA disk snapshot workflow",
+			want: map[string]*snapshotHistory{
+				"DEH": {Successes: 4, Failures: 2, LastFailureReason: "Failed to run HANA disk snapshot workflow", StartTime: startTime},
+			},
+		},
+		{
+			name:       "InvalidHistoryIsReset",
+			existing:   `not json`,
+			newHistory: true,
+			want: map[string]*snapshotHistory{
+				"DEH": {Successes: 1},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "history", "hanadiskbackup-history.json")
+			if test.existing != "" {
+				if err := os.MkdirAll(filepath.Dir(path), 0770); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(test.existing), 0660); err != nil {
+					t.Fatal(err)
+				}
+			}
+			s := &Snapshot{Sid: "DEH", historyPath: path}
+			s.recordHistory(context.Background(), test.failureReason, os.ReadFile, os.WriteFile)
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile(%s) failed: %v", path, err)
+			}
+			got := make(map[string]*snapshotHistory)
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal(%s) failed: %v", data, err)
+			}
+			var ignoreNewStartTime cmp.Option
+			if test.newHistory {
+				if got["DEH"].StartTime.IsZero() {
+					t.Errorf("recordHistory() did not set the start time of a new history")
+				}
+				ignoreNewStartTime = cmpopts.IgnoreFields(snapshotHistory{}, "StartTime")
+			}
+			if diff := cmp.Diff(test.want, got, ignoreNewStartTime); diff != "" {
+				t.Errorf("recordHistory() persisted unexpected history (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.want["DEH"], s.history, ignoreNewStartTime); diff != "" {
+				t.Errorf("recordHistory() kept unexpected history (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecordHistoryDisabled(t *testing.T) {
+	s := &Snapshot{Sid: "DEH"}
+	read := func(string) ([]byte, error) {
+		t.Fatal("recordHistory() read the history file with no history path")
+		return nil, nil
+	}
+	s.recordHistory(context.Background(), "", read, os.WriteFile)
+	if s.history != nil {
+		t.Errorf("recordHistory() with no history path kept history: %v", s.history)
+	}
+}
+
+func TestRecordHistoryWriteFailure(t *testing.T) {
+	s := &Snapshot{Sid: "DEH", historyPath: filepath.Join(t.TempDir(), "hanadiskbackup-history.json")}
+	write := func(string, []byte, os.FileMode) error { return os.ErrPermission }
+	s.recordHistory(context.Background(), "", os.ReadFile, write)
+	if s.history == nil || s.history.Successes != 1 {
+		t.Errorf("recordHistory() with write failure kept history: %v, want 1 success", s.history)
+	}
+}
+
+func TestHistoryTimeSeries(t *testing.T) {
+	s := &Snapshot{Sid: "DEH"}
+	if got := s.historyTimeSeries(defaultCloudProperties); got != nil {
+		t.Errorf("historyTimeSeries() with no history = %v, want nil", got)
+	}
+
+	s.history = &snapshotHistory{Successes: 5, Failures: 2, LastFailureReason: "Failed to connect to database", StartTime: time.Now()}
+	got := s.historyTimeSeries(defaultCloudProperties)
+	want := map[string]int64{"success": 5, "failure": 2}
+	if len(got) != len(want) {
+		t.Fatalf("historyTimeSeries() returned %d time series, want %d", len(got), len(want))
+	}
+	for _, ts := range got {
+		result := ts.GetMetric().GetLabels()["result"]
+		if v := ts.GetPoints()[0].GetValue().GetInt64Value(); v != want[result] {
+			t.Errorf("historyTimeSeries() %s count = %d, want %d", result, v, want[result])
+		}
+		if reason := ts.GetMetric().GetLabels()["last_failure_reason"]; reason != s.history.LastFailureReason {
+			t.Errorf("historyTimeSeries() last_failure_reason = %q, want %q", reason, s.history.LastFailureReason)
+		}
+		if ts.GetMetricKind().String() != "CUMULATIVE" {
+			t.Errorf("historyTimeSeries() metric kind = %v, want CUMULATIVE", ts.GetMetricKind())
+		}
+	}
+}
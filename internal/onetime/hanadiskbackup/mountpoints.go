@@ -0,0 +1,99 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanadiskbackup
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/sapagent/internal/hanabackup"
+	"github.com/GoogleCloudPlatform/sapagent/internal/instanceinfo"
+	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
+)
+
+// additionalMountPoints returns the mount points from the comma separated -additional-mount-points.
+func (s *Snapshot) additionalMountPoints() []string {
+	var paths []string
+	for _, p := range strings.Split(s.AdditionalMountPoints, ",") {
+		if p = strings.TrimSpace(p); p != "" && !slices.Contains(paths, p) {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// validateAdditionalMountPoints checks that the additional mount points are absolute paths and
+// that they are not combined with user supplied disks, as they are mapped along with /hana/data.
+func (s *Snapshot) validateAdditionalMountPoints() error {
+	paths := s.additionalMountPoints()
+	if len(paths) == 0 {
+		if s.SnapshotAdditionalMountPoints {
+			return fmt.Errorf("-snapshot-additional-mount-points requires -additional-mount-points")
+		}
+		return nil
+	}
+	for _, p := range paths {
+		if !filepath.IsAbs(p) {
+			return fmt.Errorf("-additional-mount-points must be absolute paths, got %q", p)
+		}
+	}
+	if s.Disk != "" || s.SourceDisks != "" {
+		return fmt.Errorf("-additional-mount-points cannot be used with -source-disk or -source-disks")
+	}
+	return nil
+}
+
+// readAdditionalMountMappings maps each additional mount point to the instance disks backing it,
+// using the instance properties read by readDiskMapping. The mapping of each mount point is
+// reported and, with -snapshot-additional-mount-points, its disks are added to the disks to snapshot.
+func (s *Snapshot) readAdditionalMountMappings(ctx context.Context, exec commandlineexecutor.Execute) error {
+	for _, path := range s.additionalMountPoints() {
+		if err := hanabackup.CheckMountPoint(ctx, path, exec); err != nil {
+			return err
+		}
+		device, err := hanabackup.ParseLogicalPath(ctx, path, exec)
+		if err != nil {
+			return err
+		}
+		devices := []string{filepath.Base(device)}
+		if strings.Contains(device, "/dev/mapper") {
+			if devices, err = instanceinfo.PhysicalDevicesForLogicalVolume(ctx, device, exec); err != nil {
+				return err
+			}
+		}
+		var disks []string
+		for _, d := range instanceinfo.DisksForDevices(s.instanceProperties.GetDisks(), devices) {
+			disks = append(disks, d.GetDiskName())
+		}
+		if len(disks) == 0 {
+			return fmt.Errorf("no instance disks found backing the devices %v of %s", devices, path)
+		}
+		s.oteLogger.LogMessageToFileAndConsole(ctx, fmt.Sprintf("Mount point %s is backed by the disks %s", path, strings.Join(disks, ",")))
+		if !s.SnapshotAdditionalMountPoints {
+			continue
+		}
+		for _, d := range disks {
+			if !slices.Contains(s.disks, d) {
+				s.disks = append(s.disks, d)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanadiskbackup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
+)
+
+var mountPointInstanceProperties = &ipb.InstanceProperties{
+	Disks: []*ipb.Disk{
+		{DiskName: "pd-data", Mapping: "sdb"},
+		{DiskName: "pd-log", Mapping: "sdc"},
+		{DiskName: "pd-shared", Mapping: "nvme0n3"},
+	},
+}
+
+// fakeMountExec returns the device mounted at each path from devices and the physical
+// volumes of the logical volumes from lvs.
+func fakeMountExec(devices, lvs map[string]string) commandlineexecutor.Execute {
+	return func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+		switch params.Executable {
+		case "findmnt":
+			for path := range devices {
+				if params.ArgsToSplit == "-rn -o TARGET --mountpoint "+path {
+					return commandlineexecutor.Result{StdOut: path + "\n"}
+				}
+			}
+			return commandlineexecutor.Result{ExitCode: 1, Error: cmpopts.AnyError}
+		case "/sbin/lvs":
+			if out, ok := lvs[params.Args[len(params.Args)-1]]; ok {
+				return commandlineexecutor.Result{StdOut: out}
+			}
+			return commandlineexecutor.Result{ExitCode: 5, Error: cmpopts.AnyError}
+		}
+		for path, device := range devices {
+			if params.ArgsToSplit == "-c 'df --output=source "+path+" | tail -n 1'" {
+				return commandlineexecutor.Result{StdOut: device + "\n"}
+			}
+		}
+		return commandlineexecutor.Result{ExitCode: 1, Error: cmpopts.AnyError}
+	}
+}
+
+func TestAdditionalMountPoints(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths string
+		want  []string
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:  "TrimsAndDeduplicates",
+			paths: " /hana/log, /hana/shared,,/hana/log",
+			want:  []string{"/hana/log", "/hana/shared"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Snapshot{AdditionalMountPoints: test.paths}
+			if diff := cmp.Diff(test.want, s.additionalMountPoints(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("additionalMountPoints() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateAdditionalMountPoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       *Snapshot
+		wantErr error
+	}{
+		{
+			name: "NoMountPoints",
+			s:    &Snapshot{},
+		},
+		{
+			name: "Valid",
+			s:    &Snapshot{AdditionalMountPoints: "/hana/log,/hana/shared", SnapshotAdditionalMountPoints: true},
+		},
+		{
+			name:    "SnapshotWithoutMountPoints",
+			s:       &Snapshot{SnapshotAdditionalMountPoints: true},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "RelativePath",
+			s:       &Snapshot{AdditionalMountPoints: "hana/log"},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "WithSourceDisk",
+			s:       &Snapshot{AdditionalMountPoints: "/hana/log", Disk: "pd-data"},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "WithSourceDisks",
+			s:       &Snapshot{AdditionalMountPoints: "/hana/log", SourceDisks: "pd-data,pd-data-2"},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if gotErr := test.s.validateAdditionalMountPoints(); !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("validateAdditionalMountPoints() = %v, want %v", gotErr, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadAdditionalMountMappings(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         *Snapshot
+		exec      commandlineexecutor.Execute
+		wantDisks []string
+		wantErr   error
+	}{
+		{
+			name:      "NoMountPoints",
+			s:         &Snapshot{disks: []string{"pd-data"}},
+			wantDisks: []string{"pd-data"},
+		},
+		{
+			name: "ReportOnly",
+			s: &Snapshot{
+				disks:                 []string{"pd-data"},
+				AdditionalMountPoints: "/hana/log",
+			},
+			exec:      fakeMountExec(map[string]string{"/hana/log": "/dev/sdc"}, nil),
+			wantDisks: []string{"pd-data"},
+		},
+		{
+			name: "SnapshotDisks",
+			s: &Snapshot{
+				disks:                         []string{"pd-data"},
+				AdditionalMountPoints:         "/hana/log,/hana/shared",
+				SnapshotAdditionalMountPoints: true,
+			},
+			exec: fakeMountExec(map[string]string{
+				"/hana/log":    "/dev/mapper/vg_log-lv_log",
+				"/hana/shared": "/dev/nvme0n3p1",
+			}, map[string]string{"/dev/mapper/vg_log-lv_log": "  /dev/sdc(0)\n"}),
+			wantDisks: []string{"pd-data", "pd-log", "pd-shared"},
+		},
+		{
+			name: "SharedDiskNotDuplicated",
+			s: &Snapshot{
+				disks:                         []string{"pd-data"},
+				AdditionalMountPoints:         "/hana/backup",
+				SnapshotAdditionalMountPoints: true,
+			},
+			exec:      fakeMountExec(map[string]string{"/hana/backup": "/dev/sdb2"}, nil),
+			wantDisks: []string{"pd-data"},
+		},
+		{
+			name: "NotMountPoint",
+			s: &Snapshot{
+				disks:                 []string{"pd-data"},
+				AdditionalMountPoints: "/hana/log",
+			},
+			exec:      fakeMountExec(nil, nil),
+			wantDisks: []string{"pd-data"},
+			wantErr:   cmpopts.AnyError,
+		},
+		{
+			name: "LogicalVolumeFailure",
+			s: &Snapshot{
+				disks:                 []string{"pd-data"},
+				AdditionalMountPoints: "/hana/log",
+			},
+			exec:      fakeMountExec(map[string]string{"/hana/log": "/dev/mapper/vg_log-lv_log"}, nil),
+			wantDisks: []string{"pd-data"},
+			wantErr:   cmpopts.AnyError,
+		},
+		{
+			name: "NoBackingDisk",
+			s: &Snapshot{
+				disks:                 []string{"pd-data"},
+				AdditionalMountPoints: "/hana/log",
+			},
+			exec:      fakeMountExec(map[string]string{"/hana/log": "/dev/sdz"}, nil),
+			wantDisks: []string{"pd-data"},
+			wantErr:   cmpopts.AnyError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.s.oteLogger = defaultOTELogger
+			test.s.instanceProperties = mountPointInstanceProperties
+			gotErr := test.s.readAdditionalMountMappings(context.Background(), test.exec)
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("readAdditionalMountMappings() = %v, want %v", gotErr, test.wantErr)
+			}
+			if diff := cmp.Diff(test.wantDisks, test.s.disks); diff != "" {
+				t.Errorf("readAdditionalMountMappings() returned unexpected disks diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
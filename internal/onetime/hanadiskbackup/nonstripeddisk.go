@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/GoogleCloudPlatform/sapagent/internal/hanabackup"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
 	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
@@ -30,6 +29,9 @@ import (
 )
 
 func (s *Snapshot) runWorkflowForDiskSnapshot(ctx context.Context, run queryFunc, createSnapshot diskSnapshotFunc, cp *ipb.CloudProperties) (err error) {
+	if err := s.refreshStaleDiskMapping(ctx, cp, commandlineexecutor.ExecuteCommand); err != nil {
+		return err
+	}
 	if err := s.isDiskAttachedToInstance(ctx, s.Disk, cp); err != nil {
 		return err
 	}
@@ -47,7 +49,7 @@ func (s *Snapshot) runWorkflowForDiskSnapshot(ctx context.Context, run queryFunc
 
 	op, err := s.createDiskSnapshot(ctx, createSnapshot)
 	if s.FreezeFileSystem {
-		if err := hanabackup.UnFreezeXFS(ctx, s.hanaDataPath, commandlineexecutor.ExecuteCommand); err != nil {
+		if err := s.unfreezeXFS(ctx, commandlineexecutor.ExecuteCommand); err != nil {
 			s.oteLogger.LogErrorToFileAndConsole(ctx, "Error unfreezing XFS", err)
 			return err
 		}
@@ -60,17 +62,18 @@ func (s *Snapshot) runWorkflowForDiskSnapshot(ctx context.Context, run queryFunc
 		s.diskSnapshotFailureHandler(ctx, run, snapshotID)
 		return err
 	}
-
-	if s.ConfirmDataSnapshotAfterCreate {
+	defer s.sendDurationToCloudMonitoring(ctx, metricPrefix+s.Name()+"/snapshotcreationtime", s.SnapshotName, s.snapshotCreationTime, cloudmonitoring.NewDefaultBackOffIntervals(), cp)
+	if s.confirmMode() == confirmAfterCreate {
 		log.CtxLogger(ctx).Info("Marking HANA snapshot as successful after disk snapshot is created but not yet uploaded.")
 		if err := s.markSnapshotAsSuccessful(ctx, run, snapshotID); err != nil {
 			return err
 		}
 	}
 	s.oteLogger.LogMessageToFileAndConsole(ctx, "Waiting for disk snapshot to complete uploading.")
-	if err := s.gceService.WaitForSnapshotUploadCompletionWithRetry(ctx, op, s.Project, s.DiskZone, s.SnapshotName); err != nil {
+	uploadStartTime := time.Now()
+	if err := s.gceService.WaitForSnapshotUploadCompletionWithRetry(ctx, op, s.Project, s.DiskZone, s.SnapshotName, s.snapshotPollInterval(), s.snapshotMaxWait()); err != nil {
 		log.CtxLogger(ctx).Errorw("Error uploading disk snapshot", "error", err)
-		if s.ConfirmDataSnapshotAfterCreate {
+		if s.confirmMode() == confirmAfterCreate {
 			s.oteLogger.LogErrorToFileAndConsole(
 				ctx, fmt.Sprintf("Error uploading disk snapshot, HANA snapshot %s is not successful", snapshotID), err,
 			)
@@ -78,13 +81,22 @@ func (s *Snapshot) runWorkflowForDiskSnapshot(ctx context.Context, run queryFunc
 		s.diskSnapshotFailureHandler(ctx, run, snapshotID)
 		return err
 	}
+	uploadTime := time.Since(uploadStartTime)
+	defer s.sendDurationToCloudMonitoring(ctx, metricPrefix+s.Name()+"/snapshotuploadtime", s.SnapshotName, uploadTime, cloudmonitoring.NewDefaultBackOffIntervals(), cp)
+	s.sendSnapshotSizeToMonitoring(ctx, []string{s.SnapshotName}, cloudmonitoring.NewDefaultBackOffIntervals(), cp)
 
-	log.CtxLogger(ctx).Info("Disk snapshot created, marking HANA snapshot as successful.")
-	if !s.ConfirmDataSnapshotAfterCreate {
+	switch s.confirmMode() {
+	case confirmAfterUpload:
+		log.CtxLogger(ctx).Info("Disk snapshot created, marking HANA snapshot as successful.")
 		if err := s.markSnapshotAsSuccessful(ctx, run, snapshotID); err != nil {
 			return err
 		}
+	case confirmNone:
+		s.oteLogger.LogMessageToFileAndConsole(ctx, fmt.Sprintf("Disk snapshot created, HANA snapshot %s is left prepared and must be confirmed or abandoned manually.", snapshotID))
 	}
 
+	// The replicas are copies of the uploaded disk snapshot, they do not need the HANA snapshot.
+	s.createReplicas(ctx)
+	s.waitForReplicaUploads(ctx)
 	return nil
 }
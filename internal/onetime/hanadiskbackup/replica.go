@@ -0,0 +1,166 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanadiskbackup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	compute "google.golang.org/api/compute/v1"
+	"github.com/GoogleCloudPlatform/sapagent/internal/hanabackup"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+// replicaLabel marks a snapshot as a replica managed by the agent, its value is the name of the
+// primary snapshot it replicates.
+const replicaLabel = "goog-sapagent-replica-of"
+
+// replicaLocations returns the storage locations from the comma separated -replica-locations.
+func (s *Snapshot) replicaLocations() []string {
+	var locations []string
+	for _, l := range strings.Split(s.ReplicaLocations, ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			locations = append(locations, l)
+		}
+	}
+	return locations
+}
+
+// validateReplicaLocations checks that the replica locations are distinct from each other and
+// from the primary storage locations.
+func (s *Snapshot) validateReplicaLocations() error {
+	seen := make(map[string]bool)
+	for _, l := range s.storageLocations() {
+		seen[l] = true
+	}
+	for _, l := range s.replicaLocations() {
+		if seen[l] {
+			return fmt.Errorf("-replica-locations must not repeat a location, got %q more than once", l)
+		}
+		seen[l] = true
+	}
+	return nil
+}
+
+// replicaSnapshotName returns the name of the replica of the snapshot in the storage location,
+// truncated to the length accepted by compute engine.
+func replicaSnapshotName(snapshotName, location string) string {
+	name := fmt.Sprintf("%s-%s", snapshotName, strings.ToLower(location))
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	return strings.TrimRight(name, "-")
+}
+
+// createReplicas creates a copy of the primary disk snapshot in each of the -replica-locations.
+// It must run once the primary snapshot is uploaded: compute engine cannot copy a snapshot, so each
+// replica is taken from a temporary disk restored from the primary snapshot, which is deleted once
+// the replica is uploaded. A replica which fails to be created is logged and skipped, it does not
+// fail the backup.
+func (s *Snapshot) createReplicas(ctx context.Context) {
+	for _, location := range s.replicaLocations() {
+		name := replicaSnapshotName(s.SnapshotName, location)
+		op, err := s.createReplica(ctx, name, location)
+		if err != nil {
+			s.oteLogger.LogErrorToFileAndConsole(ctx, fmt.Sprintf("Failed to create replica snapshot %s in storage location %q, continuing with the backup", name, location), err)
+			continue
+		}
+		log.CtxLogger(ctx).Infow("Replica snapshot created", "snapshotname", name, "storagelocation", location)
+		s.replicaOps = append(s.replicaOps, &snapshotOp{op: op, name: name})
+	}
+}
+
+// createReplica restores the primary snapshot to a temporary disk named after the replica and
+// snapshots the disk in the storage location. The disk is deleted if the replica is not created.
+func (s *Snapshot) createReplica(ctx context.Context, name, location string) (*compute.Operation, error) {
+	labels := s.parseLabels()
+	labels[replicaLabel] = s.SnapshotName
+	disk := &compute.Disk{
+		Name:           name,
+		Description:    fmt.Sprintf("Temporary disk for the replica of snapshot %s", s.SnapshotName),
+		SourceSnapshot: fmt.Sprintf("projects/%s/global/snapshots/%s", s.Project, s.SnapshotName),
+		Labels:         map[string]string{replicaLabel: s.SnapshotName},
+	}
+	snapshot := &compute.Snapshot{
+		Name:             name,
+		Description:      s.Description,
+		SnapshotType:     s.SnapshotType,
+		SourceDisk:       fmt.Sprintf("projects/%s/zones/%s/disks/%s", s.Project, s.DiskZone, name),
+		StorageLocations: []string{location},
+		Labels:           labels,
+	}
+	if s.DiskKeyFile != "" {
+		// The primary snapshot is encrypted with the key of the source disk.
+		srcDiskKey, err := hanabackup.ReadKey(s.DiskKeyFile, s.sourceDiskURI(), os.ReadFile)
+		if err != nil {
+			return nil, err
+		}
+		disk.SourceSnapshotEncryptionKey = &compute.CustomerEncryptionKey{RsaEncryptedKey: srcDiskKey}
+		disk.DiskEncryptionKey = &compute.CustomerEncryptionKey{RsaEncryptedKey: srcDiskKey}
+		snapshot.SourceDiskEncryptionKey = &compute.CustomerEncryptionKey{RsaEncryptedKey: srcDiskKey}
+		snapshot.SnapshotEncryptionKey = &compute.CustomerEncryptionKey{RsaEncryptedKey: srcDiskKey}
+	}
+
+	log.CtxLogger(ctx).Infow("Restoring the primary snapshot to a temporary disk for the replica", "diskname", name, "snapshotname", s.SnapshotName)
+	diskOp, err := s.gceService.CreateDisk(ctx, s.Project, s.DiskZone, disk)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.gceService.WaitForDiskOpCompletionWithRetry(ctx, diskOp, s.Project, s.DiskZone); err != nil {
+		s.deleteReplicaDisk(ctx, name)
+		return nil, err
+	}
+	op, err := s.gceService.CreateSnapshot(ctx, s.Project, snapshot)
+	if err != nil {
+		s.deleteReplicaDisk(ctx, name)
+		return nil, err
+	}
+	if err := s.gceService.WaitForSnapshotCreationCompletionWithRetry(ctx, op, s.Project, s.DiskZone, name, s.snapshotPollInterval(), s.snapshotMaxWait()); err != nil {
+		s.deleteReplicaDisk(ctx, name)
+		return nil, err
+	}
+	return op, nil
+}
+
+// deleteReplicaDisk deletes the temporary disk of a replica. A failure is logged so that the disk
+// can be deleted manually.
+func (s *Snapshot) deleteReplicaDisk(ctx context.Context, name string) {
+	op, err := s.gceService.DeleteDisk(ctx, s.Project, s.DiskZone, name)
+	if err == nil {
+		err = s.gceService.WaitForDiskOpCompletionWithRetry(ctx, op, s.Project, s.DiskZone)
+	}
+	if err != nil {
+		s.oteLogger.LogErrorToFileAndConsole(ctx, fmt.Sprintf("Failed to delete the temporary disk %s of the replica snapshot, it must be deleted manually", name), err)
+	}
+}
+
+// waitForReplicaUploads waits for the created replicas to finish uploading and records the names
+// of those which did, then deletes their temporary disks. A failed upload is logged and does not
+// fail the backup.
+func (s *Snapshot) waitForReplicaUploads(ctx context.Context) {
+	for _, r := range s.replicaOps {
+		err := s.gceService.WaitForSnapshotUploadCompletionWithRetry(ctx, r.op, s.Project, s.DiskZone, r.name, s.snapshotPollInterval(), s.snapshotMaxWait())
+		s.deleteReplicaDisk(ctx, r.name)
+		if err != nil {
+			s.oteLogger.LogErrorToFileAndConsole(ctx, fmt.Sprintf("Failed to upload replica snapshot %s, continuing with the backup", r.name), err)
+			continue
+		}
+		s.replicaSnapshots = append(s.replicaSnapshots, r.name)
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hanadiskbackup
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	compute "google.golang.org/api/compute/v1"
+	"github.com/GoogleCloudPlatform/sapagent/shared/gce/fake"
+)
+
+func TestReplicaSnapshotName(t *testing.T) {
+	tests := []struct {
+		name         string
+		snapshotName string
+		location     string
+		want         string
+	}{
+		{
+			name:         "Short",
+			snapshotName: "snapshot-disk-20240101-000000",
+			location:     "EU",
+			want:         "snapshot-disk-20240101-000000-eu",
+		},
+		{
+			name:         "Truncated",
+			snapshotName: strings.Repeat("a", 60),
+			location:     "us-central1",
+			want:         strings.Repeat("a", 60) + "-us",
+		},
+		{
+			name:         "TruncatedOnHyphen",
+			snapshotName: strings.Repeat("a", 62),
+			location:     "us",
+			want:         strings.Repeat("a", 62),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := replicaSnapshotName(test.snapshotName, test.location); got != test.want {
+				t.Errorf("replicaSnapshotName(%q, %q) = %q, want %q", test.snapshotName, test.location, got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateReplicaLocations(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       *Snapshot
+		wantErr error
+	}{
+		{
+			name: "NoReplicas",
+			s:    &Snapshot{StorageLocation: "us-central1"},
+		},
+		{
+			name: "DistinctLocations",
+			s:    &Snapshot{StorageLocation: "us-central1", ReplicaLocations: "europe-west1, asia-east1"},
+		},
+		{
+			name:    "ReplicaInPrimaryLocation",
+			s:       &Snapshot{StorageLocation: "us-central1,us", ReplicaLocations: "us"},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "RepeatedReplica",
+			s:       &Snapshot{ReplicaLocations: "eu,eu"},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if gotErr := test.s.validateReplicaLocations(); !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("validateReplicaLocations() = %v, want %v", gotErr, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateReplicas(t *testing.T) {
+	tests := []struct {
+		name         string
+		s            *Snapshot
+		wantReplicas []string
+	}{
+		{
+			name: "NoReplicaLocations",
+			s: &Snapshot{
+				SnapshotName: "snapshot",
+				gceService:   &fake.TestGCE{CreateSnapshotOp: &compute.Operation{}},
+			},
+		},
+		{
+			name: "ReplicasCreated",
+			s: &Snapshot{
+				SnapshotName:     "snapshot",
+				ReplicaLocations: "eu,asia",
+				gceService: &fake.TestGCE{
+					CreateDiskOp:     &compute.Operation{},
+					CreateSnapshotOp: &compute.Operation{},
+				},
+			},
+			wantReplicas: []string{"snapshot-eu", "snapshot-asia"},
+		},
+		{
+			name: "CreateDiskFailure",
+			s: &Snapshot{
+				SnapshotName:     "snapshot",
+				ReplicaLocations: "eu",
+				gceService: &fake.TestGCE{
+					CreateDiskErr:    cmpopts.AnyError,
+					CreateSnapshotOp: &compute.Operation{},
+				},
+			},
+		},
+		{
+			name: "DiskOpFailure",
+			s: &Snapshot{
+				SnapshotName:     "snapshot",
+				ReplicaLocations: "eu",
+				gceService: &fake.TestGCE{
+					CreateDiskOp:     &compute.Operation{},
+					DiskOpErr:        cmpopts.AnyError,
+					CreateSnapshotOp: &compute.Operation{},
+				},
+			},
+		},
+		{
+			name: "DeleteDiskFailureKeepsReplica",
+			s: &Snapshot{
+				SnapshotName:     "snapshot",
+				ReplicaLocations: "eu",
+				gceService: &fake.TestGCE{
+					CreateDiskOp:     &compute.Operation{},
+					CreateSnapshotOp: &compute.Operation{},
+					DeleteDiskErr:    cmpopts.AnyError,
+				},
+			},
+			wantReplicas: []string{"snapshot-eu"},
+		},
+		{
+			name: "CreateFailure",
+			s: &Snapshot{
+				SnapshotName:     "snapshot",
+				ReplicaLocations: "eu",
+				gceService:       &fake.TestGCE{CreateSnapshotErr: cmpopts.AnyError},
+			},
+		},
+		{
+			name: "CreationCompletionFailure",
+			s: &Snapshot{
+				SnapshotName:     "snapshot",
+				ReplicaLocations: "eu",
+				gceService: &fake.TestGCE{
+					CreateSnapshotOp:      &compute.Operation{},
+					CreationCompletionErr: cmpopts.AnyError,
+				},
+			},
+		},
+		{
+			name: "UploadFailure",
+			s: &Snapshot{
+				SnapshotName:     "snapshot",
+				ReplicaLocations: "eu",
+				gceService: &fake.TestGCE{
+					CreateSnapshotOp:    &compute.Operation{},
+					UploadCompletionErr: cmpopts.AnyError,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.s.oteLogger = defaultOTELogger
+			test.s.createReplicas(context.Background())
+			test.s.waitForReplicaUploads(context.Background())
+			if diff := cmp.Diff(test.wantReplicas, test.s.replicaSnapshots); diff != "" {
+				t.Errorf("createReplicas() returned unexpected replica snapshots (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
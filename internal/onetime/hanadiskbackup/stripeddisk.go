@@ -40,6 +40,9 @@ import (
 )
 
 func (s *Snapshot) runWorkflowForInstantSnapshotGroups(ctx context.Context, run queryFunc, cp *ipb.CloudProperties) (err error) {
+	if err := s.refreshStaleDiskMapping(ctx, cp, commandlineexecutor.ExecuteCommand); err != nil {
+		return err
+	}
 	for _, d := range s.disks {
 		if err = s.isDiskAttachedToInstance(ctx, d, cp); err != nil {
 			return err
@@ -60,7 +63,7 @@ func (s *Snapshot) runWorkflowForInstantSnapshotGroups(ctx context.Context, run
 
 	err = s.createInstantSnapshotGroup(ctx)
 	if s.FreezeFileSystem {
-		if err := hanabackup.UnFreezeXFS(ctx, s.hanaDataPath, commandlineexecutor.ExecuteCommand); err != nil {
+		if err := s.unfreezeXFS(ctx, commandlineexecutor.ExecuteCommand); err != nil {
 			s.oteLogger.LogErrorToFileAndConsole(ctx, "error unfreezing XFS", err)
 			return err
 		}
@@ -74,6 +77,7 @@ func (s *Snapshot) runWorkflowForInstantSnapshotGroups(ctx context.Context, run
 	}
 
 	var ssOps []*snapshotOp
+	creationStartTime := time.Now()
 	if ssOps, err = s.convertISGInstantSnapshots(ctx, cp); err != nil {
 		s.oteLogger.LogErrorToFileAndConsole(ctx, fmt.Sprintf("error converting instant snapshots to %s, HANA snapshot %s is not successful", strings.ToLower(s.SnapshotType), snapshotID), err)
 		s.diskSnapshotFailureHandler(ctx, run, snapshotID)
@@ -82,8 +86,10 @@ func (s *Snapshot) runWorkflowForInstantSnapshotGroups(ctx context.Context, run
 		}
 		return err
 	}
+	creationTime := time.Since(creationStartTime)
+	defer s.sendDurationToCloudMonitoring(ctx, metricPrefix+s.Name()+"/snapshotcreationtime", s.groupSnapshotName, creationTime, cloudmonitoring.NewDefaultBackOffIntervals(), cp)
 
-	if s.ConfirmDataSnapshotAfterCreate {
+	if s.confirmMode() == confirmAfterCreate {
 		log.CtxLogger(ctx).Info("Marking HANA snapshot as successful after disk snapshots are created but not yet uploaded.")
 		if err := s.markSnapshotAsSuccessful(ctx, run, snapshotID); err != nil {
 			if err := s.isgService.DeleteISG(ctx, s.Project, s.DiskZone, s.groupSnapshotName); err != nil {
@@ -94,10 +100,11 @@ func (s *Snapshot) runWorkflowForInstantSnapshotGroups(ctx context.Context, run
 	}
 
 	s.oteLogger.LogMessageToFileAndConsole(ctx, "Waiting for disk snapshots to complete uploading.")
+	uploadStartTime := time.Now()
 	for _, ssOp := range ssOps {
 		if err := s.gceService.WaitForInstantSnapshotConversionCompletionWithRetry(ctx, ssOp.op, s.Project, s.DiskZone, ssOp.name); err != nil {
 			log.CtxLogger(ctx).Errorw("Error uploading disk snapshot", "error", err)
-			if s.ConfirmDataSnapshotAfterCreate {
+			if s.confirmMode() == confirmAfterCreate {
 				s.oteLogger.LogErrorToFileAndConsole(
 					ctx, fmt.Sprintf("Error uploading disk snapshot, HANA snapshot %s is not successful", snapshotID), err,
 				)
@@ -110,15 +117,25 @@ func (s *Snapshot) runWorkflowForInstantSnapshotGroups(ctx context.Context, run
 			return err
 		}
 	}
+	uploadTime := time.Since(uploadStartTime)
+	defer s.sendDurationToCloudMonitoring(ctx, metricPrefix+s.Name()+"/snapshotuploadtime", s.groupSnapshotName, uploadTime, cloudmonitoring.NewDefaultBackOffIntervals(), cp)
+	var snapshotNames []string
+	for _, ssOp := range ssOps {
+		snapshotNames = append(snapshotNames, ssOp.name)
+	}
+	s.sendSnapshotSizeToMonitoring(ctx, snapshotNames, cloudmonitoring.NewDefaultBackOffIntervals(), cp)
 	if err := s.isgService.DeleteISG(ctx, s.Project, s.DiskZone, s.groupSnapshotName); err != nil {
 		s.oteLogger.LogErrorToFileAndConsole(ctx, "error deleting instant snapshot group, but disk snapshots are successful", err)
 	}
 
-	log.CtxLogger(ctx).Info(fmt.Sprintf("Instant snapshot group and %s equivalents created, marking HANA snapshot as successful.", strings.ToLower(s.SnapshotType)))
-	if !s.ConfirmDataSnapshotAfterCreate {
+	switch s.confirmMode() {
+	case confirmAfterUpload:
+		log.CtxLogger(ctx).Info(fmt.Sprintf("Instant snapshot group and %s equivalents created, marking HANA snapshot as successful.", strings.ToLower(s.SnapshotType)))
 		if err := s.markSnapshotAsSuccessful(ctx, run, snapshotID); err != nil {
 			return err
 		}
+	case confirmNone:
+		s.oteLogger.LogMessageToFileAndConsole(ctx, fmt.Sprintf("Instant snapshot group and %s equivalents created, HANA snapshot %s is left prepared and must be confirmed or abandoned manually.", strings.ToLower(s.SnapshotType), snapshotID))
 	}
 
 	return nil
@@ -157,7 +174,8 @@ func (s *Snapshot) createInstantSnapshotGroup(ctx context.Context) error {
 
 	dbFreezeStartTime = time.Now()
 	if s.FreezeFileSystem {
-		if err := hanabackup.FreezeXFS(ctx, s.hanaDataPath, commandlineexecutor.ExecuteCommand); err != nil {
+		var err error
+		if ctx, err = s.freezeXFS(ctx, commandlineexecutor.ExecuteCommand); err != nil {
 			return err
 		}
 	}
@@ -166,14 +184,19 @@ func (s *Snapshot) createInstantSnapshotGroup(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal json, err: %w", err)
 	}
-	if err := s.isgService.CreateISG(ctx, s.Project, s.DiskZone, data); err != nil {
+	err = s.isgService.CreateISG(ctx, s.Project, s.DiskZone, data)
+	if timeoutErr := freezeTimedOut(ctx); timeoutErr != nil {
+		return timeoutErr
+	}
+	if err != nil {
 		return err
 	}
 	baseURL := fmt.Sprintf("https://www.googleapis.com/compute/alpha/projects/%s/zones/%s/instantSnapshotGroups/%s", s.Project, s.DiskZone, s.groupSnapshotName)
-	if err := s.isgService.WaitForISGUploadCompletionWithRetry(ctx, baseURL); err != nil {
-		return err
+	err = s.isgService.WaitForISGUploadCompletionWithRetry(ctx, baseURL)
+	if timeoutErr := freezeTimedOut(ctx); timeoutErr != nil {
+		return timeoutErr
 	}
-	return nil
+	return err
 }
 
 func (s *Snapshot) convertISGInstantSnapshots(ctx context.Context, cp *ipb.CloudProperties) ([]*snapshotOp, error) {
@@ -236,7 +259,7 @@ func (s *Snapshot) createGroupBackup(ctx context.Context, instantSnapshot instan
 		return fmt.Errorf("failed to create %s snapshot for instant snapshot %s: %w", strings.ToLower(s.SnapshotType), isName, err)
 	}
 
-	if err := s.gceService.WaitForSnapshotCreationCompletionWithRetry(ctx, op, s.Project, s.DiskZone, snapshotName); err != nil {
+	if err := s.gceService.WaitForSnapshotCreationCompletionWithRetry(ctx, op, s.Project, s.DiskZone, snapshotName, s.snapshotPollInterval(), s.snapshotMaxWait()); err != nil {
 		return fmt.Errorf("failed to create %s snapshot for instant snapshot %s: %w", strings.ToLower(s.SnapshotType), isName, err)
 	}
 	*ssOps = append(*ssOps, &snapshotOp{op: op, name: snapshotName})
@@ -278,7 +301,7 @@ func (s *Snapshot) validateDisksBelongToCG(ctx context.Context) error {
 
 // readConsistencyGroup reads the consistency group (CG) from the resource policies of the disk.
 func (s *Snapshot) readConsistencyGroup(ctx context.Context, disk string) (string, error) {
-	d, err := s.gceService.GetDisk(s.Project, s.DiskZone, disk)
+	d, err := s.gceService.GetDisk(s.sourceDiskProject(), s.DiskZone, disk)
 	if err != nil {
 		return "", err
 	}
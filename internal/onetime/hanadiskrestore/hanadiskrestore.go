@@ -20,6 +20,7 @@ package hanadiskrestore
 import (
 	"context"
 	"fmt"
+	"path"
 	"runtime"
 	"strconv"
 	"strings"
@@ -154,8 +155,8 @@ func (r *Restorer) SetFlags(fs *flag.FlagSet) {
 	fs.StringVar(&r.labelsOnDetachedDisk, "labels-on-detached-disk", "", "Labels to be appended to detached disks. (optional) Default: empty. Accepts comma separated key-value pairs, like \"key1=value1,key2=value2\"")
 	fs.BoolVar(&r.ForceStopHANA, "force-stop-hana", false, "Forcefully stop HANA using `HDB kill` before attempting restore.(optional) Default: false.")
 	fs.Int64Var(&r.DiskSizeGb, "disk-size-gb", 0, "New disk size in GB, must not be less than the size of the source (optional)")
-	fs.Int64Var(&r.ProvisionedIops, "provisioned-iops", 0, "Number of I/O operations per second that the disk can handle. (optional)")
-	fs.Int64Var(&r.ProvisionedThroughput, "provisioned-throughput", 0, "Number of throughput mb per second that the disk can handle. (optional)")
+	fs.Int64Var(&r.ProvisionedIops, "provisioned-iops", 0, "Number of I/O operations per second that the disk can handle, only for pd-extreme and hyperdisk types. (optional) Default: value captured in the source snapshot")
+	fs.Int64Var(&r.ProvisionedThroughput, "provisioned-throughput", 0, "Number of throughput mb per second that the disk can handle, only for hyperdisk types. (optional) Default: value captured in the source snapshot")
 	fs.BoolVar(&r.SendToMonitoring, "send-metrics-to-monitoring", true, "Send restore related metrics to cloud monitoring. (optional) Default: true")
 	fs.StringVar(&r.CSEKKeyFile, "csek-key-file", "", `Path to a Customer-Supplied Encryption Key (CSEK) key file for the source snapshot. (required if source snapshot is encrypted)`)
 	fs.StringVar(&r.LogPath, "log-path", "", "The log path to write the log file (optional), default value is /var/log/google-cloud-sap-agent/hanadiskrestore.log")
@@ -561,6 +562,10 @@ func (r *Restorer) checkPreConditions(ctx context.Context, cp *ipb.CloudProperti
 		}
 	}
 
+	// Values passed as flags take precedence over the snapshot labels and must
+	// be valid for the new disk type.
+	iopsFromFlag, throughputFromFlag := r.ProvisionedIops > 0, r.ProvisionedThroughput > 0
+
 	// Verify the snapshot is present.
 	if !r.isGroupSnapshot {
 		if r.computeService == nil {
@@ -608,9 +613,50 @@ func (r *Restorer) checkPreConditions(ctx context.Context, cp *ipb.CloudProperti
 	} else {
 		r.NewDiskType = fmt.Sprintf("projects/%s/zones/%s/diskTypes/%s", r.Project, r.DataDiskZone, r.NewDiskType)
 	}
+	return r.validateProvisionedPerformance(ctx, iopsFromFlag, throughputFromFlag)
+}
+
+// validateProvisionedPerformance ensures provisioned IOPS and throughput are
+// only applied to disk types which support them. Values passed as flags for an
+// unsupported disk type result in an error, values read from the snapshot
+// labels are dropped.
+func (r *Restorer) validateProvisionedPerformance(ctx context.Context, iopsFromFlag, throughputFromFlag bool) error {
+	diskType := path.Base(r.NewDiskType)
+	if r.ProvisionedIops > 0 && !supportsProvisionedIops(diskType) {
+		if iopsFromFlag {
+			return fmt.Errorf("provisioned-iops is not supported for disk type %s", diskType)
+		}
+		log.CtxLogger(ctx).Infow("Not applying provisioned IOPS from snapshot, unsupported by the new disk type", "diskType", diskType, "provisionedIops", r.ProvisionedIops)
+		r.ProvisionedIops = 0
+	}
+	if r.ProvisionedThroughput > 0 && !supportsProvisionedThroughput(diskType) {
+		if throughputFromFlag {
+			return fmt.Errorf("provisioned-throughput is not supported for disk type %s", diskType)
+		}
+		log.CtxLogger(ctx).Infow("Not applying provisioned throughput from snapshot, unsupported by the new disk type", "diskType", diskType, "provisionedThroughput", r.ProvisionedThroughput)
+		r.ProvisionedThroughput = 0
+	}
 	return nil
 }
 
+// supportsProvisionedIops returns true if IOPS can be provisioned for the disk type.
+func supportsProvisionedIops(diskType string) bool {
+	switch diskType {
+	case "pd-extreme", "hyperdisk-extreme", "hyperdisk-balanced", "hyperdisk-balanced-high-availability":
+		return true
+	}
+	return false
+}
+
+// supportsProvisionedThroughput returns true if throughput can be provisioned for the disk type.
+func supportsProvisionedThroughput(diskType string) bool {
+	switch diskType {
+	case "hyperdisk-throughput", "hyperdisk-balanced", "hyperdisk-balanced-high-availability", "hyperdisk-ml":
+		return true
+	}
+	return false
+}
+
 func (r *Restorer) extractLabels(ctx context.Context, snapshot *compute.Snapshot) {
 	for key, value := range snapshot.Labels {
 		switch key {
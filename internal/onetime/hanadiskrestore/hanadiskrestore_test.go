@@ -340,6 +340,81 @@ func TestExtractLabels(t *testing.T) {
 	}
 }
 
+func TestValidateProvisionedPerformance(t *testing.T) {
+	tests := []struct {
+		name               string
+		r                  *Restorer
+		iopsFromFlag       bool
+		throughputFromFlag bool
+		wantIOPS           int64
+		wantThroughput     int64
+		wantErr            error
+	}{
+		{
+			name: "HyperdiskBalanced",
+			r: &Restorer{
+				NewDiskType:           "projects/test-project/zones/test-zone/diskTypes/hyperdisk-balanced",
+				ProvisionedIops:       3000,
+				ProvisionedThroughput: 140,
+			},
+			wantIOPS:       3000,
+			wantThroughput: 140,
+		},
+		{
+			name: "PDExtremeDropsThroughputFromLabels",
+			r: &Restorer{
+				NewDiskType:           "projects/test-project/zones/test-zone/diskTypes/pd-extreme",
+				ProvisionedIops:       10000,
+				ProvisionedThroughput: 140,
+			},
+			wantIOPS: 10000,
+		},
+		{
+			name: "PDSSDDropsLabels",
+			r: &Restorer{
+				NewDiskType:           "pd-ssd",
+				ProvisionedIops:       10000,
+				ProvisionedThroughput: 140,
+			},
+		},
+		{
+			name: "IOPSFlagUnsupported",
+			r: &Restorer{
+				NewDiskType:     "projects/test-project/zones/test-zone/diskTypes/hyperdisk-throughput",
+				ProvisionedIops: 10000,
+			},
+			iopsFromFlag: true,
+			wantIOPS:     10000,
+			wantErr:      cmpopts.AnyError,
+		},
+		{
+			name: "ThroughputFlagUnsupported",
+			r: &Restorer{
+				NewDiskType:           "projects/test-project/zones/test-zone/diskTypes/pd-balanced",
+				ProvisionedThroughput: 140,
+			},
+			throughputFromFlag: true,
+			wantThroughput:     140,
+			wantErr:            cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotErr := test.r.validateProvisionedPerformance(context.Background(), test.iopsFromFlag, test.throughputFromFlag)
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("validateProvisionedPerformance()=%v, want=%v", gotErr, test.wantErr)
+			}
+			if test.r.ProvisionedIops != test.wantIOPS {
+				t.Errorf("validateProvisionedPerformance() ProvisionedIops=%v, want=%v", test.r.ProvisionedIops, test.wantIOPS)
+			}
+			if test.r.ProvisionedThroughput != test.wantThroughput {
+				t.Errorf("validateProvisionedPerformance() ProvisionedThroughput=%v, want=%v", test.r.ProvisionedThroughput, test.wantThroughput)
+			}
+		})
+	}
+}
+
 func TestDefaultValues(t *testing.T) {
 	r := Restorer{
 		Sid:            "hdb",
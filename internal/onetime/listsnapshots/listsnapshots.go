@@ -0,0 +1,234 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package listsnapshots implements the one time execution mode which lists the disk snapshots
+// created by the hanadiskbackup command, optionally filtered by HANA SID or source disk.
+package listsnapshots
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"flag"
+	compute "google.golang.org/api/compute/v1"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/sapagent/shared/gce"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	iipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+)
+
+// Labels applied by hanadiskbackup. Every snapshot created by the agent has at least one label
+// with the agent prefix.
+const (
+	agentLabelPrefix = "goog-sapagent-"
+	sidLabel         = "goog-sapagent-sid"
+	diskNameLabel    = "goog-sapagent-disk-name"
+	groupLabel       = "goog-sapagent-isg"
+	replicaLabel     = "goog-sapagent-replica-of"
+)
+
+type (
+	// snapshotLister is the testable equivalent for gce.GCE for listing snapshots.
+	snapshotLister interface {
+		ListSnapshots(ctx context.Context, project string) (*compute.SnapshotList, error)
+	}
+
+	// newListerFunc provides a testable replacement for gce.NewGCEClient.
+	newListerFunc func(ctx context.Context) (snapshotLister, error)
+
+	// snapshotInfo is the listed information of a snapshot.
+	snapshotInfo struct {
+		Name          string `json:"name"`
+		SourceDisk    string `json:"sourceDisk"`
+		SID           string `json:"sid,omitempty"`
+		CreationTime  string `json:"creationTime"`
+		DiskSizeGB    int64  `json:"diskSizeGb"`
+		StorageBytes  int64  `json:"storageBytes"`
+		SnapshotType  string `json:"snapshotType"`
+		GroupSnapshot string `json:"groupSnapshot,omitempty"`
+		ReplicaOf     string `json:"replicaOf,omitempty"`
+	}
+)
+
+// ListSnapshots stores the arguments for the listsnapshots subcommand.
+type ListSnapshots struct {
+	Project   string `json:"project"`
+	Sid       string `json:"sid"`
+	Disk      string `json:"source-disk"`
+	JSON      bool   `json:"json,string"`
+	LogLevel  string `json:"loglevel"`
+	LogPath   string `json:"log-path"`
+	help      bool
+	oteLogger *onetime.OTELogger
+}
+
+// Name implements the subcommand interface for listsnapshots.
+func (*ListSnapshots) Name() string { return "listsnapshots" }
+
+// Synopsis implements the subcommand interface for listsnapshots.
+func (*ListSnapshots) Synopsis() string {
+	return "list the disk snapshots created by hanadiskbackup"
+}
+
+// Usage implements the subcommand interface for listsnapshots.
+func (*ListSnapshots) Usage() string {
+	return `Usage: listsnapshots [-project=<project-name>] [-sid=<HANA-sid>] [-source-disk=<disk-name>] [-json]
+	[-h] [-loglevel=<debug|info|warn|error>] [-log-path=<log-path>]
+	Lists the snapshots of the project labeled by hanadiskbackup, with their source disk, HANA SID,
+	creation time, size and type, sorted by creation time. Snapshots created by earlier versions of
+	the agent without the goog-sapagent labels are not listed.` + "\n"
+}
+
+// SetFlags implements the subcommand interface for listsnapshots.
+func (l *ListSnapshots) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&l.Project, "project", "", "GCP project of the snapshots. (optional) Default: project corresponding to this instance")
+	fs.StringVar(&l.Sid, "sid", "", "Only list the snapshots of this HANA SID. (optional)")
+	fs.StringVar(&l.Disk, "source-disk", "", "Only list the snapshots of this source disk. (optional)")
+	fs.BoolVar(&l.JSON, "json", false, "Print the snapshots as JSON. (optional) Default: false")
+	fs.BoolVar(&l.help, "h", false, "Displays help")
+	fs.StringVar(&l.LogLevel, "loglevel", "info", "Sets the logging level")
+	fs.StringVar(&l.LogPath, "log-path", "", "The log path to write the log file (optional), default value is /var/log/google-cloud-sap-agent/listsnapshots.log")
+}
+
+// Execute implements the subcommand interface for listsnapshots.
+func (l *ListSnapshots) Execute(ctx context.Context, f *flag.FlagSet, args ...any) subcommands.ExitStatus {
+	_, cp, exitStatus, completed := onetime.Init(ctx, onetime.InitOptions{
+		Name:     l.Name(),
+		Help:     l.help,
+		LogLevel: l.LogLevel,
+		LogPath:  l.LogPath,
+		Fs:       f,
+	}, args...)
+	if !completed {
+		return exitStatus
+	}
+
+	_, status := l.Run(ctx, onetime.CreateRunOptions(cp, false))
+	return status
+}
+
+// Run lists the snapshots and prints them.
+func (l *ListSnapshots) Run(ctx context.Context, opts *onetime.RunOptions) (string, subcommands.ExitStatus) {
+	l.oteLogger = onetime.CreateOTELogger(opts.DaemonMode)
+	newLister := func(ctx context.Context) (snapshotLister, error) { return gce.NewGCEClient(ctx) }
+	return l.listSnapshotsHandler(ctx, opts.CloudProperties, newLister, os.Stdout)
+}
+
+func (l *ListSnapshots) listSnapshotsHandler(ctx context.Context, cp *iipb.CloudProperties, newLister newListerFunc, w io.Writer) (string, subcommands.ExitStatus) {
+	if l.Project == "" {
+		l.Project = cp.GetProjectId()
+	}
+	if l.Project == "" {
+		errMessage := "ERROR: -project is required when the project cannot be read from the metadata server"
+		l.oteLogger.LogMessageToConsole(errMessage)
+		return errMessage, subcommands.ExitUsageError
+	}
+	lister, err := newLister(ctx)
+	if err != nil {
+		errMessage := "ERROR: Failed to create GCE service"
+		l.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
+		return errMessage, subcommands.ExitFailure
+	}
+	list, err := lister.ListSnapshots(ctx, l.Project)
+	if err != nil {
+		errMessage := fmt.Sprintf("ERROR: Failed to list the snapshots of project %s", l.Project)
+		l.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
+		return errMessage, subcommands.ExitFailure
+	}
+
+	snapshots := l.agentSnapshots(list.Items)
+	log.CtxLogger(ctx).Debugw("Listed agent snapshots", "project", l.Project, "snapshots", len(snapshots), "projectSnapshots", len(list.Items))
+	var out string
+	if l.JSON {
+		b, err := json.MarshalIndent(snapshots, "", "  ")
+		if err != nil {
+			errMessage := "ERROR: Failed to marshal the snapshots"
+			l.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
+			return errMessage, subcommands.ExitFailure
+		}
+		out = string(b) + "\n"
+	} else {
+		out = snapshotTable(snapshots)
+	}
+	fmt.Fprint(w, out)
+	return out, subcommands.ExitSuccess
+}
+
+// agentSnapshots returns the snapshots created by the agent which match the -sid and
+// -source-disk filters, sorted by creation time.
+func (l *ListSnapshots) agentSnapshots(items []*compute.Snapshot) []snapshotInfo {
+	snapshots := []snapshotInfo{}
+	for _, s := range items {
+		if !agentManaged(s.Labels) {
+			continue
+		}
+		info := snapshotInfo{
+			Name:          s.Name,
+			SourceDisk:    path.Base(s.SourceDisk),
+			SID:           s.Labels[sidLabel],
+			CreationTime:  s.CreationTimestamp,
+			DiskSizeGB:    s.DiskSizeGb,
+			StorageBytes:  s.StorageBytes,
+			SnapshotType:  s.SnapshotType,
+			GroupSnapshot: s.Labels[groupLabel],
+			ReplicaOf:     s.Labels[replicaLabel],
+		}
+		if s.SourceDisk == "" {
+			// Snapshots converted from instant snapshots of a group only name their disk in a label.
+			info.SourceDisk = s.Labels[diskNameLabel]
+		}
+		if l.Sid != "" && !strings.EqualFold(info.SID, l.Sid) {
+			continue
+		}
+		if l.Disk != "" && info.SourceDisk != l.Disk {
+			continue
+		}
+		snapshots = append(snapshots, info)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].CreationTime < snapshots[j].CreationTime })
+	return snapshots
+}
+
+// agentManaged reports whether the snapshot has any of the labels applied by the agent.
+func agentManaged(labels map[string]string) bool {
+	for k := range labels {
+		if strings.HasPrefix(k, agentLabelPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotTable returns a table with a row for each snapshot.
+func snapshotTable(snapshots []snapshotInfo) string {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSOURCE DISK\tSID\tCREATED\tDISK SIZE GB\tSTORAGE BYTES\tTYPE\tGROUP")
+	for _, s := range snapshots {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s\n", s.Name, s.SourceDisk, s.SID, s.CreationTime, s.DiskSizeGB, s.StorageBytes, s.SnapshotType, s.GroupSnapshot)
+	}
+	tw.Flush()
+	return b.String()
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package listsnapshots
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"flag"
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	iipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+)
+
+func TestMain(m *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(m.Run())
+}
+
+var (
+	defaultCloudProperties = &iipb.CloudProperties{ProjectId: "test-project"}
+
+	defaultSnapshots = &compute.SnapshotList{Items: []*compute.Snapshot{
+		{
+			Name:              "snapshot-pd-1-new",
+			SourceDisk:        "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-east1-b/disks/pd-1",
+			CreationTimestamp: "2024-03-05T10:00:00.000-08:00",
+			DiskSizeGb:        100,
+			StorageBytes:      2048,
+			SnapshotType:      "STANDARD",
+			Labels:            map[string]string{"goog-sapagent-sid": "abc"},
+		},
+		{
+			Name:              "snapshot-pd-1-old",
+			SourceDisk:        "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-east1-b/disks/pd-1",
+			CreationTimestamp: "2024-03-04T10:00:00.000-08:00",
+			DiskSizeGb:        100,
+			StorageBytes:      1024,
+			SnapshotType:      "ARCHIVE",
+			Labels:            map[string]string{"goog-sapagent-sid": "abc", "team": "sap"},
+		},
+		{
+			Name:              "group-pd-2",
+			CreationTimestamp: "2024-03-06T10:00:00.000-08:00",
+			DiskSizeGb:        200,
+			SnapshotType:      "STANDARD",
+			Labels:            map[string]string{"goog-sapagent-sid": "def", "goog-sapagent-isg": "group", "goog-sapagent-disk-name": "pd-2"},
+		},
+		{
+			Name:              "manual",
+			SourceDisk:        "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-east1-b/disks/pd-1",
+			CreationTimestamp: "2024-03-01T10:00:00.000-08:00",
+			Labels:            map[string]string{"team": "sap"},
+		},
+	}}
+)
+
+type fakeLister struct {
+	list *compute.SnapshotList
+	err  error
+}
+
+func (f *fakeLister) ListSnapshots(ctx context.Context, project string) (*compute.SnapshotList, error) {
+	return f.list, f.err
+}
+
+func fakeNewLister(l snapshotLister, err error) newListerFunc {
+	return func(context.Context) (snapshotLister, error) { return l, err }
+}
+
+func TestExecuteListSnapshots(t *testing.T) {
+	tests := []struct {
+		name string
+		l    ListSnapshots
+		want subcommands.ExitStatus
+		args []any
+	}{
+		{
+			name: "FailLengthArgs",
+			want: subcommands.ExitUsageError,
+			args: []any{},
+		},
+		{
+			name: "FailAssertFirstArgs",
+			want: subcommands.ExitUsageError,
+			args: []any{
+				"test",
+				"test2",
+				"test3",
+			},
+		},
+		{
+			name: "SuccessForHelp",
+			l:    ListSnapshots{help: true},
+			want: subcommands.ExitSuccess,
+			args: []any{
+				"test",
+				log.Parameters{},
+				"test3",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.l.Execute(context.Background(), &flag.FlagSet{Usage: func() { return }}, test.args...)
+			if got != test.want {
+				t.Errorf("Execute(%v)=%v, want %v", test.args, got, test.want)
+			}
+		})
+	}
+}
+
+func TestListSnapshotsHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		l          ListSnapshots
+		cp         *iipb.CloudProperties
+		newLister  newListerFunc
+		wantStatus subcommands.ExitStatus
+		wantNames  []string
+	}{
+		{
+			name:       "NoProject",
+			newLister:  fakeNewLister(&fakeLister{list: defaultSnapshots}, nil),
+			wantStatus: subcommands.ExitUsageError,
+		},
+		{
+			name:       "ListerError",
+			cp:         defaultCloudProperties,
+			newLister:  fakeNewLister(nil, errors.New("no credentials")),
+			wantStatus: subcommands.ExitFailure,
+		},
+		{
+			name:       "ListError",
+			cp:         defaultCloudProperties,
+			newLister:  fakeNewLister(&fakeLister{err: errors.New("permission denied")}, nil),
+			wantStatus: subcommands.ExitFailure,
+		},
+		{
+			name:       "AllAgentSnapshots",
+			cp:         defaultCloudProperties,
+			newLister:  fakeNewLister(&fakeLister{list: defaultSnapshots}, nil),
+			wantStatus: subcommands.ExitSuccess,
+			wantNames:  []string{"snapshot-pd-1-old", "snapshot-pd-1-new", "group-pd-2"},
+		},
+		{
+			name:       "FilterBySID",
+			l:          ListSnapshots{Sid: "ABC"},
+			cp:         defaultCloudProperties,
+			newLister:  fakeNewLister(&fakeLister{list: defaultSnapshots}, nil),
+			wantStatus: subcommands.ExitSuccess,
+			wantNames:  []string{"snapshot-pd-1-old", "snapshot-pd-1-new"},
+		},
+		{
+			name:       "FilterByDisk",
+			l:          ListSnapshots{Disk: "pd-2", Project: "other-project"},
+			newLister:  fakeNewLister(&fakeLister{list: defaultSnapshots}, nil),
+			wantStatus: subcommands.ExitSuccess,
+			wantNames:  []string{"group-pd-2"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.l.JSON = true
+			test.l.oteLogger = onetime.CreateOTELogger(false)
+			var w bytes.Buffer
+			got, status := test.l.listSnapshotsHandler(context.Background(), test.cp, test.newLister, &w)
+			if status != test.wantStatus {
+				t.Fatalf("listSnapshotsHandler() returned status %v, want %v", status, test.wantStatus)
+			}
+			if status != subcommands.ExitSuccess {
+				return
+			}
+			var snapshots []snapshotInfo
+			if err := json.Unmarshal([]byte(got), &snapshots); err != nil {
+				t.Fatalf("listSnapshotsHandler() printed invalid JSON %q: %v", got, err)
+			}
+			var gotNames []string
+			for _, s := range snapshots {
+				gotNames = append(gotNames, s.Name)
+			}
+			if diff := cmp.Diff(test.wantNames, gotNames); diff != "" {
+				t.Errorf("listSnapshotsHandler() returned unexpected snapshots (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSnapshotTable(t *testing.T) {
+	l := &ListSnapshots{Sid: "def"}
+	got := snapshotTable(l.agentSnapshots(defaultSnapshots.Items))
+	for _, want := range []string{
+		"NAME        SOURCE DISK  SID  CREATED",
+		"group-pd-2  pd-2         def  2024-03-06T10:00:00.000-08:00  200",
+		"STANDARD  group",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("snapshotTable() = %q, want it to contain %q", got, want)
+		}
+	}
+}
@@ -79,6 +79,29 @@ type (
 		LogToConsole bool
 		LogUsage     bool
 	}
+
+	// ErrorCategory classifies the failures of one time commands, each category exits with a
+	// distinct status so that automation wrapping the commands can react to them differently.
+	ErrorCategory int
+)
+
+// Error categories of the one time commands.
+const (
+	ErrorCategoryNone ErrorCategory = iota
+	ErrorCategoryUsage
+	ErrorCategoryPrecondition
+	ErrorCategoryGCEAPI
+	ErrorCategoryDatabase
+	ErrorCategorySnapshot
+)
+
+// Exit statuses of the error categories which have no subcommands equivalent. The values stay
+// clear of the statuses reserved by the subcommands package.
+const (
+	ExitPreconditionFailure subcommands.ExitStatus = 10
+	ExitGCEAPIError         subcommands.ExitStatus = 11
+	ExitDatabaseError       subcommands.ExitStatus = 12
+	ExitSnapshotError       subcommands.ExitStatus = 13
 )
 
 // Init performs all initialization steps for OTE subcommands.
@@ -264,3 +287,44 @@ func (l *OTELogger) LogUsageError(error int) {
 		usagemetrics.Error(error)
 	}
 }
+
+// String returns the name of the category used in status metric labels.
+func (c ErrorCategory) String() string {
+	switch c {
+	case ErrorCategoryNone:
+		return "none"
+	case ErrorCategoryUsage:
+		return "usage"
+	case ErrorCategoryPrecondition:
+		return "precondition"
+	case ErrorCategoryGCEAPI:
+		return "gce_api"
+	case ErrorCategoryDatabase:
+		return "database"
+	case ErrorCategorySnapshot:
+		return "snapshot"
+	default:
+		return "unknown"
+	}
+}
+
+// ExitStatus returns the exit status of a command failing with the category.
+// Usage errors keep subcommands.ExitUsageError.
+func (c ErrorCategory) ExitStatus() subcommands.ExitStatus {
+	switch c {
+	case ErrorCategoryNone:
+		return subcommands.ExitSuccess
+	case ErrorCategoryUsage:
+		return subcommands.ExitUsageError
+	case ErrorCategoryPrecondition:
+		return ExitPreconditionFailure
+	case ErrorCategoryGCEAPI:
+		return ExitGCEAPIError
+	case ErrorCategoryDatabase:
+		return ExitDatabaseError
+	case ErrorCategorySnapshot:
+		return ExitSnapshotError
+	default:
+		return subcommands.ExitFailure
+	}
+}
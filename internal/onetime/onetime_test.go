@@ -251,3 +251,29 @@ func TestLogFilesPath(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorCategory(t *testing.T) {
+	tests := []struct {
+		category       ErrorCategory
+		wantName       string
+		wantExitStatus subcommands.ExitStatus
+	}{
+		{category: ErrorCategoryNone, wantName: "none", wantExitStatus: subcommands.ExitSuccess},
+		{category: ErrorCategoryUsage, wantName: "usage", wantExitStatus: subcommands.ExitUsageError},
+		{category: ErrorCategoryPrecondition, wantName: "precondition", wantExitStatus: ExitPreconditionFailure},
+		{category: ErrorCategoryGCEAPI, wantName: "gce_api", wantExitStatus: ExitGCEAPIError},
+		{category: ErrorCategoryDatabase, wantName: "database", wantExitStatus: ExitDatabaseError},
+		{category: ErrorCategorySnapshot, wantName: "snapshot", wantExitStatus: ExitSnapshotError},
+		{category: ErrorCategory(100), wantName: "unknown", wantExitStatus: subcommands.ExitFailure},
+	}
+	for _, test := range tests {
+		t.Run(test.wantName, func(t *testing.T) {
+			if got := test.category.String(); got != test.wantName {
+				t.Errorf("ErrorCategory(%d).String()=%q, want: %q", test.category, got, test.wantName)
+			}
+			if got := test.category.ExitStatus(); got != test.wantExitStatus {
+				t.Errorf("ErrorCategory(%d).ExitStatus()=%v, want: %v", test.category, got, test.wantExitStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package processstatus implements the one time execution mode printing the status of the
+// processes of the SAP instances on this host, optionally refreshing it until interrupted.
+package processstatus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"flag"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/sapcontrol"
+	"github.com/GoogleCloudPlatform/sapagent/internal/sapcontrolclient"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system/sapdiscovery"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	sapb "github.com/GoogleCloudPlatform/sapagent/protos/sapapp"
+)
+
+const (
+	defaultIntervalSeconds = 5
+	// clearScreen moves the cursor to the top left corner and clears the terminal.
+	clearScreen = "\033[H\033[2J"
+)
+
+type (
+	// discoverFunc provides a testable replacement for sapdiscovery.SAPApplications.
+	discoverFunc func(context.Context) *sapb.SAPInstances
+
+	// clientFunc returns the sapcontrol client of the instance number.
+	clientFunc func(instanceNumber string) sapcontrol.ClientInterface
+)
+
+// ProcessStatus stores the arguments for the processstatus subcommand.
+type ProcessStatus struct {
+	Watch           bool   `json:"watch,string"`
+	IntervalSeconds int64  `json:"interval-seconds,string"`
+	LogLevel        string `json:"loglevel"`
+	LogPath         string `json:"log-path"`
+	help            bool
+	oteLogger       *onetime.OTELogger
+}
+
+// Name implements the subcommand interface for processstatus.
+func (*ProcessStatus) Name() string { return "processstatus" }
+
+// Synopsis implements the subcommand interface for processstatus.
+func (*ProcessStatus) Synopsis() string {
+	return "print the status of the SAP processes on this host"
+}
+
+// Usage implements the subcommand interface for processstatus.
+func (*ProcessStatus) Usage() string {
+	return `Usage: processstatus [-watch] [-interval-seconds=<seconds>]
+	[-h] [-loglevel=<debug|info|warn|error>] [-log-path=<log-path>]
+	Prints the GREEN/RED status of each process of the SAP instances on this host, as reported by
+	sapcontrol GetProcessList. With -watch the table is refreshed every -interval-seconds until
+	interrupted.` + "\n"
+}
+
+// SetFlags implements the subcommand interface for processstatus.
+func (p *ProcessStatus) SetFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&p.Watch, "watch", false, "Refresh the process statuses until interrupted. (optional) Default: false")
+	fs.Int64Var(&p.IntervalSeconds, "interval-seconds", defaultIntervalSeconds, "Seconds between the refreshes in -watch mode. (optional) Default: 5")
+	fs.BoolVar(&p.help, "h", false, "Displays help")
+	fs.StringVar(&p.LogLevel, "loglevel", "info", "Sets the logging level")
+	fs.StringVar(&p.LogPath, "log-path", "", "The log path to write the log file (optional), default value is /var/log/google-cloud-sap-agent/processstatus.log")
+}
+
+// Execute implements the subcommand interface for processstatus.
+func (p *ProcessStatus) Execute(ctx context.Context, f *flag.FlagSet, args ...any) subcommands.ExitStatus {
+	_, _, exitStatus, completed := onetime.Init(ctx, onetime.InitOptions{
+		Name:     p.Name(),
+		Help:     p.help,
+		LogLevel: p.LogLevel,
+		LogPath:  p.LogPath,
+		Fs:       f,
+	}, args...)
+	if !completed {
+		return exitStatus
+	}
+
+	_, status := p.Run(ctx, onetime.CreateRunOptions(nil, false))
+	return status
+}
+
+// Run prints the process statuses, refreshing them until interrupted in -watch mode.
+func (p *ProcessStatus) Run(ctx context.Context, opts *onetime.RunOptions) (string, subcommands.ExitStatus) {
+	p.oteLogger = onetime.CreateOTELogger(opts.DaemonMode)
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return p.processStatusHandler(ctx, sapdiscovery.SAPApplications, newClient, os.Stdout)
+}
+
+func newClient(instanceNumber string) sapcontrol.ClientInterface {
+	return sapcontrolclient.New(instanceNumber)
+}
+
+func (p *ProcessStatus) processStatusHandler(ctx context.Context, discover discoverFunc, client clientFunc, w io.Writer) (string, subcommands.ExitStatus) {
+	if p.Watch && p.IntervalSeconds <= 0 {
+		errMessage := fmt.Sprintf("ERROR: -interval-seconds must be positive, got %d", p.IntervalSeconds)
+		p.oteLogger.LogMessageToConsole(errMessage)
+		return errMessage, subcommands.ExitUsageError
+	}
+	instances := discover(ctx).GetInstances()
+	if len(instances) == 0 {
+		errMessage := "ERROR: No SAP instances found on this host"
+		p.oteLogger.LogMessageToConsole(errMessage)
+		return errMessage, subcommands.ExitFailure
+	}
+
+	table := statusTable(ctx, instances, client, time.Now())
+	if !p.Watch {
+		fmt.Fprint(w, table)
+		return table, subcommands.ExitSuccess
+	}
+	ticker := time.NewTicker(time.Duration(p.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		fmt.Fprint(w, clearScreen+table)
+		select {
+		case <-ctx.Done():
+			log.CtxLogger(ctx).Info("Process status watch interrupted")
+			return table, subcommands.ExitSuccess
+		case <-ticker.C:
+			table = statusTable(ctx, instances, client, time.Now())
+		}
+	}
+}
+
+// statusTable returns a table with a row for each process of each instance. An instance whose
+// process list cannot be read has a single row with the error.
+func statusTable(ctx context.Context, instances []*sapb.SAPInstance, client clientFunc, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "SAP process status at %s\n", now.Format(time.RFC3339))
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SID\tINSTANCE\tPROCESS\tSTATUS\tPID\tUPTIME")
+	for _, instance := range instances {
+		sc := &sapcontrol.Properties{Instance: instance}
+		processes, err := sc.GetProcessList(ctx, client(instance.GetInstanceNumber()))
+		if err != nil {
+			fmt.Fprintf(tw, "%s\t%s\t-\tERROR: %v\t-\t-\n", instance.GetSapsid(), instance.GetInstanceNumber(), err)
+			continue
+		}
+		keys := make([]int, 0, len(processes))
+		for k := range processes {
+			keys = append(keys, k)
+		}
+		sort.Ints(keys)
+		for _, k := range keys {
+			ps := processes[k]
+			uptime := "-"
+			if ps.HasUptime {
+				uptime = ps.Uptime.String()
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", instance.GetSapsid(), instance.GetInstanceNumber(), ps.Name, ps.DisplayStatus, ps.PID, uptime)
+		}
+	}
+	tw.Flush()
+	return b.String()
+}
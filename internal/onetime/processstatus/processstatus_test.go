@@ -0,0 +1,170 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processstatus
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"flag"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/sapcontrol"
+	"github.com/GoogleCloudPlatform/sapagent/internal/sapcontrolclient"
+	"github.com/GoogleCloudPlatform/sapagent/internal/sapcontrolclient/test/sapcontrolclienttest"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	sapb "github.com/GoogleCloudPlatform/sapagent/protos/sapapp"
+)
+
+func TestMain(m *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(m.Run())
+}
+
+var defaultInstances = &sapb.SAPInstances{
+	Instances: []*sapb.SAPInstance{
+		{Sapsid: "DEH", InstanceNumber: "00"},
+		{Sapsid: "DEV", InstanceNumber: "01"},
+	},
+}
+
+func fakeDiscover(instances *sapb.SAPInstances) discoverFunc {
+	return func(context.Context) *sapb.SAPInstances { return instances }
+}
+
+func fakeClient(instanceNumber string) sapcontrol.ClientInterface {
+	if instanceNumber == "01" {
+		return sapcontrolclienttest.Fake{ErrGetProcessList: errors.New("sapcontrol unavailable")}
+	}
+	return sapcontrolclienttest.Fake{
+		Processes: []sapcontrolclient.OSProcess{
+			{Name: "hdbdaemon", Dispstatus: "SAPControl-GREEN", Pid: 111, Elapsedtime: "1:02:03"},
+			{Name: "hdbindexserver", Dispstatus: "SAPControl-RED", Pid: 222},
+		},
+	}
+}
+
+func TestExecuteProcessStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		p    ProcessStatus
+		want subcommands.ExitStatus
+		args []any
+	}{
+		{
+			name: "FailLengthArgs",
+			want: subcommands.ExitUsageError,
+			args: []any{},
+		},
+		{
+			name: "FailAssertFirstArgs",
+			want: subcommands.ExitUsageError,
+			args: []any{
+				"test",
+				"test2",
+				"test3",
+			},
+		},
+		{
+			name: "SuccessForHelp",
+			p:    ProcessStatus{help: true},
+			want: subcommands.ExitSuccess,
+			args: []any{
+				"test",
+				log.Parameters{},
+				"test3",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.p.Execute(context.Background(), &flag.FlagSet{Usage: func() { return }}, test.args...)
+			if got != test.want {
+				t.Errorf("Execute(%v)=%v, want %v", test.args, got, test.want)
+			}
+		})
+	}
+}
+
+func TestProcessStatusHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		p             ProcessStatus
+		instances     *sapb.SAPInstances
+		wantStatus    subcommands.ExitStatus
+		wantContains  []string
+		wantNoRefresh bool
+	}{
+		{
+			name:       "NoInstances",
+			instances:  &sapb.SAPInstances{},
+			wantStatus: subcommands.ExitFailure,
+		},
+		{
+			name:       "InvalidInterval",
+			p:          ProcessStatus{Watch: true, IntervalSeconds: 0},
+			instances:  defaultInstances,
+			wantStatus: subcommands.ExitUsageError,
+		},
+		{
+			name:       "Once",
+			instances:  defaultInstances,
+			wantStatus: subcommands.ExitSuccess,
+			wantContains: []string{
+				"SID  INSTANCE  PROCESS",
+				"DEH  00        hdbdaemon       GREEN",
+				"DEH  00        hdbindexserver  RED",
+				"1h2m3s",
+				"DEV  01        -               ERROR: sapcontrol unavailable",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.p.oteLogger = onetime.CreateOTELogger(false)
+			var w bytes.Buffer
+			got, status := test.p.processStatusHandler(context.Background(), fakeDiscover(test.instances), fakeClient, &w)
+			if status != test.wantStatus {
+				t.Errorf("processStatusHandler() returned status %v, want %v", status, test.wantStatus)
+			}
+			for _, want := range test.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("processStatusHandler() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessStatusHandlerWatch(t *testing.T) {
+	p := &ProcessStatus{Watch: true, IntervalSeconds: 1, oteLogger: onetime.CreateOTELogger(false)}
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	var w bytes.Buffer
+	if _, status := p.processStatusHandler(ctx, fakeDiscover(defaultInstances), fakeClient, &w); status != subcommands.ExitSuccess {
+		t.Errorf("processStatusHandler() returned status %v, want %v", status, subcommands.ExitSuccess)
+	}
+	if got := strings.Count(w.String(), clearScreen); got != 2 {
+		t.Errorf("processStatusHandler() refreshed the table %d times, want 2", got)
+	}
+}
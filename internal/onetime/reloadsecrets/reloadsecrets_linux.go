@@ -0,0 +1,104 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reloadsecrets implements an OTE which requests the running agent to re-read the
+// Secret Manager secrets and reconnect the HANA Monitoring databases without a restart.
+package reloadsecrets
+
+import (
+	"context"
+
+	"flag"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
+)
+
+var (
+	executeCommand = commandlineexecutor.ExecuteCommand
+)
+
+// ReloadSecrets has args for reloadsecrets subcommands.
+type ReloadSecrets struct {
+	help      bool
+	logPath   string
+	oteLogger *onetime.OTELogger
+}
+
+// Name implements the subcommand interface for the reloadsecrets OTE.
+func (*ReloadSecrets) Name() string { return "reloadsecrets" }
+
+// Synopsis implements the subcommand interface for the reloadsecrets OTE.
+func (*ReloadSecrets) Synopsis() string {
+	return "re-read the Secret Manager secrets and reconnect the HANA Monitoring databases of the running agent"
+}
+
+// Usage implements the subcommand interface for the reloadsecrets OTE.
+func (*ReloadSecrets) Usage() string {
+	return `Usage: reloadsecrets [args]
+
+  Sends SIGHUP to the google-cloud-sap-agent systemd service. The agent re-reads the Secret Manager
+  secrets of the HANA Monitoring databases and reconnects them, the refreshed connections are
+  logged in the agent log.
+
+  Args (optional):
+    [-log-path="/var/log/google-cloud-sap-agent/reloadsecrets.log"]			The full linux log path to write the log file (optional).
+		    Default value is /var/log/google-cloud-sap-agent/reloadsecrets.log
+
+  Global options:
+    [-h]` + "\n"
+}
+
+// SetFlags implements the subcommand interface for the reloadsecrets OTE.
+func (c *ReloadSecrets) SetFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&c.help, "h", false, "Displays help")
+	fs.StringVar(&c.logPath, "log-path", "", "The log path to write the log file (optional), default value is /var/log/google-cloud-sap-agent/reloadsecrets.log")
+}
+
+// Execute implements the subcommand interface for the reloadsecrets OTE.
+func (c *ReloadSecrets) Execute(ctx context.Context, f *flag.FlagSet, args ...any) subcommands.ExitStatus {
+	_, _, exitStatus, completed := onetime.Init(ctx, onetime.InitOptions{
+		Name:     c.Name(),
+		Help:     c.help,
+		Fs:       f,
+		LogLevel: "info",
+		LogPath:  c.logPath,
+	}, args...)
+	if !completed {
+		return exitStatus
+	}
+	return c.Run(ctx, onetime.CreateRunOptions(nil, false))
+}
+
+// Run signals the running agent to reload its secrets.
+func (c *ReloadSecrets) Run(ctx context.Context, runOpts *onetime.RunOptions) subcommands.ExitStatus {
+	c.oteLogger = onetime.CreateOTELogger(runOpts.DaemonMode)
+	c.oteLogger.LogMessageToFileAndConsole(ctx, "Reload secrets starting")
+	c.oteLogger.LogUsageAction(usagemetrics.ReloadSecretsStarted)
+	result := executeCommand(ctx, commandlineexecutor.Params{
+		Executable: "sudo",
+		Args:       []string{"systemctl", "kill", "--signal=SIGHUP", "--kill-who=main", "google-cloud-sap-agent"},
+	})
+	if result.Error != nil {
+		c.oteLogger.LogErrorToFileAndConsole(ctx, "Reload secrets: FAILED", result.Error)
+		c.oteLogger.LogUsageError(usagemetrics.ReloadSecretsFailure)
+		return subcommands.ExitFailure
+	}
+	c.oteLogger.LogMessageToFileAndConsole(ctx, "Reload secrets requested, the refreshed connections are logged in the agent log")
+	c.oteLogger.LogUsageAction(usagemetrics.ReloadSecretsFinished)
+	return subcommands.ExitSuccess
+}
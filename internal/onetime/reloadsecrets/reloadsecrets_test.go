@@ -0,0 +1,119 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reloadsecrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"flag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+func TestMain(t *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(t.Run())
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name string
+		r    ReloadSecrets
+		exec func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result
+		want subcommands.ExitStatus
+		args []any
+	}{
+		{
+			name: "FailLengthArgs",
+			want: subcommands.ExitUsageError,
+			args: []any{},
+		},
+		{
+			name: "SuccessForHelp",
+			r:    ReloadSecrets{help: true},
+			want: subcommands.ExitSuccess,
+			args: []any{
+				"h",
+				log.Parameters{},
+				&ipb.CloudProperties{},
+			},
+		},
+		{
+			name: "Success",
+			args: []any{
+				"test",
+				log.Parameters{},
+				&ipb.CloudProperties{},
+			},
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{}
+			},
+			want: subcommands.ExitSuccess,
+		},
+		{
+			name: "Failure",
+			args: []any{
+				"test",
+				log.Parameters{},
+				&ipb.CloudProperties{},
+			},
+			exec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: errors.New("test error")}
+			},
+			want: subcommands.ExitFailure,
+		},
+	}
+	defer func(f func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result) {
+		executeCommand = f
+	}(executeCommand)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			executeCommand = test.exec
+			got := test.r.Execute(context.Background(), &flag.FlagSet{Usage: func() { return }}, test.args...)
+			if got != test.want {
+				t.Errorf("Execute(%v, %v)=%v, want %v", test.r, test.args, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRunSignalsAgent(t *testing.T) {
+	defer func(f func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result) {
+		executeCommand = f
+	}(executeCommand)
+	var got commandlineexecutor.Params
+	executeCommand = func(_ context.Context, p commandlineexecutor.Params) commandlineexecutor.Result {
+		got = p
+		return commandlineexecutor.Result{}
+	}
+	r := &ReloadSecrets{}
+	r.Run(context.Background(), onetime.CreateRunOptions(nil, false))
+	want := commandlineexecutor.Params{
+		Executable: "sudo",
+		Args:       []string{"systemctl", "kill", "--signal=SIGHUP", "--kill-who=main", "google-cloud-sap-agent"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() executed unexpected command (-want +got):\n%s", diff)
+	}
+}
@@ -88,7 +88,7 @@ type discoveryInterface interface {
 // RemoteValidation has args for remote subcommands.
 type RemoteValidation struct {
 	project, instanceid, instancename, zone, config string
-	help                                            bool
+	help, explain                                   bool
 }
 
 // Name implements the subcommand interface for remote.
@@ -101,7 +101,7 @@ func (*RemoteValidation) Synopsis() string {
 
 // Usage implements the subcommand interface for remote.
 func (*RemoteValidation) Usage() string {
-	return "Usage: remote -project=<project-id> -instance=<instance-id> -name=<instance-name> -zone=<instance-zone> [-h]\n"
+	return "Usage: remote -project=<project-id> -instance=<instance-id> -name=<instance-name> -zone=<instance-zone> [-explain] [-h]\n"
 }
 
 // SetFlags implements the subcommand interface for remote.
@@ -116,6 +116,7 @@ func (r *RemoteValidation) SetFlags(fs *flag.FlagSet) {
 	fs.StringVar(&r.zone, "zone", "", "zone of this instance")
 	fs.StringVar(&r.config, "c", "", "workload validation collection config")
 	fs.StringVar(&r.config, "config", "", "workload validation collection config")
+	fs.BoolVar(&r.explain, "explain", false, "Print the inputs read and the evaluation result of each rule instead of the collected metrics")
 	fs.BoolVar(&r.help, "h", false, "Display help")
 }
 
@@ -152,7 +153,8 @@ func (r *RemoteValidation) Execute(ctx context.Context, f *flag.FlagSet, args ..
 			Execute: commandlineexecutor.ExecuteCommand,
 		},
 		SapDiscoveryInterface: &appsdiscovery.SapDiscovery{
-			Execute: commandlineexecutor.ExecuteCommand,
+			Execute:      commandlineexecutor.ExecuteCommand,
+			HostResolver: net.LookupHost,
 		},
 	}
 	system.StartSAPSystemDiscovery(ctx, config, systemDiscovery)
@@ -212,10 +214,38 @@ func (r *RemoteValidation) remoteValidationHandler(ctx context.Context, opts han
 		Discovery:             opts.discovery,
 	}
 	wlmparams.Init(ctx)
+	if r.explain {
+		writeExplanations(os.Stdout, workloadmanager.ExplainRules(ctx, wlmparams))
+		return subcommands.ExitSuccess
+	}
 	fmt.Println(workloadmanager.CollectMetricsToJSON(ctx, wlmparams))
 	return subcommands.ExitSuccess
 }
 
+// writeExplanations writes the inputs and the evaluation result of each rule.
+func writeExplanations(w io.Writer, explanations []workloadmanager.RuleExplanation) {
+	for _, e := range explanations {
+		fmt.Fprintf(w, "%s %s\n", e.Validation, e.Label)
+		if e.Source != "" {
+			fmt.Fprintf(w, "  source: %s\n", e.Source)
+		}
+		if e.Skipped != "" {
+			fmt.Fprintf(w, "  skipped: %s\n", e.Skipped)
+			continue
+		}
+		if e.Input.StdOut != "" {
+			fmt.Fprintf(w, "  stdout: %q\n", e.Input.StdOut)
+		}
+		if e.Input.StdErr != "" {
+			fmt.Fprintf(w, "  stderr: %q\n", e.Input.StdErr)
+		}
+		if e.Input.ExitCode != "" {
+			fmt.Fprintf(w, "  exit code: %s\n", e.Input.ExitCode)
+		}
+		fmt.Fprintf(w, "  result: %t, value: %q\n", e.Result, e.Value)
+	}
+}
+
 func (r *RemoteValidation) createConfiguration() *cpb.Configuration {
 	return &cpb.Configuration{
 		CloudProperties: &iipb.CloudProperties{
@@ -17,6 +17,7 @@ limitations under the License.
 package remotevalidation
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io/fs"
@@ -29,8 +30,10 @@ import (
 	"google.golang.org/protobuf/testing/protocmp"
 	"github.com/google/subcommands"
 	"github.com/GoogleCloudPlatform/sapagent/internal/collectiondefinition"
+	"github.com/GoogleCloudPlatform/sapagent/internal/configurablemetrics"
 	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
 	"github.com/GoogleCloudPlatform/sapagent/internal/instanceinfo"
+	"github.com/GoogleCloudPlatform/sapagent/internal/workloadmanager"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 
 	dpb "google.golang.org/protobuf/types/known/durationpb"
@@ -217,6 +220,18 @@ func TestRemoteValidationHandler(t *testing.T) {
 			discovery: &fakeDiscoveryInterface{},
 			want:      subcommands.ExitFailure,
 		},
+		{
+			name: "ExplainSuccess",
+			remote: &RemoteValidation{
+				project:    "project-1",
+				instanceid: "instance-1",
+				zone:       "zone-1",
+				explain:    true,
+			},
+			loadOptions: defaultLoadOptions,
+			discovery:   &fakeDiscoveryInterface{},
+			want:        subcommands.ExitSuccess,
+		},
 		{
 			name: "CollectionDefinitionLoadSuccess",
 			remote: &RemoteValidation{
@@ -244,8 +259,43 @@ func TestRemoteValidationHandler(t *testing.T) {
 	}
 }
 
+func TestWriteExplanations(t *testing.T) {
+	explanations := []workloadmanager.RuleExplanation{
+		{
+			Validation: "workload.googleapis.com/sap/validation/system",
+			Explanation: configurablemetrics.Explanation{
+				Label:  "kernel",
+				Source: "uname -r",
+				Input:  configurablemetrics.Output{StdOut: "5.14.21-default", ExitCode: "0"},
+				Value:  "true",
+				Result: true,
+			},
+		},
+		{
+			Validation: "workload.googleapis.com/sap/validation/hana",
+			Explanation: configurablemetrics.Explanation{
+				Label:   "fast_restart",
+				Skipped: "HANA not active on instance",
+			},
+		},
+	}
+	want := `workload.googleapis.com/sap/validation/system kernel
+  source: uname -r
+  stdout: "5.14.21-default"
+  exit code: 0
+  result: true, value: "true"
+workload.googleapis.com/sap/validation/hana fast_restart
+  skipped: HANA not active on instance
+`
+	var buf bytes.Buffer
+	writeExplanations(&buf, explanations)
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("writeExplanations() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
 func TestUsageForRemoteValidation(t *testing.T) {
-	want := "Usage: remote -project=<project-id> -instance=<instance-id> -name=<instance-name> -zone=<instance-zone> [-h]\n"
+	want := "Usage: remote -project=<project-id> -instance=<instance-id> -name=<instance-name> -zone=<instance-zone> [-explain] [-h]\n"
 	rv := RemoteValidation{}
 	got := rv.Usage()
 	if got != want {
@@ -254,7 +304,7 @@ func TestUsageForRemoteValidation(t *testing.T) {
 }
 
 func TestSetFlagsForRemoteValidation(t *testing.T) {
-	flags := []string{"project", "instance", "zone", "name"}
+	flags := []string{"project", "instance", "zone", "name", "explain"}
 	fs := flag.NewFlagSet("flags", flag.ExitOnError)
 	remoteValidation := RemoteValidation{}
 	remoteValidation.SetFlags(fs)
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"time"
 
 	"flag"
 	logging "cloud.google.com/go/logging"
@@ -145,6 +146,19 @@ func (sd *SystemDiscovery) systemDiscoveryHandler(ctx context.Context, cloudLogg
 	if err := sd.initDefaults(ctx, cloudLoggingClient, gce.NewGCEClient); err != nil {
 		return nil, fmt.Errorf("failed to initialize SystemDiscovery params: %v", err)
 	}
+	if cd, ok := sd.CloudDiscoveryInterface.(*clouddiscovery.CloudDiscovery); ok {
+		cd.DiscoverSnapshots = config.GetDiscoveryConfiguration().GetEnableSnapshotDiscovery().GetValue()
+		cd.MaxResources = int(config.GetDiscoveryConfiguration().GetMaxResourcesPerPass())
+		cd.DisabledResourceKinds = config.GetDiscoveryConfiguration().GetDisabledResourceKinds()
+		cd.FilestoreLocations = config.GetDiscoveryConfiguration().GetFilestoreLocations()
+	}
+	commandTimeout := time.Duration(config.GetDiscoveryConfiguration().GetCommandTimeoutSec()) * time.Second
+	if hd, ok := sd.HostDiscoveryInterface.(*hostdiscovery.HostDiscovery); ok {
+		hd.CommandTimeout = commandTimeout
+	}
+	if ad, ok := sd.SapDiscoveryInterface.(*appsdiscovery.SapDiscovery); ok {
+		ad.CommandTimeout = commandTimeout
+	}
 
 	// Initialize the Discovery object.
 	discovery := &system.Discovery{
@@ -183,8 +197,9 @@ func (sd *SystemDiscovery) initDefaults(ctx context.Context, cloudLoggingClient
 
 	if sd.SapDiscoveryInterface == nil {
 		sd.SapDiscoveryInterface = &appsdiscovery.SapDiscovery{
-			Execute:    commandlineexecutor.ExecuteCommand,
-			FileSystem: filesystem.Helper{},
+			Execute:      commandlineexecutor.ExecuteCommand,
+			FileSystem:   filesystem.Helper{},
+			HostResolver: net.LookupHost,
 		}
 	}
 
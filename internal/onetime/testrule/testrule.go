@@ -0,0 +1,196 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testrule implements the one time execution mode for evaluating an event rule against
+// a sample value, reporting whether the rule would fire without sending the event.
+package testrule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"flag"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/events"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+
+	evpb "github.com/GoogleCloudPlatform/sapagent/protos/events"
+)
+
+type (
+	// readFileFunc provides a testable replacement for os.ReadFile.
+	readFileFunc func(string) ([]byte, error)
+
+	// readDirFunc provides a testable replacement for os.ReadDir.
+	readDirFunc func(string) ([]os.DirEntry, error)
+)
+
+// TestRule stores the arguments for the testrule subcommand.
+type TestRule struct {
+	RulesFile string `json:"rules-file"`
+	RulesDir  string `json:"rules-dir"`
+	RuleID    string `json:"rule-id"`
+	Value     string `json:"value"`
+	RHSValue  string `json:"rhs-value"`
+	LogLevel  string `json:"loglevel"`
+	LogPath   string `json:"log-path"`
+	help      bool
+	oteLogger *onetime.OTELogger
+}
+
+// Name implements the subcommand interface for testrule.
+func (*TestRule) Name() string { return "testrule" }
+
+// Synopsis implements the subcommand interface for testrule.
+func (*TestRule) Synopsis() string { return "evaluate an event rule against a sample value" }
+
+// Usage implements the subcommand interface for testrule.
+func (*TestRule) Usage() string {
+	return `Usage: testrule <-rules-file=<path>|-rules-dir=<path>> -rule-id=<id> -value=<value> [-rhs-value=<value>]
+	[-h] [-loglevel=<debug|info|warn|error>] [-log-path=<log-path>]
+	Evaluates the trigger of the rule against the value and shows whether the rule would fire
+	and the targets the event would be sent to. No event is sent. With -rules-dir the rules of
+	all of the .json and .textproto files of the directory are loaded.` + "\n"
+}
+
+// SetFlags implements the subcommand interface for testrule.
+func (t *TestRule) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&t.RulesFile, "rules-file", "", "Path of the JSON file containing the event rules. (required unless -rules-dir is set)")
+	fs.StringVar(&t.RulesDir, "rules-dir", "", "Path of a directory of .json and .textproto rule files, rule IDs must be unique across the files. (required unless -rules-file is set)")
+	fs.StringVar(&t.RuleID, "rule-id", "", "ID of the rule to evaluate. (required)")
+	fs.StringVar(&t.Value, "value", "", "Sample value of the event source, converted to the value type of the rule source. (required)")
+	fs.StringVar(&t.RHSValue, "rhs-value", "", "Sample value of the rhs source of the trigger, used in place of reading the source. (required when the trigger has an rhs source)")
+	fs.BoolVar(&t.help, "h", false, "Displays help")
+	fs.StringVar(&t.LogLevel, "loglevel", "info", "Sets the logging level")
+	fs.StringVar(&t.LogPath, "log-path", "", "The log path to write the log file (optional), default value is /var/log/google-cloud-sap-agent/testrule.log")
+}
+
+// Execute implements the subcommand interface for testrule.
+func (t *TestRule) Execute(ctx context.Context, f *flag.FlagSet, args ...any) subcommands.ExitStatus {
+	_, _, exitStatus, completed := onetime.Init(ctx, onetime.InitOptions{
+		Name:     t.Name(),
+		Help:     t.help,
+		LogLevel: t.LogLevel,
+		LogPath:  t.LogPath,
+		Fs:       f,
+	}, args...)
+	if !completed {
+		return exitStatus
+	}
+
+	_, status := t.Run(ctx, onetime.CreateRunOptions(nil, false))
+	return status
+}
+
+// Run evaluates the rule and returns the formatted result.
+func (t *TestRule) Run(ctx context.Context, opts *onetime.RunOptions) (string, subcommands.ExitStatus) {
+	t.oteLogger = onetime.CreateOTELogger(opts.DaemonMode)
+	return t.testRuleHandler(ctx, os.ReadFile, os.ReadDir)
+}
+
+func (t *TestRule) testRuleHandler(ctx context.Context, readFile readFileFunc, readDir readDirFunc) (string, subcommands.ExitStatus) {
+	if (t.RulesFile == "") == (t.RulesDir == "") || t.RuleID == "" {
+		errMessage := "ERROR: -rule-id and one of -rules-file or -rules-dir are required"
+		t.oteLogger.LogMessageToConsole(errMessage)
+		return errMessage, subcommands.ExitUsageError
+	}
+	result, err := t.evaluate(ctx, readFile, readDir)
+	if err != nil {
+		errMessage := fmt.Sprintf("ERROR: Failed to evaluate rule %s", t.RuleID)
+		t.oteLogger.LogErrorToFileAndConsole(ctx, errMessage, err)
+		return errMessage, subcommands.ExitFailure
+	}
+	t.oteLogger.LogMessageToConsole(result)
+	return result, subcommands.ExitSuccess
+}
+
+// evaluate reads the rule from the rule files and evaluates its trigger against the value.
+func (t *TestRule) evaluate(ctx context.Context, readFile readFileFunc, readDir readDirFunc) (string, error) {
+	paths, source := []string{t.RulesFile}, t.RulesFile
+	if t.RulesDir != "" {
+		var err error
+		if paths, err = events.RuleFilesInDir(t.RulesDir, events.ReadDirFunc(readDir)); err != nil {
+			return "", err
+		}
+		source = t.RulesDir
+	}
+	rules, err := events.LoadRuleFiles(paths, events.ReadFileFunc(readFile))
+	if err != nil {
+		return "", err
+	}
+	var rule *evpb.Rule
+	for _, r := range rules {
+		if r.GetId() == t.RuleID {
+			rule = r
+			break
+		}
+	}
+	if rule == nil {
+		return "", fmt.Errorf("rule %q not found in %s", t.RuleID, source)
+	}
+
+	valueType := events.SourceValueType(rule.GetSource())
+	value, err := events.ParseValue(t.Value, valueType)
+	if err != nil {
+		return "", fmt.Errorf("converting value %q to %s: %w", t.Value, valueType, err)
+	}
+	rhs, err := events.ResolveRHS(ctx, rule.GetTrigger(), t.readRHSSource)
+	if err != nil {
+		return "", err
+	}
+	fires := rule.GetForceTrigger()
+	if !fires {
+		if fires, err = events.EvaluateWithSource(ctx, rule.GetTrigger(), value, t.readRHSSource); err != nil {
+			return "", err
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Rule: %s\n", rule.GetId())
+	fmt.Fprintf(&b, "Value: %v (%s)\n", value, valueType)
+	fmt.Fprintf(&b, "Trigger: %s %s\n", rule.GetTrigger().GetOperation(), rhs)
+	fmt.Fprintf(&b, "Fires: %t\n", fires)
+	if fires {
+		for _, target := range rule.GetTarget() {
+			fmt.Fprintf(&b, "Target: %s\n", describeTarget(target))
+		}
+	}
+	return b.String(), nil
+}
+
+// readRHSSource returns the -rhs-value converted to the value type of the rhs source, so that
+// no source is read while testing a rule.
+func (t *TestRule) readRHSSource(_ context.Context, source *evpb.EventSource) (any, error) {
+	if t.RHSValue == "" {
+		return nil, fmt.Errorf("the trigger has an rhs source, -rhs-value is required")
+	}
+	return events.ParseValue(t.RHSValue, events.SourceValueType(source))
+}
+
+// describeTarget returns the kind and destination of the event target.
+func describeTarget(target *evpb.EventTarget) string {
+	switch tgt := target.GetTarget().(type) {
+	case *evpb.EventTarget_HttpEndpoint:
+		return "http endpoint " + tgt.HttpEndpoint
+	case *evpb.EventTarget_FileEndpoint:
+		return "file " + tgt.FileEndpoint
+	case *evpb.EventTarget_CloudLogging:
+		return "cloud logging log " + tgt.CloudLogging.GetLogName()
+	}
+	return "unspecified"
+}
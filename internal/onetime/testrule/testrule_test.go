@@ -0,0 +1,253 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testrule
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"flag"
+	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+const defaultRules = `[
+	{
+		"id": "hana-memory",
+		"source": {"cloudMonitoringMetric": {"metricUrl": "workload.googleapis.com/sap/hana/memory", "metricValueType": "DOUBLE"}},
+		"trigger": {"rhs": "90", "operation": "GT"},
+		"target": [{"fileEndpoint": "/tmp/events.json"}, {"cloudLogging": {"logName": "sap-agent-events"}}]
+	},
+	{
+		"id": "replication",
+		"source": {"metadata": {"url": "instance/attributes/replication", "valueType": "STRING"}},
+		"trigger": {"rhs": "ACTIVE", "operation": "EQSTR"},
+		"target": [{"httpEndpoint": "https://example.com/events"}]
+	},
+	{
+		"id": "hana-memory-threshold",
+		"source": {"cloudMonitoringMetric": {"metricUrl": "workload.googleapis.com/sap/hana/memory", "metricValueType": "DOUBLE"}},
+		"trigger": {"rhs": "90", "operation": "GT", "rhsSource": {"metadata": {"url": "instance/attributes/memory-threshold", "valueType": "DOUBLE"}}},
+		"target": [{"fileEndpoint": "/tmp/events.json"}]
+	},
+	{
+		"id": "forced",
+		"source": {"metadata": {"url": "instance/attributes/replication", "valueType": "STRING"}},
+		"forceTrigger": true
+	}
+]`
+
+func TestMain(m *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(m.Run())
+}
+
+func fakeReadFile(data string, err error) readFileFunc {
+	return func(string) ([]byte, error) {
+		return []byte(data), err
+	}
+}
+
+func TestSetFlags(t *testing.T) {
+	r := &TestRule{}
+	fs := flag.NewFlagSet("flags", flag.ExitOnError)
+	r.SetFlags(fs)
+	for _, f := range []string{"rules-file", "rules-dir", "rule-id", "value", "rhs-value", "h", "loglevel", "log-path"} {
+		if fs.Lookup(f) == nil {
+			t.Errorf("SetFlags(%#v) flag not found: %s", fs, f)
+		}
+	}
+}
+
+func TestExecute(t *testing.T) {
+	r := &TestRule{}
+	if got := r.Execute(context.Background(), &flag.FlagSet{Usage: func() {}}); got != subcommands.ExitUsageError {
+		t.Errorf("Execute() with no arguments = %v, want: %v", got, subcommands.ExitUsageError)
+	}
+}
+
+func TestTestRuleHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		r               *TestRule
+		readFile        readFileFunc
+		want            subcommands.ExitStatus
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name:     "Fires",
+			r:        &TestRule{RulesFile: "/etc/events.json", RuleID: "hana-memory", Value: "95.5"},
+			readFile: fakeReadFile(defaultRules, nil),
+			want:     subcommands.ExitSuccess,
+			wantContains: []string{
+				"Value: 95.5 (DOUBLE)",
+				"Trigger: GT 90",
+				"Fires: true",
+				"Target: file /tmp/events.json",
+				"Target: cloud logging log sap-agent-events",
+			},
+		},
+		{
+			name:            "DoesNotFire",
+			r:               &TestRule{RulesFile: "/etc/events.json", RuleID: "hana-memory", Value: "50"},
+			readFile:        fakeReadFile(defaultRules, nil),
+			want:            subcommands.ExitSuccess,
+			wantContains:    []string{"Fires: false"},
+			wantNotContains: []string{"Target:"},
+		},
+		{
+			name:         "StringRule",
+			r:            &TestRule{RulesFile: "/etc/events.json", RuleID: "replication", Value: "ACTIVE"},
+			readFile:     fakeReadFile(defaultRules, nil),
+			want:         subcommands.ExitSuccess,
+			wantContains: []string{"Fires: true", "Target: http endpoint https://example.com/events"},
+		},
+		{
+			name:         "ForceTrigger",
+			r:            &TestRule{RulesFile: "/etc/events.json", RuleID: "forced", Value: "anything"},
+			readFile:     fakeReadFile(defaultRules, nil),
+			want:         subcommands.ExitSuccess,
+			wantContains: []string{"Fires: true"},
+		},
+		{
+			name:            "RHSSource",
+			r:               &TestRule{RulesFile: "/etc/events.json", RuleID: "hana-memory-threshold", Value: "95.5", RHSValue: "97"},
+			readFile:        fakeReadFile(defaultRules, nil),
+			want:            subcommands.ExitSuccess,
+			wantContains:    []string{"Trigger: GT 97", "Fires: false"},
+			wantNotContains: []string{"Target:"},
+		},
+		{
+			name:     "RHSSourceMissingValue",
+			r:        &TestRule{RulesFile: "/etc/events.json", RuleID: "hana-memory-threshold", Value: "95.5"},
+			readFile: fakeReadFile(defaultRules, nil),
+			want:     subcommands.ExitFailure,
+		},
+		{
+			name:     "MissingArguments",
+			r:        &TestRule{RulesFile: "/etc/events.json"},
+			readFile: fakeReadFile(defaultRules, nil),
+			want:     subcommands.ExitUsageError,
+		},
+		{
+			name:     "RulesFileAndDir",
+			r:        &TestRule{RulesFile: "/etc/events.json", RulesDir: "/etc/events.d", RuleID: "hana-memory", Value: "95"},
+			readFile: fakeReadFile(defaultRules, nil),
+			want:     subcommands.ExitUsageError,
+		},
+		{
+			name:     "RuleNotFound",
+			r:        &TestRule{RulesFile: "/etc/events.json", RuleID: "unknown", Value: "1"},
+			readFile: fakeReadFile(defaultRules, nil),
+			want:     subcommands.ExitFailure,
+		},
+		{
+			name:     "InvalidValue",
+			r:        &TestRule{RulesFile: "/etc/events.json", RuleID: "hana-memory", Value: "high"},
+			readFile: fakeReadFile(defaultRules, nil),
+			want:     subcommands.ExitFailure,
+		},
+		{
+			name:     "ReadFileFailure",
+			r:        &TestRule{RulesFile: "/etc/events.json", RuleID: "hana-memory", Value: "95"},
+			readFile: fakeReadFile("", os.ErrNotExist),
+			want:     subcommands.ExitFailure,
+		},
+		{
+			name:     "InvalidRulesFile",
+			r:        &TestRule{RulesFile: "/etc/events.json", RuleID: "hana-memory", Value: "95"},
+			readFile: fakeReadFile("not json", nil),
+			want:     subcommands.ExitFailure,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.r.oteLogger = onetime.CreateOTELogger(false)
+			got, status := tc.r.testRuleHandler(context.Background(), tc.readFile, os.ReadDir)
+			if status != tc.want {
+				t.Errorf("testRuleHandler() = %v, want: %v, message: %s", status, tc.want, got)
+			}
+			for _, s := range tc.wantContains {
+				if !strings.Contains(got, s) {
+					t.Errorf("testRuleHandler() = %q, want it to contain %q", got, s)
+				}
+			}
+			for _, s := range tc.wantNotContains {
+				if strings.Contains(got, s) {
+					t.Errorf("testRuleHandler() = %q, want it to not contain %q", got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestTestRuleHandlerRulesDir(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        map[string]string
+		ruleID       string
+		want         subcommands.ExitStatus
+		wantContains []string
+	}{
+		{
+			name: "RuleFromTextproto",
+			files: map[string]string{
+				"hana.json":           defaultRules,
+				"pacemaker.textproto": `id: "pacemaker" source { metadata { url: "instance/attributes/pacemaker" value_type: STRING } } trigger { rhs: "offline" operation: EQSTR }`,
+			},
+			ruleID:       "pacemaker",
+			want:         subcommands.ExitSuccess,
+			wantContains: []string{"Rule: pacemaker", "Fires: true"},
+		},
+		{
+			name: "DuplicateRuleID",
+			files: map[string]string{
+				"hana.json":    defaultRules,
+				"replica.json": `[{"id": "replication"}]`,
+			},
+			ruleID: "replication",
+			want:   subcommands.ExitFailure,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, data := range tc.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+					t.Fatalf("os.WriteFile(%s) failed: %v", name, err)
+				}
+			}
+			r := &TestRule{RulesDir: dir, RuleID: tc.ruleID, Value: "offline", oteLogger: onetime.CreateOTELogger(false)}
+			got, status := r.testRuleHandler(context.Background(), os.ReadFile, os.ReadDir)
+			if status != tc.want {
+				t.Errorf("testRuleHandler() = %v, want: %v, message: %s", status, tc.want, got)
+			}
+			for _, s := range tc.wantContains {
+				if !strings.Contains(got, s) {
+					t.Errorf("testRuleHandler() = %q, want it to contain %q", got, s)
+				}
+			}
+		})
+	}
+}
@@ -19,15 +19,41 @@ package version
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"flag"
 	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
 )
 
-// Version has args for version subcommands.
-type Version struct{}
+type (
+	// Version has args for version subcommands.
+	Version struct {
+		CheckLatest bool   `json:"check-latest,string"`
+		LatestURL   string `json:"latest-url"`
+		JSON        bool   `json:"json,string"`
+		fetchLatest fetchLatestFunc
+	}
+
+	// fetchLatestFunc returns the latest published agent version read from the given URL.
+	fetchLatestFunc func(ctx context.Context, url string) (string, error)
+
+	// versionInfo is the version report printed by the version subcommand.
+	versionInfo struct {
+		AgentName        string `json:"agent_name"`
+		AgentVersion     string `json:"agent_version"`
+		AgentBuildChange string `json:"agent_build_change"`
+		LatestVersion    string `json:"latest_version,omitempty"`
+		UpdateAvailable  *bool  `json:"update_available,omitempty"`
+	}
+)
 
 // Name implements the subcommand interface for version.
 func (*Version) Name() string { return "version" }
@@ -37,11 +63,15 @@ func (*Version) Synopsis() string { return "print Agent for SAP version informat
 
 // Usage implements the subcommand interface for version.
 func (*Version) Usage() string {
-	return "Usage: version\n"
+	return "Usage: version [-check-latest -latest-url=<gs://bucket/object|https://url>] [-json]\n"
 }
 
 // SetFlags implements the subcommand interface for version.
-func (v *Version) SetFlags(fs *flag.FlagSet) {}
+func (v *Version) SetFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&v.CheckLatest, "check-latest", false, "Check whether a newer version of the agent has been published, nothing is installed")
+	fs.StringVar(&v.LatestURL, "latest-url", "", "The GCS object (gs://bucket/object) or URL holding the latest published agent version, required with -check-latest")
+	fs.BoolVar(&v.JSON, "json", false, "Print the version information as JSON")
+}
 
 // Execute implements the subcommand interface for version.
 func (v *Version) Execute(ctx context.Context, f *flag.FlagSet, args ...any) subcommands.ExitStatus {
@@ -50,6 +80,105 @@ func (v *Version) Execute(ctx context.Context, f *flag.FlagSet, args ...any) sub
 	return exitStatus
 }
 
+// Run returns the version information of the agent, optionally compared against the latest
+// published version.
 func (v *Version) Run(ctx context.Context, opts onetime.RunOptions) (string, subcommands.ExitStatus) {
-	return onetime.GetAgentVersion(), subcommands.ExitSuccess
+	info := versionInfo{
+		AgentName:        configuration.AgentName,
+		AgentVersion:     configuration.AgentVersion,
+		AgentBuildChange: configuration.AgentBuildChange,
+	}
+	if v.CheckLatest {
+		if v.LatestURL == "" {
+			return "-latest-url is required with -check-latest", subcommands.ExitUsageError
+		}
+		if v.fetchLatest == nil {
+			v.fetchLatest = fetchLatestVersion
+		}
+		latest, err := v.fetchLatest(ctx, latestVersionURL(v.LatestURL))
+		if err != nil {
+			return fmt.Sprintf("could not fetch the latest published version from %s, error: %v", v.LatestURL, err), subcommands.ExitFailure
+		}
+		updateAvailable := compareVersions(latest, configuration.AgentVersion) > 0
+		info.LatestVersion = latest
+		info.UpdateAvailable = &updateAvailable
+	}
+
+	if v.JSON {
+		b, err := json.Marshal(info)
+		if err != nil {
+			return fmt.Sprintf("could not marshal version information, error: %v", err), subcommands.ExitFailure
+		}
+		return string(b), subcommands.ExitSuccess
+	}
+	msg := fmt.Sprintf("%s\nAgent name: %s", onetime.GetAgentVersion(), info.AgentName)
+	if info.UpdateAvailable != nil {
+		if *info.UpdateAvailable {
+			msg += fmt.Sprintf("\nLatest published version: %s, an update is available", info.LatestVersion)
+		} else {
+			msg += fmt.Sprintf("\nLatest published version: %s, the agent is up to date", info.LatestVersion)
+		}
+	}
+	return msg, subcommands.ExitSuccess
+}
+
+// latestVersionURL converts a gs://bucket/object path to its public storage URL.
+func latestVersionURL(url string) string {
+	if object, ok := strings.CutPrefix(url, "gs://"); ok {
+		return "https://storage.googleapis.com/" + object
+	}
+	return url
+}
+
+// fetchLatestVersion reads the latest published version from the given URL.
+// The response body is expected to hold only the version, e.g. "3.6".
+func fetchLatestVersion(ctx context.Context, url string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unsuccessful response: %s", res.Status)
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	latest := strings.TrimSpace(string(body))
+	if latest == "" {
+		return "", fmt.Errorf("empty version in response")
+	}
+	return latest, nil
+}
+
+// compareVersions compares two dotted versions component by component and returns a positive
+// number if a is newer than b, a negative number if a is older than b and 0 if they are equal.
+// Non numeric components are compared as strings.
+func compareVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		aPart, bPart := "0", "0"
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		aNum, aErr := strconv.Atoi(aPart)
+		bNum, bErr := strconv.Atoi(bPart)
+		switch {
+		case aErr == nil && bErr == nil && aNum != bNum:
+			return aNum - bNum
+		case (aErr != nil || bErr != nil) && aPart != bPart:
+			return strings.Compare(aPart, bPart)
+		}
+	}
+	return 0
 }
@@ -18,10 +18,16 @@ package version
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"flag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/subcommands"
+	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
 	"github.com/GoogleCloudPlatform/sapagent/internal/onetime"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 )
@@ -74,3 +80,150 @@ func TestRunVersion(t *testing.T) {
 		t.Errorf("Run(%v, %v)=%v, want %v", v, args, gotString, want)
 	}
 }
+
+func TestRunCheckLatest(t *testing.T) {
+	tests := []struct {
+		name       string
+		v          Version
+		wantMsg    string
+		wantStatus subcommands.ExitStatus
+	}{
+		{
+			name:       "MissingURL",
+			v:          Version{CheckLatest: true},
+			wantMsg:    "-latest-url is required with -check-latest",
+			wantStatus: subcommands.ExitUsageError,
+		},
+		{
+			name: "FetchFailure",
+			v: Version{
+				CheckLatest: true,
+				LatestURL:   "gs://bucket/latest",
+				fetchLatest: func(context.Context, string) (string, error) { return "", cmpopts.AnyError },
+			},
+			wantMsg:    "could not fetch the latest published version from gs://bucket/latest, error: any error",
+			wantStatus: subcommands.ExitFailure,
+		},
+		{
+			name: "UpdateAvailableJSON",
+			v: Version{
+				CheckLatest: true,
+				LatestURL:   "gs://bucket/latest",
+				JSON:        true,
+				fetchLatest: func(context.Context, string) (string, error) { return "99.0", nil },
+			},
+			wantMsg:    fmt.Sprintf(`{"agent_name":"%s","agent_version":"%s","agent_build_change":"%s","latest_version":"99.0","update_available":true}`, configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange),
+			wantStatus: subcommands.ExitSuccess,
+		},
+		{
+			name: "UpToDate",
+			v: Version{
+				CheckLatest: true,
+				LatestURL:   "https://example.com/latest",
+				fetchLatest: func(context.Context, string) (string, error) { return configuration.AgentVersion, nil },
+			},
+			wantMsg:    fmt.Sprintf("%s\nAgent name: %s\nLatest published version: %s, the agent is up to date", onetime.GetAgentVersion(), configuration.AgentName, configuration.AgentVersion),
+			wantStatus: subcommands.ExitSuccess,
+		},
+		{
+			name:       "JSONWithoutCheck",
+			v:          Version{JSON: true},
+			wantMsg:    fmt.Sprintf(`{"agent_name":"%s","agent_version":"%s","agent_build_change":"%s"}`, configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange),
+			wantStatus: subcommands.ExitSuccess,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotMsg, gotStatus := test.v.Run(context.Background(), onetime.RunOptions{})
+			if gotStatus != test.wantStatus {
+				t.Errorf("Run()=%v, want %v", gotStatus, test.wantStatus)
+			}
+			if gotMsg != test.wantMsg {
+				t.Errorf("Run()=%q, want %q", gotMsg, test.wantMsg)
+			}
+		})
+	}
+}
+
+func TestFetchLatestVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			status: http.StatusOK,
+			body:   "3.7\n",
+			want:   "3.7",
+		},
+		{
+			name:    "NotFound",
+			status:  http.StatusNotFound,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "EmptyBody",
+			status:  http.StatusOK,
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+				fmt.Fprint(w, test.body)
+			}))
+			defer ts.Close()
+
+			got, err := fetchLatestVersion(context.Background(), ts.URL)
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("fetchLatestVersion() returned error: %v, want: %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("fetchLatestVersion()=%q, want: %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLatestVersionURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "gs://bucket/path/latest", want: "https://storage.googleapis.com/bucket/path/latest"},
+		{url: "https://example.com/latest", want: "https://example.com/latest"},
+	}
+
+	for _, test := range tests {
+		if got := latestVersionURL(test.url); got != test.want {
+			t.Errorf("latestVersionURL(%q)=%q, want: %q", test.url, got, test.want)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "3.6", b: "3.6", want: 0},
+		{a: "3.7", b: "3.6", want: 1},
+		{a: "3.6", b: "3.10", want: -1},
+		{a: "3.6.1", b: "3.6", want: 1},
+		{a: "3.6", b: "3.6.0", want: 0},
+		{a: "4.0", b: "3.9", want: 1},
+	}
+
+	for _, test := range tests {
+		got := compareVersions(test.a, test.b)
+		if (got > 0) != (test.want > 0) || (got < 0) != (test.want < 0) {
+			t.Errorf("compareVersions(%q, %q)=%d, want sign of %d", test.a, test.b, got, test.want)
+		}
+	}
+}
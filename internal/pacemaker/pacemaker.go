@@ -25,6 +25,7 @@ package pacemaker
 import (
 	"context"
 	"encoding/xml"
+	"errors"
 
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
@@ -102,6 +103,10 @@ type (
 	}
 )
 
+// ErrCRMMonNotFound is returned by Data when crm_mon is not installed, so the instance cannot be
+// a pacemaker cluster member. Any other error means the cluster status is unknown.
+var ErrCRMMonNotFound = errors.New("crm_mon not found")
+
 // Data gets the crm_mon data and parses it into the CRMMon struct.
 func Data(ctx context.Context) (*CRMMon, error) {
 	return data(ctx, commandlineexecutor.ExecuteCommand)
@@ -116,6 +121,9 @@ func data(ctx context.Context, runner commandlineexecutor.Execute) (*CRMMon, err
 		log.CtxLogger(ctx).Debugw("Command 'crm_mon --as-xml' failed", "stdout", result.StdOut, "stderr", result.StdErr, "error", result.Error)
 		return nil, result.Error
 	}
+	if !result.ExecutableFound && result.Error != nil {
+		return nil, ErrCRMMonNotFound
+	}
 	return parseCRMMon([]byte(result.StdOut))
 }
 
@@ -269,6 +269,50 @@ func TestParseCRMMon(t *testing.T) {
 	}
 }
 
+func TestData(t *testing.T) {
+	tests := []struct {
+		name     string
+		fakeExec commandlineexecutor.Execute
+		wantNil  bool
+		wantErr  error
+	}{
+		{
+			name: "Success",
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: exampleXMLData, ExecutableFound: true}
+			},
+		},
+		{
+			name: "CRMMonNotFound",
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: cmpopts.AnyError, ExecutableFound: false}
+			},
+			wantNil: true,
+			wantErr: ErrCRMMonNotFound,
+		},
+		{
+			name: "CRMMonFailure",
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: cmpopts.AnyError, ExecutableFound: true}
+			},
+			wantNil: true,
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, gotErr := data(context.Background(), test.fakeExec)
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("data() returned error: %v, want: %v", gotErr, test.wantErr)
+			}
+			if (got == nil) != test.wantNil {
+				t.Errorf("data() returned %v, want nil: %t", got, test.wantNil)
+			}
+		})
+	}
+}
+
 func TestIsEnabled(t *testing.T) {
 	tests := []struct {
 		name     string
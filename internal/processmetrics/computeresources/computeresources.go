@@ -25,6 +25,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/shirou/gopsutil/v3/process"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/sapcontrol"
@@ -63,6 +64,10 @@ var (
 	dashChars        = regexp.MustCompile(`\-`)
 )
 
+// lastValueMu guards the LastValue maps, collectors of different SAP instances
+// may collect IOPS at the same time.
+var lastValueMu sync.Mutex
+
 type (
 	// Parameters struct contains the parameters necessary for computeresources package common methods.
 	Parameters struct {
@@ -350,14 +355,16 @@ func CollectIOPSPerProcess(ctx context.Context, p Parameters, processes []*Proce
 			continue
 		}
 		key := fmt.Sprintf("%s:%s", processInfo.Name, processInfo.PID)
-		if _, ok := p.LastValue[key]; !ok {
+		lastValueMu.Lock()
+		lastVal, ok := p.LastValue[key]
+		p.LastValue[key] = currVal
+		lastValueMu.Unlock()
+		if !ok {
 			log.CtxLogger(ctx).Debugw("not creating metric since last value is not updated for IOPS stats", "pid", pid)
-			p.LastValue[key] = currVal
 			continue
 		}
-		deltaReads := float64(currVal.ReadBytes-p.LastValue[key].ReadBytes) / thousand
-		deltaWrites := float64(currVal.WriteBytes-p.LastValue[key].WriteBytes) / thousand
-		p.LastValue[key] = currVal
+		deltaReads := float64(currVal.ReadBytes-lastVal.ReadBytes) / thousand
+		deltaWrites := float64(currVal.WriteBytes-lastVal.WriteBytes) / thousand
 		freq := p.Config.GetCollectionConfiguration().GetProcessMetricsFrequency()
 		diskUsages = append(diskUsages, &DiskUsage{
 			DeltaReads:  &Metric{ProcessInfo: processInfo, Value: deltaReads / float64(freq), TimeStamp: tspb.Now()},
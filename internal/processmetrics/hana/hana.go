@@ -27,6 +27,7 @@ import (
 	"time"
 
 	backoff "github.com/cenkalti/backoff/v4"
+	"github.com/GoogleCloudPlatform/sapagent/internal/databaseconnector"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/sapcontrol"
 	"github.com/GoogleCloudPlatform/sapagent/internal/sapcontrolclient"
 	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
@@ -56,7 +57,11 @@ type (
 		HANAQueryFailCount int64
 		SkippedMetrics     map[string]bool
 		PMBackoffPolicy    backoff.BackOffContext
+		db                 *databaseconnector.DBHandle
 	}
+
+	// queryFunc provides testable replacement to the SQL API.
+	queryFunc func(context.Context, string) (string, error)
 )
 
 // HANA DB locks the user out after 3 failed authentication attempts, so we
@@ -99,10 +104,16 @@ const (
 const (
 	metricURL            = "workload.googleapis.com"
 	servicePath          = "/sap/hana/service"
+	serviceUptimePath    = "/sap/hana/service/uptime"
 	queryStatePath       = "/sap/hana/query/state"
 	queryOverallTimePath = "/sap/hana/query/overalltime"
 	queryServerTimePath  = "/sap/hana/query/servertime"
+	backupAgePath        = "/sap/hana/backup/age"
 	hanaQuery            = "select * from dummy"
+
+	// backupAgeQuery returns the seconds since the latest successful data backup completed, or -1
+	// if the backup catalog does not have a successful data backup.
+	backupAgeQuery = "SELECT IFNULL(SECONDS_BETWEEN(MAX(UTC_END_TIME), CURRENT_UTCTIMESTAMP), -1) FROM M_BACKUP_CATALOG WHERE ENTRY_TYPE_NAME = 'complete data backup' AND STATE_NAME = 'successful'"
 )
 
 var (
@@ -132,6 +143,7 @@ func (p *InstanceProperties) Collect(ctx context.Context) ([]*mrpb.TimeSeries, e
 		if queryMetrics != nil {
 			metrics = append(metrics, queryMetrics...)
 		}
+		metrics = append(metrics, collectHANABackupMetrics(ctx, p, p.runQuery)...)
 	}
 
 	return metrics, metricsCollectionErr
@@ -178,6 +190,7 @@ func collectHANAServiceMetrics(ctx context.Context, ip *InstanceProperties, scc
 		metrics   []*mrpb.TimeSeries
 	)
 
+	// The uptime is read from the same process list, it is collected with the service metric.
 	if _, ok := ip.SkippedMetrics[servicePath]; !ok {
 		processes, err = sc.GetProcessList(ctx, scc)
 		if err != nil {
@@ -202,6 +215,11 @@ func collectHANAServiceMetrics(ctx context.Context, ip *InstanceProperties, scc
 				Identifier: process.Name,
 			})
 			metrics = append(metrics, createMetrics(ip, servicePath, extraLabels, now, boolToInt64(process.IsGreen)))
+			// The uptime is labeled without the pid so that a restarting service shows as repeated
+			// drops in a single time series.
+			if !ip.SkippedMetrics[serviceUptimePath] && process.HasUptime {
+				metrics = append(metrics, createMetrics(ip, serviceUptimePath, map[string]string{"service_name": process.Name}, now, int64(process.Uptime.Seconds())))
+			}
 		}
 	}
 	log.CtxLogger(ctx).Debugw("Time taken to collect metrics in CollectReplicationHA()", "duration", time.Since(now.AsTime()))
@@ -286,6 +304,67 @@ func runHANAQuery(ctx context.Context, p *InstanceProperties, exec commandlineex
 	return queryState{state: int64(result.ExitCode), overallTime: overallTime, serverTime: serverTime}, nil
 }
 
+// collectHANABackupMetrics collects the age of the latest successful data backup from the backup
+// catalog of the HANA instance. Query failures are logged and no metric is returned, so that an
+// unreachable database does not fail the collection of the other HANA metrics.
+func collectHANABackupMetrics(ctx context.Context, p *InstanceProperties, run queryFunc) []*mrpb.TimeSeries {
+	if p.SkippedMetrics[backupAgePath] {
+		return nil
+	}
+	if p.HANAQueryFailCount >= maxHANAQueryFailCount {
+		log.CtxLogger(ctx).Debugw("Not querying for HANA backup age as failcount has reached max allowed fail count.", "instanceid", p.SAPInstance.GetInstanceId(), "failcount", p.HANAQueryFailCount)
+		return nil
+	}
+	val, err := run(ctx, backupAgeQuery)
+	if err != nil {
+		if databaseconnector.IsAuthError(err) {
+			p.HANAQueryFailCount++
+		}
+		log.CtxLogger(ctx).Debugw("Could not query HANA backup catalog", "instanceid", p.SAPInstance.GetInstanceId(), "error", err)
+		return nil
+	}
+	age, err := strconv.ParseInt(strings.TrimSpace(val), 10, 64)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Could not parse HANA backup age", "value", val, "error", err)
+		return nil
+	}
+	if age < 0 {
+		log.CtxLogger(ctx).Debugw("No successful data backup found in the HANA backup catalog", "instanceid", p.SAPInstance.GetInstanceId())
+		return nil
+	}
+	return []*mrpb.TimeSeries{createMetrics(p, backupAgePath, nil, tspb.Now(), age)}
+}
+
+// runQuery runs the query against the HANA database of the instance and returns the value of the
+// last row. The database handle is created on first use and reused across collections.
+func (p *InstanceProperties) runQuery(ctx context.Context, q string) (string, error) {
+	if p.db == nil {
+		db, err := databaseconnector.CreateDBHandle(ctx, databaseconnector.Params{
+			Username:   p.SAPInstance.GetHanaDbUser(),
+			Password:   p.SAPInstance.GetHanaDbPassword(),
+			Host:       "localhost",
+			Port:       fmt.Sprintf("3%s15", p.SAPInstance.GetInstanceNumber()),
+			HDBUserKey: p.SAPInstance.GetHdbuserstoreKey(),
+			SID:        p.SAPInstance.GetSapsid(),
+		})
+		if err != nil {
+			return "", err
+		}
+		p.db = db
+	}
+	rows, err := p.db.Query(ctx, q, commandlineexecutor.ExecuteCommand)
+	if err != nil {
+		return "", err
+	}
+	val := ""
+	for rows.Next() {
+		if err := rows.ReadRow(&val); err != nil {
+			return "", err
+		}
+	}
+	return val, nil
+}
+
 // parseQueryOutput parses a string to get the time taken by the query.
 func parseQueryOutput(str string, regex *regexp.Regexp) (int64, error) {
 	matches := regex.FindStringSubmatch(str)
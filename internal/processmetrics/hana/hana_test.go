@@ -135,6 +135,31 @@ func TestCollectHANAServiceMetrics(t *testing.T) {
 			wantMetricCount:    7,
 			instanceProperties: defaultAPIInstanceProperties,
 		},
+		{
+			name: "Uptime",
+			fakeClient: sapcontrolclienttest.Fake{
+				Processes: []sapcontrolclient.OSProcess{
+					{Name: "hdbdaemon", Dispstatus: "SAPControl-GREEN", Pid: 9609, Elapsedtime: "847:25:31"},
+					{Name: "hdbindexserver", Dispstatus: "SAPControl-GREEN", Pid: 10013, Elapsedtime: "0:01:02"},
+				},
+			},
+			wantMetricCount:    4,
+			instanceProperties: defaultAPIInstanceProperties,
+		},
+		{
+			name: "UptimeSkipped",
+			fakeClient: sapcontrolclienttest.Fake{
+				Processes: []sapcontrolclient.OSProcess{
+					{Name: "hdbdaemon", Dispstatus: "SAPControl-GREEN", Pid: 9609, Elapsedtime: "847:25:31"},
+				},
+			},
+			instanceProperties: &InstanceProperties{
+				Config:         defaultConfig,
+				SAPInstance:    defaultSAPInstance,
+				SkippedMetrics: map[string]bool{serviceUptimePath: true},
+			},
+			wantMetricCount: 1,
+		},
 		{
 			name:               "FailureWebmethodGetProcessList",
 			fakeClient:         sapcontrolclienttest.Fake{ErrGetProcessList: cmpopts.AnyError},
@@ -355,6 +380,91 @@ func TestCollectHANAQueryMetricsWithMaxFailCounts(t *testing.T) {
 	}
 }
 
+func TestCollectHANABackupMetrics(t *testing.T) {
+	tests := []struct {
+		name          string
+		ip            *InstanceProperties
+		run           queryFunc
+		wantAge       []int64
+		wantFailCount int64
+	}{
+		{
+			name: "Success",
+			ip:   &InstanceProperties{Config: defaultConfig, SAPInstance: defaultSAPInstance},
+			run: func(context.Context, string) (string, error) {
+				return "3600", nil
+			},
+			wantAge: []int64{3600},
+		},
+		{
+			name: "NoSuccessfulBackup",
+			ip:   &InstanceProperties{Config: defaultConfig, SAPInstance: defaultSAPInstance},
+			run: func(context.Context, string) (string, error) {
+				return "-1", nil
+			},
+		},
+		{
+			name: "UnparsableValue",
+			ip:   &InstanceProperties{Config: defaultConfig, SAPInstance: defaultSAPInstance},
+			run: func(context.Context, string) (string, error) {
+				return "not-a-number", nil
+			},
+		},
+		{
+			name: "QueryFailure",
+			ip:   &InstanceProperties{Config: defaultConfig, SAPInstance: defaultSAPInstance},
+			run: func(context.Context, string) (string, error) {
+				return "", cmpopts.AnyError
+			},
+		},
+		{
+			name: "MetricSkipped",
+			ip: &InstanceProperties{
+				Config:         defaultConfig,
+				SAPInstance:    defaultSAPInstance,
+				SkippedMetrics: map[string]bool{backupAgePath: true},
+			},
+			run: func(context.Context, string) (string, error) {
+				return "3600", nil
+			},
+		},
+		{
+			name: "MaxFailCountReached",
+			ip: &InstanceProperties{
+				Config:             defaultConfig,
+				SAPInstance:        defaultSAPInstance,
+				HANAQueryFailCount: maxHANAQueryFailCount,
+			},
+			run: func(context.Context, string) (string, error) {
+				return "3600", nil
+			},
+			wantFailCount: maxHANAQueryFailCount,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := collectHANABackupMetrics(context.Background(), test.ip, test.run)
+			var gotAge []int64
+			for _, m := range got {
+				if m.GetMetric().GetType() != metricURL+backupAgePath {
+					t.Errorf("collectHANABackupMetrics() metric type: %s, want: %s", m.GetMetric().GetType(), metricURL+backupAgePath)
+				}
+				if m.GetMetric().GetLabels()["sid"] != "TST" {
+					t.Errorf("collectHANABackupMetrics() sid label: %q, want: %q", m.GetMetric().GetLabels()["sid"], "TST")
+				}
+				gotAge = append(gotAge, m.GetPoints()[0].GetValue().GetInt64Value())
+			}
+			if diff := cmp.Diff(test.wantAge, gotAge); diff != "" {
+				t.Errorf("collectHANABackupMetrics() returned diff (-want +got):\n%s", diff)
+			}
+			if test.ip.HANAQueryFailCount != test.wantFailCount {
+				t.Errorf("collectHANABackupMetrics() HANAQueryFailCount: %d, want: %d", test.ip.HANAQueryFailCount, test.wantFailCount)
+			}
+		})
+	}
+}
+
 func TestCollect(t *testing.T) {
 	tests := []struct {
 		name       string
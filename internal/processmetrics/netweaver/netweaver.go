@@ -20,12 +20,15 @@ package netweaver
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	backoff "github.com/cenkalti/backoff/v4"
@@ -51,9 +54,21 @@ type (
 		Client          cloudmonitoring.TimeSeriesCreator
 		SkippedMetrics  map[string]bool
 		PMBackoffPolicy backoff.BackOffContext
+		// HTTPTimeout bounds the HTTP health check requests of the instance.
+		// Defaults to defaultHTTPTimeout when unset.
+		HTTPTimeout time.Duration
+
+		clientOnce sync.Once
+		client     *http.Client
 	}
 )
 
+const defaultHTTPTimeout = 5 * time.Second
+
+// defaultEnqLockAgeThreshold is the age above which an enqueue lock is reported as long held
+// when enq_lock_age_threshold is not configured.
+const defaultEnqLockAgeThreshold = time.Hour
+
 // Netweaver system availability.
 const (
 	systemAtLeastOneProcessNotGreen = 0
@@ -63,6 +78,7 @@ const (
 const (
 	metricURL                  = "workload.googleapis.com"
 	nwServicePath              = "/sap/nw/service"
+	nwServiceUptimePath        = "/sap/nw/service/uptime"
 	nwICMRCodePath             = "/sap/nw/icm/rcode"
 	nwICMRTimePath             = "/sap/nw/icm/rtime"
 	nwMSResponseCodePath       = "/sap/nw/ms/rcode"
@@ -71,12 +87,17 @@ const (
 	nwABAPProcBusyPath         = "/sap/nw/abap/proc/busy"
 	nwABAPProcCountPath        = "/sap/nw/abap/proc/count"
 	nwABAPProcUtilPath         = "/sap/nw/abap/proc/utilization"
+	nwABAPProcOverloadedPath   = "/sap/nw/abap/proc/overloaded"
 	nwABAPProcQueueCurrentPath = "/sap/nw/abap/queue/current"
 	nwABAPProcQueuePeakPath    = "/sap/nw/abap/queue/peak"
 	nwABAPSessionsPath         = "/sap/nw/abap/sessions"
 	nwABAPRFCPath              = "/sap/nw/abap/rfc"
+	nwABAPShortDumpsPath       = "/sap/nw/abap/shortdumps"
 	nwEnqLocksPath             = "/sap/nw/enq/locks/usercountowner"
+	nwEnqLocksLongHeldPath     = "/sap/nw/enq/locks/longheld"
+	nwEnqLocksOldestAgePath    = "/sap/nw/enq/locks/oldestage"
 	nwInstanceRolePath         = "/sap/nw/instance/role"
+	nwKernelVersionPath        = "/sap/nw/kernel/version"
 )
 
 var (
@@ -97,104 +118,143 @@ var (
 	appProcessRegex = regexp.MustCompile(`(enq|enqr|ms|dw|jstart).sap([A-Za-z][A-Za-z0-9]{2})_(D|ASCS|ERS)([0-9]{2}) pf=/(usr/sap|sapmnt)/([A-Za-z][A-Za-z0-9]{2})/SYS/profile/([A-Za-z][A-Za-z0-9]{2})_(D|ASCS|ERS)([0-9]{2})_(.*)`)
 )
 
+// Names of the NetWeaver sub-collectors which can be selected with netweaver_collectors.
+const (
+	collectorService           = "service"
+	collectorHTTP              = "http"
+	collectorABAPProcessStatus = "abap_process_status"
+	collectorABAPQueue         = "abap_queue"
+	collectorABAPSessions      = "abap_sessions"
+	collectorABAPRFC           = "abap_rfc"
+	collectorABAPShortDumps    = "abap_short_dumps"
+	collectorEnqLocks          = "enq_locks"
+	collectorKernelVersion     = "kernel_version"
+	collectorRole              = "role"
+)
+
+// subCollector collects one group of NetWeaver metrics.
+type subCollector struct {
+	name    string
+	collect func(ctx context.Context) ([]*mrpb.TimeSeries, error)
+}
+
 // Collect is Netweaver implementation of Collector interface from processmetrics.go.
 // Returns a list of NetWeaver related metrics.
 // Collect method keeps on collecting all the metrics it can, logs errors if it encounters
 // any and returns the collected metrics with the last error encountered while collecting metrics.
+// Only the sub-collectors listed in netweaver_collectors are run, all of them if the list is empty.
 func (p *InstanceProperties) Collect(ctx context.Context) ([]*mrpb.TimeSeries, error) {
 	scc := sapcontrolclient.New(p.SAPInstance.GetInstanceNumber())
-	var metricsCollectionError error
-	metrics, err := collectNetWeaverMetrics(ctx, p, scc)
-	if err != nil {
-		metricsCollectionError = err
-	}
-
-	httpMetrics, err := collectHTTPMetrics(ctx, p)
-	if err != nil {
-		metricsCollectionError = err
-	}
-	if httpMetrics != nil {
-		metrics = append(metrics, httpMetrics...)
-	}
-
-	abapProcessStatusMetrics, err := collectABAPProcessStatus(ctx, p, scc)
-	if err != nil {
-		metricsCollectionError = err
-	}
-	if abapProcessStatusMetrics != nil {
-		metrics = append(metrics, abapProcessStatusMetrics...)
-	}
-
-	abapQueueStats, err := collectABAPQueueStats(ctx, p, scc)
-	if err != nil {
-		metricsCollectionError = err
-	}
-	if abapQueueStats != nil {
-		metrics = append(metrics, abapQueueStats...)
+	var (
+		metrics                []*mrpb.TimeSeries
+		metricsCollectionError error
+	)
+	collectors := p.subCollectors(scc)
+	enabled := p.enabledCollectors(ctx, collectors)
+	metricCounts := make(map[string]int)
+	for _, c := range collectors {
+		if !enabled(c.name) {
+			continue
+		}
+		collected, err := c.collect(ctx)
+		if err != nil {
+			metricsCollectionError = err
+		}
+		metrics = append(metrics, collected...)
+		metricCounts[c.name] = len(collected)
 	}
+	log.CtxLogger(ctx).Debugw("Ran NetWeaver sub-collectors", "instanceid", p.SAPInstance.GetInstanceId(), "metriccounts", metricCounts)
+	return metrics, metricsCollectionError
+}
 
+// subCollectors returns the NetWeaver sub-collectors in the order they are run.
+func (p *InstanceProperties) subCollectors(scc sapcontrol.ClientInterface) []subCollector {
 	dpmonPath := `/usr/sap/` + p.SAPInstance.GetSapsid() + `/SYS/exe/run/dpmon`
-	command := `-c 'echo q | %s pf=%s v'`
-	abapSessionParams := commandlineexecutor.Params{
-		User:        p.SAPInstance.GetUser(),
-		Executable:  "bash",
-		ArgsToSplit: fmt.Sprintf(command, dpmonPath, p.SAPInstance.GetProfilePath()),
-		Env: []string{
-			"PATH=$PATH:" + p.SAPInstance.GetLdLibraryPath(),
-			"LD_LIBRARY_PATH=" + p.SAPInstance.GetLdLibraryPath(),
-		},
-	}
-	abapSessionStats, err := collectABAPSessionStats(ctx, p, commandlineexecutor.ExecuteCommand, abapSessionParams)
-	if err != nil {
-		metricsCollectionError = err
-	}
-	if abapSessionStats != nil {
-		metrics = append(metrics, abapSessionStats...)
-	}
-
-	command = `-c 'echo q | %s pf=%s c'`
-	abapRFCParams := commandlineexecutor.Params{
-		User:        p.SAPInstance.GetUser(),
-		Executable:  "bash",
-		ArgsToSplit: fmt.Sprintf(command, dpmonPath, p.SAPInstance.GetProfilePath()),
-		Env: []string{
-			"PATH=$PATH:" + p.SAPInstance.GetLdLibraryPath(),
-			"LD_LIBRARY_PATH=" + p.SAPInstance.GetLdLibraryPath(),
-		},
-	}
-
-	rffcConnectionsMetric, err := collectRFCConnections(ctx, p, commandlineexecutor.ExecuteCommand, abapRFCParams)
-	if err != nil {
-		metricsCollectionError = err
+	dpmonParams := func(option string) commandlineexecutor.Params {
+		return commandlineexecutor.Params{
+			User:        p.SAPInstance.GetUser(),
+			Executable:  "bash",
+			ArgsToSplit: fmt.Sprintf(`-c 'echo q | %s pf=%s %s'`, dpmonPath, p.SAPInstance.GetProfilePath(), option),
+			Env: []string{
+				"PATH=$PATH:" + p.SAPInstance.GetLdLibraryPath(),
+				"LD_LIBRARY_PATH=" + p.SAPInstance.GetLdLibraryPath(),
+			},
+		}
 	}
-	if rffcConnectionsMetric != nil {
-		metrics = append(metrics, rffcConnectionsMetric...)
+	sapcontrolParams := func(function string) commandlineexecutor.Params {
+		return commandlineexecutor.Params{
+			User:        p.SAPInstance.GetUser(),
+			Executable:  p.SAPInstance.GetSapcontrolPath(),
+			ArgsToSplit: fmt.Sprintf("-nr %s -function %s", p.SAPInstance.GetInstanceNumber(), function),
+			Env:         []string{"LD_LIBRARY_PATH=" + p.SAPInstance.GetLdLibraryPath()},
+		}
 	}
 
-	enqLockParams := commandlineexecutor.Params{
-		User:        p.SAPInstance.GetUser(),
-		Executable:  p.SAPInstance.GetSapcontrolPath(),
-		ArgsToSplit: fmt.Sprintf("-nr %s -function EnqGetLockTable", p.SAPInstance.GetInstanceNumber()),
-		Env:         []string{"LD_LIBRARY_PATH=" + p.SAPInstance.GetLdLibraryPath()},
-	}
-	enqLockMetrics, err := collectEnqLockMetrics(ctx, p, commandlineexecutor.ExecuteCommand, enqLockParams, scc)
-	if err != nil {
-		metricsCollectionError = err
-	}
-	if enqLockMetrics != nil {
-		metrics = append(metrics, enqLockMetrics...)
+	return []subCollector{
+		{collectorService, func(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+			return collectNetWeaverMetrics(ctx, p, scc)
+		}},
+		{collectorHTTP, func(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+			return collectHTTPMetrics(ctx, p)
+		}},
+		{collectorABAPProcessStatus, func(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+			return collectABAPProcessStatus(ctx, p, scc)
+		}},
+		{collectorABAPQueue, func(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+			return collectABAPQueueStats(ctx, p, scc)
+		}},
+		{collectorABAPSessions, func(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+			return collectABAPSessionStats(ctx, p, commandlineexecutor.ExecuteCommand, dpmonParams("v"))
+		}},
+		{collectorABAPRFC, func(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+			return collectRFCConnections(ctx, p, commandlineexecutor.ExecuteCommand, dpmonParams("c"))
+		}},
+		{collectorABAPShortDumps, func(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+			return collectABAPShortDumps(ctx, p, commandlineexecutor.ExecuteCommand, sapcontrolParams("ABAPReadSyslog"))
+		}},
+		{collectorEnqLocks, func(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+			return collectEnqLockMetrics(ctx, p, commandlineexecutor.ExecuteCommand, sapcontrolParams("EnqGetLockTable"), scc)
+		}},
+		{collectorKernelVersion, func(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+			metric, err := collectKernelVersion(ctx, p, commandlineexecutor.ExecuteCommand, sapcontrolParams("GetVersionInfo -format script"), scc)
+			if metric == nil {
+				return nil, err
+			}
+			return []*mrpb.TimeSeries{metric}, err
+		}},
+		{collectorRole, func(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+			metric, err := collectRoleMetrics(ctx, p, commandlineexecutor.ExecuteCommand)
+			if err != nil {
+				log.CtxLogger(ctx).Debugw("Error in collecting role metrics", "error", err)
+			}
+			if metric == nil {
+				return nil, err
+			}
+			return []*mrpb.TimeSeries{metric}, err
+		}},
 	}
+}
 
-	roleMetrics, err := collectRoleMetrics(ctx, p, commandlineexecutor.ExecuteCommand)
-	if err != nil {
-		log.CtxLogger(ctx).Debugw("Error in collecting role metrics", "error", err)
-		metricsCollectionError = err
-	}
-	if roleMetrics != nil {
-		metrics = append(metrics, roleMetrics)
+// enabledCollectors returns a check of whether a sub-collector is selected by netweaver_collectors.
+// All sub-collectors are enabled when the list is empty.
+func (p *InstanceProperties) enabledCollectors(ctx context.Context, collectors []subCollector) func(name string) bool {
+	names := p.Config.GetCollectionConfiguration().GetNetweaverCollectors()
+	if len(names) == 0 {
+		return func(string) bool { return true }
+	}
+	known := make(map[string]bool, len(collectors))
+	for _, c := range collectors {
+		known[c.name] = true
+	}
+	enabled := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !known[name] {
+			log.CtxLogger(ctx).Warnw("Ignoring unknown NetWeaver sub-collector in netweaver_collectors", "name", name)
+			continue
+		}
+		enabled[name] = true
 	}
-
-	return metrics, metricsCollectionError
+	return func(name string) bool { return enabled[name] }
 }
 
 // CollectWithRetry decorates the Collect method with retry mechanism.
@@ -228,6 +288,7 @@ func (p *InstanceProperties) CollectWithRetry(ctx context.Context) ([]*mrpb.Time
 
 // collectNetWeaverMetrics builds a slice of SAP metrics containing all relevant NetWeaver metrics
 func collectNetWeaverMetrics(ctx context.Context, p *InstanceProperties, scc sapcontrol.ClientInterface) ([]*mrpb.TimeSeries, error) {
+	// The uptime is read from the same process list, it is collected with the service metric.
 	if _, ok := p.SkippedMetrics[nwServicePath]; ok {
 		return nil, nil
 	}
@@ -277,6 +338,9 @@ func collectServiceMetrics(ctx context.Context, p *InstanceProperties, procs map
 			Identifier: proc.Name,
 		})
 		metrics = append(metrics, createMetrics(p, nwServicePath, extraLabels, now, value))
+		if _, ok := p.SkippedMetrics[nwServiceUptimePath]; !ok && proc.HasUptime {
+			metrics = append(metrics, createMetrics(p, nwServiceUptimePath, extraLabels, now, int64(proc.Uptime.Seconds())))
+		}
 	}
 	log.CtxLogger(ctx).Debugw("Time taken to collect metrics in collectServiceMetrics()", "time", time.Since(start.AsTime()))
 	return metrics
@@ -313,16 +377,22 @@ func collectICMMetrics(ctx context.Context, p *InstanceProperties, url string) (
 		return nil, nil
 	}
 	now := tspb.Now()
-	response, err := http.Get(url)
+	response, err := p.httpClient().Get(url)
 	timeTaken := time.Since(now.AsTime())
+	extraLabels := map[string]string{"service_name": p.SAPInstance.GetServiceName()}
 	if err != nil {
 		log.CtxLogger(ctx).Debugw("HTTP GET failed", "instanceid", p.SAPInstance.GetInstanceId(), "url", url, "error", err)
+		if isTimeout(err) {
+			// A health check which does not respond in time is reported as unavailable.
+			return []*mrpb.TimeSeries{
+				createMetrics(p, nwICMRCodePath, extraLabels, now, http.StatusServiceUnavailable),
+				createMetrics(p, nwICMRTimePath, extraLabels, now, timeTaken.Milliseconds()),
+			}, err
+		}
 		return nil, err
 	}
 	defer response.Body.Close()
 
-	extraLabels := map[string]string{"service_name": p.SAPInstance.GetServiceName()}
-
 	log.CtxLogger(ctx).Debugw("Time taken to collect metrics in collectICMMetrics", "time", time.Since(now.AsTime()))
 	return []*mrpb.TimeSeries{
 		createMetrics(p, nwICMRCodePath, extraLabels, now, int64(response.StatusCode)),
@@ -341,16 +411,22 @@ func collectMessageServerMetrics(ctx context.Context, p *InstanceProperties, url
 		return nil, nil
 	}
 	now := tspb.Now()
-	response, err := http.Get(url)
+	response, err := p.httpClient().Get(url)
 	timeTaken := time.Since(now.AsTime())
+	extraLabels := map[string]string{"service_name": p.SAPInstance.GetServiceName()}
 	if err != nil {
 		log.CtxLogger(ctx).Debugw("HTTP GET failed", "instanceid", p.SAPInstance.GetInstanceId(), "url", url, "error", err)
+		if isTimeout(err) {
+			// A health check which does not respond in time is reported as unavailable.
+			return []*mrpb.TimeSeries{
+				createMetrics(p, nwMSResponseCodePath, extraLabels, now, http.StatusServiceUnavailable),
+				createMetrics(p, nwMSResponseTimePath, extraLabels, now, timeTaken.Milliseconds()),
+			}, err
+		}
 		return nil, err
 	}
 	defer response.Body.Close()
 
-	extraLabels := map[string]string{"service_name": p.SAPInstance.GetServiceName()}
-
 	metrics := []*mrpb.TimeSeries{
 		createMetrics(p, nwMSResponseCodePath, extraLabels, now, int64(response.StatusCode)),
 		createMetrics(p, nwMSResponseTimePath, extraLabels, now, timeTaken.Milliseconds()),
@@ -372,6 +448,28 @@ func collectMessageServerMetrics(ctx context.Context, p *InstanceProperties, url
 	return append(metrics, createMetrics(p, nwMSWorkProcessesPath, extraLabels, now, int64(workProcessCount))), nil
 }
 
+// httpClient returns the client used for the HTTP health checks of the instance, it is created
+// on the first call and reused so that the connections are kept alive between collections.
+func (p *InstanceProperties) httpClient() *http.Client {
+	p.clientOnce.Do(func() {
+		timeout := p.HTTPTimeout
+		if timeout <= 0 {
+			timeout = defaultHTTPTimeout
+		}
+		p.client = &http.Client{Timeout: timeout}
+	})
+	return p.client
+}
+
+// isTimeout reports whether an HTTP request failed because it timed out.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
 // parseWorkProcessCount processes the HTTP text/plain response body one line at a time
 // to find the message server work process count.
 // Returns the work process count on success, an error on failures.
@@ -436,10 +534,40 @@ func collectABAPProcessStatus(ctx context.Context, p *InstanceProperties, scc sa
 			"metric", nwABAPProcUtilPath, "abapprocess", k, "instancenumber", p.SAPInstance.GetInstanceNumber(), "value", v)
 		metrics = append(metrics, createMetrics(p, nwABAPProcUtilPath, extraLabels, now, int64(v)))
 	}
+	if !p.SkippedMetrics[nwABAPProcOverloadedPath] {
+		metrics = append(metrics, collectABAPProcessOverload(ctx, p, busyPercentage, now)...)
+	}
 	log.CtxLogger(ctx).Debugw("Time taken to collect metrics in collectABAPProcessStatus()", "time", time.Since(now.AsTime()))
 	return metrics, nil
 }
 
+// collectABAPProcessOverload reports whether the busy percentage of each work process type
+// exceeds the threshold configured in abap_work_process_busy_thresholds. Types without a
+// threshold are not reported, "Total" covers all work processes of the instance.
+func collectABAPProcessOverload(ctx context.Context, p *InstanceProperties, busyPercentage map[string]int, now *tspb.Timestamp) []*mrpb.TimeSeries {
+	var metrics []*mrpb.TimeSeries
+	for workProcessType, threshold := range p.Config.GetCollectionConfiguration().GetAbapWorkProcessBusyThresholds() {
+		percentage, ok := busyPercentage[workProcessType]
+		if !ok {
+			continue
+		}
+		overloaded := int64(percentage) > threshold
+		if overloaded {
+			log.CtxLogger(ctx).Infow("ABAP work processes are overloaded", "abapprocess", workProcessType, "instancenumber", p.SAPInstance.GetInstanceNumber(), "busypercentage", percentage, "threshold", threshold)
+		}
+		params := timeseries.Params{
+			CloudProp:    timeseries.ConvertCloudProperties(p.Config.CloudProperties),
+			MetricType:   metricURL + nwABAPProcOverloadedPath,
+			MetricLabels: metricLabels(p, map[string]string{"abap_process": workProcessType}),
+			Timestamp:    now,
+			BoolValue:    overloaded,
+			BareMetal:    p.Config.BareMetal,
+		}
+		metrics = append(metrics, timeseries.BuildBool(params))
+	}
+	return metrics
+}
+
 // collectABAPQueueStats collects ABAP Queue utilization metrics using dpmon tool.
 func collectABAPQueueStats(ctx context.Context, p *InstanceProperties, scc sapcontrol.ClientInterface) ([]*mrpb.TimeSeries, error) {
 	now := tspb.Now()
@@ -543,7 +671,39 @@ func collectRFCConnections(ctx context.Context, p *InstanceProperties, exec comm
 	return metrics, nil
 }
 
+// collectABAPShortDumps collects the count of ABAP short dumps in the recent entries of the
+// system log using sapcontrol, the system log is only available on ABAP application servers.
+func collectABAPShortDumps(ctx context.Context, p *InstanceProperties, exec commandlineexecutor.Execute, params commandlineexecutor.Params) ([]*mrpb.TimeSeries, error) {
+	now := tspb.Now()
+	if _, ok := p.SkippedMetrics[nwABAPShortDumpsPath]; ok {
+		return nil, nil
+	}
+	instance := p.SAPInstance.GetInstanceId()
+	if strings.HasPrefix(instance, "ASCS") || strings.HasPrefix(instance, "ERS") {
+		log.CtxLogger(ctx).Debugw("The ABAP short dump metric is not applicable for application type: ASCS or ERS.", "InstanceID", instance)
+		return nil, nil
+	}
+	result := exec(ctx, params)
+	log.CtxLogger(ctx).Debugw("ABAPReadSyslog output", "stdout", result.StdOut, "stderr", result.StdErr, "exitcode", result.ExitCode, "error", result.Error)
+	if result.Error != nil {
+		log.CtxLogger(ctx).Debugw("ABAPReadSyslog failed", log.Error(result.Error))
+		return nil, result.Error
+	}
+
+	count, err := parseABAPShortDumps(result.StdOut)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("ABAPReadSyslog ran successfully, but the system log could not be parsed", log.Error(err))
+		return nil, err
+	}
+	log.CtxLogger(ctx).Debugw("Creating metric with labels",
+		"metric", nwABAPShortDumpsPath, "instancenumber", p.SAPInstance.GetInstanceNumber(), "value", count)
+	metrics := []*mrpb.TimeSeries{createMetrics(p, nwABAPShortDumpsPath, nil, now, int64(count))}
+	log.CtxLogger(ctx).Debugw("Time taken to collect metrics in collectABAPShortDumps()", "time", time.Since(now.AsTime()))
+	return metrics, nil
+}
+
 // collectEnqLockMetrics builds Enq Locks for SAP Netweaver ASCS instances.
+// Skipping the enq lock metric also skips the long held lock metrics derived from the lock table.
 func collectEnqLockMetrics(ctx context.Context, p *InstanceProperties, exec commandlineexecutor.Execute, params commandlineexecutor.Params, scc sapcontrol.ClientInterface) ([]*mrpb.TimeSeries, error) {
 	if _, ok := p.SkippedMetrics[nwEnqLocksPath]; ok {
 		return nil, nil
@@ -584,9 +744,71 @@ func collectEnqLockMetrics(ctx context.Context, p *InstanceProperties, exec comm
 		metrics = append(metrics, createMetrics(p, nwEnqLocksPath, extraLabels, now, lock.UserCountOwner))
 
 	}
+	metrics = append(metrics, enqLockAgeMetrics(ctx, p, enqLocks, now)...)
 	return metrics, nil
 }
 
+// enqLockAgeMetrics builds the count of enqueue locks held longer than the configured threshold
+// and the age in seconds of the oldest lock. Locks held for long, in particular after an ASCS/ERS
+// failover, point to a problem with the enqueue server.
+func enqLockAgeMetrics(ctx context.Context, p *InstanceProperties, enqLocks []*sapcontrol.EnqLock, now *tspb.Timestamp) []*mrpb.TimeSeries {
+	threshold := time.Duration(p.Config.GetCollectionConfiguration().GetEnqLockAgeThreshold()) * time.Second
+	if threshold <= 0 {
+		threshold = defaultEnqLockAgeThreshold
+	}
+	var longHeld int64
+	var oldest time.Duration
+	for _, lock := range enqLocks {
+		ownerTime, err := lock.OwnerTime()
+		if err != nil {
+			log.CtxLogger(ctx).Debugw("Could not read the time the enq lock was taken", "lockname", lock.LockName, "lockarg", lock.LockArg, log.Error(err))
+			continue
+		}
+		age := now.AsTime().Sub(ownerTime)
+		if age > threshold {
+			longHeld++
+		}
+		if age > oldest {
+			oldest = age
+		}
+	}
+	if longHeld > 0 {
+		log.CtxLogger(ctx).Warnw("Enq locks held longer than the threshold", "count", longHeld, "threshold", threshold, "oldestage", oldest, "instanceid", p.SAPInstance.GetInstanceId())
+	}
+
+	var metrics []*mrpb.TimeSeries
+	if _, ok := p.SkippedMetrics[nwEnqLocksLongHeldPath]; !ok {
+		metrics = append(metrics, createMetrics(p, nwEnqLocksLongHeldPath, nil, now, longHeld))
+	}
+	if _, ok := p.SkippedMetrics[nwEnqLocksOldestAgePath]; !ok {
+		metrics = append(metrics, createMetrics(p, nwEnqLocksOldestAgePath, nil, now, int64(oldest.Seconds())))
+	}
+	return metrics
+}
+
+// collectKernelVersion builds an info metric labeled with the kernel release and patch level
+// of the instance. The sapcontrol command line is used when the web method call fails.
+func collectKernelVersion(ctx context.Context, p *InstanceProperties, exec commandlineexecutor.Execute, params commandlineexecutor.Params, scc sapcontrol.ClientInterface) (*mrpb.TimeSeries, error) {
+	if _, ok := p.SkippedMetrics[nwKernelVersionPath]; ok {
+		return nil, nil
+	}
+	now := tspb.Now()
+	sc := &sapcontrol.Properties{Instance: p.SAPInstance}
+	kernelVersion, err := sc.GetVersionInfo(ctx, scc)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("GetVersionInfo web method failed, falling back to the sapcontrol command", log.Error(err))
+		if kernelVersion, err = sc.ParseVersionInfo(ctx, exec, params); err != nil {
+			return nil, err
+		}
+	}
+	extraLabels := map[string]string{
+		"kernel_release":    kernelVersion.Release,
+		"kernel_patch":      kernelVersion.PatchLevel,
+		"kernel_changelist": kernelVersion.Changelist,
+	}
+	return createMetrics(p, nwKernelVersionPath, extraLabels, now, 1), nil
+}
+
 func collectRoleMetrics(ctx context.Context, p *InstanceProperties, exec commandlineexecutor.Execute) (*mrpb.TimeSeries, error) {
 	params := commandlineexecutor.Params{
 		Executable: "ps",
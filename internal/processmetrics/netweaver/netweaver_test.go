@@ -237,6 +237,7 @@ func TestCollectServiceMetrics(t *testing.T) {
 	tests := []struct {
 		name       string
 		fakeClient sapcontrolclienttest.Fake
+		properties *InstanceProperties
 		wantCount  int
 	}{
 		{
@@ -462,6 +463,39 @@ func TestCollectServiceMetrics(t *testing.T) {
 			},
 			wantCount: 1,
 		},
+		{
+			name: "Uptime",
+			fakeClient: sapcontrolclienttest.Fake{
+				Processes: []sapcontrolclient.OSProcess{
+					sapcontrolclient.OSProcess{
+						Name:        "msg_server",
+						Dispstatus:  "SAPControl-GREEN",
+						Pid:         111,
+						Elapsedtime: "0:05:12",
+					},
+				},
+			},
+			wantCount: 2,
+		},
+		{
+			name: "UptimeSkipped",
+			fakeClient: sapcontrolclienttest.Fake{
+				Processes: []sapcontrolclient.OSProcess{
+					sapcontrolclient.OSProcess{
+						Name:        "msg_server",
+						Dispstatus:  "SAPControl-GREEN",
+						Pid:         111,
+						Elapsedtime: "0:05:12",
+					},
+				},
+			},
+			properties: &InstanceProperties{
+				SAPInstance:    defaultSAPInstance,
+				Config:         defaultConfig,
+				SkippedMetrics: map[string]bool{nwServiceUptimePath: true},
+			},
+			wantCount: 1,
+		},
 	}
 
 	for _, test := range tests {
@@ -473,7 +507,11 @@ func TestCollectServiceMetrics(t *testing.T) {
 			if err != nil {
 				t.Errorf("ProcessList() failed with: %v.", err)
 			}
-			got := collectServiceMetrics(context.Background(), defaultInstanceProperties, procs, timestamppb.Now())
+			p := test.properties
+			if p == nil {
+				p = defaultInstanceProperties
+			}
+			got := collectServiceMetrics(context.Background(), p, procs, timestamppb.Now())
 			if len(got) != test.wantCount {
 				t.Errorf("Failure in collectNWServiceMetrics(), got: %d want: %d.",
 					len(got), test.wantCount)
@@ -578,6 +616,76 @@ func TestCollect(t *testing.T) {
 	}
 }
 
+func TestCollectDisabledSubCollectors(t *testing.T) {
+	p := &InstanceProperties{
+		SAPInstance: defaultSAPInstance,
+		Config: &cpb.Configuration{
+			CollectionConfiguration: &cpb.CollectionConfiguration{
+				NetweaverCollectors: []string{collectorHTTP},
+			},
+		},
+	}
+	// The nw/service metric is not collected when the service sub-collector is disabled.
+	metrics, _ := p.Collect(context.Background())
+	if len(metrics) != 0 {
+		t.Errorf("Collect() metric count mismatch, got: %v want: 0.", len(metrics))
+	}
+}
+
+func TestEnabledCollectors(t *testing.T) {
+	collectors := defaultInstanceProperties.subCollectors(nil)
+	tests := []struct {
+		name        string
+		collectors  []string
+		wantEnabled map[string]bool
+	}{
+		{
+			name: "AllEnabledByDefault",
+			wantEnabled: map[string]bool{
+				collectorService:      true,
+				collectorABAPSessions: true,
+				collectorRole:         true,
+			},
+		},
+		{
+			name:       "OnlyListedEnabled",
+			collectors: []string{collectorService, collectorHTTP},
+			wantEnabled: map[string]bool{
+				collectorService:      true,
+				collectorHTTP:         true,
+				collectorABAPSessions: false,
+				collectorABAPRFC:      false,
+			},
+		},
+		{
+			name:       "UnknownIgnored",
+			collectors: []string{"unknown", collectorEnqLocks},
+			wantEnabled: map[string]bool{
+				"unknown":         false,
+				collectorEnqLocks: true,
+				collectorService:  false,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &InstanceProperties{
+				Config: &cpb.Configuration{
+					CollectionConfiguration: &cpb.CollectionConfiguration{
+						NetweaverCollectors: test.collectors,
+					},
+				},
+			}
+			enabled := p.enabledCollectors(context.Background(), collectors)
+			for name, want := range test.wantEnabled {
+				if got := enabled(name); got != want {
+					t.Errorf("enabledCollectors(%v)(%s) = %t, want: %t", test.collectors, name, got, want)
+				}
+			}
+		})
+	}
+}
+
 func TestCollectHTTPMetrics(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -762,6 +870,83 @@ func TestCollectMessageServerMetrics(t *testing.T) {
 	}
 }
 
+func TestHTTPMetricsTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		collect func(context.Context, *InstanceProperties, string) ([]*mrpb.TimeSeries, error)
+	}{
+		{
+			name:    "ICM",
+			collect: collectICMMetrics,
+		},
+		{
+			name:    "MessageServer",
+			collect: collectMessageServerMetrics,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			release := make(chan struct{})
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-release:
+				case <-r.Context().Done():
+				}
+			}))
+			defer ts.Close()
+			defer close(release)
+
+			p := &InstanceProperties{
+				Config:      defaultConfig,
+				SAPInstance: defaultSAPInstance,
+				HTTPTimeout: 10 * time.Millisecond,
+			}
+			got, gotErr := test.collect(context.Background(), p, ts.URL)
+			if gotErr == nil {
+				t.Errorf("%s metrics collection succeeded for a slow server, want error", test.name)
+			}
+			if len(got) != 2 {
+				t.Fatalf("%s metrics count mismatch for a slow server, got: %d want: 2", test.name, len(got))
+			}
+			if rcode := got[0].GetPoints()[0].GetValue().GetInt64Value(); rcode != http.StatusServiceUnavailable {
+				t.Errorf("%s rcode for a slow server, got: %d want: %d", test.name, rcode, http.StatusServiceUnavailable)
+			}
+		})
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	tests := []struct {
+		name        string
+		httpTimeout time.Duration
+		want        time.Duration
+	}{
+		{
+			name: "DefaultTimeout",
+			want: defaultHTTPTimeout,
+		},
+		{
+			name:        "ConfiguredTimeout",
+			httpTimeout: 30 * time.Second,
+			want:        30 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &InstanceProperties{HTTPTimeout: test.httpTimeout}
+			client := p.httpClient()
+			if client.Timeout != test.want {
+				t.Errorf("httpClient() timeout = %v, want: %v", client.Timeout, test.want)
+			}
+			if again := p.httpClient(); again != client {
+				t.Error("httpClient() returned a new client on the second call, want the same client")
+			}
+		})
+	}
+}
+
 func TestParseWorkProcessCount(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -830,6 +1015,22 @@ func TestCollectABAPProcessStatus(t *testing.T) {
 			wantMetricCount:    8,
 			instanceProperties: defaultAPIInstanceProperties,
 		},
+		{
+			name: "SuccessWithBusyThresholds",
+			fakeClient: sapcontrolclienttest.Fake{WorkProcesses: []sapcontrolclient.WorkProcess{
+				{No: 0, Type: "DIA", Pid: 7488, Status: "Run", Time: "4", User: ""},
+				{No: 1, Type: "BTC", Pid: 7489, Status: "Wait", Time: "", User: ""},
+			}},
+			wantMetricCount: 10,
+			instanceProperties: &InstanceProperties{
+				SAPInstance: defaultAPIInstanceProperties.SAPInstance,
+				Config: &cpb.Configuration{
+					CollectionConfiguration: &cpb.CollectionConfiguration{
+						AbapWorkProcessBusyThresholds: map[string]int64{"DIA": 90, "Total": 80, "UPD": 90},
+					},
+				},
+			},
+		},
 		{
 			name: "SkipNwABAPProcCount",
 			fakeClient: sapcontrolclienttest.Fake{WorkProcesses: []sapcontrolclient.WorkProcess{
@@ -897,6 +1098,30 @@ func TestCollectABAPProcessStatus(t *testing.T) {
 	}
 }
 
+func TestCollectABAPProcessOverload(t *testing.T) {
+	p := &InstanceProperties{
+		SAPInstance: defaultSAPInstance,
+		Config: &cpb.Configuration{
+			CollectionConfiguration: &cpb.CollectionConfiguration{
+				AbapWorkProcessBusyThresholds: map[string]int64{"DIA": 90, "BTC": 50, "Total": 80, "UPD": 90},
+			},
+		},
+	}
+	busyPercentage := map[string]int{"DIA": 95, "BTC": 50, "Total": 75}
+	want := map[string]bool{"DIA": true, "BTC": false, "Total": false}
+
+	got := make(map[string]bool)
+	for _, m := range collectABAPProcessOverload(context.Background(), p, busyPercentage, timestamppb.Now()) {
+		if m.GetMetric().GetType() != metricURL+nwABAPProcOverloadedPath {
+			t.Errorf("collectABAPProcessOverload() returned metric type %q, want: %q", m.GetMetric().GetType(), metricURL+nwABAPProcOverloadedPath)
+		}
+		got[m.GetMetric().GetLabels()["abap_process"]] = m.GetPoints()[0].GetValue().GetBoolValue()
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("collectABAPProcessOverload() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
 func TestCollectABAPQueueStats(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -1080,6 +1305,79 @@ func TestCollectABAPSessionStats(t *testing.T) {
 	}
 }
 
+func TestCollectABAPShortDumps(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties *InstanceProperties
+		fakeExec   commandlineexecutor.Execute
+		wantCount  []int64
+		wantErr    error
+	}{
+		{
+			name:       "ABAPReadSyslogSuccess",
+			properties: defaultAPIInstanceProperties,
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: abapReadSyslogOutput}
+			},
+			wantCount: []int64{3},
+		},
+		{
+			name:       "ABAPReadSyslogFailure",
+			properties: defaultAPIInstanceProperties,
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: cmpopts.AnyError}
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:       "SystemLogNotFound",
+			properties: defaultAPIInstanceProperties,
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "FAIL: NIECONN_REFUSED"}
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "ASCSInstance",
+			properties: &InstanceProperties{
+				Config:      defaultConfig,
+				SAPInstance: &sapb.SAPInstance{Sapsid: "TST", InstanceNumber: "01", InstanceId: "ASCS01"},
+			},
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: abapReadSyslogOutput}
+			},
+		},
+		{
+			name: "SkipShortDumpsMetric",
+			properties: &InstanceProperties{
+				Config:         defaultConfig,
+				SAPInstance:    defaultSAPInstance,
+				SkippedMetrics: map[string]bool{nwABAPShortDumpsPath: true},
+			},
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: abapReadSyslogOutput}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, gotErr := collectABAPShortDumps(context.Background(), test.properties, test.fakeExec, commandlineexecutor.Params{})
+
+			var gotCount []int64
+			for _, m := range got {
+				gotCount = append(gotCount, m.GetPoints()[0].GetValue().GetInt64Value())
+			}
+			if diff := cmp.Diff(test.wantCount, gotCount); diff != "" {
+				t.Errorf("collectABAPShortDumps() unexpected metric values (-want +got):\n%s", diff)
+			}
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("collectABAPShortDumps() unexpected error, got: %v, want: %v.", gotErr, test.wantErr)
+			}
+		})
+	}
+}
+
 //go:embed dpmon_output/rfc_connections.txt
 var dpmonRFCConnectionsOutput string
 
@@ -1197,7 +1495,7 @@ func TestCollectEnqLockMetrics(t *testing.T) {
 					},
 				},
 			},
-			wantMetricCount: 1,
+			wantMetricCount: 3,
 		},
 		{
 			name: "ERSInstanceSuccess",
@@ -1230,7 +1528,7 @@ func TestCollectEnqLockMetrics(t *testing.T) {
 					},
 				},
 			},
-			wantMetricCount: 1,
+			wantMetricCount: 3,
 		},
 		{
 			name: "UseGetEnqLockTableAPISuccess",
@@ -1263,7 +1561,7 @@ func TestCollectEnqLockMetrics(t *testing.T) {
 					},
 				},
 			},
-			wantMetricCount: 1,
+			wantMetricCount: 3,
 		},
 		{
 			name: "UseGetEnqLockTableAPIError",
@@ -1330,6 +1628,166 @@ func TestCollectEnqLockMetrics(t *testing.T) {
 	}
 }
 
+func TestEnqLockAgeMetrics(t *testing.T) {
+	now := timestamppb.New(time.Date(2023, 5, 9, 13, 0, 0, 0, time.UTC))
+	locks := []*sapcontrol.EnqLock{
+		{LockName: "USR04", LockArg: "001BASIS", Owner: "20230509120639629460000602dnwh75ldbci....................."},
+		{LockName: "USR04", LockArg: "001CALM_USER", Owner: "20230509124620130684000702dnwh75ldbci....................."},
+		{LockName: "USR04", LockArg: "000DDIC", Owner: "dnwh75ldbci"},
+	}
+	tests := []struct {
+		name           string
+		props          *InstanceProperties
+		locks          []*sapcontrol.EnqLock
+		wantLongHeld   int64
+		wantOldestAge  int64
+		wantMetricsLen int
+	}{
+		{
+			name: "DefaultThreshold",
+			props: &InstanceProperties{
+				Config:      defaultConfig,
+				SAPInstance: &sapb.SAPInstance{InstanceId: "ASCS01"},
+			},
+			locks:          locks,
+			wantOldestAge:  3200,
+			wantMetricsLen: 2,
+		},
+		{
+			name: "ConfiguredThreshold",
+			props: &InstanceProperties{
+				Config: &cpb.Configuration{CollectionConfiguration: &cpb.CollectionConfiguration{
+					EnqLockAgeThreshold: 1200,
+				}},
+				SAPInstance: &sapb.SAPInstance{InstanceId: "ASCS01"},
+			},
+			locks:          locks,
+			wantLongHeld:   1,
+			wantOldestAge:  3200,
+			wantMetricsLen: 2,
+		},
+		{
+			name: "NoLocks",
+			props: &InstanceProperties{
+				Config:      defaultConfig,
+				SAPInstance: &sapb.SAPInstance{InstanceId: "ASCS01"},
+			},
+			wantMetricsLen: 2,
+		},
+		{
+			name: "SkipOldestAge",
+			props: &InstanceProperties{
+				Config:         defaultConfig,
+				SAPInstance:    &sapb.SAPInstance{InstanceId: "ASCS01"},
+				SkippedMetrics: map[string]bool{nwEnqLocksOldestAgePath: true},
+			},
+			locks:          locks,
+			wantMetricsLen: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := enqLockAgeMetrics(context.Background(), tc.props, tc.locks, now)
+			if len(got) != tc.wantMetricsLen {
+				t.Fatalf("enqLockAgeMetrics() returned %d metrics, want %d", len(got), tc.wantMetricsLen)
+			}
+			for _, m := range got {
+				value := m.GetPoints()[0].GetValue().GetInt64Value()
+				switch m.GetMetric().GetType() {
+				case metricURL + nwEnqLocksLongHeldPath:
+					if value != tc.wantLongHeld {
+						t.Errorf("enqLockAgeMetrics() long held locks = %d, want %d", value, tc.wantLongHeld)
+					}
+				case metricURL + nwEnqLocksOldestAgePath:
+					if value != tc.wantOldestAge {
+						t.Errorf("enqLockAgeMetrics() oldest lock age = %d, want %d", value, tc.wantOldestAge)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCollectKernelVersion(t *testing.T) {
+	versionInfoOutput := `OK
+0 Filename: /usr/sap/DEV/D00/exe/disp+work
+0 VersionInfo: 789, patch 52, changelist 2104542, optimized, x86_64 Linux, SAPGLOBALLEVEL
+0 Time: 2023 10 05 06:44:00`
+	tests := []struct {
+		name       string
+		props      *InstanceProperties
+		fakeExec   commandlineexecutor.Execute
+		fakeClient sapcontrolclienttest.Fake
+		wantLabels map[string]string
+		wantErr    error
+	}{
+		{
+			name:  "WebMethodSuccess",
+			props: &InstanceProperties{Config: defaultConfig, SAPInstance: defaultSAPInstance},
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: cmpopts.AnyError}
+			},
+			fakeClient: sapcontrolclienttest.Fake{
+				Versions: []sapcontrolclient.VersionInfo{
+					{Filename: "/usr/sap/DEV/D00/exe/disp+work", VersionInfo: "789, patch 100, changelist 2112021, optimized, x86_64 Linux, SAPGLOBALLEVEL"},
+				},
+			},
+			wantLabels: map[string]string{"kernel_release": "789", "kernel_patch": "100", "kernel_changelist": "2112021"},
+		},
+		{
+			name:  "CommandLineFallback",
+			props: &InstanceProperties{Config: defaultConfig, SAPInstance: defaultSAPInstance},
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: versionInfoOutput}
+			},
+			fakeClient: sapcontrolclienttest.Fake{ErrGetVersionInfo: cmpopts.AnyError},
+			wantLabels: map[string]string{"kernel_release": "789", "kernel_patch": "52", "kernel_changelist": "2104542"},
+		},
+		{
+			name:  "BothPathsFail",
+			props: &InstanceProperties{Config: defaultConfig, SAPInstance: defaultSAPInstance},
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: cmpopts.AnyError}
+			},
+			fakeClient: sapcontrolclienttest.Fake{ErrGetVersionInfo: cmpopts.AnyError},
+			wantErr:    cmpopts.AnyError,
+		},
+		{
+			name: "SkipKernelVersion",
+			props: &InstanceProperties{
+				Config:         defaultConfig,
+				SAPInstance:    defaultSAPInstance,
+				SkippedMetrics: map[string]bool{nwKernelVersionPath: true},
+			},
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: versionInfoOutput}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, gotErr := collectKernelVersion(context.Background(), test.props, test.fakeExec, commandlineexecutor.Params{}, test.fakeClient)
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("collectKernelVersion() unexpected error, got: %v, want: %v.", gotErr, test.wantErr)
+			}
+			if test.wantLabels == nil {
+				if got != nil {
+					t.Errorf("collectKernelVersion()=%v, want nil", got)
+				}
+				return
+			}
+			if got.GetPoints()[0].GetValue().GetInt64Value() != 1 {
+				t.Errorf("collectKernelVersion() value=%v, want 1", got.GetPoints()[0].GetValue())
+			}
+			for k, want := range test.wantLabels {
+				if label := got.GetMetric().GetLabels()[k]; label != want {
+					t.Errorf("collectKernelVersion() label %s=%q, want %q", k, label, want)
+				}
+			}
+		})
+	}
+}
+
 func TestCollectWithRetry(t *testing.T) {
 	c := context.Background()
 	p := &InstanceProperties{
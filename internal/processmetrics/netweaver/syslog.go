@@ -0,0 +1,64 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netweaver
+
+// The ABAP system log (transaction SM21) records an entry with message number AB0 for every
+// ABAP runtime error, each of which is written as a short dump visible in transaction ST22.
+// 'sapcontrol -function ABAPReadSyslog' returns the recent entries of the system log.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+const (
+	syslogMessageNumberColumn = "MNo"
+	shortDumpMessageNumber    = "AB0"
+)
+
+// parseABAPShortDumps parses the text output of 'sapcontrol -function ABAPReadSyslog'.
+// Returns:
+//   - count - the number of system log entries for ABAP runtime errors.
+//   - error in case the system log table is not found in the output, nil otherwise.
+func parseABAPShortDumps(text string) (count int, err error) {
+	messageNumberColumn := -1
+	for _, line := range strings.Split(text, "\n") {
+		columns := strings.Split(line, ",")
+		if messageNumberColumn == -1 {
+			// Ignore the lines preceding the header line.
+			for i, c := range columns {
+				if strings.TrimSpace(c) == syslogMessageNumberColumn {
+					messageNumberColumn = i
+				}
+			}
+			continue
+		}
+		if len(columns) <= messageNumberColumn {
+			continue
+		}
+		if strings.TrimSpace(columns[messageNumberColumn]) == shortDumpMessageNumber {
+			log.Logger.Debugw("Found ABAP short dump in system log", "entry", line)
+			count++
+		}
+	}
+	if messageNumberColumn == -1 {
+		return 0, fmt.Errorf("system log table not found in ABAPReadSyslog output")
+	}
+	return count, nil
+}
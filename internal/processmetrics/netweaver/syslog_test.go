@@ -0,0 +1,73 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netweaver
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+//go:embed sapcontrol_output/abap_read_syslog.txt
+var abapReadSyslogOutput string
+
+func TestParseABAPShortDumps(t *testing.T) {
+	tests := []struct {
+		name         string
+		syslogOutput string
+		wantCount    int
+		wantErr      error
+	}{
+		{
+			name:         "SuccessFullOutput",
+			syslogOutput: abapReadSyslogOutput,
+			wantCount:    3,
+		},
+		{
+			name: "NoShortDumps",
+			syslogOutput: `OK
+Time, Typ, Client, User, Terminal, TCode, MNo, Text, Severity
+2024 10 16 09:12:40, DIA, 000, SAPSYS, , , Q0I, Operating system call recv failed (error no. 104), SAPControl-YELLOW`,
+			wantCount: 0,
+		},
+		{
+			name:         "EmptySystemLog",
+			syslogOutput: "OK\nTime, Typ, Client, User, Terminal, TCode, MNo, Text, Severity\n",
+			wantCount:    0,
+		},
+		{
+			name:         "NoSystemLogTable",
+			syslogOutput: "FAIL: NIECONN_REFUSED (Connection refused), NiRawConnect failed in plugin_fopen()",
+			wantErr:      cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotCount, gotErr := parseABAPShortDumps(test.syslogOutput)
+
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("parseABAPShortDumps() error got=%v, want=%v", gotErr, test.wantErr)
+			}
+			if gotCount != test.wantCount {
+				t.Errorf("parseABAPShortDumps() count got=%d, want=%d", gotCount, test.wantCount)
+			}
+		})
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"os"
 	"regexp"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -45,8 +46,10 @@ import (
 	"github.com/shirou/gopsutil/v3/process"
 	"github.com/gammazero/workerpool"
 	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
+	"github.com/GoogleCloudPlatform/sapagent/internal/drain"
 	"github.com/GoogleCloudPlatform/sapagent/internal/heartbeat"
 	"github.com/GoogleCloudPlatform/sapagent/internal/metricoverrides"
+	"github.com/GoogleCloudPlatform/sapagent/internal/metricsink"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/cluster"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/computeresources"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics/fastmovingmetrics"
@@ -61,12 +64,15 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/internal/sapcontrolclient"
 	"github.com/GoogleCloudPlatform/sapagent/internal/system/sapdiscovery"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/sapagent/internal/utils/jitter"
 	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 	"github.com/GoogleCloudPlatform/sapagent/shared/recovery"
+	"github.com/GoogleCloudPlatform/sapagent/shared/timeseries"
 
 	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
 	pcm "github.com/GoogleCloudPlatform/sapagent/internal/pacemaker"
 	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
 	sapb "github.com/GoogleCloudPlatform/sapagent/protos/sapapp"
@@ -100,10 +106,14 @@ type (
 	Properties struct {
 		Config                *cpb.Configuration
 		Client                cloudmonitoring.TimeSeriesCreator
+		Sink                  metricsink.MetricSink
 		Collectors            []Collector
 		FastMovingCollectors  []Collector
 		ReliabilityCollectors []Collector
 		HeartbeatSpec         *heartbeat.Spec
+		Discovery             discoveryInterface
+		ReadyFunc             func()
+		sapInitRunning        func(context.Context, commandlineexecutor.Execute) (bool, error)
 	}
 
 	// CreateMetricClient provides an easily testable translation to the cloud monitoring API.
@@ -121,6 +131,8 @@ type (
 		Discovery      discoveryInterface
 		PCMParams      pcm.Parameters
 		OSStatReader   func(string) (os.FileInfo, error)
+		// ReadyFunc is called after each successful collection and send, it may be nil.
+		ReadyFunc func()
 	}
 )
 
@@ -131,8 +143,33 @@ const (
 	minimumFrequencyForReliability = 60
 	metricOverridePath             = "/etc/google-cloud-sap-agent/metrics-override.yaml"
 	pmMetricTypePrefix             = "workload.googleapis.com/sap/"
+	systemAvailabilityPath         = "/sap/system/availability"
+	instanceCountPath              = "/sap/discovery/instances"
+	defaultMaxConcurrency          = 4
 )
 
+// sapReadinessPollInterval is how often sapinit is polled while waiting for SAP to start.
+var sapReadinessPollInterval = 10 * time.Second
+
+// instanceCountKinds are the type and kind of the SAP instances counted by the instance count
+// metric. A count is reported for each of them, including zero, so that an instance which
+// disappears can be detected.
+var instanceCountKinds = []struct {
+	instanceType sapb.InstanceType
+	kind         sapb.InstanceKind
+}{
+	{sapb.InstanceType_HANA, sapb.InstanceKind_INSTANCE_KIND_UNDEFINED},
+	{sapb.InstanceType_NETWEAVER, sapb.InstanceKind_CS},
+	{sapb.InstanceType_NETWEAVER, sapb.InstanceKind_APP},
+	{sapb.InstanceType_NETWEAVER, sapb.InstanceKind_ERS},
+}
+
+// Per instance availability metrics which are rolled up into the system availability metric.
+var instanceAvailabilityMetrics = []string{
+	pmMetricTypePrefix + "hana/availability",
+	pmMetricTypePrefix + "nw/availability",
+}
+
 /*
 Start starts the collection of relevant metrics based on whether the
 collect_reliability_metrics or collect_process_metrics is enabled in the
@@ -149,6 +186,29 @@ func Start(ctx context.Context, parameters Parameters) bool {
 	return rm || pm
 }
 
+/*
+CollectOnce runs a single collection across all process metrics collectors for the discovered
+SAP instances, sends the metrics to cloud monitoring and logs a summary of the number of time
+series produced by each collector. No background routines are started.
+
+Return false if process metrics could not be collected.
+*/
+func CollectOnce(ctx context.Context, parameters Parameters) bool {
+	p, _ := newProcessMetricsProperties(ctx, parameters)
+	if p == nil {
+		return false
+	}
+	summaries, err := p.collectAndSendOnce(ctx, parameters.BackOffs)
+	for _, s := range summaries {
+		log.CtxLogger(ctx).Infow("Process metrics collector summary", "collector", s.collector, "timeseries", s.timeSeries, "error", s.err)
+	}
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Error sending process metrics", "error", err)
+		return false
+	}
+	return true
+}
+
 /*
 startProcessMetrics starts collection if collect_process_metrics config option is enabled
 in the configuration. The function is a NO-OP if the config option is not enabled.
@@ -159,41 +219,22 @@ in the background and return control to the caller with return value = true.
 Return false if the config option is not enabled.
 */
 func startProcessMetrics(ctx context.Context, parameters Parameters) bool {
-	cpm := parameters.Config.GetCollectionConfiguration().GetCollectProcessMetrics()
-	cf := parameters.Config.GetCollectionConfiguration().GetProcessMetricsFrequency()
-	slowPmf := parameters.Config.GetCollectionConfiguration().GetSlowProcessMetricsFrequency()
-
-	log.CtxLogger(ctx).Infow("Configuration option for collect_process_metrics", "collectprocessmetrics", cpm)
-
-	switch {
-	case !cpm:
-		log.CtxLogger(ctx).Info("Not collecting Process Metrics.")
-		return false
-	case parameters.OSType == "windows":
-		log.CtxLogger(ctx).Info("Process Metrics collection is not supported for windows platform.")
-		return false
-	case cf < minimumFrequency:
-		log.CtxLogger(ctx).Infow("Process metrics frequency is smaller than minimum supported value.", "frequency", cf, "minimumfrequency", minimumFrequency)
-		log.CtxLogger(ctx).Info("Not collecting Process Metrics.")
-		return false
-	case slowPmf < minimumFrequencyForSlowMoving:
-		log.CtxLogger(ctx).Infow("Slow process metrics frequency is smaller than minimum supported value.", "frequency", slowPmf, "minimumfrequency", minimumFrequencyForSlowMoving)
-		log.CtxLogger(ctx).Info("Not collecting Process Metrics.")
+	p, demo := newProcessMetricsProperties(ctx, parameters)
+	if p == nil {
 		return false
 	}
-
-	ua := fmt.Sprintf("sap-core-eng/%s/%s.%s/processmetrics", configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange)
-	clientOptions := []option.ClientOption{option.WithUserAgent(ua)}
-	mc, err := parameters.MetricClient(ctx, clientOptions...)
-	if err != nil {
-		log.CtxLogger(ctx).Errorw("Failed to create Cloud Monitoring client", "error", err)
-		usagemetrics.Error(usagemetrics.ProcessMetricsMetricClientCreateFailure) // Failed to create Cloud Monitoring client
-		return false
+	if sink, ok := p.Sink.(*metricsink.Prometheus); ok {
+		port := parameters.Config.GetCollectionConfiguration().GetPrometheusPort()
+		if port == 0 {
+			port = metricsink.DefaultPrometheusPort
+		}
+		go func() {
+			if err := sink.ListenAndServe(ctx, port); err != nil {
+				log.CtxLogger(ctx).Errorw("Failed to serve process metrics for Prometheus", "port", port, "error", err)
+			}
+		}()
 	}
-
-	if fileInfo, err := parameters.OSStatReader(metricOverridePath); fileInfo != nil && err == nil {
-		log.CtxLogger(ctx).Info("Using override metrics from yaml file ", metricOverridePath)
-		p := createDemoCollectors(ctx, parameters, mc, metricoverrides.DemoMetricsReader)
+	if demo {
 		demoMetricsRoutine = &recovery.RecoverableRoutine{
 			Routine: func(ctx context.Context, a any) {
 				if pm, ok := a.(Parameters); ok {
@@ -209,17 +250,6 @@ func startProcessMetrics(ctx context.Context, parameters Parameters) bool {
 		return true
 	}
 
-	sapInstances := instancesWithCredentials(ctx, &parameters)
-	if len(sapInstances.GetInstances()) == 0 {
-		log.CtxLogger(ctx).Error("No SAP Instances found. Cannot start process metrics collection.")
-		usagemetrics.Error(usagemetrics.NoSAPInstancesFound) // NO SAP instances found
-		return false
-	}
-	// Log usagemetric if hdbuserstore key is configured.
-	if parameters.Config.GetCollectionConfiguration().GetHanaMetricsConfig().GetHdbuserstoreKey() != "" {
-		usagemetrics.Action(usagemetrics.HDBUserstoreKeyConfigured)
-	}
-
 	log.CtxLogger(ctx).Info("Starting process metrics collection in background.")
 
 	dailyMetricsRoutine = &recovery.RecoverableRoutine{
@@ -231,7 +261,6 @@ func startProcessMetrics(ctx context.Context, parameters Parameters) bool {
 	}
 	dailyMetricsRoutine.StartRoutine(ctx)
 
-	p := createProcessCollectors(ctx, parameters, mc, sapInstances)
 	collectAndSendFastMetricsRoutine = &recovery.RecoverableRoutine{
 		Routine: func(ctx context.Context, a any) {
 			if parameters, ok := a.(Parameters); ok {
@@ -266,6 +295,65 @@ func startProcessMetrics(ctx context.Context, parameters Parameters) bool {
 	return true
 }
 
+// newProcessMetricsProperties validates the process metrics configuration, discovers the SAP
+// instances and creates the collectors for them. Returns a nil Properties if process metrics
+// cannot be collected. The demo return value is true when the collectors were created from the
+// metric override file instead of the discovered SAP instances.
+func newProcessMetricsProperties(ctx context.Context, parameters Parameters) (p *Properties, demo bool) {
+	cpm := parameters.Config.GetCollectionConfiguration().GetCollectProcessMetrics()
+	cf := parameters.Config.GetCollectionConfiguration().GetProcessMetricsFrequency()
+	slowPmf := parameters.Config.GetCollectionConfiguration().GetSlowProcessMetricsFrequency()
+
+	log.CtxLogger(ctx).Infow("Configuration option for collect_process_metrics", "collectprocessmetrics", cpm)
+
+	switch {
+	case !cpm:
+		log.CtxLogger(ctx).Info("Not collecting Process Metrics.")
+		return nil, false
+	case parameters.OSType == "windows":
+		log.CtxLogger(ctx).Info("Process Metrics collection is not supported for windows platform.")
+		return nil, false
+	case cf < minimumFrequency:
+		log.CtxLogger(ctx).Infow("Process metrics frequency is smaller than minimum supported value.", "frequency", cf, "minimumfrequency", minimumFrequency)
+		log.CtxLogger(ctx).Info("Not collecting Process Metrics.")
+		return nil, false
+	case slowPmf < minimumFrequencyForSlowMoving:
+		log.CtxLogger(ctx).Infow("Slow process metrics frequency is smaller than minimum supported value.", "frequency", slowPmf, "minimumfrequency", minimumFrequencyForSlowMoving)
+		log.CtxLogger(ctx).Info("Not collecting Process Metrics.")
+		return nil, false
+	}
+
+	ua := fmt.Sprintf("sap-core-eng/%s/%s.%s/processmetrics", configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange)
+	clientOptions := []option.ClientOption{option.WithUserAgent(ua)}
+	mc, err := parameters.MetricClient(ctx, clientOptions...)
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Failed to create Cloud Monitoring client", "error", err)
+		usagemetrics.Error(usagemetrics.ProcessMetricsMetricClientCreateFailure) // Failed to create Cloud Monitoring client
+		return nil, false
+	}
+
+	if fileInfo, err := parameters.OSStatReader(metricOverridePath); fileInfo != nil && err == nil {
+		log.CtxLogger(ctx).Info("Using override metrics from yaml file ", metricOverridePath)
+		p := createDemoCollectors(ctx, parameters, mc, metricoverrides.DemoMetricsReader)
+		p.Sink = metricsink.New(parameters.Config, mc)
+		return p, true
+	}
+
+	sapInstances := instancesWithCredentials(ctx, &parameters)
+	if len(sapInstances.GetInstances()) == 0 {
+		log.CtxLogger(ctx).Error("No SAP Instances found. Cannot start process metrics collection.")
+		usagemetrics.Error(usagemetrics.NoSAPInstancesFound) // NO SAP instances found
+		return nil, false
+	}
+	// Log usagemetric if hdbuserstore key is configured.
+	if parameters.Config.GetCollectionConfiguration().GetHanaMetricsConfig().GetHdbuserstoreKey() != "" {
+		usagemetrics.Action(usagemetrics.HDBUserstoreKeyConfigured)
+	}
+	p = createProcessCollectors(ctx, parameters, mc, sapInstances)
+	p.Sink = metricsink.New(parameters.Config, mc)
+	return p, false
+}
+
 // NewMetricClient is the production version that calls cloud monitoring API.
 func NewMetricClient(ctx context.Context, opts ...option.ClientOption) (cloudmonitoring.TimeSeriesCreator, error) {
 	return monitoring.NewMetricClient(ctx, opts...)
@@ -274,9 +362,12 @@ func NewMetricClient(ctx context.Context, opts ...option.ClientOption) (cloudmon
 // createProcessCollectors sets up the processmetrics properties and metric collectors for SAP Instances.
 func createProcessCollectors(ctx context.Context, params Parameters, client cloudmonitoring.TimeSeriesCreator, sapInstances *sapb.SAPInstances) *Properties {
 	p := &Properties{
-		Config:        params.Config,
-		Client:        client,
-		HeartbeatSpec: params.HeartbeatSpec,
+		Config:         params.Config,
+		Client:         client,
+		HeartbeatSpec:  params.HeartbeatSpec,
+		Discovery:      params.Discovery,
+		ReadyFunc:      params.ReadyFunc,
+		sapInitRunning: sapdiscovery.SAPInitRunning,
 	}
 
 	// For retries logic and backoff policy:
@@ -425,6 +516,7 @@ func createProcessCollectors(ctx context.Context, params Parameters, client clou
 				Client:          p.Client,
 				SkippedMetrics:  skippedMetrics,
 				PMBackoffPolicy: cloudmonitoring.LongExponentialBackOffPolicy(ctx, time.Duration(pmSlowFreq)*time.Second, 3, 3*time.Minute, 2*time.Minute),
+				HTTPTimeout:     time.Duration(p.Config.GetCollectionConfiguration().GetNetweaverHttpTimeout()) * time.Second,
 			}
 			p.Collectors = append(p.Collectors, netweaverComputeresourcesCollector, netweaverCollector)
 
@@ -511,7 +603,18 @@ func (p *Properties) collectAndSendFastMovingMetrics(ctx context.Context, bo *cl
 		return fmt.Errorf("expected non-zero fast moving collectors, got: %d", len(p.FastMovingCollectors))
 	}
 
+	if !p.waitForSAP(ctx, "fast moving") {
+		log.CtxLogger(ctx).Info("Process metrics context cancelled, exiting collectAndSend.")
+		return nil
+	}
 	cf := p.Config.GetCollectionConfiguration().GetProcessMetricsFrequency()
+	// Offset the start of collection so that hosts deployed together do not send at the same time.
+	delay := jitter.Delay(p.Config.GetCloudProperties().GetInstanceId(), time.Duration(cf)*time.Second, p.Config.GetCollectionConfiguration().GetCollectionJitterFraction())
+	log.CtxLogger(ctx).Infow("Delaying start of fast moving process metrics collection", "delay", delay)
+	if !jitter.Wait(ctx, delay) {
+		log.CtxLogger(ctx).Info("Process metrics context cancelled, exiting collectAndSend.")
+		return nil
+	}
 	collectTicker := time.NewTicker(time.Duration(cf) * time.Second)
 	defer collectTicker.Stop()
 
@@ -528,32 +631,80 @@ func (p *Properties) collectAndSendFastMovingMetrics(ctx context.Context, bo *cl
 			p.HeartbeatSpec.Beat()
 		case <-collectTicker.C:
 			p.HeartbeatSpec.Beat()
+			if drain.Active() {
+				log.CtxLogger(ctx).Debug("Agent is in drain mode, skipping fast moving process metrics collection")
+				continue
+			}
 			sent, batchCount, err := p.collectAndSendFastMovingMetricsOnce(ctx, bo)
 			if err != nil {
 				log.CtxLogger(ctx).Errorw("Error sending process metrics", "error", err)
 				lastErr = err
+			} else if p.ReadyFunc != nil {
+				p.ReadyFunc()
 			}
 			log.CtxLogger(ctx).Infow("Sent process metrics from collectAndSendFastMovingMetrics.", "sent", sent, "batches", batchCount, "sleeping", cf)
 		}
 	}
 }
 
+// waitForSAP delays the first collection until sapinit reports running or
+// process_metrics_startup_max_wait elapses, so that a freshly booted host does
+// not report SAP as unavailable while it is still starting.
+// Returns false if the context is cancelled while waiting.
+func (p *Properties) waitForSAP(ctx context.Context, collection string) bool {
+	maxWait := time.Duration(p.Config.GetCollectionConfiguration().GetProcessMetricsStartupMaxWait()) * time.Second
+	if maxWait <= 0 {
+		return true
+	}
+	sapInitRunning := p.sapInitRunning
+	if sapInitRunning == nil {
+		sapInitRunning = sapdiscovery.SAPInitRunning
+	}
+	start := time.Now()
+	deadline := time.NewTimer(maxWait)
+	defer deadline.Stop()
+	ticker := time.NewTicker(sapReadinessPollInterval)
+	defer ticker.Stop()
+	for {
+		running, err := sapInitRunning(ctx, commandlineexecutor.ExecuteCommand)
+		if err != nil {
+			log.CtxLogger(ctx).Debugw("Could not check if sapinit is running", "error", err)
+		}
+		if running {
+			log.CtxLogger(ctx).Infow("SAP is running, starting process metrics collection", "collection", collection, "waited", time.Since(start).Round(time.Second))
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-deadline.C:
+			log.CtxLogger(ctx).Infow("SAP did not come up within the maximum wait, starting process metrics collection", "collection", collection, "waited", time.Since(start).Round(time.Second), "maxWait", maxWait)
+			return true
+		case <-ticker.C:
+		}
+	}
+}
+
 type collectFastMetricsRoutineArgs struct {
 	c    Collector
 	slot int
 }
 
 func (p *Properties) collectAndSendFastMovingMetricsOnce(ctx context.Context, bo *cloudmonitoring.BackOffIntervals) (sent, batchCount int, err error) {
+	instanceCounts := p.instanceCountMetrics(ctx)
 	var wg sync.WaitGroup
 	msgs := make([][]*mrpb.TimeSeries, len(p.FastMovingCollectors))
 	defer (func() { msgs = nil })() // free up reference in memory.
-	log.CtxLogger(ctx).Debugw("Starting collectors in parallel.", "numberOfCollectors", len(p.Collectors))
+	log.CtxLogger(ctx).Debugw("Starting collectors in parallel.", "numberOfCollectors", len(p.FastMovingCollectors), "maxConcurrency", p.maxConcurrency())
+	sem := make(chan struct{}, p.maxConcurrency())
 	var routines []*recovery.RecoverableRoutine
 	for i, collector := range p.FastMovingCollectors {
 		wg.Add(1)
 		r := &recovery.RecoverableRoutine{
 			Routine: func(ctx context.Context, a any) {
 				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
 				if args, ok := a.(collectFastMetricsRoutineArgs); ok {
 					var err error
 					msgs[args.slot], err = args.c.CollectWithRetry(ctx) // Each collector writes to its own slot.
@@ -573,7 +724,142 @@ func (p *Properties) collectAndSendFastMovingMetricsOnce(ctx context.Context, bo
 	}
 	log.CtxLogger(ctx).Debug("Waiting for fast moving collectors to finish.")
 	wg.Wait()
-	return cloudmonitoring.SendTimeSeries(ctx, flatten(msgs), p.Client, bo, p.Config.GetCloudProperties().GetProjectId())
+	metrics := append(flatten(msgs), instanceCounts...)
+	if p.reportsSystemAvailability() {
+		metrics = append(metrics, p.systemAvailabilityMetric(ctx, msgs))
+	}
+	return p.send(ctx, metrics, bo)
+}
+
+// maxConcurrency returns the number of collectors which may collect at the same time. Hosts
+// with several SAP instances have collectors for each of them, bounding the concurrency keeps
+// the load of a collection on the host and on sapcontrol predictable.
+func (p *Properties) maxConcurrency() int {
+	if n := p.Config.GetCollectionConfiguration().GetProcessMetricsMaxConcurrency(); n > 0 {
+		return int(n)
+	}
+	return defaultMaxConcurrency
+}
+
+// collectorSummary records the outcome of a single collection for one collector.
+type collectorSummary struct {
+	collector  string
+	timeSeries int
+	err        error
+}
+
+// collectAndSendOnce collects from all fast and slow moving collectors in parallel, at most
+// process_metrics_max_concurrency at a time, and sends the metrics in a single request. Returns a summary per collector in the order of collection.
+func (p *Properties) collectAndSendOnce(ctx context.Context, bo *cloudmonitoring.BackOffIntervals) ([]collectorSummary, error) {
+	instanceCounts := p.instanceCountMetrics(ctx)
+	collectors := append(slices.Clone(p.FastMovingCollectors), p.Collectors...)
+	msgs := make([][]*mrpb.TimeSeries, len(collectors))
+	errs := make([]error, len(collectors))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, p.maxConcurrency())
+	for i, collector := range collectors {
+		wg.Add(1)
+		go func(slot int, c Collector) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			msgs[slot], errs[slot] = c.CollectWithRetry(ctx) // Each collector writes to its own slot.
+		}(i, collector)
+	}
+	wg.Wait()
+
+	summaries := make([]collectorSummary, len(collectors))
+	for i, c := range collectors {
+		summaries[i] = collectorSummary{collector: fmt.Sprintf("%T", c), timeSeries: len(msgs[i]), err: errs[i]}
+	}
+	metrics := append(flatten(msgs), instanceCounts...)
+	if p.reportsSystemAvailability() {
+		metrics = append(metrics, p.systemAvailabilityMetric(ctx, msgs[:len(p.FastMovingCollectors)]))
+	}
+	sent, batchCount, err := p.send(ctx, metrics, bo)
+	log.CtxLogger(ctx).Infow("Sent process metrics from collectAndSendOnce.", "sent", sent, "batches", batchCount, "error", err)
+	return summaries, err
+}
+
+// reportsSystemAvailability reports whether the system availability metric is sent. It is not
+// sent when there is no SAP instance on the host, when it is skipped, or when one of the instance
+// availability metrics it rolls up is skipped, as the instances would not report as green.
+func (p *Properties) reportsSystemAvailability() bool {
+	if len(p.FastMovingCollectors) == 0 {
+		return false
+	}
+	return !slices.ContainsFunc(p.Config.GetCollectionConfiguration().GetProcessMetricsToSkip(), func(path string) bool {
+		return path == systemAvailabilityPath || slices.Contains(instanceAvailabilityMetrics, pmMetricTypePrefix+strings.TrimPrefix(path, "/sap/"))
+	})
+}
+
+// systemAvailabilityMetric rolls up the availability reported by each fast moving collector into a
+// single metric for the host. The value is 1 only if every SAP instance reported all of its
+// processes green, an instance which failed to report its availability counts as not green.
+func (p *Properties) systemAvailabilityMetric(ctx context.Context, msgs [][]*mrpb.TimeSeries) *mrpb.TimeSeries {
+	var value int64 = 1
+	for _, instanceMetrics := range msgs {
+		green := false
+		for _, m := range instanceMetrics {
+			if !slices.Contains(instanceAvailabilityMetrics, m.GetMetric().GetType()) {
+				continue
+			}
+			green = len(m.GetPoints()) > 0 && m.GetPoints()[0].GetValue().GetInt64Value() == 1
+			break
+		}
+		if !green {
+			value = 0
+			break
+		}
+	}
+	host := p.Config.GetCloudProperties().GetInstanceName()
+	log.CtxLogger(ctx).Debugw("Collected SAP system availability", "host", host, "value", value, "numberofinstances", len(msgs))
+	return timeseries.BuildInt(timeseries.Params{
+		CloudProp:    timeseries.ConvertCloudProperties(p.Config.GetCloudProperties()),
+		MetricType:   pmMetricTypePrefix + "system/availability",
+		MetricLabels: map[string]string{"host": host},
+		Timestamp:    tspb.Now(),
+		Int64Value:   value,
+		BareMetal:    p.Config.GetBareMetal(),
+	})
+}
+
+// instanceCountMetrics counts the SAP instances discovered on the host per type and kind, from
+// the latest discovery result. Returns nil if the metric is skipped.
+func (p *Properties) instanceCountMetrics(ctx context.Context) []*mrpb.TimeSeries {
+	if p.Discovery == nil || slices.Contains(p.Config.GetCollectionConfiguration().GetProcessMetricsToSkip(), instanceCountPath) {
+		return nil
+	}
+	counts := make(map[sapb.InstanceType]map[sapb.InstanceKind]int64)
+	for _, instance := range p.Discovery.GetSAPInstances().GetInstances() {
+		kind := instance.GetKind()
+		if instance.GetType() == sapb.InstanceType_HANA {
+			kind = sapb.InstanceKind_INSTANCE_KIND_UNDEFINED
+		}
+		if counts[instance.GetType()] == nil {
+			counts[instance.GetType()] = make(map[sapb.InstanceKind]int64)
+		}
+		counts[instance.GetType()][kind]++
+	}
+
+	now := tspb.Now()
+	var metrics []*mrpb.TimeSeries
+	for _, k := range instanceCountKinds {
+		labels := map[string]string{"type": strings.ToLower(k.instanceType.String())}
+		if k.kind != sapb.InstanceKind_INSTANCE_KIND_UNDEFINED {
+			labels["kind"] = strings.ToLower(k.kind.String())
+		}
+		metrics = append(metrics, timeseries.BuildInt(timeseries.Params{
+			CloudProp:    timeseries.ConvertCloudProperties(p.Config.GetCloudProperties()),
+			MetricType:   pmMetricTypePrefix + "discovery/instances",
+			MetricLabels: labels,
+			Timestamp:    now,
+			Int64Value:   counts[k.instanceType][k.kind],
+			BareMetal:    p.Config.GetBareMetal(),
+		}))
+	}
+	log.CtxLogger(ctx).Debugw("Collected SAP instance counts", "counts", counts)
+	return metrics
 }
 
 /*
@@ -625,6 +911,7 @@ func createReliabilityCollectors(ctx context.Context, params Parameters, sapInst
 	p := &Properties{
 		Config:        params.Config,
 		HeartbeatSpec: params.HeartbeatSpec,
+		ReadyFunc:     params.ReadyFunc,
 	}
 
 	// For retries logic and backoff policy: 3 retries on failures, which means 4 attempts in total.
@@ -683,7 +970,14 @@ func (p *Properties) collectAndSendReliabilityMetrics(ctx context.Context, bo *c
 			p.HeartbeatSpec.Beat()
 		case <-reliabilityCollectTicker.C:
 			p.HeartbeatSpec.Beat()
+			if drain.Active() {
+				log.CtxLogger(ctx).Debug("Agent is in drain mode, skipping reliability metrics collection")
+				continue
+			}
 			p.collectAndSendReliabilityMetricsOnce(ctx, bo)
+			if p.ReadyFunc != nil {
+				p.ReadyFunc()
+			}
 			log.CtxLogger(ctx).Debugw("Sent reliability metrics from collectAndSend.", "sleeping", minimumFrequencyForReliability)
 		}
 	}
@@ -724,8 +1018,20 @@ func (p *Properties) collectAndSendReliabilityMetricsOnce(ctx context.Context, b
 }
 
 func createWorkerPoolForSlowMetrics(ctx context.Context, p *Properties, bo *cloudmonitoring.BackOffIntervals) {
-	log.CtxLogger(ctx).Infow("Creating worker pool for slow metrics.", "numberofCollectors", len(p.Collectors))
-	wp := workerpool.New(len(p.Collectors))
+	if !p.waitForSAP(ctx, "slow moving") {
+		log.CtxLogger(ctx).Info("Process metrics context cancelled, not creating worker pool for slow metrics.")
+		return
+	}
+	cf := p.Config.GetCollectionConfiguration().GetSlowProcessMetricsFrequency()
+	delay := jitter.Delay(p.Config.GetCloudProperties().GetInstanceId(), time.Duration(cf)*time.Second, p.Config.GetCollectionConfiguration().GetCollectionJitterFraction())
+	log.CtxLogger(ctx).Infow("Delaying start of slow moving process metrics collection", "delay", delay)
+	if !jitter.Wait(ctx, delay) {
+		log.CtxLogger(ctx).Info("Process metrics context cancelled, not creating worker pool for slow metrics.")
+		return
+	}
+	workers := min(len(p.Collectors), p.maxConcurrency())
+	log.CtxLogger(ctx).Infow("Creating worker pool for slow metrics.", "numberofCollectors", len(p.Collectors), "workers", workers)
+	wp := workerpool.New(workers)
 	for _, collector := range p.Collectors {
 		collector := collector
 		// Since wp.Submit() is non-blocking, the for loop might progress before the
@@ -739,8 +1045,14 @@ func createWorkerPoolForSlowMetrics(ctx context.Context, p *Properties, bo *clou
 }
 
 func collectAndSendSlowMovingMetrics(ctx context.Context, p *Properties, c Collector, bo *cloudmonitoring.BackOffIntervals, wp *workerpool.WorkerPool) error {
-	sent, batchCount, err := collectAndSendSlowMovingMetricsOnce(ctx, p, c, bo)
-	log.CtxLogger(ctx).Infow("Sent metrics from collectAndSendSlowMovingMetrics.", "sent", sent, "batches", batchCount, "error", err)
+	var err error
+	if drain.Active() {
+		log.CtxLogger(ctx).Debug("Agent is in drain mode, skipping slow moving process metrics collection")
+	} else {
+		var sent, batchCount int
+		sent, batchCount, err = collectAndSendSlowMovingMetricsOnce(ctx, p, c, bo)
+		log.CtxLogger(ctx).Infow("Sent metrics from collectAndSendSlowMovingMetrics.", "sent", sent, "batches", batchCount, "error", err)
+	}
 	time.AfterFunc(time.Duration(p.Config.GetCollectionConfiguration().GetSlowProcessMetricsFrequency())*time.Second, func() {
 		select {
 		case <-ctx.Done():
@@ -760,7 +1072,15 @@ func collectAndSendSlowMovingMetricsOnce(ctx context.Context, p *Properties, c C
 	if err != nil && len(metrics) == 0 {
 		return 0, 0, err
 	}
-	return cloudmonitoring.SendTimeSeries(ctx, metrics, p.Client, bo, p.Config.GetCloudProperties().GetProjectId())
+	return p.send(ctx, metrics, bo)
+}
+
+// send sends the metrics to the configured sink, or to Cloud Monitoring when no sink is set.
+func (p *Properties) send(ctx context.Context, metrics []*mrpb.TimeSeries, bo *cloudmonitoring.BackOffIntervals) (sent, batchCount int, err error) {
+	if p.Sink == nil {
+		return cloudmonitoring.SendTimeSeriesForSubsystem(ctx, cloudmonitoring.SubsystemProcess, metrics, p.Client, bo, p.Config.GetCloudProperties().GetProjectId())
+	}
+	return p.Sink.Send(ctx, metrics, bo)
 }
 
 // flatten converts an 2D array of metric slices to a flat 1D array of metrics.
@@ -32,7 +32,9 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/internal/pacemaker"
 	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
 	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring/fake"
+	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+	"github.com/GoogleCloudPlatform/sapagent/shared/timeseries"
 
 	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
 	cmpb "github.com/GoogleCloudPlatform/sapagent/protos/configurablemetrics"
@@ -372,6 +374,181 @@ func TestStartProcessMetrics(t *testing.T) {
 	}
 }
 
+func TestCollectOnce(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters Parameters
+		want       bool
+	}{
+		{
+			name: "DemoCollectionMode",
+			parameters: Parameters{
+				Config:       defaultConfig,
+				OSType:       "linux",
+				MetricClient: fakeNewMetricClient,
+				BackOffs:     defaultBackOffIntervals,
+				OSStatReader: func(data string) (os.FileInfo, error) {
+					return &mockFileInfo{}, nil
+				},
+			},
+			want: true,
+		},
+		{
+			name: "FailsDisabled",
+			parameters: Parameters{
+				Config: &cpb.Configuration{
+					CollectionConfiguration: &cpb.CollectionConfiguration{
+						CollectProcessMetrics: false,
+					},
+				},
+				OSType:       "linux",
+				MetricClient: fakeNewMetricClient,
+				BackOffs:     defaultBackOffIntervals,
+				OSStatReader: func(data string) (os.FileInfo, error) { return nil, nil },
+			},
+			want: false,
+		},
+		{
+			name: "ZeroSAPApplications",
+			parameters: Parameters{
+				Config:       defaultConfig,
+				OSType:       "linux",
+				MetricClient: fakeNewMetricClient,
+				BackOffs:     defaultBackOffIntervals,
+				Discovery: &fakeDiscoveryInterface{
+					instances: fakeSAPInstances("NOSAP"),
+				},
+				OSStatReader: func(data string) (os.FileInfo, error) { return nil, nil },
+			},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := CollectOnce(context.Background(), test.parameters)
+			if got != test.want {
+				t.Errorf("CollectOnce(%v), got: %t want: %t", test.parameters, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCollectAndSendOnce(t *testing.T) {
+	tests := []struct {
+		name          string
+		properties    *Properties
+		wantSummaries []collectorSummary
+		wantErr       error
+	}{
+		{
+			name: "FastAndSlowCollectors",
+			properties: &Properties{
+				Client:               &fake.TimeSeriesCreatorThreadSafe{},
+				FastMovingCollectors: fakeCollectors(1, 2),
+				Collectors:           []Collector{&fakeCollector{timeSeriesCount: 3}, &fakeCollectorError{}},
+				Config:               quickTestConfig,
+			},
+			wantSummaries: []collectorSummary{
+				{collector: "*processmetrics.fakeCollector", timeSeries: 2},
+				{collector: "*processmetrics.fakeCollector", timeSeries: 3},
+				{collector: "*processmetrics.fakeCollectorError", err: cmpopts.AnyError},
+			},
+		},
+		{
+			name: "SendFailure",
+			properties: &Properties{
+				Client:     &fake.TimeSeriesCreator{Err: cmpopts.AnyError},
+				Collectors: fakeCollectors(1, 1),
+				Config:     quickTestConfig,
+			},
+			wantSummaries: []collectorSummary{
+				{collector: "*processmetrics.fakeCollector", timeSeries: 1},
+			},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, gotErr := test.properties.collectAndSendOnce(context.Background(), defaultBackOffIntervals)
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("collectAndSendOnce() gotErr: %v wantErr: %v", gotErr, test.wantErr)
+			}
+			if diff := cmp.Diff(test.wantSummaries, got, cmp.AllowUnexported(collectorSummary{}), cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("collectAndSendOnce() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// inFlightCollector records the most collectors seen collecting at the same time.
+type inFlightCollector struct {
+	m        *sync.Mutex
+	inFlight *int
+	maxSeen  *int
+}
+
+func (f *inFlightCollector) Collect(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+	return f.CollectWithRetry(ctx)
+}
+
+func (f *inFlightCollector) CollectWithRetry(ctx context.Context) ([]*mrpb.TimeSeries, error) {
+	f.m.Lock()
+	*f.inFlight++
+	*f.maxSeen = max(*f.maxSeen, *f.inFlight)
+	f.m.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	f.m.Lock()
+	*f.inFlight--
+	f.m.Unlock()
+	return []*mrpb.TimeSeries{{}}, nil
+}
+
+func TestCollectAndSendOnceMaxConcurrency(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxConcurrency int64
+		want           int
+	}{
+		{
+			name:           "Configured",
+			maxConcurrency: 2,
+			want:           2,
+		},
+		{
+			name: "Default",
+			want: defaultMaxConcurrency,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var m sync.Mutex
+			var inFlight, maxSeen int
+			var collectors []Collector
+			for i := 0; i < 8; i++ {
+				collectors = append(collectors, &inFlightCollector{m: &m, inFlight: &inFlight, maxSeen: &maxSeen})
+			}
+			p := &Properties{
+				Client:               &fake.TimeSeriesCreatorThreadSafe{},
+				FastMovingCollectors: collectors[:4],
+				Collectors:           collectors[4:],
+				Config: &cpb.Configuration{
+					CollectionConfiguration: &cpb.CollectionConfiguration{ProcessMetricsMaxConcurrency: test.maxConcurrency},
+					CloudProperties:         defaultCloudProperties,
+				},
+			}
+			if _, err := p.collectAndSendOnce(context.Background(), defaultBackOffIntervals); err != nil {
+				t.Fatalf("collectAndSendOnce() returned error: %v", err)
+			}
+			if maxSeen > test.want {
+				t.Errorf("collectAndSendOnce() ran %d collectors at the same time, want at most %d", maxSeen, test.want)
+			}
+		})
+	}
+}
+
 func TestCreateProcessCollectors(t *testing.T) {
 	tests := []struct {
 		name                   string
@@ -604,7 +781,7 @@ func TestCollectAndSendOnceFastMovingMetrics(t *testing.T) {
 				FastMovingCollectors: fakeCollectors(3, 1),
 				Config:               quickTestConfig,
 			},
-			wantSent:       3,
+			wantSent:       4, // Three collected metrics and the system availability metric.
 			wantBatchCount: 1,
 		},
 		{
@@ -639,6 +816,120 @@ func TestCollectAndSendOnceFastMovingMetrics(t *testing.T) {
 	}
 }
 
+func availabilityTimeSeries(metricType string, value int64) *mrpb.TimeSeries {
+	return timeseries.BuildInt(timeseries.Params{
+		CloudProp:  timeseries.ConvertCloudProperties(defaultCloudProperties),
+		MetricType: metricType,
+		Int64Value: value,
+	})
+}
+
+func TestSystemAvailabilityMetric(t *testing.T) {
+	tests := []struct {
+		name string
+		msgs [][]*mrpb.TimeSeries
+		want int64
+	}{
+		{
+			name: "AllInstancesGreen",
+			msgs: [][]*mrpb.TimeSeries{
+				{availabilityTimeSeries("workload.googleapis.com/sap/hana/ha/availability", 4), availabilityTimeSeries("workload.googleapis.com/sap/hana/availability", 1)},
+				{availabilityTimeSeries("workload.googleapis.com/sap/nw/availability", 1)},
+			},
+			want: 1,
+		},
+		{
+			name: "OneInstanceNotGreen",
+			msgs: [][]*mrpb.TimeSeries{
+				{availabilityTimeSeries("workload.googleapis.com/sap/hana/availability", 1)},
+				{availabilityTimeSeries("workload.googleapis.com/sap/nw/availability", 0)},
+			},
+			want: 0,
+		},
+		{
+			name: "InstanceAvailabilityMissing",
+			msgs: [][]*mrpb.TimeSeries{
+				{availabilityTimeSeries("workload.googleapis.com/sap/hana/availability", 1)},
+				nil,
+			},
+			want: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &Properties{Config: defaultConfig}
+			got := p.systemAvailabilityMetric(context.Background(), test.msgs)
+			if got.GetMetric().GetType() != "workload.googleapis.com/sap/system/availability" {
+				t.Errorf("systemAvailabilityMetric() metric type = %s, want: workload.googleapis.com/sap/system/availability", got.GetMetric().GetType())
+			}
+			if got.GetMetric().GetLabels()["host"] != "test-instance" {
+				t.Errorf("systemAvailabilityMetric() host label = %s, want: test-instance", got.GetMetric().GetLabels()["host"])
+			}
+			if gotValue := got.GetPoints()[0].GetValue().GetInt64Value(); gotValue != test.want {
+				t.Errorf("systemAvailabilityMetric() value = %d, want: %d", gotValue, test.want)
+			}
+		})
+	}
+}
+
+func TestReportsSystemAvailability(t *testing.T) {
+	tests := []struct {
+		name       string
+		collectors []Collector
+		skip       []string
+		want       bool
+	}{
+		{
+			name:       "Reported",
+			collectors: fakeCollectors(2, 1),
+			want:       true,
+		},
+		{
+			name: "NoInstances",
+			want: false,
+		},
+		{
+			name:       "Skipped",
+			collectors: fakeCollectors(2, 1),
+			skip:       []string{"/sap/system/availability"},
+			want:       false,
+		},
+		{
+			name:       "HANAAvailabilitySkipped",
+			collectors: fakeCollectors(2, 1),
+			skip:       []string{"/sap/hana/availability"},
+			want:       false,
+		},
+		{
+			name:       "NetweaverAvailabilitySkipped",
+			collectors: fakeCollectors(2, 1),
+			skip:       []string{"/sap/nw/availability"},
+			want:       false,
+		},
+		{
+			name:       "OtherMetricSkipped",
+			collectors: fakeCollectors(2, 1),
+			skip:       []string{"/sap/hana/ha/availability"},
+			want:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &Properties{
+				FastMovingCollectors: test.collectors,
+				Config: &cpb.Configuration{
+					CollectionConfiguration: &cpb.CollectionConfiguration{ProcessMetricsToSkip: test.skip},
+				},
+			}
+			if got := p.reportsSystemAvailability(); got != test.want {
+				t.Errorf("reportsSystemAvailability() = %t, want: %t", got, test.want)
+			}
+		})
+	}
+}
+
 func TestInstancesWithCredentials(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -1135,3 +1426,145 @@ func TestExtractKernelVersionAndPatch(t *testing.T) {
 		}
 	}
 }
+
+func TestInstanceCountMetrics(t *testing.T) {
+	tests := []struct {
+		name      string
+		discovery discoveryInterface
+		skip      []string
+		want      map[string]int64
+	}{
+		{
+			name:      "NoDiscovery",
+			discovery: nil,
+		},
+		{
+			name:      "Skipped",
+			discovery: &fakeDiscoveryInterface{},
+			skip:      []string{"/sap/discovery/instances"},
+		},
+		{
+			name:      "NoInstances",
+			discovery: &fakeDiscoveryInterface{},
+			want:      map[string]int64{"hana/": 0, "netweaver/cs": 0, "netweaver/app": 0, "netweaver/ers": 0},
+		},
+		{
+			name: "InstancesPerTypeAndKind",
+			discovery: &fakeDiscoveryInterface{
+				instances: &sapb.SAPInstances{
+					Instances: []*sapb.SAPInstance{
+						{Sapsid: "HDB", Type: sapb.InstanceType_HANA},
+						{Sapsid: "NWS", Type: sapb.InstanceType_NETWEAVER, Kind: sapb.InstanceKind_CS},
+						{Sapsid: "NWS", Type: sapb.InstanceType_NETWEAVER, Kind: sapb.InstanceKind_APP},
+						{Sapsid: "NWS", Type: sapb.InstanceType_NETWEAVER, Kind: sapb.InstanceKind_APP},
+					},
+				},
+			},
+			want: map[string]int64{"hana/": 1, "netweaver/cs": 1, "netweaver/app": 2, "netweaver/ers": 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &cpb.Configuration{
+				CollectionConfiguration: &cpb.CollectionConfiguration{ProcessMetricsToSkip: test.skip},
+				CloudProperties:         defaultCloudProperties,
+			}
+			p := &Properties{Config: config, Discovery: test.discovery}
+			var got map[string]int64
+			for _, m := range p.instanceCountMetrics(context.Background()) {
+				if m.GetMetric().GetType() != "workload.googleapis.com/sap/discovery/instances" {
+					t.Errorf("instanceCountMetrics() metric type = %s, want: workload.googleapis.com/sap/discovery/instances", m.GetMetric().GetType())
+				}
+				if got == nil {
+					got = make(map[string]int64)
+				}
+				labels := m.GetMetric().GetLabels()
+				got[labels["type"]+"/"+labels["kind"]] = m.GetPoints()[0].GetValue().GetInt64Value()
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("instanceCountMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWaitForSAP(t *testing.T) {
+	oldInterval := sapReadinessPollInterval
+	sapReadinessPollInterval = 10 * time.Millisecond
+	defer func() { sapReadinessPollInterval = oldInterval }()
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		maxWait        int64
+		sapInitRunning func(context.Context, commandlineexecutor.Execute) (bool, error)
+		want           bool
+		wantCalls      bool
+	}{
+		{
+			name:    "Disabled",
+			ctx:     context.Background(),
+			maxWait: 0,
+			sapInitRunning: func(context.Context, commandlineexecutor.Execute) (bool, error) {
+				return false, nil
+			},
+			want: true,
+		},
+		{
+			name:    "SAPRunning",
+			ctx:     context.Background(),
+			maxWait: 60,
+			sapInitRunning: func(context.Context, commandlineexecutor.Execute) (bool, error) {
+				return true, nil
+			},
+			want:      true,
+			wantCalls: true,
+		},
+		{
+			name:    "MaxWaitElapsed",
+			ctx:     context.Background(),
+			maxWait: 1,
+			sapInitRunning: func(context.Context, commandlineexecutor.Execute) (bool, error) {
+				return false, cmpopts.AnyError
+			},
+			want:      true,
+			wantCalls: true,
+		},
+		{
+			name:    "ContextCancelled",
+			ctx:     cancelledCtx,
+			maxWait: 60,
+			sapInitRunning: func(context.Context, commandlineexecutor.Execute) (bool, error) {
+				return false, nil
+			},
+			want:      false,
+			wantCalls: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			called := false
+			p := &Properties{
+				Config: &cpb.Configuration{
+					CollectionConfiguration: &cpb.CollectionConfiguration{
+						ProcessMetricsStartupMaxWait: test.maxWait,
+					},
+				},
+				sapInitRunning: func(ctx context.Context, exec commandlineexecutor.Execute) (bool, error) {
+					called = true
+					return test.sapInitRunning(ctx, exec)
+				},
+			}
+			if got := p.waitForSAP(test.ctx, "test"); got != test.want {
+				t.Errorf("waitForSAP() = %t, want %t", got, test.want)
+			}
+			if called != test.wantCalls {
+				t.Errorf("waitForSAP() called sapInitRunning = %t, want %t", called, test.wantCalls)
+			}
+		})
+	}
+}
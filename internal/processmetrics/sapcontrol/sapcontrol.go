@@ -20,9 +20,11 @@ package sapcontrol
 import (
 	"context"
 	"fmt"
+	"path"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/GoogleCloudPlatform/sapagent/internal/sapcontrolclient"
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
@@ -38,6 +40,10 @@ var (
 	processDisplayStatusRegex = regexp.MustCompile(`([0-9]+) dispstatus: ([a-z|A-Z|_|\+]+)`)
 	// Expected format: "(Process ID) pid: (PID)"
 	processPIDRegex = regexp.MustCompile(`([0-9]+) pid: ([0-9]+)`)
+	// Expected format: "(Item Index) (Filename|VersionInfo|Time): (Value)"
+	versionInfoItemRegex = regexp.MustCompile(`^([0-9]+) (Filename|VersionInfo|Time): (.*)$`)
+	// Expected format: "(Release), patch (Patch), changelist (Changelist), ..."
+	kernelVersionRegex = regexp.MustCompile(`^\s*([0-9]+),\s*patch\s+([0-9]+),\s*changelist\s+([0-9]+)`)
 
 	sapcontrolStatus = map[int]string{
 		0: "Last webmethod call successful.",
@@ -61,6 +67,7 @@ type (
 		ABAPGetWPTable() ([]sapcontrolclient.WorkProcess, error)
 		GetQueueStatistic() ([]sapcontrolclient.TaskHandlerQueue, error)
 		GetEnqLockTable() ([]sapcontrolclient.EnqLock, error)
+		GetVersionInfo() ([]sapcontrolclient.VersionInfo, error)
 	}
 
 	// ProcessStatus has the sap process status.
@@ -69,6 +76,9 @@ type (
 		DisplayStatus string
 		IsGreen       bool
 		PID           string
+		// Uptime is the time since the process started, valid when HasUptime is true.
+		Uptime    time.Duration
+		HasUptime bool
 	}
 
 	// EnqLock has the attributes returned by sapcontrol's EnqGetLockTable function.
@@ -77,6 +87,12 @@ type (
 		UserCountOwner, UserCountOwnerVB            int64
 		Client, User, Transaction, Object, Backup   string
 	}
+
+	// KernelVersion has the SAP kernel release and patch level returned by sapcontrol's
+	// GetVersionInfo function.
+	KernelVersion struct {
+		Release, PatchLevel, Changelist string
+	}
 )
 
 // ExecProcessList uses the SAPControl command to obtain the process list result.
@@ -154,12 +170,38 @@ func createProcessMapFromAPIResp(ctx context.Context, resp []sapcontrolclient.OS
 			PID:           fmt.Sprintf("%d", p.Pid),
 			IsGreen:       strings.ToUpper(splitDs[1]) == "GREEN",
 		}
+		if p.Elapsedtime != "" {
+			uptime, err := parseElapsedTime(p.Elapsedtime)
+			if err != nil {
+				log.CtxLogger(ctx).Debugw("Could not parse the elapsed time of the process", "name", p.Name, "elapsedtime", p.Elapsedtime, "error", err)
+				continue
+			}
+			processes[i].Uptime, processes[i].HasUptime = uptime, true
+		}
 	}
 
 	log.CtxLogger(ctx).Debugw("Process statuses", "statuses", processes)
 	return processes
 }
 
+// parseElapsedTime parses the elapsed time of a process returned by GetProcessList, formatted
+// as hours:minutes:seconds where the hours are not bounded, e.g. "847:25:31".
+func parseElapsedTime(elapsed string) (time.Duration, error) {
+	parts := strings.Split(elapsed, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid elapsed time %q, want hours:minutes:seconds", elapsed)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		v, err := strconv.ParseInt(strings.TrimSpace(parts[i]), 10, 64)
+		if err != nil || v < 0 {
+			return 0, fmt.Errorf("invalid elapsed time %q, want hours:minutes:seconds", elapsed)
+		}
+		d += time.Duration(v) * unit
+	}
+	return d, nil
+}
+
 // WorkProcessDetails contains the maps that will be used by the consumers to derive metrics.
 //   - processes - A map with key->worker_process_type and value->total_process_count.
 //   - busyProcesses - A map with key->worker_process_type and value->busy_process_count.
@@ -321,3 +363,96 @@ func (p *Properties) EnqGetLockTable(ctx context.Context, c ClientInterface) ([]
 	}
 	return enqLocks, nil
 }
+
+// ownerTimeLayout is the layout of the timestamp at the start of an enqueue lock owner, the
+// lock creation time in UTC with microseconds, e.g. "20230509120639629460" of
+// "20230509120639629460000602dnwh75ldbci.....................".
+const ownerTimeLayout = "20060102150405.000000"
+
+// OwnerTime parses the time at which the lock was taken from the timestamp embedded in the owner.
+func (l *EnqLock) OwnerTime() (time.Time, error) {
+	if len(l.Owner) < 20 {
+		return time.Time{}, fmt.Errorf("lock owner %q is too short to hold a timestamp", l.Owner)
+	}
+	t, err := time.Parse(ownerTimeLayout, l.Owner[:14]+"."+l.Owner[14:20])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing the timestamp of lock owner %q: %w", l.Owner, err)
+	}
+	return t, nil
+}
+
+// GetVersionInfo performs the GetVersionInfo soap request.
+// Returns:
+//   - The kernel version of the instance, taken from the dispatcher when it is listed.
+//   - Error if the API call fails or no kernel version could be parsed.
+func (p *Properties) GetVersionInfo(ctx context.Context, c ClientInterface) (*KernelVersion, error) {
+	versions, err := c.GetVersionInfo()
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("GetVersionInfo API call failed", log.Error(err))
+		return nil, err
+	}
+	return kernelVersionFromItems(ctx, versions)
+}
+
+// ParseVersionInfo runs and parses the output of sapcontrol function GetVersionInfo.
+// Returns:
+//   - The kernel version of the instance, taken from the dispatcher when it is listed.
+//   - Error if the command fails or no kernel version could be parsed.
+func (p *Properties) ParseVersionInfo(ctx context.Context, exec commandlineexecutor.Execute, params commandlineexecutor.Params) (*KernelVersion, error) {
+	result := exec(ctx, params)
+	if result.Error != nil && !result.ExitStatusParsed {
+		log.CtxLogger(ctx).Debugw("Failed to run GetVersionInfo", log.Error(result.Error))
+		return nil, result.Error
+	}
+
+	var versions []sapcontrolclient.VersionInfo
+	items := make(map[string]int)
+	for _, line := range strings.Split(result.StdOut, "\n") {
+		match := versionInfoItemRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if len(match) != 4 {
+			continue
+		}
+		index, ok := items[match[1]]
+		if !ok {
+			index = len(versions)
+			items[match[1]] = index
+			versions = append(versions, sapcontrolclient.VersionInfo{})
+		}
+		switch match[2] {
+		case "Filename":
+			versions[index].Filename = match[3]
+		case "VersionInfo":
+			versions[index].VersionInfo = match[3]
+		case "Time":
+			versions[index].Time = match[3]
+		}
+	}
+	return kernelVersionFromItems(ctx, versions)
+}
+
+// kernelVersionFromItems parses the kernel version out of the executables listed by
+// GetVersionInfo. The dispatcher version is preferred, falling back to the first
+// executable with a parsable version.
+func kernelVersionFromItems(ctx context.Context, versions []sapcontrolclient.VersionInfo) (*KernelVersion, error) {
+	var kernelVersion *KernelVersion
+	for _, v := range versions {
+		match := kernelVersionRegex.FindStringSubmatch(v.VersionInfo)
+		if len(match) != 4 {
+			log.CtxLogger(ctx).Debugw("Could not parse version info", "filename", v.Filename, "versioninfo", v.VersionInfo)
+			continue
+		}
+		current := &KernelVersion{Release: match[1], PatchLevel: match[2], Changelist: match[3]}
+		if path.Base(v.Filename) == "disp+work" {
+			kernelVersion = current
+			break
+		}
+		if kernelVersion == nil {
+			kernelVersion = current
+		}
+	}
+	if kernelVersion == nil {
+		return nil, fmt.Errorf("no kernel version found in %d version info items", len(versions))
+	}
+	log.CtxLogger(ctx).Debugw("Found kernel version", "kernelversion", kernelVersion)
+	return kernelVersion, nil
+}
@@ -19,7 +19,9 @@ package sapcontrol
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -80,11 +82,11 @@ func TestGetProcessList(t *testing.T) {
 		{
 			name: "SucceedsAllProcesses",
 			respProcesses: []sapcontrolclient.OSProcess{
-				{"hdbdaemon", "SAPControl-GREEN", 9609},
-				{"hdbcompileserver", "SAPControl-GREEN", 9972},
-				{"hdbindexserver", "SAPControl-GREEN", 10013},
-				{"hdbnameserver", "SAPControl-GREEN", 9642},
-				{"hdbpreprocessor", "SAPControl-GREEN", 9975},
+				{"hdbdaemon", "SAPControl-GREEN", 9609, ""},
+				{"hdbcompileserver", "SAPControl-GREEN", 9972, ""},
+				{"hdbindexserver", "SAPControl-GREEN", 10013, ""},
+				{"hdbnameserver", "SAPControl-GREEN", 9642, ""},
+				{"hdbpreprocessor", "SAPControl-GREEN", 9975, ""},
 			},
 			wantProcStatus: map[int]*ProcessStatus{
 				0: &ProcessStatus{Name: "hdbdaemon", DisplayStatus: "GREEN", IsGreen: true, PID: "9609"},
@@ -98,8 +100,8 @@ func TestGetProcessList(t *testing.T) {
 		{
 			name: "NoNameForProcess",
 			respProcesses: []sapcontrolclient.OSProcess{
-				{"", "SAPControl-GREEN", 9609},
-				{"hdbcompileserver", "SAPControl-GREEN", 9972},
+				{"", "SAPControl-GREEN", 9609, ""},
+				{"hdbcompileserver", "SAPControl-GREEN", 9972, ""},
 			},
 			wantProcStatus: map[int]*ProcessStatus{
 				1: &ProcessStatus{Name: "hdbcompileserver", DisplayStatus: "GREEN", IsGreen: true, PID: "9972"},
@@ -109,8 +111,8 @@ func TestGetProcessList(t *testing.T) {
 		{
 			name: "NoPIDForProcess",
 			respProcesses: []sapcontrolclient.OSProcess{
-				{"hdbdaemon", "SAPControl-GREEN", 9609},
-				{"hdbcompileserver", "SAPControl-GREEN", 0},
+				{"hdbdaemon", "SAPControl-GREEN", 9609, ""},
+				{"hdbcompileserver", "SAPControl-GREEN", 0, ""},
 			},
 			wantProcStatus: map[int]*ProcessStatus{
 				0: &ProcessStatus{Name: "hdbdaemon", DisplayStatus: "GREEN", IsGreen: true, PID: "9609"},
@@ -120,8 +122,8 @@ func TestGetProcessList(t *testing.T) {
 		{
 			name: "NoDispstatus",
 			respProcesses: []sapcontrolclient.OSProcess{
-				{"hdbdaemon", "SAPControl-GREEN", 9609},
-				{"hdbcompileserver", "", 9972},
+				{"hdbdaemon", "SAPControl-GREEN", 9609, ""},
+				{"hdbcompileserver", "", 9972, ""},
 			},
 			wantProcStatus: map[int]*ProcessStatus{
 				0: &ProcessStatus{Name: "hdbdaemon", DisplayStatus: "GREEN", IsGreen: true, PID: "9609"},
@@ -131,14 +133,25 @@ func TestGetProcessList(t *testing.T) {
 		{
 			name: "WrongFormatDispstatus",
 			respProcesses: []sapcontrolclient.OSProcess{
-				{"hdbdaemon", "SAP-Control-GREEN", 9609},
-				{"hdbcompileserver", "SAPControl-GREEN", 9972},
+				{"hdbdaemon", "SAP-Control-GREEN", 9609, ""},
+				{"hdbcompileserver", "SAPControl-GREEN", 9972, ""},
 			},
 			wantProcStatus: map[int]*ProcessStatus{
 				1: &ProcessStatus{Name: "hdbcompileserver", DisplayStatus: "GREEN", IsGreen: true, PID: "9972"},
 			},
 			wantErr: nil,
 		},
+		{
+			name: "ElapsedTime",
+			respProcesses: []sapcontrolclient.OSProcess{
+				{"hdbdaemon", "SAPControl-GREEN", 9609, "847:25:31"},
+				{"hdbcompileserver", "SAPControl-GREEN", 9972, "invalid"},
+			},
+			wantProcStatus: map[int]*ProcessStatus{
+				0: &ProcessStatus{Name: "hdbdaemon", DisplayStatus: "GREEN", IsGreen: true, PID: "9609", Uptime: 847*time.Hour + 25*time.Minute + 31*time.Second, HasUptime: true},
+				1: &ProcessStatus{Name: "hdbcompileserver", DisplayStatus: "GREEN", IsGreen: true, PID: "9972"},
+			},
+		},
 		{
 			name:           "Error",
 			respProcesses:  nil,
@@ -165,6 +178,51 @@ func TestGetProcessList(t *testing.T) {
 	}
 }
 
+func TestParseElapsedTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed string
+		want    time.Duration
+		wantErr error
+	}{
+		{
+			name:    "JustStarted",
+			elapsed: "0:00:00",
+		},
+		{
+			name:    "MoreThanADay",
+			elapsed: "847:25:31",
+			want:    847*time.Hour + 25*time.Minute + 31*time.Second,
+		},
+		{
+			name:    "MissingHours",
+			elapsed: "25:31",
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "NotANumber",
+			elapsed: "1:aa:31",
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "Negative",
+			elapsed: "-1:00:00",
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseElapsedTime(tc.elapsed)
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("parseElapsedTime(%q) returned error: %v, want: %v", tc.elapsed, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("parseElapsedTime(%q) = %v, want: %v", tc.elapsed, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestABAPGetWPTable(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -408,3 +466,172 @@ func TestEnqGetLockTable(t *testing.T) {
 		})
 	}
 }
+
+func TestGetVersionInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       sapcontrolclienttest.Fake
+		want    *KernelVersion
+		wantErr error
+	}{
+		{
+			name: "DispatcherPreferred",
+			c: sapcontrolclienttest.Fake{
+				Versions: []sapcontrolclient.VersionInfo{
+					{Filename: "/usr/sap/DEV/D00/exe/sapstartsrv", VersionInfo: "789, patch 52, changelist 2104542, optimized, x86_64 Linux, SAPGLOBALLEVEL"},
+					{Filename: "/usr/sap/DEV/D00/exe/disp+work", VersionInfo: "789, patch 100, changelist 2112021, optimized, x86_64 Linux, SAPGLOBALLEVEL"},
+				},
+			},
+			want: &KernelVersion{Release: "789", PatchLevel: "100", Changelist: "2112021"},
+		},
+		{
+			name: "FirstParsableWithoutDispatcher",
+			c: sapcontrolclienttest.Fake{
+				Versions: []sapcontrolclient.VersionInfo{
+					{Filename: "/usr/sap/HDB/HDB00/exe/sapstartsrv", VersionInfo: "invalid"},
+					{Filename: "/usr/sap/HDB/HDB00/exe/sapwebdisp", VersionInfo: "753, patch 1000, changelist 2085960, optimized, x86_64 Linux"},
+				},
+			},
+			want: &KernelVersion{Release: "753", PatchLevel: "1000", Changelist: "2085960"},
+		},
+		{
+			name:    "NoParsableVersion",
+			c:       sapcontrolclienttest.Fake{Versions: []sapcontrolclient.VersionInfo{{VersionInfo: "invalid"}}},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "Error",
+			c:       sapcontrolclienttest.Fake{ErrGetVersionInfo: cmpopts.AnyError},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var p Properties
+			got, err := p.GetVersionInfo(context.Background(), tc.c)
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("GetVersionInfo(%v)=%v, want %v", tc.c, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetVersionInfo(%v) returned an unexpected diff (-want +got): %v", tc.c, diff)
+			}
+		})
+	}
+}
+
+func TestParseVersionInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		fakeExec commandlineexecutor.Execute
+		want     *KernelVersion
+		wantErr  error
+	}{
+		{
+			name: "Success",
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{
+					StdOut: `
+					18.01.2024 10:00:00
+					GetVersionInfo
+					OK
+					0 Filename: /usr/sap/DEV/D00/exe/sapstartsrv
+					0 VersionInfo: 789, patch 52, changelist 2104542, optimized, x86_64 Linux, SAPGLOBALLEVEL
+					0 Time: 2023 10 05 06:44:00
+					1 Filename: /usr/sap/DEV/D00/exe/disp+work
+					1 VersionInfo: 789, patch 100, changelist 2112021, optimized, x86_64 Linux, SAPGLOBALLEVEL
+					1 Time: 2023 12 01 08:12:00`,
+				}
+			},
+			want: &KernelVersion{Release: "789", PatchLevel: "100", Changelist: "2112021"},
+		},
+		{
+			name: "NoVersionInfo",
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "OK\n"}
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name: "Error",
+			fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: cmpopts.AnyError}
+			},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var p Properties
+			got, err := p.ParseVersionInfo(context.Background(), test.fakeExec, commandlineexecutor.Params{})
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("ParseVersionInfo(%v)=%v, want: %v.", test.fakeExec, err, test.wantErr)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ParseVersionInfo(%v) returned an unexpected diff (-want +got): %v", test.fakeExec, diff)
+			}
+		})
+	}
+}
+
+// enqLockOwners returns the owner column of the lock rows of the EnqGetLockTable output.
+func enqLockOwners(output string) []string {
+	var owners []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), ", ")
+		if len(fields) < 12 || fields[0] == "lock_name" {
+			continue
+		}
+		owners = append(owners, fields[3])
+	}
+	return owners
+}
+
+func TestOwnerTime(t *testing.T) {
+	multilineOwners := enqLockOwners(multilineEnqTableOutput)
+	tests := []struct {
+		name    string
+		owner   string
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name:  "DefaultOutput",
+			owner: enqLockOwners(defaultEnqTableOutput)[0],
+			want:  time.Date(2023, 4, 24, 7, 36, 48, 639586000, time.UTC),
+		},
+		{
+			name:  "MultilineOutputFirstLock",
+			owner: multilineOwners[0],
+			want:  time.Date(2023, 5, 9, 12, 6, 39, 629460000, time.UTC),
+		},
+		{
+			name:  "MultilineOutputSecondLock",
+			owner: multilineOwners[1],
+			want:  time.Date(2023, 5, 9, 12, 6, 20, 130684000, time.UTC),
+		},
+		{
+			name:    "TooShort",
+			owner:   "dnwh75ldbci",
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "NotATimestamp",
+			owner:   "dnwh75ldbci.....................",
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lock := &EnqLock{Owner: tc.owner}
+			got, err := lock.OwnerTime()
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("OwnerTime() returned error: %v, want error: %v", err, tc.wantErr)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("OwnerTime() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -49,6 +49,8 @@ type (
 		Name       string `xml:"name,omitempty"`
 		Dispstatus string `xml:"dispstatus,omitempty"`
 		Pid        int64  `xml:"pid,omitempty"`
+		// Elapsedtime is the time since the process started, formatted as hours:minutes:seconds.
+		Elapsedtime string `xml:"elapsedtime,omitempty"`
 	}
 
 	// ABAPGetWPTableRequest struct for ABAPGetWPTable soap request body.
@@ -116,6 +118,24 @@ type (
 		Object          string `xml:"object,omitempty"`
 		Backup          string `xml:"backup,omitempty"`
 	}
+
+	// GetVersionInfoRequest struct for GetVersionInfo soap request body.
+	GetVersionInfoRequest struct {
+		XMLName xml.Name `xml:"urn:SAPControl GetVersionInfo"`
+	}
+
+	// GetVersionInfoResponse struct for GetVersionInfo soap response body.
+	GetVersionInfoResponse struct {
+		XMLName  xml.Name      `xml:"SAPControl GetVersionInfoResponse"`
+		Versions []VersionInfo `xml:"version>item"`
+	}
+
+	// VersionInfo struct holds the version of one executable of the sap instance.
+	VersionInfo struct {
+		Filename    string `xml:"Filename,omitempty"`
+		VersionInfo string `xml:"VersionInfo,omitempty"`
+		Time        string `xml:"Time,omitempty"`
+	}
 )
 
 // New returns a Client for soap calls supported by all types of sap instances.
@@ -184,3 +204,16 @@ func (c Client) GetEnqLockTable() ([]EnqLock, error) {
 	log.Logger.Infow("Sapcontrol GetEnqLockTable", "apiResponse", res.EnqLocks)
 	return res.EnqLocks, nil
 }
+
+// GetVersionInfo performs GetVersionInfo soap request.
+// Returns:
+//   - GetVersionInfo API call response as a list of VersionInfo structs.
+//   - Error if Client.call fails, nil otherwise.
+func (c Client) GetVersionInfo() ([]VersionInfo, error) {
+	res := &GetVersionInfoResponse{}
+	if err := c.call(&GetVersionInfoRequest{}, res); err != nil {
+		return nil, err
+	}
+	log.Logger.Debugw("Sapcontrol GetVersionInfo", "apiResponse", res.Versions)
+	return res.Versions, nil
+}
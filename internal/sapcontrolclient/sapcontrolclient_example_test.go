@@ -92,7 +92,7 @@ func ExampleClient_GetProcessList() {
 	// Stopping the mock SAP server.
 	mock.Stop()
 	// Output:
-	// [{hdbdaemon SAPControl-GREEN 9609} { SAPControl-GREEN 9972}] <nil>
+	// [{hdbdaemon SAPControl-GREEN 9609 847:25:31} { SAPControl-GREEN 9972 847:25:24}] <nil>
 }
 
 // Example to get response from ABAPGetWPTable SAPControl webmethod.
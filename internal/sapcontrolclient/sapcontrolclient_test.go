@@ -70,6 +70,9 @@ var (
 
 	//go:embed testdata/enqgetlocktable/enqgetlocktable_success_response.xml
 	enqLocksResponse string
+
+	//go:embed testdata/getversioninfo/all_versions.xml
+	versionInfoResponse string
 )
 
 // NewSapControl returns a new mock for sapcontrol.
@@ -122,13 +125,13 @@ func TestGetProcessList(t *testing.T) {
 			name:         "SucceedsAllProcesses",
 			fakeResponse: processListResponse,
 			wantProcStatus: []OSProcess{
-				{"hdbdaemon", "SAPControl-GREEN", 9609},
-				{"hdbcompileserver", "SAPControl-GREEN", 9972},
-				{"hdbindexserver", "SAPControl-GREEN", 10013},
-				{"hdbnameserver", "SAPControl-GREEN", 9642},
-				{"hdbpreprocessor", "SAPControl-GREEN", 9975},
-				{"hdbwebdispatcher", "SAPControl-GREEN", 11322},
-				{"hdbxsengine", "SAPControl-GREEN", 10016},
+				{"hdbdaemon", "SAPControl-GREEN", 9609, "847:25:31"},
+				{"hdbcompileserver", "SAPControl-GREEN", 9972, "847:25:24"},
+				{"hdbindexserver", "SAPControl-GREEN", 10013, "847:25:24"},
+				{"hdbnameserver", "SAPControl-GREEN", 9642, "847:25:31"},
+				{"hdbpreprocessor", "SAPControl-GREEN", 9975, "847:25:24"},
+				{"hdbwebdispatcher", "SAPControl-GREEN", 11322, "847:25:08"},
+				{"hdbxsengine", "SAPControl-GREEN", 10016, "847:25:24"},
 			},
 			wantErr: nil,
 		},
@@ -141,16 +144,16 @@ func TestGetProcessList(t *testing.T) {
 			name:         "NoPIDForProcess",
 			fakeResponse: noPidProcessListResponse,
 			wantProcStatus: []OSProcess{
-				OSProcess{"hdbdaemon", "SAPControl-GREEN", 9609},
-				OSProcess{"hdbcompileserver", "SAPControl-GREEN", 9972},
-				OSProcess{"hdbindexserver", "SAPControl-GREEN", 0},
+				OSProcess{"hdbdaemon", "SAPControl-GREEN", 9609, "847:25:31"},
+				OSProcess{"hdbcompileserver", "SAPControl-GREEN", 9972, "847:25:24"},
+				OSProcess{"hdbindexserver", "SAPControl-GREEN", 0, "847:25:24"},
 			},
 			wantErr: nil,
 		},
 		{
 			name:           "NoNameForProcess",
 			fakeResponse:   noNameProcessListResponse,
-			wantProcStatus: []OSProcess{OSProcess{"hdbdaemon", "SAPControl-GREEN", 9609}, OSProcess{"", "SAPControl-GREEN", 9972}},
+			wantProcStatus: []OSProcess{OSProcess{"hdbdaemon", "SAPControl-GREEN", 9609, "847:25:31"}, OSProcess{"", "SAPControl-GREEN", 9972, "847:25:24"}},
 			wantErr:        nil,
 		},
 	}
@@ -315,3 +318,50 @@ func TestGetEnqLockTable(t *testing.T) {
 		})
 	}
 }
+
+func TestGetVersionInfo(t *testing.T) {
+	tests := []struct {
+		name         string
+		fakeResponse string
+		wantVersions []VersionInfo
+		wantErr      error
+	}{
+		{
+			name:         "SuccessVersions",
+			fakeResponse: versionInfoResponse,
+			wantVersions: []VersionInfo{
+				{
+					Filename:    "/usr/sap/DEV/D00/exe/sapstartsrv",
+					VersionInfo: "789, patch 52, changelist 2104542, optimized, x86_64 Linux, SAPGLOBALLEVEL",
+					Time:        "2023 10 05 06:44:00",
+				},
+				{
+					Filename:    "/usr/sap/DEV/D00/exe/disp+work",
+					VersionInfo: "789, patch 52, changelist 2104542, optimized, x86_64 Linux, SAPGLOBALLEVEL",
+					Time:        "2023 10 05 06:44:00",
+				},
+			},
+		},
+		{
+			name:         "Failure",
+			fakeResponse: faultResponse,
+			wantErr:      cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupSAPMocks(t, test.fakeResponse)
+			c := setupClient(t)
+			gotVersions, gotErr := c.GetVersionInfo()
+
+			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("GetVersionInfo(), gotErr: %v wantErr: %v.", gotErr, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.wantVersions, gotVersions); diff != "" {
+				t.Errorf("GetVersionInfo() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -25,11 +25,13 @@ type Fake struct {
 	WorkProcesses []sapcontrolclient.WorkProcess
 	TaskQueues    []sapcontrolclient.TaskHandlerQueue
 	EnqLocks      []sapcontrolclient.EnqLock
+	Versions      []sapcontrolclient.VersionInfo
 
 	ErrGetProcessList    error
 	ErrABAPGetWPTable    error
 	ErrGetQueueStatistic error
 	ErrEnqGetLockTable   error
+	ErrGetVersionInfo    error
 }
 
 // GetProcessList a mock that returns map describing the statuses of SAP processes.
@@ -51,3 +53,8 @@ func (c Fake) GetQueueStatistic() ([]sapcontrolclient.TaskHandlerQueue, error) {
 func (c Fake) GetEnqLockTable() ([]sapcontrolclient.EnqLock, error) {
 	return c.EnqLocks, c.ErrEnqGetLockTable
 }
+
+// GetVersionInfo is a fake implementation of sapcontrol package GetVersionInfo method.
+func (c Fake) GetVersionInfo() ([]sapcontrolclient.VersionInfo, error) {
+	return c.Versions, c.ErrGetVersionInfo
+}
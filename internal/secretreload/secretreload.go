@@ -0,0 +1,85 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secretreload notifies the services of the agent when the secrets they use have been
+// rotated, so that they can re-read the secrets and reconnect without restarting the agent.
+package secretreload
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+var (
+	mu          sync.Mutex
+	subscribers = map[chan struct{}]bool{}
+)
+
+// Subscribe returns a channel receiving a value each time a secret reload is requested, and a
+// function which stops the notifications. Requests made while a previous one is still pending
+// are coalesced.
+func Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	mu.Lock()
+	subscribers[ch] = true
+	mu.Unlock()
+	return ch, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(subscribers, ch)
+	}
+}
+
+// Notify requests a secret reload from every subscriber.
+func Notify(ctx context.Context) {
+	mu.Lock()
+	defer mu.Unlock()
+	log.CtxLogger(ctx).Infow("Requesting a reload of the secrets", "subscribers", len(subscribers))
+	for ch := range subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Start requests a secret reload each time the agent receives the reload signal, until the
+// context is done. A reload cannot be signaled on platforms without a reload signal.
+func Start(ctx context.Context) {
+	if len(signals) == 0 {
+		return
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+	go handleSignals(ctx, ch)
+}
+
+// handleSignals requests a secret reload for every signal received on the channel.
+func handleSignals(ctx context.Context, ch <-chan os.Signal) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-ch:
+			log.CtxLogger(ctx).Infow("Secret reload signal received", "signal", sig)
+			Notify(ctx)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretreload
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+func TestMain(m *testing.M) {
+	log.SetupLoggingForTest()
+	os.Exit(m.Run())
+}
+
+func TestNotify(t *testing.T) {
+	ctx := context.Background()
+	ch, unsubscribe := Subscribe()
+	defer unsubscribe()
+
+	select {
+	case <-ch:
+		t.Fatalf("received a reload before Notify() was called")
+	default:
+	}
+	// Pending requests are coalesced into a single notification.
+	Notify(ctx)
+	Notify(ctx)
+	select {
+	case <-ch:
+	default:
+		t.Fatalf("no reload received after Notify()")
+	}
+	select {
+	case <-ch:
+		t.Errorf("received a second reload for coalesced requests")
+	default:
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	ch, unsubscribe := Subscribe()
+	unsubscribe()
+	Notify(context.Background())
+	select {
+	case <-ch:
+		t.Errorf("received a reload after unsubscribing")
+	default:
+	}
+}
+
+func TestHandleSignals(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, unsubscribe := Subscribe()
+	defer unsubscribe()
+
+	sigs := make(chan os.Signal)
+	go handleSignals(ctx, sigs)
+	sigs <- os.Interrupt
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Errorf("no reload received after the reload signal")
+	}
+}
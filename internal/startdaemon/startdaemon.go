@@ -26,13 +26,16 @@ import (
 	"os/signal"
 	"os/user"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"flag"
 	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
+	"cloud.google.com/go/logging/logadmin"
 	"cloud.google.com/go/storage"
 	"google.golang.org/api/option"
 	"golang.org/x/oauth2/google"
@@ -41,16 +44,21 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/internal/agentmetrics"
 	"github.com/GoogleCloudPlatform/sapagent/internal/collectiondefinition"
 	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
+	"github.com/GoogleCloudPlatform/sapagent/internal/drain"
+	"github.com/GoogleCloudPlatform/sapagent/internal/events"
 	"github.com/GoogleCloudPlatform/sapagent/internal/gcebeta"
 	"github.com/GoogleCloudPlatform/sapagent/internal/guestactions"
 	"github.com/GoogleCloudPlatform/sapagent/internal/hanamonitoring"
+	"github.com/GoogleCloudPlatform/sapagent/internal/healthcheck"
 	"github.com/GoogleCloudPlatform/sapagent/internal/heartbeat"
 	"github.com/GoogleCloudPlatform/sapagent/internal/hostmetrics/agenttime"
 	"github.com/GoogleCloudPlatform/sapagent/internal/hostmetrics/cloudmetricreader"
 	"github.com/GoogleCloudPlatform/sapagent/internal/hostmetrics"
 	"github.com/GoogleCloudPlatform/sapagent/internal/instanceinfo"
+	"github.com/GoogleCloudPlatform/sapagent/internal/metadatalabels"
 	"github.com/GoogleCloudPlatform/sapagent/internal/pacemaker"
 	"github.com/GoogleCloudPlatform/sapagent/internal/processmetrics"
+	"github.com/GoogleCloudPlatform/sapagent/internal/secretreload"
 	"github.com/GoogleCloudPlatform/sapagent/internal/system/appsdiscovery"
 	"github.com/GoogleCloudPlatform/sapagent/internal/system/clouddiscovery"
 	"github.com/GoogleCloudPlatform/sapagent/internal/system/hostdiscovery"
@@ -58,16 +66,20 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/internal/system"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/sapagent/internal/utils/filesystem"
+	"github.com/GoogleCloudPlatform/sapagent/internal/utils/sdnotify"
 	"github.com/GoogleCloudPlatform/sapagent/internal/workloadmanager"
 	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/gce"
+	"github.com/GoogleCloudPlatform/sapagent/shared/gce/metadataserver"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 	"github.com/GoogleCloudPlatform/sapagent/shared/recovery"
 
 	cdpb "github.com/GoogleCloudPlatform/sapagent/protos/collectiondefinition"
 	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
 	iipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
+	wlmpb "github.com/GoogleCloudPlatform/sapagent/protos/wlmvalidation"
+	wpb "google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 const (
@@ -75,8 +87,18 @@ const (
 	hostMetricsServiceName     = "hostmetrics"
 	processMetricsServiceName  = "processmetrics"
 	workloadManagerServiceName = "workloadmanager"
+	hanaMonitoringServiceName  = "hanamonitoring"
+	systemDiscoveryServiceName = "systemdiscovery"
+	eventsServiceName          = "events"
 )
 
+// serviceSwitch is the configuration option which turns a service on or off.
+type serviceSwitch struct {
+	service string
+	option  string
+	enabled bool
+}
+
 var (
 	osStatReader = workloadmanager.OSStatReader(func(f string) (os.FileInfo, error) {
 		return os.Stat(f)
@@ -102,10 +124,20 @@ var (
 
 // Daemon has args for startdaemon subcommand.
 type Daemon struct {
-	configFilePath string
-	lp             log.Parameters
-	config         *cpb.Configuration
-	cloudProps     *iipb.CloudProperties
+	configFilePath  string
+	disabledQueries string
+	configOverride  string
+	singleShot      bool
+	lp              log.Parameters
+	config          *cpb.Configuration
+	cloudProps      *iipb.CloudProperties
+	health          serviceHealth
+
+	// The metadata server fetch options, they override the environment variables read by
+	// metadataserver.FetchOptionsFromEnv. metadataRetries is negative when it is not set.
+	metadataRetries       int
+	metadataRetryInterval time.Duration
+	metadataSecondaryURL  string
 }
 
 // Name implements the subcommand interface for startdaemon.
@@ -116,13 +148,18 @@ func (*Daemon) Synopsis() string { return "start daemon mode of the agent" }
 
 // Usage implements the subcommand interface for startdaemon.
 func (*Daemon) Usage() string {
-	return "Usage: startdaemon [-config <path-to-config-file>] \n"
+	return "Usage: startdaemon [-config <path-to-config-file>] [-disable-query=<query-name1,query-name2>] [-single-shot] [-metadata-retries=<n>] [-metadata-retry-interval=<duration>] [-metadata-secondary-url=<url>]\n"
 }
 
 // SetFlags implements the subcommand interface for startdaemon.
 func (d *Daemon) SetFlags(fs *flag.FlagSet) {
 	fs.StringVar(&d.configFilePath, "config", "", "configuration path for startdaemon mode")
 	fs.StringVar(&d.configFilePath, "c", "", "configuration path for startdaemon mode")
+	fs.StringVar(&d.disabledQueries, "disable-query", "", "comma separated list of HANA Monitoring query names to skip")
+	fs.BoolVar(&d.singleShot, "single-shot", false, "collect process metrics once, send them and exit")
+	fs.IntVar(&d.metadataRetries, "metadata-retries", -1, "number of retries when the cloud properties could not be fetched at startup, overrides "+metadataserver.RetriesEnv)
+	fs.DurationVar(&d.metadataRetryInterval, "metadata-retry-interval", 0, "fixed interval between the metadata server retries, ex: 5s, overrides "+metadataserver.RetryIntervalEnv)
+	fs.StringVar(&d.metadataSecondaryURL, "metadata-secondary-url", "", "metadata server endpoint tried when the primary one cannot be reached, overrides "+metadataserver.SecondaryURLEnv)
 }
 
 // Execute implements the subcommand interface for startdaemon.
@@ -143,9 +180,21 @@ func (d *Daemon) Execute(ctx context.Context, f *flag.FlagSet, args ...any) subc
 		return subcommands.ExitUsageError
 	}
 	d.createLogDir()
+	d.lp = configuration.ApplyLogRotation(d.lp, configuration.ReadLogRotation(d.configFilePath, os.ReadFile))
 	log.SetupLogging(d.lp)
+	if !d.singleShot {
+		// SIGUSR1 toggles the drain mode of the agent, see the drain package. The handler is not
+		// tied to the cancelable context, so that it keeps running when the services are restarted.
+		drain.Start(ctx)
+		// SIGHUP reloads the secrets and the instance metadata metric labels, see the secretreload
+		// package. One time commands keep the default behavior of terminating on SIGHUP.
+		secretreload.Start(ctx)
+	}
 	ctx, cancel := context.WithCancel(ctx)
+	d.refetchCloudProperties()
+	d.fetchConfigOverride()
 	d.config = configuration.ReadFromFile(d.configFilePath, os.ReadFile)
+	d.config = configuration.ApplyMetadataOverride(d.config, d.configOverride)
 	if d.config.GetBareMetal() && d.config.GetCloudProperties() == nil {
 		log.Logger.Error("Bare metal instance detected without cloud properties set. Manually set cloud properties in the configuration file to continue.")
 		usagemetrics.Error(usagemetrics.BareMetalCloudPropertiesNotSet)
@@ -159,6 +208,57 @@ func (d *Daemon) Execute(ctx context.Context, f *flag.FlagSet, args ...any) subc
 	return d.startdaemonHandler(ctx, cancel, false)
 }
 
+// refetchCloudProperties retries fetching the cloud properties with the metadata flags when
+// they could not be fetched at startup with the environment options.
+func (d *Daemon) refetchCloudProperties() {
+	opts, ok := d.fetchOptions(metadataserver.FetchOptionsFromEnv(d.lp.OSType, os.Getenv))
+	if !ok || d.cloudProps.GetInstanceId() != "" {
+		return
+	}
+	opts.BareMetal = configuration.ReadBareMetal(d.configFilePath, os.ReadFile)
+	log.Logger.Infow("Retrying to fetch the cloud properties with the metadata flags", "retries", opts.Retries, "retryInterval", opts.RetryInterval, "secondaryURL", opts.SecondaryURL)
+	cp := metadataserver.FetchCloudPropertiesWithOptions(opts)
+	if cp == nil {
+		return
+	}
+	d.cloudProps = &iipb.CloudProperties{
+		ProjectId:        cp.ProjectID,
+		InstanceId:       cp.InstanceID,
+		Zone:             cp.Zone,
+		InstanceName:     cp.InstanceName,
+		Image:            cp.Image,
+		NumericProjectId: cp.NumericProjectID,
+		MachineType:      cp.MachineType,
+	}
+}
+
+// fetchOptions overrides opts with the metadata flags, ok is false when none of them are set.
+func (d *Daemon) fetchOptions(opts metadataserver.FetchOptions) (_ metadataserver.FetchOptions, ok bool) {
+	if d.metadataRetries >= 0 {
+		opts.Retries, ok = d.metadataRetries, true
+	}
+	if d.metadataRetryInterval > 0 {
+		opts.RetryInterval, ok = d.metadataRetryInterval, true
+	}
+	if d.metadataSecondaryURL != "" {
+		opts.SecondaryURL, ok = d.metadataSecondaryURL, true
+	}
+	return opts, ok
+}
+
+// fetchConfigOverride reads the configuration overrides set by fleet operators in the instance
+// metadata, they take precedence over the configuration file. Nothing is read when the metadata
+// server is not reachable, such as on bare metal.
+func (d *Daemon) fetchConfigOverride() {
+	if d.cloudProps.GetInstanceId() == "" {
+		return
+	}
+	var err error
+	if d.configOverride, err = metadataserver.FetchAgentConfigOverride(); err != nil {
+		log.Logger.Warnw("Could not read configuration override from instance metadata", "key", metadataserver.AgentConfigKey, "error", err)
+	}
+}
+
 func (d *Daemon) createLogDir() {
 	// Setup demon logging with default config, till we read it from the config file.
 	d.lp.CloudLogName = `google-cloud-sap-agent`
@@ -202,10 +302,12 @@ func (d *Daemon) startdaemonHandler(ctx context.Context, cancel context.CancelFu
 	// Daemon mode operation
 	if restarting {
 		d.config = configuration.ReadFromFile(d.configFilePath, os.ReadFile)
+		d.config = configuration.ApplyMetadataOverride(d.config, d.configOverride)
 		d.config = configuration.ApplyDefaults(d.config, d.cloudProps)
 	}
 	d.lp.LogToCloud = d.config.GetLogToCloud().GetValue()
 	d.lp.Level = configuration.LogLevelToZapcore(d.config.GetLogLevel())
+	d.lp = configuration.ApplyLogRotation(d.lp, d.config.GetLogRotation())
 	log.SetupLogging(d.lp)
 
 	log.Logger.Infow("Agent version currently running", "version", configuration.AgentVersion)
@@ -228,6 +330,9 @@ func (d *Daemon) startdaemonHandler(ctx context.Context, cancel context.CancelFu
 
 	configureUsageMetricsForDaemon(d.config.GetCloudProperties())
 	usagemetrics.Configured()
+	if d.singleShot {
+		return d.collectOnce(ctx, runtime.GOOS)
+	}
 	if !restarting {
 		usagemetrics.Started()
 		go usagemetrics.LogRunningDaily()
@@ -258,6 +363,9 @@ func (d *Daemon) startServices(ctx context.Context, cancel context.CancelFunc, g
 	shutdownch := make(chan os.Signal, 1)
 	signal.Notify(shutdownch, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
 
+	enabled := logServiceSwitches(d.config)
+	d.applySharedSettings(ctx)
+
 	// When not collecting agent metrics and service health, the NullMonitor will provide
 	// sensible NOOPs. Downstream services can safely register and use the provided *Spec
 	// without fear nor penalty.
@@ -270,6 +378,28 @@ func (d *Daemon) startServices(ctx context.Context, cancel context.CancelFunc, g
 			return
 		}
 	}
+	if _, ok := sdnotify.WatchdogInterval(); ok {
+		// The systemd watchdog is pinged only while the started services keep beating, so the missed
+		// heartbeats are counted, including when the agent metrics are not collected.
+		if m, ok := healthMonitor.(*heartbeat.Monitor); ok {
+			m.Run(ctx)
+		} else {
+			healthMonitor = startWatchdogHealthMonitor(ctx, d.config)
+		}
+	}
+	d.health.set(healthMonitor)
+
+	// The health check endpoint reports ready once every started collector completed a successful cycle.
+	var readyStatus *healthcheck.Status
+	if port := d.config.GetCollectionConfiguration().GetHealthCheckPort(); port != 0 {
+		readyStatus = &healthcheck.Status{}
+		hcCtx := log.SetCtx(ctx, "context", "HealthCheck")
+		go func() {
+			if err := readyStatus.ListenAndServe(hcCtx, port); err != nil {
+				log.CtxLogger(hcCtx).Errorw("Failed to serve health check endpoints", "port", port, "error", err)
+			}
+		}()
+	}
 
 	// Create channels to subscribe to collection definition updates.
 	chWLM := make(chan *cdpb.CollectionDefinition)
@@ -284,25 +414,12 @@ func (d *Daemon) startServices(ctx context.Context, cancel context.CancelFunc, g
 	}
 	cd := collectiondefinition.Start(cdCtx, chs, collectiondefinition.StartOptions{
 		HeartbeatSpec: cdHeartbeatSpec,
-		LoadOptions: collectiondefinition.LoadOptions{
-			CollectionConfig: d.config.GetCollectionConfiguration(),
-			ReadFile:         os.ReadFile,
-			OSType:           goos,
-			Version:          configuration.AgentVersion,
-			FetchOptions: collectiondefinition.FetchOptions{
-				OSType:     goos,
-				Env:        d.config.GetCollectionConfiguration().GetWorkloadValidationCollectionDefinition().GetConfigTargetEnvironment(),
-				Client:     storage.NewClient,
-				CreateTemp: os.CreateTemp,
-				Execute:    execute,
-			},
-		},
+		LoadOptions:   d.collectionDefinitionLoadOptions(goos),
 	})
+	d.health.watch(collectionDefinitionName)
 
-	gceService, err := gce.NewGCEClient(ctx)
+	gceService, gceBetaService, err := d.gceServices(ctx)
 	if err != nil {
-		log.Logger.Errorw("Failed to create GCE service", "error", err)
-		usagemetrics.Error(usagemetrics.GCEServiceCreateFailure)
 		return
 	}
 
@@ -313,51 +430,67 @@ func (d *Daemon) startServices(ctx context.Context, cancel context.CancelFunc, g
 		return
 	}
 
+	discoveryWLMService := wlmService
+	if d.config.GetDiscoveryConfiguration().GetCompressWriteInsight() {
+		discoveryWLMService = wlmService.WithCompression()
+	}
+
 	// Start SAP System Discovery
 	ssdCtx := log.SetCtx(ctx, "context", "SAPSystemDiscovery")
 	systemDiscovery := &system.Discovery{
-		WlmService:    wlmService,
+		WlmService:    discoveryWLMService,
 		AppsDiscovery: sapdiscovery.SAPApplications,
 		CloudDiscoveryInterface: &clouddiscovery.CloudDiscovery{
-			GceService:   gceService,
-			HostResolver: net.LookupHost,
+			GceService:            gceService,
+			HostResolver:          net.LookupHost,
+			DiscoverSnapshots:     d.config.GetDiscoveryConfiguration().GetEnableSnapshotDiscovery().GetValue(),
+			MaxResources:          int(d.config.GetDiscoveryConfiguration().GetMaxResourcesPerPass()),
+			DisabledResourceKinds: d.config.GetDiscoveryConfiguration().GetDisabledResourceKinds(),
+			FilestoreLocations:    d.config.GetDiscoveryConfiguration().GetFilestoreLocations(),
 		},
 		HostDiscoveryInterface: &hostdiscovery.HostDiscovery{
-			Exists:  commandlineexecutor.CommandExists,
-			Execute: commandlineexecutor.ExecuteCommand,
+			Exists:         commandlineexecutor.CommandExists,
+			Execute:        commandlineexecutor.ExecuteCommand,
+			CommandTimeout: time.Duration(d.config.GetDiscoveryConfiguration().GetCommandTimeoutSec()) * time.Second,
 		},
 		SapDiscoveryInterface: &appsdiscovery.SapDiscovery{
-			Execute:    commandlineexecutor.ExecuteCommand,
-			FileSystem: filesystem.Helper{},
+			Execute:        commandlineexecutor.ExecuteCommand,
+			FileSystem:     filesystem.Helper{},
+			HostResolver:   net.LookupHost,
+			CommandTimeout: time.Duration(d.config.GetDiscoveryConfiguration().GetCommandTimeoutSec()) * time.Second,
 		},
 		OSStatReader: osStatReader,
 		FileReader:   configFileReader,
+		BackOffs:     cloudmonitoring.NewDefaultBackOffIntervals(),
 	}
-	if d.lp.CloudLoggingClient != nil {
+	ua := fmt.Sprintf("sap-core-eng/%s/%s.%s/systemdiscovery", configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange)
+	if discoveryMetricClient, err := monitoring.NewMetricClient(ctx, option.WithUserAgent(ua)); err != nil {
+		log.Logger.Warnw("Failed to create Cloud Monitoring metric client for system discovery metrics", "error", err)
+	} else {
+		systemDiscovery.TimeSeriesCreator = discoveryMetricClient
+	}
+	if bucket := d.config.GetDiscoveryConfiguration().GetArchiveBucket(); bucket != "" {
+		if archiver, ok := system.NewGCSArchiver(ctx, bucket, storage.NewClient); ok {
+			systemDiscovery.Archiver = archiver
+		} else {
+			log.Logger.Warnw("Failed to create the Cloud Storage client, discovered systems will not be archived", "bucket", bucket)
+		}
+	}
+	switch {
+	case !enabled[systemDiscoveryServiceName]:
+		// The collectors still need the SAP instances of the host.
+		systemDiscovery.DiscoverSAPInstancesOnce(ssdCtx, d.config)
+	case d.lp.CloudLoggingClient != nil:
 		systemDiscovery.CloudLogInterface = d.lp.CloudLoggingClient.Logger("google-cloud-sap-agent")
 		system.StartSAPSystemDiscovery(ssdCtx, d.config, systemDiscovery)
 		log.FlushCloudLog()
-	} else {
+	default:
 		system.StartSAPSystemDiscovery(ssdCtx, d.config, systemDiscovery)
 	}
 
-	gceBetaService := &gcebeta.GCEBeta{}
-	if strings.Contains(d.config.GetServiceEndpointOverride(), "beta") {
-		gceBetaService, err = gcebeta.NewGCEClient(ctx)
-		if err != nil {
-			log.Logger.Errorw("Failed to create GCE beta service", "error", err)
-			usagemetrics.Error(usagemetrics.GCEServiceCreateFailure)
-			return
-		}
-		log.Logger.Infow("Service endpoint override", "endpoint", d.config.GetServiceEndpointOverride())
-		gceService.OverrideComputeBasePath(d.config.GetServiceEndpointOverride())
-		if gceBetaService != nil {
-			gceBetaService.OverrideComputeBasePath(d.config.GetServiceEndpointOverride())
-		}
-	}
 	ppr := &instanceinfo.PhysicalPathReader{OS: goos}
 	instanceInfoReader := instanceinfo.New(ppr, gceService)
-	ua := fmt.Sprintf("sap-core-eng/%s/%s.%s/wlmevaluation", configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange)
+	ua = fmt.Sprintf("sap-core-eng/%s/%s.%s/wlmevaluation", configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange)
 	clientOptions := []option.ClientOption{option.WithUserAgent(ua)}
 	wlmMetricClient, err := monitoring.NewMetricClient(ctx, clientOptions...)
 	if err != nil {
@@ -390,11 +523,15 @@ func (d *Daemon) startServices(ctx context.Context, cancel context.CancelFunc, g
 		wlmparams.Remote = true
 		log.Logger.Info("Collecting Workload Manager metrics remotely, will not start any other services")
 		wmCtx := log.SetCtx(ctx, "context", "WorkloadManagerMetrics")
-		workloadmanager.StartMetricsCollection(wmCtx, wlmparams)
-		waitForShutdown(ctx, shutdownch, cancel, restarting)
+		if workloadmanager.StartMetricsCollection(wmCtx, wlmparams) {
+			d.health.watch(workloadManagerServiceName)
+		}
+		waitForShutdown(ctx, shutdownch, cancel, restarting, d.health.unhealthy)
 		return
 	}
 
+	pcmp := d.pacemakerParams(cd.GetWorkloadValidation())
+
 	// The functions being called below should be asynchronous.
 	// A typical StartXXX() will do the necessary initialisation synchronously
 	// and start its own goroutines for the long running tasks. The control
@@ -413,19 +550,208 @@ func (d *Daemon) startServices(ctx context.Context, cancel context.CancelFunc, g
 	}
 
 	// start the Host Metrics Collection
-	hmCtx := log.SetCtx(ctx, "context", "HostMetrics")
-	hmp := HostMetricsParams{d.config, instanceInfoReader, cmr, healthMonitor}
-	hmp.startCollection(hmCtx, restarting)
+	if enabled[hostMetricsServiceName] {
+		hmCtx := log.SetCtx(ctx, "context", "HostMetrics")
+		hmp := HostMetricsParams{d.config, instanceInfoReader, cmr, healthMonitor}
+		trackReadiness(readyStatus, &d.health, hostMetricsServiceName, func(ready func()) bool {
+			return hmp.startCollection(hmCtx, restarting, ready)
+		})
+	}
 
 	// Start the Workload Manager metrics collection
-	wmCtx := log.SetCtx(ctx, "context", "WorkloadManagerMetrics")
-	wmp := WorkloadManagerParams{wlmparams, instanceInfoReader, goos}
-	wmp.startCollection(wmCtx)
+	if enabled[workloadManagerServiceName] {
+		wmCtx := log.SetCtx(ctx, "context", "WorkloadManagerMetrics")
+		wmp := WorkloadManagerParams{wlmparams, instanceInfoReader, goos}
+		trackReadiness(readyStatus, &d.health, workloadManagerServiceName, func(ready func()) bool {
+			return wmp.startCollection(wmCtx, ready)
+		})
+	}
+
+	// Start Process Metrics Collection
+	if enabled[processMetricsServiceName] {
+		pmCtx := log.SetCtx(ctx, "context", "ProcessMetrics")
+		pmp := ProcessMetricsParams{d.config, goos, healthMonitor, gceService, gceBetaService, systemDiscovery, pcmp}
+		trackReadiness(readyStatus, &d.health, processMetricsServiceName, func(ready func()) bool {
+			return pmp.startCollection(pmCtx, ready)
+		})
+	}
+
+	// Start HANA Monitoring
+	if enabled[hanaMonitoringServiceName] {
+		hanaCtx := log.SetCtx(ctx, "context", "HANAMonitoring")
+		ua = fmt.Sprintf("sap-core-eng/%s/%s.%s/hanamonitoring", configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange)
+		clientOptions = []option.ClientOption{option.WithUserAgent(ua)}
+		hanaMonitoringMetricClient, err := monitoring.NewMetricClient(ctx, clientOptions...)
+		if err != nil {
+			log.Logger.Errorw("Failed to create Cloud Monitoring metric client for HANA Monitoring metrics", "error", err)
+			usagemetrics.Error(usagemetrics.MetricClientCreateFailure)
+			return
+		}
+		trackReadiness(readyStatus, &d.health, hanaMonitoringServiceName, func(ready func()) bool {
+			return hanamonitoring.Start(hanaCtx, hanamonitoring.Parameters{
+				Config:            d.config,
+				GCEService:        gceService,
+				BackOffs:          cloudmonitoring.NewDefaultBackOffIntervals(),
+				TimeSeriesCreator: hanaMonitoringMetricClient,
+				HRC:               sapdiscovery.HANAReplicationConfig,
+				DisabledQueries:   d.disabledQueryNames(),
+				ReadyFunc:         ready,
+			})
+		})
+	}
+
+	// Start the events engine
+	if enabled[eventsServiceName] {
+		evCtx := log.SetCtx(ctx, "context", "Events")
+		evp := d.eventsParams(evCtx, gceService)
+		trackReadiness(readyStatus, &d.health, eventsServiceName, func(ready func()) bool {
+			evp.ReadyFunc = ready
+			return events.Start(evCtx, evp)
+		})
+	}
+
+	waitForShutdown(ctx, shutdownch, cancel, restarting, d.health.unhealthy)
+}
+
+// collectOnce runs a single process metrics collection for -single-shot. No service is started:
+// the SAP instances are discovered once before the collection, and nothing is written to the WLM
+// API. Returns ExitFailure if the collection does not complete.
+func (d *Daemon) collectOnce(ctx context.Context, goos string) subcommands.ExitStatus {
+	if d.config.GetCloudProperties() == nil {
+		log.Logger.Error("Cloud properties are not set, cannot run the process metrics collection.")
+		usagemetrics.Error(usagemetrics.CloudPropertiesNotSet)
+		return subcommands.ExitFailure
+	}
+	log.Logger.Info("Running a single process metrics collection, will not start any other services")
+	d.applySharedSettings(ctx)
+
+	gceService, gceBetaService, err := d.gceServices(ctx)
+	if err != nil {
+		return subcommands.ExitFailure
+	}
+
+	var workloadConfig *wlmpb.WorkloadValidation
+	if d.config.GetCollectionConfiguration().GetCollectWorkloadValidationMetrics().GetValue() {
+		cdCtx := log.SetCtx(ctx, "context", "CollectionDefinition")
+		cd, err := collectiondefinition.Load(cdCtx, d.collectionDefinitionLoadOptions(goos))
+		if err != nil {
+			log.CtxLogger(cdCtx).Warnw("Failed to load collection definition, pacemaker metrics will use the defaults", "error", err)
+		}
+		workloadConfig = cd.GetWorkloadValidation()
+	}
+
+	pmCtx := log.SetCtx(ctx, "context", "ProcessMetrics")
+	systemDiscovery := &system.Discovery{
+		AppsDiscovery: sapdiscovery.SAPApplications,
+		OSStatReader:  osStatReader,
+	}
+	instances := systemDiscovery.DiscoverSAPInstancesOnce(pmCtx, d.config)
+	log.CtxLogger(pmCtx).Infow("Discovered SAP instances for the single collection", "instances", len(instances.GetInstances()))
+
+	pmp := ProcessMetricsParams{d.config, goos, &heartbeat.NullMonitor{}, gceService, gceBetaService, systemDiscovery, d.pacemakerParams(workloadConfig)}
+	if !pmp.collectOnce(pmCtx) {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+// eventsParams returns the parameters of the events engine. The cloud_logging event sources are
+// not read when the Cloud Logging admin client cannot be created, and the events metrics are not
+// sent when the metric client cannot be created.
+func (d *Daemon) eventsParams(ctx context.Context, gceService *gce.GCE) events.Parameters {
+	projectID := d.config.GetCloudProperties().GetProjectId()
+	var timeSeriesCreator cloudmonitoring.TimeSeriesCreator
+	ua := fmt.Sprintf("sap-core-eng/%s/%s.%s/events", configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange)
+	if metricClient, err := monitoring.NewMetricClient(ctx, option.WithUserAgent(ua)); err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to create Cloud Monitoring metric client for the events metrics", "error", err)
+	} else {
+		timeSeriesCreator = metricClient
+	}
+	var cloudLogging *events.CloudLoggingReader
+	if client, err := logadmin.NewClient(ctx, projectID, option.WithUserAgent(configuration.UserAgent())); err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to create the Cloud Logging admin client, cloud_logging event sources will not be read", "error", err)
+	} else {
+		cloudLogging = events.NewCloudLoggingReader(client)
+	}
+	targets := events.TargetClients{
+		HTTPAuth:     events.HTTPAuth{ProjectID: projectID, GetSecret: gceService.GetSecret},
+		CloudLogging: d.lp.CloudLoggingClient,
+	}
+	return events.Parameters{
+		Config:            d.config,
+		Read:              events.NewSourceReader(events.NewMetadataReader(), events.NewGuestLogReader(), cloudLogging),
+		NewTarget:         targets.NewTarget,
+		ReadFile:          os.ReadFile,
+		ReadDir:           os.ReadDir,
+		Stat:              os.Stat,
+		TimeSeriesCreator: timeSeriesCreator,
+		BackOffs:          cloudmonitoring.NewDefaultBackOffIntervals(),
+	}
+}
 
-	// Declaring pacemaker Params
-	pcmp := pacemaker.Parameters{
+// applySharedSettings applies the configuration shared by all services.
+func (d *Daemon) applySharedSettings(ctx context.Context) {
+	// The rate limit is shared by all services sending metrics to cloud monitoring.
+	cloudmonitoring.SetRequestsPerMinute(d.config.GetCollectionConfiguration().GetMonitoringRequestsPerMinute())
+	// The instance metadata labels are added to the metrics of all services.
+	metadatalabels.Start(log.SetCtx(ctx, "context", "MetadataLabels"), d.config.GetCollectionConfiguration().GetMetricLabelMetadataAttributes(), metadataserver.FetchInstanceAttribute)
+	// The HANA replication status is read by discovery, process metrics and HANA Monitoring.
+	settingsPath := d.config.GetCollectionConfiguration().GetHanaSettingsPath()
+	statusCommand := d.config.GetCollectionConfiguration().GetHanaReplicationStatusCommand()
+	if settingsPath != "" || statusCommand != "" {
+		log.Logger.Infow("Overriding the HANA replication status command", "hanaSettingsPath", settingsPath, "hanaReplicationStatusCommand", statusCommand)
+	}
+	sapdiscovery.SetReplicationStatusCommand(settingsPath, statusCommand)
+}
+
+// gceServices creates the GCE service, and the GCE beta service when the service endpoint
+// override points to the beta API. Errors are logged.
+func (d *Daemon) gceServices(ctx context.Context) (*gce.GCE, *gcebeta.GCEBeta, error) {
+	gceService, err := gce.NewGCEClient(ctx)
+	if err != nil {
+		log.Logger.Errorw("Failed to create GCE service", "error", err)
+		usagemetrics.Error(usagemetrics.GCEServiceCreateFailure)
+		return nil, nil, err
+	}
+	gceBetaService := &gcebeta.GCEBeta{}
+	if strings.Contains(d.config.GetServiceEndpointOverride(), "beta") {
+		gceBetaService, err = gcebeta.NewGCEClient(ctx)
+		if err != nil {
+			log.Logger.Errorw("Failed to create GCE beta service", "error", err)
+			usagemetrics.Error(usagemetrics.GCEServiceCreateFailure)
+			return nil, nil, err
+		}
+		log.Logger.Infow("Service endpoint override", "endpoint", d.config.GetServiceEndpointOverride())
+		gceService.OverrideComputeBasePath(d.config.GetServiceEndpointOverride())
+		if gceBetaService != nil {
+			gceBetaService.OverrideComputeBasePath(d.config.GetServiceEndpointOverride())
+		}
+	}
+	return gceService, gceBetaService, nil
+}
+
+// collectionDefinitionLoadOptions returns the options to load the collection definition.
+func (d *Daemon) collectionDefinitionLoadOptions(goos string) collectiondefinition.LoadOptions {
+	return collectiondefinition.LoadOptions{
+		CollectionConfig: d.config.GetCollectionConfiguration(),
+		ReadFile:         os.ReadFile,
+		OSType:           goos,
+		Version:          configuration.AgentVersion,
+		FetchOptions: collectiondefinition.FetchOptions{
+			OSType:     goos,
+			Env:        d.config.GetCollectionConfiguration().GetWorkloadValidationCollectionDefinition().GetConfigTargetEnvironment(),
+			Client:     storage.NewClient,
+			CreateTemp: os.CreateTemp,
+			Execute:    execute,
+		},
+	}
+}
+
+// pacemakerParams returns the pacemaker parameters of process metrics.
+func (d *Daemon) pacemakerParams(workloadConfig *wlmpb.WorkloadValidation) pacemaker.Parameters {
+	return pacemaker.Parameters{
 		Config:                d.config,
-		WorkloadConfig:        cd.GetWorkloadValidation(),
+		WorkloadConfig:        workloadConfig,
 		ConfigFileReader:      pacemaker.ConfigFileReader(configFileReader),
 		DefaultTokenGetter:    pacemaker.DefaultTokenGetter(defaultTokenGetter),
 		JSONCredentialsGetter: pacemaker.JSONCredentialsGetter(jsonCredentialsGetter),
@@ -433,31 +759,68 @@ func (d *Daemon) startServices(ctx context.Context, cancel context.CancelFunc, g
 		Exists:                exists,
 		OSReleaseFilePath:     workloadmanager.OSReleaseFilePath,
 	}
+}
 
-	// Start Process Metrics Collection
-	pmCtx := log.SetCtx(ctx, "context", "ProcessMetrics")
-	pmp := ProcessMetricsParams{d.config, goos, healthMonitor, gceService, gceBetaService, systemDiscovery, pcmp}
-	pmp.startCollection(pmCtx)
+// serviceSwitches returns the configuration option turning each service on or off, and whether
+// the service is started. The master switches are applied to the options of their service by
+// configuration.ApplyDefaults. Process metrics and discovery are started unless their master switch
+// is off, as the reliability metrics and the discovered SAP instances do not depend on the options
+// of the service.
+func serviceSwitches(config *cpb.Configuration) []serviceSwitch {
+	return []serviceSwitch{
+		{hostMetricsServiceName, switchOption(config.GetCollectHostMetrics(), "collect_host_metrics", "provide_sap_host_agent_metrics"), config.GetProvideSapHostAgentMetrics().GetValue()},
+		{processMetricsServiceName, switchOption(config.GetCollectProcessMetrics(), "collect_process_metrics", "collection_configuration.collect_process_metrics"), config.GetCollectProcessMetrics() == nil || config.GetCollectProcessMetrics().GetValue()},
+		{workloadManagerServiceName, "collection_configuration.collect_workload_validation_metrics", config.GetCollectionConfiguration().GetCollectWorkloadValidationMetrics().GetValue()},
+		{hanaMonitoringServiceName, switchOption(config.GetCollectHanaMonitoring(), "collect_hana_monitoring", "hana_monitoring_configuration.enabled"), config.GetHanaMonitoringConfiguration().GetEnabled()},
+		{systemDiscoveryServiceName, switchOption(config.GetEnableDiscovery(), "enable_discovery", "discovery_configuration.enable_discovery"), config.GetEnableDiscovery() == nil || config.GetEnableDiscovery().GetValue()},
+		{eventsServiceName, "enable_events", config.GetEnableEvents().GetValue()},
+	}
+}
 
-	// Start HANA Monitoring
-	hanaCtx := log.SetCtx(ctx, "context", "HANAMonitoring")
-	ua = fmt.Sprintf("sap-core-eng/%s/%s.%s/hanamonitoring", configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange)
-	clientOptions = []option.ClientOption{option.WithUserAgent(ua)}
-	hanaMonitoringMetricClient, err := monitoring.NewMetricClient(ctx, clientOptions...)
-	if err != nil {
-		log.Logger.Errorw("Failed to create Cloud Monitoring metric client for HANA Monitoring metrics", "error", err)
-		usagemetrics.Error(usagemetrics.MetricClientCreateFailure)
+// switchOption returns the name of the master switch when it is set, and the name of the option of
+// the service otherwise.
+func switchOption(master *wpb.BoolValue, name, option string) string {
+	if master != nil {
+		return name
+	}
+	return option
+}
+
+// logServiceSwitches logs whether each service is enabled, so that a service which is not
+// collecting can be traced back to its configuration option. Returns the enabled state by service.
+func logServiceSwitches(config *cpb.Configuration) map[string]bool {
+	enabled := make(map[string]bool)
+	for _, s := range serviceSwitches(config) {
+		log.Logger.Infow("Service configuration", "service", s.service, "enabled", s.enabled, "option", s.option)
+		enabled[s.service] = s.enabled
+	}
+	return enabled
+}
+
+// trackReadiness registers the collector with the health check status before calling start, which
+// reports whether the collector was started. Collectors which are not started are not waited for,
+// and their heartbeats are not watched.
+func trackReadiness(status *healthcheck.Status, health *serviceHealth, name string, start func(ready func()) bool) {
+	if status == nil {
+		if start(nil) {
+			health.watch(name)
+		}
 		return
 	}
-	hanamonitoring.Start(hanaCtx, hanamonitoring.Parameters{
-		Config:            d.config,
-		GCEService:        gceService,
-		BackOffs:          cloudmonitoring.NewDefaultBackOffIntervals(),
-		TimeSeriesCreator: hanaMonitoringMetricClient,
-		HRC:               sapdiscovery.HANAReplicationConfig,
-	})
+	status.Register(name)
+	if !start(status.ReadyFunc(name)) {
+		status.Deregister(name)
+		return
+	}
+	health.watch(name)
+}
 
-	waitForShutdown(ctx, shutdownch, cancel, restarting)
+// disabledQueryNames parses the -disable-query flag into a list of query names.
+func (d *Daemon) disabledQueryNames() []string {
+	if d.disabledQueries == "" {
+		return nil
+	}
+	return strings.Split(d.disabledQueries, ",")
 }
 
 func (d *Daemon) startGuestActions(cancel context.CancelFunc) {
@@ -498,6 +861,70 @@ func startAgentMetricsService(ctx context.Context, c *cpb.Configuration) (*heart
 	return healthMonitor, nil
 }
 
+// startWatchdogHealthMonitor returns a running health monitor for the systemd watchdog when the
+// agent metrics are not collected. The heartbeat defaults of the agent metrics are used when the
+// configuration does not set them. Returns a NullMonitor if the monitor cannot be created.
+func startWatchdogHealthMonitor(ctx context.Context, c *cpb.Configuration) agentmetrics.HealthMonitor {
+	cc := &cpb.CollectionConfiguration{HeartbeatFrequency: 60, MissedHeartbeatThreshold: 10}
+	if f := c.GetCollectionConfiguration().GetHeartbeatFrequency(); f > 0 {
+		cc.HeartbeatFrequency = f
+	}
+	if t := c.GetCollectionConfiguration().GetMissedHeartbeatThreshold(); t > 0 {
+		cc.MissedHeartbeatThreshold = t
+	}
+	healthMonitor, err := heartbeat.NewMonitor(heartbeat.Parameters{Config: &cpb.Configuration{CollectionConfiguration: cc}})
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to create the heartbeat monitor, the systemd watchdog will not observe the services health", "error", err)
+		return &heartbeat.NullMonitor{}
+	}
+	healthMonitor.Run(ctx)
+	return healthMonitor
+}
+
+// serviceHealth holds the health monitor of the running services, it is replaced when the
+// services are restarted. Only the heartbeats of the services which were started are watched,
+// the services which are registered but not started never beat.
+type serviceHealth struct {
+	mu      sync.Mutex
+	monitor agentmetrics.HealthMonitor
+	started map[string]bool
+}
+
+// set replaces the health monitor and forgets the services started with the previous one.
+func (h *serviceHealth) set(m agentmetrics.HealthMonitor) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.monitor = m
+	h.started = make(map[string]bool)
+}
+
+// watch marks the service as started.
+func (h *serviceHealth) watch(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.started == nil {
+		h.started = make(map[string]bool)
+	}
+	h.started[name] = true
+}
+
+// unhealthy returns the sorted names of the started services which missed their heartbeats.
+func (h *serviceHealth) unhealthy() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.monitor == nil {
+		return nil
+	}
+	var names []string
+	for name, healthy := range h.monitor.GetStatuses() {
+		if !healthy && h.started[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // ProcessMetricsParams has arguments for startProcessMetricsCollection.
 type ProcessMetricsParams struct {
 	config         *cpb.Configuration
@@ -510,13 +937,14 @@ type ProcessMetricsParams struct {
 }
 
 // startCollection for ProcessMetricsParams initiates collection of ProcessMetrics.
-func (pmp ProcessMetricsParams) startCollection(ctx context.Context) {
+// Returns true if the collection is started.
+func (pmp ProcessMetricsParams) startCollection(ctx context.Context, ready func()) bool {
 	pmHeartbeatSpec, err := pmp.healthMonitor.Register(processMetricsServiceName)
 	if err != nil {
 		log.Logger.Error("Failed to register process metrics service", log.Error(err))
 		usagemetrics.Error(usagemetrics.HeartbeatMonitorRegistrationFailure)
 		log.Logger.Error("Process metrics collection could not be started")
-		return
+		return false
 	}
 	if success := processmetrics.Start(ctx, processmetrics.Parameters{
 		Config:         pmp.config,
@@ -529,9 +957,32 @@ func (pmp ProcessMetricsParams) startCollection(ctx context.Context) {
 		Discovery:      pmp.discovery,
 		PCMParams:      pmp.pcmparams,
 		OSStatReader:   osStatReader,
-	}); success != true {
+		ReadyFunc:      ready,
+	}); !success {
 		log.Logger.Info("Process metrics collection not started")
+		return false
+	}
+	return true
+}
+
+// collectOnce for ProcessMetricsParams runs a single collection of ProcessMetrics.
+// Returns true if the metrics are collected and sent.
+func (pmp ProcessMetricsParams) collectOnce(ctx context.Context) bool {
+	if success := processmetrics.CollectOnce(ctx, processmetrics.Parameters{
+		Config:         pmp.config,
+		OSType:         pmp.goos,
+		MetricClient:   processmetrics.NewMetricClient,
+		BackOffs:       cloudmonitoring.NewDefaultBackOffIntervals(),
+		GCEService:     pmp.gceService,
+		GCEBetaService: pmp.gceBetaService,
+		Discovery:      pmp.discovery,
+		PCMParams:      pmp.pcmparams,
+		OSStatReader:   osStatReader,
+	}); !success {
+		log.CtxLogger(ctx).Error("Process metrics single collection did not complete")
+		return false
 	}
+	return true
 }
 
 // HostMetricsParams has arguments for startHostMetricsCollection.
@@ -543,21 +994,23 @@ type HostMetricsParams struct {
 }
 
 // startCollection for HostMetricsParams initiates collection of HostMetrics.
-func (hmp HostMetricsParams) startCollection(ctx context.Context, restarting bool) {
+// Returns true if the collection is started.
+func (hmp HostMetricsParams) startCollection(ctx context.Context, restarting bool, ready func()) bool {
 	hmHeartbeatSpec, err := hmp.healthMonitor.Register(hostMetricsServiceName)
 	if err != nil {
 		log.Logger.Error("Failed to register host metrics service", log.Error(err))
 		usagemetrics.Error(usagemetrics.HeartbeatMonitorRegistrationFailure)
 		log.Logger.Error("Failed to start host metrics collection")
-		return
+		return false
 	}
 	hmCtx, hmCancel := context.WithCancel(ctx)
-	hostmetrics.StartSAPHostAgentProvider(hmCtx, hmCancel, restarting, hostmetrics.Parameters{
+	return hostmetrics.StartSAPHostAgentProvider(hmCtx, hmCancel, restarting, hostmetrics.Parameters{
 		Config:             hmp.config,
 		InstanceInfoReader: *hmp.instanceInfoReader,
 		CloudMetricReader:  *hmp.cmr,
 		AgentTime:          *agenttime.New(agenttime.Clock{}),
 		HeartbeatSpec:      hmHeartbeatSpec,
+		ReadyFunc:          ready,
 	})
 }
 
@@ -569,7 +1022,8 @@ type WorkloadManagerParams struct {
 }
 
 // startCollection for WorkLoadManagerParams initiates collection of WorkloadManagerMetrics.
-func (wmp WorkloadManagerParams) startCollection(ctx context.Context) {
+// Returns true if the collection is started.
+func (wmp WorkloadManagerParams) startCollection(ctx context.Context, ready func()) bool {
 	wmp.wlmparams.OSType = wmp.goos
 	wmp.wlmparams.ConfigFileReader = configFileReader
 	wmp.wlmparams.InstanceInfoReader = *wmp.instanceInfoReader
@@ -578,23 +1032,57 @@ func (wmp WorkloadManagerParams) startCollection(ctx context.Context) {
 	wmp.wlmparams.InterfaceAddrsGetter = net.InterfaceAddrs
 	wmp.wlmparams.DefaultTokenGetter = defaultTokenGetter
 	wmp.wlmparams.JSONCredentialsGetter = jsonCredentialsGetter
+	wmp.wlmparams.ReadyFunc = ready
 	wmp.wlmparams.Init(ctx)
-	workloadmanager.StartMetricsCollection(ctx, wmp.wlmparams)
+	return workloadmanager.StartMetricsCollection(ctx, wmp.wlmparams)
 }
 
 // waitForShutdown observes a channel for a shutdown signal, then proceeds to shut down the Agent.
-func waitForShutdown(ctx context.Context, ch <-chan os.Signal, cancel context.CancelFunc, restarting bool) {
+// The systemd watchdog is pinged while unhealthy reports no service, so that systemd restarts
+// the agent when a collector stalls.
+func waitForShutdown(ctx context.Context, ch <-chan os.Signal, cancel context.CancelFunc, restarting bool, unhealthy func() []string) {
 	// If we're restarting, we wait for context cancellation instead of a shutdown signal.
 	if restarting {
+		// The services have started again, the first call keeps pinging the watchdog.
+		if _, err := sdnotify.Notify(sdnotify.Ready); err != nil {
+			log.Logger.Warnw("Failed to notify systemd that the agent is ready", "error", err)
+		}
 		<-ctx.Done()
 		log.Logger.Info("Skipping shutdown signal handling during restart")
 		return
 	}
 
+	// Services have started, let systemd know when running with Type=notify.
+	if _, err := sdnotify.Notify(sdnotify.Ready); err != nil {
+		log.Logger.Warnw("Failed to notify systemd that the agent is ready", "error", err)
+	}
+	// When WatchdogSec is set systemd restarts the agent unless it is pinged at least once per interval.
+	var watchdog <-chan time.Time
+	if interval, ok := sdnotify.WatchdogInterval(); ok {
+		log.Logger.Infow("Sending systemd watchdog notifications", "interval", interval/2)
+		ticker := time.NewTicker(interval / 2)
+		defer ticker.Stop()
+		watchdog = ticker.C
+	}
+
 	// If the shutdown signal is observed, we will shut down by executing the following lines.
-	<-ch
+	for shutdown := false; !shutdown; {
+		select {
+		case <-ch:
+			shutdown = true
+		case <-watchdog:
+			if stalled := unhealthy(); len(stalled) > 0 {
+				log.Logger.Warnw("Skipping systemd watchdog notification, services missed their heartbeats", "services", stalled)
+				continue
+			}
+			if _, err := sdnotify.Notify(sdnotify.Watchdog); err != nil {
+				log.Logger.Warnw("Failed to send systemd watchdog notification", "error", err)
+			}
+		}
+	}
 
 	log.Logger.Info("Shutdown signal observed, the agent will begin shutting down")
+	sdnotify.Notify(sdnotify.Stopping)
 	cancel()
 	usagemetrics.Stopped()
 	time.Sleep(3 * time.Second)
@@ -22,14 +22,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	backoff "github.com/cenkalti/backoff/v4"
 	"golang.org/x/exp/slices"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/sapagent/internal/system/discoverycommand"
 	"github.com/GoogleCloudPlatform/sapagent/internal/utils/filesystem"
 	sappb "github.com/GoogleCloudPlatform/sapagent/protos/sapapp"
 	spb "github.com/GoogleCloudPlatform/sapagent/protos/system"
@@ -46,6 +50,16 @@ var (
 	netweaverKernelRegex      = regexp.MustCompile(`kernel release\s+([0-9]+)`)
 	netweaverPatchNumberRegex = regexp.MustCompile(`patch number\s+([0-9]+)`)
 	sapDbHostRegex            = regexp.MustCompile(`SAPDBHOST\s+=\s+(.*)`)
+	// profileDBSIDRegex matches the database SID parameters in the grep output of the profiles,
+	// optionally prefixed by the profile file name and the parameter path, e.g. rsdb/dbid.
+	// Commented out parameters are not matched.
+	profileDBSIDRegex = regexp.MustCompile(`(?m)^(?:[^#\n:]*:)?[ \t]*(?:[\w/]*/)?(dbid|dbms/name|j2ee/dbname|dbs/hdb/dbname)\s*=\s*([a-zA-Z][a-zA-Z0-9]{2})`)
+	// abapProfileSIDKeys are the profile parameters holding the database SID of ABAP systems, in
+	// priority order.
+	abapProfileSIDKeys = []string{profileDBIDNameKey, profileDBMSNameKey, profileJ2EEDBNameKey, profileDBSHDBNameKey}
+	// javaProfileSIDKeys are the profile parameters holding the database SID of Java systems, in
+	// priority order.
+	javaProfileSIDKeys = []string{profileJ2EEDBNameKey, profileDBSHDBNameKey, profileDBIDNameKey, profileDBMSNameKey}
 )
 
 const (
@@ -66,6 +80,24 @@ type fileReader func(filename string) ([]byte, error)
 type SapDiscovery struct {
 	Execute    commandlineexecutor.Execute
 	FileSystem filesystem.FileSystem
+	// CommandTimeout bounds each command, discoverycommand.DefaultTimeout when it is not set.
+	CommandTimeout time.Duration
+	// HostResolver resolves the DB hosts read from hdbuserstore, no resolution is done when nil.
+	HostResolver func(string) ([]string, error)
+}
+
+// hostResolveRetryPolicy bounds the attempts to resolve a DB host so that a transient DNS
+// failure does not drop the app to DB connection for the whole discovery pass.
+var hostResolveRetryPolicy = func() backoff.BackOff {
+	exp := backoff.NewExponentialBackOff()
+	exp.InitialInterval = 500 * time.Millisecond
+	exp.MaxInterval = 2 * time.Second
+	return backoff.WithMaxRetries(exp, 2) // 2 retries (3 total attempts)
+}
+
+// execute runs the command bounded by the command timeout, see discoverycommand.Execute.
+func (d *SapDiscovery) execute(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+	return discoverycommand.Execute(ctx, d.Execute, params, d.CommandTimeout)
 }
 
 // SapSystemDetails contains information about an ASP system running on the current host.
@@ -457,7 +489,7 @@ func (d *SapDiscovery) discoverNetweaverHosts(ctx context.Context, app *sappb.SA
 		Executable: "sudo",
 		Args:       []string{"-i", "-u", sidAdm, "sapcontrol", "-nr", app.InstanceNumber, "-function", "GetSystemInstanceList"},
 	}
-	res := d.Execute(ctx, cmd)
+	res := d.execute(ctx, cmd)
 	if res.Error != nil {
 		return nil, nil, nil
 	}
@@ -559,7 +591,7 @@ func (d *SapDiscovery) discoverNetweaverHA(ctx context.Context, app *sappb.SAPIn
 		Executable: "sudo",
 		Args:       []string{"-i", "-u", sidAdm, "sapcontrol", "-nr", app.InstanceNumber, "-function", "HAGetFailoverConfig"},
 	}
-	res := d.Execute(ctx, params)
+	res := d.execute(ctx, params)
 	if res.Error != nil {
 		return false, nil
 	}
@@ -588,7 +620,7 @@ func (d *SapDiscovery) discoverNetweaverHA(ctx context.Context, app *sappb.SAPIn
 			Executable: "pcs",
 			Args:       []string{"config", "show"},
 		}
-		res = d.Execute(ctx, params)
+		res = d.execute(ctx, params)
 
 		lines := strings.Split(res.StdOut, "\n")
 		for i, line := range lines {
@@ -606,7 +638,7 @@ func (d *SapDiscovery) discoverAppToDBConnection(ctx context.Context, sid string
 	sidLower := strings.ToLower(sid)
 	sidAdm := fmt.Sprintf("%sadm", sidLower)
 	if abap {
-		result := d.Execute(ctx, commandlineexecutor.Params{
+		result := d.execute(ctx, commandlineexecutor.Params{
 			Executable: "sudo",
 			Args:       []string{"-i", "-u", sidAdm, "hdbuserstore", "list", "DEFAULT"},
 		})
@@ -620,10 +652,11 @@ func (d *SapDiscovery) discoverAppToDBConnection(ctx context.Context, sid string
 			log.CtxLogger(ctx).Infow("Unable to find DB hostname and port in hdbuserstore output", "sid", sid)
 			return nil, errors.New("Unable to find DB hostname and port in hdbuserstore output")
 		}
+		dbHosts = d.resolveDBHosts(ctx, sid, dbHosts, hostResolveRetryPolicy())
 	} else {
 		sidUpper := strings.ToUpper(sid)
 		profilePath := fmt.Sprintf("/usr/sap/%s/SYS/profile/*", sidUpper)
-		result := d.Execute(ctx, commandlineexecutor.Params{
+		result := d.execute(ctx, commandlineexecutor.Params{
 			Executable:  "sh",
 			ArgsToSplit: `-c 'grep "SAPDBHOST" ` + profilePath + `'`,
 		})
@@ -656,7 +689,7 @@ func (d *SapDiscovery) discoverNetweaverJava(ctx context.Context, app *sappb.SAP
 		Executable: "sudo",
 		Args:       []string{"-i", "-u", sidAdm, cmdPath, "-task", "get.versions.of.deployed.units"},
 	}
-	result := d.Execute(ctx, params)
+	result := d.execute(ctx, params)
 	log.CtxLogger(ctx).Debugw("batchconfig.csh result", "result", result)
 	if result.Error != nil {
 		return false, nil, result.Error
@@ -747,7 +780,7 @@ func (d *SapDiscovery) discoverNetweaverABAP(ctx context.Context, app *sappb.SAP
 		Executable: "sudo",
 		Args:       []string{"-i", "-u", sidAdm, "R3trans", "-d", "-w", r3transTmpFolder + "tmp.log"},
 	}
-	result := d.Execute(ctx, params)
+	result := d.execute(ctx, params)
 	log.CtxLogger(ctx).Debugw("R3trans result", "result", result)
 	if result.Error != nil {
 		return false, nil, result.Error
@@ -779,14 +812,14 @@ func (d *SapDiscovery) discoverNetweaverABAP(ctx context.Context, app *sappb.SAP
 
 	// Run R3trans with the control file
 	params.Args = []string{"-i", "-u", sidAdm, "R3trans", "-w", r3transOutputPath, tmpControlFilePath}
-	if result = d.Execute(ctx, params); result.Error != nil {
+	if result = d.execute(ctx, params); result.Error != nil {
 		log.CtxLogger(ctx).Infow("Error running R3trans with control file", "error", result.Error)
 		return false, nil, result.Error
 	}
 
 	// Export the data
 	params.Args = []string{"-i", "-u", sidAdm, "R3trans", "-w", r3transOutputPath, "-v", "-l", r3transTmpFolder + "export_products.dat"}
-	if result = d.Execute(ctx, params); result.Error != nil {
+	if result = d.execute(ctx, params); result.Error != nil {
 		log.CtxLogger(ctx).Infow("Error exporting data", "error", result.Error)
 		return false, nil, result.Error
 	}
@@ -896,6 +929,51 @@ func parseR3transOutput(ctx context.Context, s string) (wlProps *spb.SapDiscover
 	}
 }
 
+// resolveDBHosts resolves the DB host names, retrying failures as per the policy. A name which
+// still fails to resolve is replaced by the IP addresses among the hosts, if any, so that the
+// app to DB connection is not lost.
+func (d *SapDiscovery) resolveDBHosts(ctx context.Context, sid string, dbHosts []string, bo backoff.BackOff) []string {
+	if d.HostResolver == nil {
+		return dbHosts
+	}
+	var ips []string
+	for _, h := range dbHosts {
+		if net.ParseIP(h) != nil {
+			ips = append(ips, h)
+		}
+	}
+
+	var resolved []string
+	for _, h := range dbHosts {
+		if net.ParseIP(h) != nil {
+			resolved = append(resolved, h)
+			continue
+		}
+		bo.Reset()
+		attempt := 1
+		err := backoff.Retry(func() error {
+			_, err := d.HostResolver(h)
+			if err != nil {
+				log.CtxLogger(ctx).Debugw("Failed to resolve DB host", "sid", sid, "host", h, "attempt", attempt, "error", err)
+				attempt++
+			}
+			return err
+		}, backoff.WithContext(bo, ctx))
+		switch {
+		case err == nil:
+			log.CtxLogger(ctx).Debugw("Resolved DB host", "sid", sid, "host", h, "attempts", attempt)
+			resolved = append(resolved, h)
+		case len(ips) > 0:
+			log.CtxLogger(ctx).Infow("Could not resolve DB host, using the IP addresses from hdbuserstore", "sid", sid, "host", h, "ips", ips, "error", err)
+			resolved = append(resolved, ips...)
+		default:
+			log.CtxLogger(ctx).Infow("Could not resolve DB host", "sid", sid, "host", h, "error", err)
+			resolved = append(resolved, h)
+		}
+	}
+	return removeDuplicates(resolved)
+}
+
 func parseDBHosts(s string) (dbHosts []string) {
 	lines := strings.Split(s, "\n")
 	for _, l := range lines {
@@ -940,7 +1018,7 @@ func (d *SapDiscovery) discoverDatabaseSID(ctx context.Context, appSID string, a
 }
 
 func (d *SapDiscovery) discoverDatabaseSIDUserStore(ctx context.Context, sidUpper string, sidAdm string) (string, error) {
-	result := d.Execute(ctx, commandlineexecutor.Params{
+	result := d.execute(ctx, commandlineexecutor.Params{
 		Executable: "sudo",
 		Args:       []string{"-i", "-u", sidAdm, "hdbuserstore", "list"},
 	})
@@ -965,7 +1043,7 @@ func (d *SapDiscovery) discoverDatabaseSIDUserStore(ctx context.Context, sidUppe
 func (d *SapDiscovery) discoverDatabaseSIDProfiles(ctx context.Context, sidUpper string, sidAdm string, abap bool) (string, error) {
 	// No DB SID in userstore, check profiles
 	profilePath := fmt.Sprintf("/usr/sap/%s/SYS/profile/*", sidUpper)
-	result := d.Execute(ctx, commandlineexecutor.Params{
+	result := d.execute(ctx, commandlineexecutor.Params{
 		Executable:  "sh",
 		ArgsToSplit: `-c 'grep "dbid\|dbms/name\|j2ee/dbname\|dbs/hdb/dbname" ` + profilePath + `'`,
 	})
@@ -977,81 +1055,27 @@ func (d *SapDiscovery) discoverDatabaseSIDProfiles(ctx context.Context, sidUpper
 		return "", result.Error
 	}
 
-	re, err := regexp.Compile(`(dbid|dbms\/name|j2ee\/dbname|dbs\/hdb\/dbname)\s*=\s*([a-zA-Z][a-zA-Z0-9]{2})`)
-	if err != nil {
-		log.CtxLogger(ctx).Infow("Error compiling regex", "error", err)
-		return "", err
-	}
-	matches := re.FindAllStringSubmatch(result.StdOut, -1)
+	matches := profileDBSIDRegex.FindAllStringSubmatch(result.StdOut, -1)
 	log.CtxLogger(ctx).Debugw("Profile grep matches", "sid", sidUpper, "matches", matches)
-	var sidKey, sidValue string
-	if len(matches) == 1 {
-		log.CtxLogger(ctx).Debugw("Single match", "sid", sidUpper, "match", matches[0])
-		match := matches[0]
-		if len(match) > 2 {
-			sidValue = match[2]
-			log.CtxLogger(ctx).Debugw("Match", "sid", sidValue, "match", match)
-		}
-	} else if len(matches) > 1 {
-		log.CtxLogger(ctx).Debugw("Multiple matches", "sid", sidUpper, "matches", matches)
-		// Multiple matches, prioritize based on abap or Java
-		// ABAP order: dbid, dbms/name, j2ee/dbname, dbs/hdb/dbname
-		// Java order: j2ee/dbname, dbs/hdb/dbname, dbid, dbms/name
-	matchLoop:
-		for _, match := range matches {
-			log.CtxLogger(ctx).Debugw("Match", "sid", sidUpper, "match", match)
-			if len(match) > 2 {
-				if abap {
-					switch match[1] {
-					case profileDBIDNameKey:
-						sidValue = match[2]
-						break matchLoop
-					case profileDBMSNameKey:
-						if sidValue == "" || sidKey == "j2ee/dbname" || sidKey == "dbs/hdb/dbname" {
-							sidKey = match[1]
-							sidValue = match[2]
-						}
-					case profileJ2EEDBNameKey:
-						if sidValue == "" || sidKey == "dbs/hdb/dbname" {
-							sidKey = match[1]
-							sidValue = match[2]
-						}
-					case profileDBSHDBNameKey:
-						if sidValue == "" {
-							sidKey = match[1]
-							sidValue = match[2]
-						}
-					}
-				} else {
-					switch match[1] {
-					case profileJ2EEDBNameKey:
-						sidValue = match[2]
-						break matchLoop
-					case profileDBSHDBNameKey:
-						if sidValue == "" || sidKey == "dbid" || sidKey == "dbms/name" {
-							sidKey = match[1]
-							sidValue = match[2]
-						}
-					case profileDBIDNameKey:
-						if sidValue == "" || sidKey == "dbms/name" {
-							sidKey = match[1]
-							sidValue = match[2]
-						}
-					case profileDBMSNameKey:
-						if sidValue == "" {
-							sidValue = match[2]
-							sidKey = match[1]
-						}
-					}
-				}
-			}
+	// The first value of each parameter is kept, the parameter with the highest priority for the
+	// system type provides the SID.
+	values := map[string]string{}
+	for _, match := range matches {
+		if _, ok := values[match[1]]; !ok {
+			values[match[1]] = match[2]
 		}
 	}
-
-	if sidValue == "" {
-		return "", errors.New("No database SID found in profiles")
+	keys := javaProfileSIDKeys
+	if abap {
+		keys = abapProfileSIDKeys
+	}
+	for _, key := range keys {
+		if sidValue, ok := values[key]; ok {
+			log.CtxLogger(ctx).Infow("Discovered database SID from profile", "sid", sidUpper, "parameter", key, "dbSID", sidValue)
+			return sidValue, nil
+		}
 	}
-	return sidValue, nil
+	return "", errors.New("No database SID found in profiles")
 }
 
 func (d *SapDiscovery) discoverDBNodes(ctx context.Context, sid, instanceNumber string) ([]string, error) {
@@ -1064,7 +1088,7 @@ func (d *SapDiscovery) discoverDBNodes(ctx context.Context, sid, instanceNumber
 		Executable: "sudo",
 		Args:       []string{"-i", "-u", sidAdm, "sapcontrol", "-nr", instanceNumber, "-function", "GetSystemInstanceList"},
 	}
-	result := d.Execute(ctx, cmd)
+	result := d.execute(ctx, cmd)
 	if result.Error != nil || result.ExitCode != 0 {
 		log.CtxLogger(ctx).Infow("Error running GetSystemInstanceList", "sid", sid, "error", result.Error, "stdOut", result.StdOut, "stdErr", result.StdErr, "exitcode", result.ExitCode)
 		return nil, result.Error
@@ -1102,7 +1126,7 @@ func (d *SapDiscovery) discoverASCS(ctx context.Context, sid string) (string, er
 		Executable: "grep",
 		Args:       []string{"rdisp/mshost", profilePath},
 	}
-	res := d.Execute(ctx, p)
+	res := d.execute(ctx, p)
 	if res.Error != nil {
 		log.CtxLogger(ctx).Infow("Error executing grep", "error", res.Error, "stdOut", res.StdOut, "stdErr", res.StdErr, "exitcode", res.ExitCode)
 		return "", res.Error
@@ -1127,7 +1151,7 @@ func (d *SapDiscovery) discoverAppNFS(ctx context.Context, sid string) (string,
 		Executable: "df",
 		Args:       []string{"-h"},
 	}
-	res := d.Execute(ctx, p)
+	res := d.execute(ctx, p)
 	if res.Error != nil {
 		log.CtxLogger(ctx).Infow("Error executing df -h", "error", res.Error, "stdOut", res.StdOut, "stdErr", res.StdErr, "exitcode", res.ExitCode)
 		return "", res.Error
@@ -1156,7 +1180,7 @@ func (d *SapDiscovery) discoverNetweaverKernelVersion(ctx context.Context, sid s
 		Executable: "sudo",
 		Args:       []string{"-i", "-u", sidAdm, "disp+work"},
 	}
-	res := d.Execute(ctx, p)
+	res := d.execute(ctx, p)
 	if res.Error != nil {
 		log.CtxLogger(ctx).Infow("Error executing disp+work command", "error", res.Error, "stdOut", res.StdOut, "stdErr", res.StdErr, "exitcode", res.ExitCode)
 		return "", res.Error
@@ -1186,7 +1210,7 @@ func (d *SapDiscovery) discoverDatabaseNFS(ctx context.Context) (string, error)
 		Executable: "df",
 		Args:       []string{"-h"},
 	}
-	res := d.Execute(ctx, p)
+	res := d.execute(ctx, p)
 	if res.Error != nil {
 		log.CtxLogger(ctx).Infow("Error executing df -h", "error", res.Error, "stdOut", res.StdOut, "stdErr", res.StdErr, "exitcode", res.ExitCode)
 		return "", res.Error
@@ -1218,7 +1242,7 @@ func (d *SapDiscovery) discoverHANAVersion(ctx context.Context, app *sappb.SAPIn
 		Args:       []string{"version"},
 		User:       sidAdm,
 	}
-	res := d.Execute(ctx, p)
+	res := d.execute(ctx, p)
 	if res.Error != nil {
 		log.CtxLogger(ctx).Infow("Error executing HDB version command", "error", res.Error, "stdOut", res.StdOut, "stdErr", res.StdErr, "exitcode", res.ExitCode)
 		return "", "", res.Error
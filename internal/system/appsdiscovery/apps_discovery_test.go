@@ -26,6 +26,7 @@ import (
 	dpb "google.golang.org/protobuf/types/known/durationpb"
 	wpb "google.golang.org/protobuf/types/known/wrapperspb"
 
+	backoff "github.com/cenkalti/backoff/v4"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"golang.org/x/exp/slices"
@@ -382,6 +383,74 @@ func (f *fakeCommandExecutor) Execute(ctx context.Context, params commandlineexe
 	return f.results[f.executeCallCount]
 }
 
+func TestResolveDBHosts(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver func(string) ([]string, error)
+		dbHosts  []string
+		want     []string
+		wantCall int
+	}{{
+		name:     "noResolver",
+		dbHosts:  []string{"dbhost", "10.0.0.5"},
+		want:     []string{"dbhost", "10.0.0.5"},
+		wantCall: 0,
+	}, {
+		name:     "resolved",
+		resolver: func(string) ([]string, error) { return []string{"10.0.0.1"}, nil },
+		dbHosts:  []string{"dbhost", "10.0.0.5"},
+		want:     []string{"dbhost", "10.0.0.5"},
+		wantCall: 1,
+	}, {
+		name: "resolvedAfterRetry",
+		resolver: func() func(string) ([]string, error) {
+			calls := 0
+			return func(string) ([]string, error) {
+				calls++
+				if calls < 3 {
+					return nil, errors.New("temporary failure in name resolution")
+				}
+				return []string{"10.0.0.1"}, nil
+			}
+		}(),
+		dbHosts:  []string{"dbhost"},
+		want:     []string{"dbhost"},
+		wantCall: 3,
+	}, {
+		name:     "fallbackToIP",
+		resolver: func(string) ([]string, error) { return nil, errors.New("no such host") },
+		dbHosts:  []string{"dbhost", "10.0.0.5"},
+		want:     []string{"10.0.0.5"},
+		wantCall: 3,
+	}, {
+		name:     "unresolvedWithoutIP",
+		resolver: func(string) ([]string, error) { return nil, errors.New("no such host") },
+		dbHosts:  []string{"dbhost"},
+		want:     []string{"dbhost"},
+		wantCall: 3,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			d := SapDiscovery{}
+			if tc.resolver != nil {
+				d.HostResolver = func(h string) ([]string, error) {
+					calls++
+					return tc.resolver(h)
+				}
+			}
+			bo := backoff.WithMaxRetries(&backoff.ZeroBackOff{}, 2)
+			got := d.resolveDBHosts(context.Background(), "abc", tc.dbHosts, bo)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("resolveDBHosts(%v) returned an unexpected diff (-want +got): %v", tc.dbHosts, diff)
+			}
+			if calls != tc.wantCall {
+				t.Errorf("resolveDBHosts(%v) resolved %d times, want %d", tc.dbHosts, calls, tc.wantCall)
+			}
+		})
+	}
+}
+
 func TestDiscoverAppToDBConnection(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -752,6 +821,45 @@ j2ee/scs/system = 01`,
 		},
 		abap: false,
 		want: "HN2",
+	}, {
+		name: "commentedParameterIgnored",
+		exec: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+			return commandlineexecutor.Result{
+				StdOut: `#dbid = HN1
+dbms/name = HN2`,
+			}
+		},
+		abap: true,
+		want: "HN2",
+	}, {
+		name: "onlyCommentedParameters",
+		exec: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+			return commandlineexecutor.Result{
+				StdOut: `# dbid = HN1
+/usr/sap/PTJ/SYS/profile/DEFAULT.PFL:#j2ee/dbname = HN3`,
+			}
+		},
+		wantErr: cmpopts.AnyError,
+	}, {
+		name: "profileFileNamePrefix",
+		exec: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+			return commandlineexecutor.Result{
+				StdOut: `/usr/sap/PTJ/SYS/profile/DEFAULT.PFL:dbms/name = HN2
+/usr/sap/PTJ/SYS/profile/PTJ_J00_host:j2ee/dbname = HN3`,
+			}
+		},
+		abap: false,
+		want: "HN3",
+	}, {
+		name: "firstValueOfParameterUsed",
+		exec: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+			return commandlineexecutor.Result{
+				StdOut: `dbid = HN1
+dbid = HN5`,
+			}
+		},
+		abap: true,
+		want: "HN1",
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
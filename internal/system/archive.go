@@ -0,0 +1,140 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	s "cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/encoding/protojson"
+	"github.com/GoogleCloudPlatform/sapagent/internal/storage"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	spb "github.com/GoogleCloudPlatform/sapagent/protos/system"
+)
+
+const (
+	archiveObjectPrefix = "sap-discovery"
+	archiveTimeFormat   = "20060102T150405Z"
+	archiveTimeout      = 2 * time.Minute
+	archiveMaxRetries   = 2
+)
+
+// ArchiveInterface is exported to be used by the system discovery OTE.
+type ArchiveInterface interface {
+	Archive(ctx context.Context, objectName string, data []byte) error
+}
+
+// GCSArchiver writes the discovered systems to a Cloud Storage bucket.
+type GCSArchiver struct {
+	BucketHandle *s.BucketHandle
+	BucketName   string
+}
+
+// NewGCSArchiver connects to the bucket. The connection is not verified, since listing the
+// objects of the bucket requires more permissions than writing them, so a missing bucket is
+// reported by the first Archive call. Returns false if the storage client cannot be created.
+func NewGCSArchiver(ctx context.Context, bucketName string, client storage.Client) (*GCSArchiver, bool) {
+	bh, ok := storage.ConnectToBucket(ctx, &storage.ConnectParameters{
+		StorageClient:   client,
+		BucketName:      bucketName,
+		UserAgentSuffix: "SAP System Discovery",
+		MaxRetries:      archiveMaxRetries,
+	})
+	if !ok {
+		return nil, false
+	}
+	return &GCSArchiver{BucketHandle: bh, BucketName: bucketName}, true
+}
+
+// Archive uploads the data to the object of the bucket.
+func (a *GCSArchiver) Archive(ctx context.Context, objectName string, data []byte) error {
+	rw := storage.ReadWriter{
+		Reader:       bytes.NewReader(data),
+		Copier:       io.Copy,
+		BucketHandle: a.BucketHandle,
+		BucketName:   a.BucketName,
+		ObjectName:   objectName,
+		TotalBytes:   int64(len(data)),
+		ChunkSizeMb:  1,
+		MaxRetries:   archiveMaxRetries,
+	}
+	if _, err := rw.Upload(ctx); err != nil {
+		return archiveError(a.BucketName, err)
+	}
+	return nil
+}
+
+// archiveError adds the likely cause to the errors of a missing bucket or missing permissions.
+func archiveError(bucketName string, err error) error {
+	var e *googleapi.Error
+	switch {
+	case errors.Is(err, s.ErrBucketNotExist), errors.As(err, &e) && e.Code == http.StatusNotFound:
+		return fmt.Errorf("archive bucket %s does not exist: %w", bucketName, err)
+	case errors.As(err, &e) && e.Code == http.StatusForbidden:
+		return fmt.Errorf("permission denied writing to archive bucket %s, the service account needs storage.objects.create on the bucket: %w", bucketName, err)
+	}
+	return fmt.Errorf("writing to archive bucket %s: %w", bucketName, err)
+}
+
+// archiveSystems writes the JSON of each system to the archive. Failures are logged and do not
+// stop the remaining systems from being archived.
+func (d *Discovery) archiveSystems(ctx context.Context, instanceName string, systems []*spb.SapDiscovery, now time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, archiveTimeout)
+	defer cancel()
+	for i, sys := range systems {
+		objectName := archiveObjectName(instanceName, sys, i, now)
+		data, err := protojson.Marshal(sys)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Could not marshal system for the discovery archive", "object", objectName, "error", err)
+			continue
+		}
+		if err := d.Archiver.Archive(ctx, objectName, data); err != nil {
+			log.CtxLogger(ctx).Warnw("Could not archive discovered system", "object", objectName, "error", err)
+			continue
+		}
+		log.CtxLogger(ctx).Debugw("Archived discovered system", "object", objectName)
+	}
+}
+
+// archiveObjectName returns the object name of a system discovered in the pass at the time,
+// sap-discovery/<instance>/<time>/<system>.json. The system is named by its system ID, or else
+// by its SIDs, so that the objects of the same system can be compared across passes.
+func archiveObjectName(instanceName string, sys *spb.SapDiscovery, index int, now time.Time) string {
+	name := sys.GetSystemId()
+	if name == "" {
+		var sids []string
+		for _, sid := range []string{sys.GetApplicationLayer().GetSid(), sys.GetDatabaseLayer().GetSid()} {
+			if sid != "" {
+				sids = append(sids, sid)
+			}
+		}
+		name = strings.Join(sids, "-")
+	}
+	if name == "" {
+		name = fmt.Sprintf("system-%d", index)
+	}
+	return fmt.Sprintf("%s/%s/%s/%s.json", archiveObjectPrefix, instanceName, now.UTC().Format(archiveTimeFormat), name)
+}
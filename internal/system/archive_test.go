@@ -0,0 +1,150 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	s "cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/googleapi"
+
+	spb "github.com/GoogleCloudPlatform/sapagent/protos/system"
+)
+
+var archiveTime = time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+
+// fakeArchiver records the archived object names, failing for the names in errs.
+type fakeArchiver struct {
+	objects []string
+	errs    map[string]error
+}
+
+func (f *fakeArchiver) Archive(ctx context.Context, objectName string, data []byte) error {
+	if err, ok := f.errs[objectName]; ok {
+		return err
+	}
+	f.objects = append(f.objects, objectName)
+	return nil
+}
+
+func TestArchiveObjectName(t *testing.T) {
+	tests := []struct {
+		name string
+		sys  *spb.SapDiscovery
+		want string
+	}{
+		{
+			name: "SystemID",
+			sys: &spb.SapDiscovery{
+				SystemId:         "prod-erp",
+				ApplicationLayer: &spb.SapDiscovery_Component{Sid: "ABC"},
+			},
+			want: "sap-discovery/test-instance/20240304T050607Z/prod-erp.json",
+		},
+		{
+			name: "SIDs",
+			sys: &spb.SapDiscovery{
+				ApplicationLayer: &spb.SapDiscovery_Component{Sid: "ABC"},
+				DatabaseLayer:    &spb.SapDiscovery_Component{Sid: "DEF"},
+			},
+			want: "sap-discovery/test-instance/20240304T050607Z/ABC-DEF.json",
+		},
+		{
+			name: "DatabaseOnly",
+			sys: &spb.SapDiscovery{
+				DatabaseLayer: &spb.SapDiscovery_Component{Sid: "DEF"},
+			},
+			want: "sap-discovery/test-instance/20240304T050607Z/DEF.json",
+		},
+		{
+			name: "Unnamed",
+			sys:  &spb.SapDiscovery{},
+			want: "sap-discovery/test-instance/20240304T050607Z/system-2.json",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := archiveObjectName("test-instance", tc.sys, 2, archiveTime); got != tc.want {
+				t.Errorf("archiveObjectName() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestArchiveError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "BucketNotExist",
+			err:  s.ErrBucketNotExist,
+			want: "does not exist",
+		},
+		{
+			name: "NotFound",
+			err:  &googleapi.Error{Code: http.StatusNotFound},
+			want: "does not exist",
+		},
+		{
+			name: "PermissionDenied",
+			err:  &googleapi.Error{Code: http.StatusForbidden},
+			want: "permission denied",
+		},
+		{
+			name: "Other",
+			err:  errors.New("connection reset"),
+			want: "writing to archive bucket",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := archiveError("test-bucket", tc.err)
+			if !strings.Contains(got.Error(), tc.want) || !errors.Is(got, tc.err) {
+				t.Errorf("archiveError(%v) = %v, want an error wrapping it containing %q", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestArchiveSystems(t *testing.T) {
+	systems := []*spb.SapDiscovery{
+		{ApplicationLayer: &spb.SapDiscovery_Component{Sid: "ABC"}},
+		{ApplicationLayer: &spb.SapDiscovery_Component{Sid: "DEF"}},
+		{ApplicationLayer: &spb.SapDiscovery_Component{Sid: "GHI"}},
+	}
+	a := &fakeArchiver{errs: map[string]error{
+		"sap-discovery/test-instance/20240304T050607Z/DEF.json": errors.New("permission denied"),
+	}}
+	d := &Discovery{Archiver: a}
+	d.archiveSystems(context.Background(), "test-instance", systems, archiveTime)
+
+	want := []string{
+		"sap-discovery/test-instance/20240304T050607Z/ABC.json",
+		"sap-discovery/test-instance/20240304T050607Z/GHI.json",
+	}
+	if diff := cmp.Diff(want, a.objects); diff != "" {
+		t.Errorf("archiveSystems() archived unexpected objects (-want +got):\n%s", diff)
+	}
+}
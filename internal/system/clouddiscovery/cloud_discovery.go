@@ -19,16 +19,21 @@ package clouddiscovery
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
+	backoff "github.com/cenkalti/backoff/v4"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	"golang.org/x/exp/slices"
 	compute "google.golang.org/api/compute/v1"
 	file "google.golang.org/api/file/v1"
+	"google.golang.org/api/googleapi"
 	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
 	spb "github.com/GoogleCloudPlatform/sapagent/protos/system"
+	"github.com/GoogleCloudPlatform/sapagent/shared/gce"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 )
 
@@ -47,8 +52,17 @@ const (
 	filestoresURIPart      = "filestores"
 	healthChecksURIPart    = "healthChecks"
 	locationsURIPart       = "locations"
+	snapshotsURIPart       = "snapshots"
+
+	// defaultMaxResources caps the resources collected in a discovery pass when MaxResources is unset.
+	defaultMaxResources = 1000
+	// computeRetries is the number of retries of a compute API call failing with a transient error.
+	computeRetries = 3
 )
 
+// errResourceKindDisabled is returned for the resources of a kind listed in DisabledResourceKinds.
+var errResourceKindDisabled = errors.New("resource kind is disabled")
+
 type gceInterface interface {
 	GetInstance(project, zone, instance string) (*compute.Instance, error)
 	GetInstanceByIP(project, ip string) (*compute.Instance, error)
@@ -61,8 +75,9 @@ type gceInterface interface {
 	ListInstanceGroupInstances(project, zone, name string) (*compute.InstanceGroupsListInstances, error)
 	GetFilestore(project, location, name string) (*file.Instance, error)
 	GetFilestoreByIP(project, location, ip string) (*file.ListInstancesResponse, error)
-	GetURIForIP(project, ip, region, subnetwok string) (string, error)
+	GetURIForIP(project, ip, region, subnetwok string, filestoreLocations []string) (string, error)
 	GetHealthCheck(projectID, name string) (*compute.HealthCheck, error)
+	ListDiskSnapshots(project, diskURI string) (*compute.SnapshotList, error)
 }
 
 func extractFromURI(uri, field string) string {
@@ -94,10 +109,29 @@ func getResourceKind(uri string) string {
 
 // CloudDiscovery provides methods to discover a set of resources, and ones related to those.
 type CloudDiscovery struct {
-	GceService         gceInterface
-	HostResolver       func(string) ([]string, error)
+	GceService   gceInterface
+	HostResolver func(string) ([]string, error)
+	// DiscoverSnapshots adds the snapshots of each discovered disk as related resources.
+	DiscoverSnapshots bool
+	// MaxResources caps the resources collected between calls to EndPass, defaultMaxResources
+	// is used when it is not positive.
+	MaxResources int
+	// DisabledResourceKinds lists the resource kinds, named as in the resource URIs, which are
+	// not discovered.
+	DisabledResourceKinds []string
+	// FilestoreLocations lists the regions or zones searched for Filestore instances by IP
+	// address, all locations are searched when it is empty.
+	FilestoreLocations []string
 	discoveryFunctions map[string]func(context.Context, string) (*spb.SapDiscovery_Resource, []toDiscover, error)
 	resourceCache      map[string]cacheEntry
+	// unresolvedAddrs holds the addresses which did not map to a resource in this pass.
+	unresolvedAddrs map[string]bool
+	passResources   int
+	capReached      bool
+	skippedKinds    map[string]bool
+	// computeBackOff provides the backoff for retrying compute API calls, defaultComputeBackOff
+	// is used when it is nil.
+	computeBackOff func(context.Context) backoff.BackOff
 }
 
 type toDiscover struct {
@@ -124,12 +158,18 @@ func (d *CloudDiscovery) configureDiscoveryFunctions() {
 	d.discoveryFunctions[healthChecksURIPart] = d.discoverHealthCheck
 	d.discoveryFunctions[subnetworksURIPart] = d.discoverSubnetwork
 	d.discoveryFunctions[networksURIPart] = d.discoverNetwork
+	d.discoveryFunctions[snapshotsURIPart] = d.discoverSnapshot
 }
 
 // DiscoverComputeResources attempts to gather information about the provided hosts and any additional
 // resources that are identified as related from the cloud descriptions.
 func (d *CloudDiscovery) DiscoverComputeResources(ctx context.Context, parentResource *spb.SapDiscovery_Resource, parentSubnetwork string, hostList []string, cp *ipb.CloudProperties) []*spb.SapDiscovery_Resource {
 	log.CtxLogger(ctx).Debugw("DiscoverComputeResources called", "parent", parentResource, "hostList", hostList)
+	if len(d.FilestoreLocations) > 0 {
+		log.CtxLogger(ctx).Debugw("Searching for Filestore instances in the configured locations", "locations", d.FilestoreLocations)
+	} else {
+		log.CtxLogger(ctx).Debug("Searching for Filestore instances in all locations")
+	}
 	var res []*spb.SapDiscovery_Resource
 	var uris []string
 	var discoverQueue []toDiscover
@@ -156,6 +196,13 @@ func (d *CloudDiscovery) DiscoverComputeResources(ctx context.Context, parentRes
 			// Already discovered, ignore
 			continue
 		}
+		if d.passResources >= d.maxResources() {
+			if !d.capReached {
+				log.CtxLogger(ctx).Warnw("Discovery resource cap reached, related resources are no longer expanded", "maxResources", d.maxResources(), "discovered", d.passResources, "notExpanded", len(discoverQueue)+1)
+			}
+			d.capReached = true
+			break
+		}
 		r, dis, err := d.discoverResource(ctx, h, cp.GetProjectId())
 		if err != nil {
 			continue
@@ -163,6 +210,7 @@ func (d *CloudDiscovery) DiscoverComputeResources(ctx context.Context, parentRes
 		log.CtxLogger(ctx).Debugw("Adding to queue", "dis", dis, "h", h.name)
 		discoverQueue = append(discoverQueue, dis...)
 		res = append(res, r)
+		d.passResources++
 		uris = append(uris, h.name)
 		if h.name != r.ResourceUri {
 			uris = append(uris, r.ResourceUri)
@@ -172,6 +220,23 @@ func (d *CloudDiscovery) DiscoverComputeResources(ctx context.Context, parentRes
 	return res
 }
 
+// EndPass returns the number of resources collected since the previous call and whether the
+// resource cap was reached, and resets both for the next discovery pass.
+func (d *CloudDiscovery) EndPass() (resources int, capReached bool) {
+	resources, capReached = d.passResources, d.capReached
+	d.passResources, d.capReached = 0, false
+	d.skippedKinds = nil
+	d.unresolvedAddrs = nil
+	return resources, capReached
+}
+
+func (d *CloudDiscovery) maxResources() int {
+	if d.MaxResources <= 0 {
+		return defaultMaxResources
+	}
+	return d.MaxResources
+}
+
 func (d *CloudDiscovery) discoverResource(ctx context.Context, host toDiscover, project string) (*spb.SapDiscovery_Resource, []toDiscover, error) {
 	log.CtxLogger(ctx).Debugw("discoverResource", "name", host.name, "parent", host.parent.GetResourceUri())
 	if d.resourceCache == nil {
@@ -192,26 +257,41 @@ func (d *CloudDiscovery) discoverResource(ctx context.Context, host toDiscover,
 	log.CtxLogger(ctx).Debugw("discoverResource addresses", "addrs", addrs)
 	// An error may just mean that
 	if len(addrs) > 0 {
-		addr = addrs[0]
-
-		// Check cache for this address
-		if c, ok := d.resourceCache[addr]; ok {
-			// Cache did not hit for the hostname, add it
-			d.resourceCache[host.name] = c
-			if now.Sub(c.res.UpdateTime.AsTime()) < (10 * time.Minute) {
-				log.CtxLogger(ctx).Debugw("discoverResource cache hit", "name", host.name, "now", now, "res", c.res, "related", c.related)
-				return c.res, c.related, nil
-			}
-		}
-
 		if host.parent != nil {
 			project = extractFromURI(host.parent.ResourceUri, projectsURIPart)
 		}
 
+		// A hostname may resolve to multiple addresses, use the first one that maps to a resource.
 		var err error
-		uri, err = d.GceService.GetURIForIP(project, addr, host.region, host.subnetwork)
+		for _, a := range addrs {
+			// Check cache for this address
+			if c, ok := d.resourceCache[a]; ok {
+				// Cache did not hit for the hostname, add it
+				d.resourceCache[host.name] = c
+				if now.Sub(c.res.UpdateTime.AsTime()) < (10 * time.Minute) {
+					log.CtxLogger(ctx).Debugw("discoverResource cache hit", "name", host.name, "now", now, "res", c.res, "related", c.related)
+					return c.res, c.related, nil
+				}
+			}
+
+			if d.unresolvedAddrs[a] {
+				log.CtxLogger(ctx).Debugw("discoverResource address did not resolve earlier in this pass", "addr", a, "host", host.name)
+				err = fmt.Errorf("address %s did not map to a resource earlier in this pass", a)
+				continue
+			}
+			var u string
+			if u, err = d.GceService.GetURIForIP(project, a, host.region, host.subnetwork, d.FilestoreLocations); err != nil {
+				log.CtxLogger(ctx).Infow("discoverResource URI error", "err", err, "addr", a, "host", host.name)
+				if d.unresolvedAddrs == nil {
+					d.unresolvedAddrs = make(map[string]bool)
+				}
+				d.unresolvedAddrs[a] = true
+				continue
+			}
+			addr, uri = a, u
+			break
+		}
 		if err != nil {
-			log.CtxLogger(ctx).Infow("discoverResource URI error", "err", err, "addr", addr, "host", host.name)
 			return nil, nil, err
 		}
 		log.CtxLogger(ctx).Debugw("discoverResource uri for ip", "uri", uri)
@@ -260,6 +340,16 @@ func (d *CloudDiscovery) discoverResourceForURI(ctx context.Context, uri string)
 		d.configureDiscoveryFunctions()
 	}
 	resourceKind := getResourceKind(uri)
+	if slices.Contains(d.DisabledResourceKinds, resourceKind) {
+		if !d.skippedKinds[resourceKind] {
+			log.CtxLogger(ctx).Infow("Skipping discovery of disabled resource kind", "kind", resourceKind)
+			if d.skippedKinds == nil {
+				d.skippedKinds = make(map[string]bool)
+			}
+			d.skippedKinds[resourceKind] = true
+		}
+		return nil, nil, errResourceKindDisabled
+	}
 	f, ok := d.discoveryFunctions[resourceKind]
 	if !ok {
 		return nil, nil, fmt.Errorf("Unsupported resource URI: %q", uri)
@@ -302,12 +392,45 @@ func (d *CloudDiscovery) discoverAddress(ctx context.Context, addressURI string)
 	return ar, toAdd, nil
 }
 
+func defaultComputeBackOff(ctx context.Context) backoff.BackOff {
+	return backoff.WithContext(backoff.WithMaxRetries(backoff.NewExponentialBackOff(), computeRetries), ctx)
+}
+
+// withComputeRetry runs call, retrying it only while it fails with a transient error.
+// Permanent errors, such as a missing resource or a permission denied, are returned at once.
+func (d *CloudDiscovery) withComputeRetry(ctx context.Context, uri string, call func() error) error {
+	newBackOff := d.computeBackOff
+	if newBackOff == nil {
+		newBackOff = defaultComputeBackOff
+	}
+	attempt := 1
+	return backoff.Retry(func() error {
+		err := call()
+		if err == nil {
+			return nil
+		}
+		if gce.ClassifyError(err) != gce.ErrorTransient {
+			if gce.ClassifyError(err) == gce.ErrorPermanent {
+				log.CtxLogger(ctx).Infow("Permanent error from the compute API, not retrying", "uri", uri, "error", err)
+			}
+			return backoff.Permanent(err)
+		}
+		log.CtxLogger(ctx).Debugw("Transient error from the compute API, retrying", "uri", uri, "attempt", attempt, "error", err)
+		attempt++
+		return err
+	}, newBackOff(ctx))
+}
+
 func (d *CloudDiscovery) discoverInstance(ctx context.Context, instanceURI string) (*spb.SapDiscovery_Resource, []toDiscover, error) {
 	project := extractFromURI(instanceURI, projectsURIPart)
 	zone := extractFromURI(instanceURI, zonesURIPart)
 	region := regionFromZone(zone)
 	instanceName := extractFromURI(instanceURI, instancesURIPart)
-	ci, err := d.GceService.GetInstance(project, zone, instanceName)
+	var ci *compute.Instance
+	err := d.withComputeRetry(ctx, instanceURI, func() (err error) {
+		ci, err = d.GceService.GetInstance(project, zone, instanceName)
+		return err
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -357,16 +480,49 @@ func (d *CloudDiscovery) discoverDisk(ctx context.Context, diskURI string) (*spb
 	diskName := extractFromURI(diskURI, disksURIPart)
 	diskZone := extractFromURI(diskURI, zonesURIPart)
 	projectID := extractFromURI(diskURI, projectsURIPart)
-	cd, err := d.GceService.GetDisk(projectID, diskZone, diskName)
+	var cd *compute.Disk
+	err := d.withComputeRetry(ctx, diskURI, func() (err error) {
+		cd, err = d.GceService.GetDisk(projectID, diskZone, diskName)
+		return err
+	})
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return &spb.SapDiscovery_Resource{
+	dr := &spb.SapDiscovery_Resource{
 		ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
 		ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_DISK,
 		ResourceUri:  cd.SelfLink,
 		UpdateTime:   timestamppb.Now(),
+	}
+	if !d.DiscoverSnapshots {
+		return dr, nil, nil
+	}
+
+	toAdd := []toDiscover{}
+	snapshots, err := d.GceService.ListDiskSnapshots(projectID, cd.SelfLink)
+	if err != nil {
+		// The snapshots are supplementary, the disk is still reported.
+		log.CtxLogger(ctx).Infow("Error listing disk snapshots", "disk", cd.SelfLink, "error", err)
+		return dr, toAdd, nil
+	}
+	for _, snapshot := range snapshots.Items {
+		toAdd = append(toAdd, toDiscover{
+			name:   snapshot.SelfLink,
+			parent: dr,
+		})
+	}
+	return dr, toAdd, nil
+}
+
+// discoverSnapshot describes a snapshot found by listing the snapshots of a disk, so no
+// further API call is needed.
+func (d *CloudDiscovery) discoverSnapshot(ctx context.Context, snapshotURI string) (*spb.SapDiscovery_Resource, []toDiscover, error) {
+	return &spb.SapDiscovery_Resource{
+		ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_STORAGE,
+		ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_SNAPSHOT,
+		ResourceUri:  snapshotURI,
+		UpdateTime:   timestamppb.Now(),
 	}, nil, nil
 }
 
@@ -374,9 +530,26 @@ func (d *CloudDiscovery) discoverForwardingRule(ctx context.Context, fwrURI stri
 	project := extractFromURI(fwrURI, projectsURIPart)
 	region := extractFromURI(fwrURI, regionsURIPart)
 	fwrName := extractFromURI(fwrURI, forwardingRulesURIPart)
-	fwr, err := d.GceService.GetForwardingRule(project, region, fwrName)
-	if err != nil {
-		return nil, nil, err
+	// Forwarding rules can be regional or global, try the regional rule first and fall back to the
+	// global rule of the same name when the regional rule does not exist.
+	var fwr *compute.ForwardingRule
+	var err error
+	if region != "" {
+		fwr, err = d.GceService.GetForwardingRule(project, region, fwrName)
+		var gErr *googleapi.Error
+		switch {
+		case err == nil:
+		case errors.As(err, &gErr) && gErr.Code == http.StatusNotFound:
+			log.CtxLogger(ctx).Debugw("Regional forwarding rule not found, trying global", "project", project, "region", region, "name", fwrName, "error", err)
+		default:
+			return nil, nil, err
+		}
+	}
+	if fwr == nil {
+		fwr, err = d.GceService.GetForwardingRule(project, "", fwrName)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	fr := &spb.SapDiscovery_Resource{
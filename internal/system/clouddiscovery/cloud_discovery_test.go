@@ -23,11 +23,13 @@ import (
 	"testing"
 	"time"
 
+	backoff "github.com/cenkalti/backoff/v4"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	compute "google.golang.org/api/compute/v1"
 	file "google.golang.org/api/file/v1"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/protobuf/testing/protocmp"
 	"github.com/GoogleCloudPlatform/sapagent/shared/gce/fake"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
@@ -244,6 +246,64 @@ func TestDiscoverComputeResources(t *testing.T) {
 	}
 }
 
+func TestDiscoverComputeResourcesCap(t *testing.T) {
+	c := CloudDiscovery{
+		HostResolver: func(string) ([]string, error) { return []string{}, nil },
+		GceService: &fake.TestGCE{
+			GetDiskResp: []*compute.Disk{{SelfLink: "disk-1"}, {SelfLink: "disk-2"}, {SelfLink: "disk-3"}},
+			GetDiskErr:  []error{nil, nil, nil},
+		},
+		MaxResources: 2,
+	}
+	hostList := []string{
+		"projects/test-project/zones/test-zone/disks/disk-1",
+		"projects/test-project/zones/test-zone/disks/disk-2",
+		"projects/test-project/zones/test-zone/disks/disk-3",
+	}
+	got := c.DiscoverComputeResources(context.Background(), nil, "", hostList, defaultCloudProperties)
+	if len(got) != 2 {
+		t.Errorf("DiscoverComputeResources() with a cap of 2 returned %d resources, want: 2", len(got))
+	}
+	// The cap is shared by the calls of a pass.
+	if got := c.DiscoverComputeResources(context.Background(), nil, "", hostList[2:], defaultCloudProperties); len(got) != 0 {
+		t.Errorf("DiscoverComputeResources() after the cap was reached returned %d resources, want: 0", len(got))
+	}
+	if resources, capReached := c.EndPass(); resources != 2 || !capReached {
+		t.Errorf("EndPass() = (%d, %t), want: (2, true)", resources, capReached)
+	}
+	if resources, capReached := c.EndPass(); resources != 0 || capReached {
+		t.Errorf("EndPass() after reset = (%d, %t), want: (0, false)", resources, capReached)
+	}
+}
+
+func TestDiscoverComputeResourcesDisabledKinds(t *testing.T) {
+	c := CloudDiscovery{
+		HostResolver: func(string) ([]string, error) { return []string{}, nil },
+		GceService: &fake.TestGCE{
+			GetDiskResp: []*compute.Disk{{SelfLink: "projects/test-project/zones/test-zone/disks/disk-1"}},
+			GetDiskErr:  []error{nil},
+		},
+		DisabledResourceKinds: []string{"instanceGroups", "backendServices"},
+	}
+	hostList := []string{
+		"projects/test-project/zones/test-zone/disks/disk-1",
+		"projects/test-project/zones/test-zone/instanceGroups/group-1",
+		"projects/test-project/regions/test-region/backendServices/service-1",
+		"projects/test-project/zones/test-zone/instanceGroups/group-2",
+	}
+	got := c.DiscoverComputeResources(context.Background(), nil, "", hostList, defaultCloudProperties)
+	if len(got) != 1 || got[0].GetResourceKind() != spb.SapDiscovery_Resource_RESOURCE_KIND_DISK {
+		t.Errorf("DiscoverComputeResources() with disabled kinds returned %v, want only the disk", got)
+	}
+	if want := map[string]bool{"instanceGroups": true, "backendServices": true}; !cmp.Equal(c.skippedKinds, want) {
+		t.Errorf("DiscoverComputeResources() skipped kinds = %v, want: %v", c.skippedKinds, want)
+	}
+	c.EndPass()
+	if c.skippedKinds != nil {
+		t.Errorf("EndPass() did not reset the skipped kinds, got: %v", c.skippedKinds)
+	}
+}
+
 func TestDiscoverResourceCache(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -681,6 +741,40 @@ func TestDiscoverResource(t *testing.T) {
 			GetURIForIPErr: []error{fmt.Errorf("some error")},
 		},
 		wantErr: cmpopts.AnyError,
+	}, {
+		name:     "hostnameResolvesToMultipleAddresses",
+		host:     toDiscover{name: "filestore.example.com"},
+		project:  "test-project",
+		resolver: func(string) ([]string, error) { return []string{"1.2.3.4", "5.6.7.8"}, nil },
+		gceService: &fake.TestGCE{
+			GetURIForIPResp: []string{"", "projects/test-project/locations/test-zone/filestores/test-filestore"},
+			GetURIForIPErr:  []error{fmt.Errorf("some error"), nil},
+			GetURIForIPArgs: []*fake.GetURIForIPArguments{{
+				Project: "test-project",
+				IP:      "1.2.3.4",
+			}, {
+				Project: "test-project",
+				IP:      "5.6.7.8",
+			}},
+			GetFilestoreResp: []*file.Instance{{
+				Name: "projects/test-project/locations/test-zone/filestores/test-filestore",
+			}},
+			GetFilestoreErr: []error{nil},
+		},
+		want: &spb.SapDiscovery_Resource{
+			ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_STORAGE,
+			ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FILESTORE,
+			ResourceUri:  "projects/test-project/locations/test-zone/filestores/test-filestore",
+		},
+	}, {
+		name:     "noResolvedAddressMapsToResource",
+		host:     toDiscover{name: "filestore.example.com"},
+		resolver: func(string) ([]string, error) { return []string{"1.2.3.4", "5.6.7.8"}, nil },
+		gceService: &fake.TestGCE{
+			GetURIForIPResp: []string{"", ""},
+			GetURIForIPErr:  []error{fmt.Errorf("some error"), fmt.Errorf("some error")},
+		},
+		wantErr: cmpopts.AnyError,
 	}, {
 		name:     "discoverResourceForURISuccess",
 		host:     toDiscover{name: "projects/test-project/zones/test-zone/filestores/test-filestore"},
@@ -757,6 +851,40 @@ func toDiscoverLess(a, b toDiscover) bool {
 	return a.name < b.name
 }
 
+func TestDiscoverResourceUnresolvedAddress(t *testing.T) {
+	gceService := &fake.TestGCE{
+		GetURIForIPResp: []string{"", "projects/test-project/zones/test-zone/disks/test-disk"},
+		GetURIForIPErr:  []error{fmt.Errorf("some error"), nil},
+		GetDiskResp:     []*compute.Disk{{SelfLink: "test-disk"}},
+		GetDiskErr:      []error{nil},
+	}
+	c := CloudDiscovery{
+		GceService:   gceService,
+		HostResolver: func(string) ([]string, error) { return []string{"1.2.3.4"}, nil },
+	}
+	ctx := context.Background()
+	host := toDiscover{name: "some-host"}
+
+	if _, _, err := c.discoverResource(ctx, host, "test-project"); err == nil {
+		t.Errorf("discoverResource() first call succeeded, want error")
+	}
+	if _, _, err := c.discoverResource(ctx, host, "test-project"); err == nil {
+		t.Errorf("discoverResource() second call succeeded, want error")
+	}
+	if gceService.GetURIForIPCallCount != 1 {
+		t.Errorf("GetURIForIP() called %d times in the pass, want 1", gceService.GetURIForIPCallCount)
+	}
+
+	c.EndPass()
+	got, _, err := c.discoverResource(ctx, host, "test-project")
+	if err != nil {
+		t.Fatalf("discoverResource() after EndPass() returned unexpected error: %v", err)
+	}
+	if got.GetResourceUri() != "test-disk" {
+		t.Errorf("discoverResource() after EndPass()=%v, want test-disk", got.GetResourceUri())
+	}
+}
+
 func TestDiscoverResourceForURI(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -1128,6 +1256,15 @@ func TestDiscoverResourceForURI(t *testing.T) {
 		name:    "unsupportedURIFailure",
 		uri:     "some/other/unknown/uri",
 		wantErr: cmpopts.AnyError,
+	}, {
+		name:       "discoverSnapshot",
+		uri:        "projects/test-project/global/snapshots/test-snapshot",
+		gceService: &fake.TestGCE{},
+		wantResource: &spb.SapDiscovery_Resource{
+			ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_STORAGE,
+			ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_SNAPSHOT,
+			ResourceUri:  "projects/test-project/global/snapshots/test-snapshot",
+		},
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -1317,12 +1454,13 @@ func TestDiscoverInstance(t *testing.T) {
 
 func TestDiscoverDisk(t *testing.T) {
 	tests := []struct {
-		name           string
-		diskURI        string
-		gceService     *fake.TestGCE
-		want           *spb.SapDiscovery_Resource
-		wantToDiscover []toDiscover
-		wantErr        error
+		name              string
+		diskURI           string
+		discoverSnapshots bool
+		gceService        *fake.TestGCE
+		want              *spb.SapDiscovery_Resource
+		wantToDiscover    []toDiscover
+		wantErr           error
 	}{{
 		name:    "success",
 		diskURI: makeZonalURI(defaultProjectID, defaultZone, "disks", "some-disk"),
@@ -1355,11 +1493,90 @@ func TestDiscoverDisk(t *testing.T) {
 			}},
 		},
 		wantErr: cmpopts.AnyError,
+	}, {
+		name:    "transientErrorRetried",
+		diskURI: makeZonalURI(defaultProjectID, defaultZone, "disks", "some-disk"),
+		gceService: &fake.TestGCE{
+			GetDiskResp: []*compute.Disk{nil, {
+				SelfLink: "some-disk",
+			}},
+			GetDiskErr: []error{&googleapi.Error{Code: 503}, nil},
+		},
+		want: &spb.SapDiscovery_Resource{
+			ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+			ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_DISK,
+			ResourceUri:  "some-disk",
+		},
+	}, {
+		name:    "permanentErrorNotRetried",
+		diskURI: makeZonalURI(defaultProjectID, defaultZone, "disks", "some-disk"),
+		gceService: &fake.TestGCE{
+			GetDiskResp: []*compute.Disk{nil, {
+				SelfLink: "some-disk",
+			}},
+			GetDiskErr: []error{&googleapi.Error{Code: 404}, nil},
+		},
+		wantErr: cmpopts.AnyError,
+	}, {
+		name:              "snapshots",
+		diskURI:           makeZonalURI(defaultProjectID, defaultZone, "disks", "some-disk"),
+		discoverSnapshots: true,
+		gceService: &fake.TestGCE{
+			GetDiskResp: []*compute.Disk{{
+				SelfLink: "some-disk",
+			}},
+			GetDiskErr: []error{nil},
+			ListDiskSnapshotsResp: []*compute.SnapshotList{{
+				Items: []*compute.Snapshot{{SelfLink: "snapshot-1"}, {SelfLink: "snapshot-2"}},
+			}},
+			ListDiskSnapshotsErr: []error{nil},
+		},
+		want: &spb.SapDiscovery_Resource{
+			ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+			ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_DISK,
+			ResourceUri:  "some-disk",
+		},
+		wantToDiscover: []toDiscover{{
+			name: "snapshot-1",
+			parent: &spb.SapDiscovery_Resource{
+				ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+				ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_DISK,
+				ResourceUri:  "some-disk",
+			},
+		}, {
+			name: "snapshot-2",
+			parent: &spb.SapDiscovery_Resource{
+				ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+				ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_DISK,
+				ResourceUri:  "some-disk",
+			},
+		}},
+	}, {
+		name:              "snapshotListFailure",
+		diskURI:           makeZonalURI(defaultProjectID, defaultZone, "disks", "some-disk"),
+		discoverSnapshots: true,
+		gceService: &fake.TestGCE{
+			GetDiskResp: []*compute.Disk{{
+				SelfLink: "some-disk",
+			}},
+			GetDiskErr:            []error{nil},
+			ListDiskSnapshotsResp: []*compute.SnapshotList{nil},
+			ListDiskSnapshotsErr:  []error{cmpopts.AnyError},
+		},
+		want: &spb.SapDiscovery_Resource{
+			ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+			ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_DISK,
+			ResourceUri:  "some-disk",
+		},
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			c := CloudDiscovery{
-				GceService: test.gceService,
+				GceService:        test.gceService,
+				DiscoverSnapshots: test.discoverSnapshots,
+				computeBackOff: func(context.Context) backoff.BackOff {
+					return backoff.WithMaxRetries(&backoff.ZeroBackOff{}, 3)
+				},
 			}
 			ctx := context.Background()
 			got, gotToDiscover, err := c.discoverDisk(ctx, test.diskURI)
@@ -1378,6 +1595,7 @@ func TestDiscoverDisk(t *testing.T) {
 func TestDiscoverForwardingRule(t *testing.T) {
 	tests := []struct {
 		name           string
+		uri            string
 		gceService     *fake.TestGCE
 		wantResource   *spb.SapDiscovery_Resource
 		wantToDiscover []toDiscover
@@ -1426,6 +1644,106 @@ func TestDiscoverForwardingRule(t *testing.T) {
 				ResourceUri:  "some-forwarding-rule",
 			},
 		}},
+	}, {
+		name: "regionalNotFoundGlobalFallback",
+		gceService: &fake.TestGCE{
+			GetForwardingRuleResp: []*compute.ForwardingRule{nil, {
+				SelfLink: "some-global-forwarding-rule",
+			}},
+			GetForwardingRuleErr: []error{&googleapi.Error{Code: 404}, nil},
+			GetForwardingRuleArgs: []*fake.GetForwardingRuleArguments{{
+				Project:  defaultProjectID,
+				Location: defaultRegion,
+				Name:     "some-forwarding-rule",
+			}, {
+				Project:  defaultProjectID,
+				Location: "",
+				Name:     "some-forwarding-rule",
+			}},
+		},
+		wantResource: &spb.SapDiscovery_Resource{
+			ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+			ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FORWARDING_RULE,
+			ResourceUri:  "some-global-forwarding-rule",
+		},
+		wantToDiscover: []toDiscover{{
+			region: defaultRegion,
+			parent: &spb.SapDiscovery_Resource{
+				ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+				ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FORWARDING_RULE,
+				ResourceUri:  "some-global-forwarding-rule",
+			},
+		}, {
+			region: defaultRegion,
+			parent: &spb.SapDiscovery_Resource{
+				ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+				ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FORWARDING_RULE,
+				ResourceUri:  "some-global-forwarding-rule",
+			},
+		}, {
+			region: defaultRegion,
+			parent: &spb.SapDiscovery_Resource{
+				ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+				ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FORWARDING_RULE,
+				ResourceUri:  "some-global-forwarding-rule",
+			},
+		}},
+	}, {
+		name: "regionalErrorNoFallback",
+		gceService: &fake.TestGCE{
+			GetForwardingRuleResp: []*compute.ForwardingRule{nil, {
+				SelfLink: "some-global-forwarding-rule",
+			}},
+			GetForwardingRuleErr: []error{&googleapi.Error{Code: 403}, nil},
+			GetForwardingRuleArgs: []*fake.GetForwardingRuleArguments{{
+				Project:  defaultProjectID,
+				Location: defaultRegion,
+				Name:     "some-forwarding-rule",
+			}},
+		},
+		wantErr: cmpopts.AnyError,
+	}, {
+		name: "globalURI",
+		uri:  makeGlobalURI(defaultProjectID, "forwardingRules", "some-forwarding-rule"),
+		gceService: &fake.TestGCE{
+			GetForwardingRuleResp: []*compute.ForwardingRule{{
+				SelfLink:       "some-global-forwarding-rule",
+				Network:        "some-network",
+				BackendService: "some-backend-service",
+			}},
+			GetForwardingRuleErr: []error{nil},
+			GetForwardingRuleArgs: []*fake.GetForwardingRuleArguments{{
+				Project:  defaultProjectID,
+				Location: "",
+				Name:     "some-forwarding-rule",
+			}},
+		},
+		wantResource: &spb.SapDiscovery_Resource{
+			ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+			ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FORWARDING_RULE,
+			ResourceUri:  "some-global-forwarding-rule",
+		},
+		wantToDiscover: []toDiscover{{
+			name: "some-network",
+			parent: &spb.SapDiscovery_Resource{
+				ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+				ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FORWARDING_RULE,
+				ResourceUri:  "some-global-forwarding-rule",
+			},
+		}, {
+			parent: &spb.SapDiscovery_Resource{
+				ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+				ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FORWARDING_RULE,
+				ResourceUri:  "some-global-forwarding-rule",
+			},
+		}, {
+			name: "some-backend-service",
+			parent: &spb.SapDiscovery_Resource{
+				ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+				ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FORWARDING_RULE,
+				ResourceUri:  "some-global-forwarding-rule",
+			},
+		}},
 	}, {
 		name: "failure",
 		gceService: &fake.TestGCE{
@@ -1440,7 +1758,11 @@ func TestDiscoverForwardingRule(t *testing.T) {
 				GceService: test.gceService,
 			}
 			ctx := context.Background()
-			fwrURI := makeRegionalURI(defaultProjectID, defaultRegion, "forwardingRules", "some-forwarding-rule")
+			fwrURI := test.uri
+			if fwrURI == "" {
+				fwrURI = makeRegionalURI(defaultProjectID, defaultRegion, "forwardingRules", "some-forwarding-rule")
+			}
+			test.gceService.T = t
 			gotResource, gotToDiscover, err := c.discoverForwardingRule(ctx, fwrURI)
 			if diff := cmp.Diff(test.wantToDiscover, gotToDiscover, toDiscoverListOpts...); diff != "" {
 				t.Errorf("discoverForwardingRule() returned unexpected diff (-want +got):\n%s", diff)
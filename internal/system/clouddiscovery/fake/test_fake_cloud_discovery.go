@@ -41,6 +41,10 @@ type CloudDiscovery struct {
 	DiscoverComputeResourcesArgs      []DiscoverComputeResourcesArgs
 	DiscoverComputeResourcesArgsDiffs []string
 	discoverComputeResourcesCallCount int
+
+	EndPassResources  int
+	EndPassCapReached bool
+	EndPassCallCount  int
 }
 
 // DiscoverComputeResources is a fake implementation for the CloudDiscovery method.
@@ -58,3 +62,9 @@ func (c *CloudDiscovery) DiscoverComputeResources(ctx context.Context, parent *s
 
 	return c.DiscoverComputeResourcesResp[c.discoverComputeResourcesCallCount]
 }
+
+// EndPass is a fake implementation for the CloudDiscovery method.
+func (c *CloudDiscovery) EndPass() (int, bool) {
+	c.EndPassCallCount++
+	return c.EndPassResources, c.EndPassCapReached
+}
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -32,12 +33,16 @@ import (
 	"golang.org/x/exp/slices"
 	"google.golang.org/protobuf/encoding/protojson"
 	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
+	"github.com/GoogleCloudPlatform/sapagent/internal/drain"
 	"github.com/GoogleCloudPlatform/sapagent/internal/system/appsdiscovery"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/sapagent/internal/workloadmanager"
+	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 	"github.com/GoogleCloudPlatform/sapagent/shared/recovery"
+	"github.com/GoogleCloudPlatform/sapagent/shared/timeseries"
 
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
 	dwpb "github.com/GoogleCloudPlatform/sapagent/protos/datawarehouse"
@@ -46,24 +51,65 @@ import (
 	spb "github.com/GoogleCloudPlatform/sapagent/protos/system"
 )
 
-const systemDiscoveryOverride = "/etc/google-cloud-sap-agent/system.json"
+const (
+	systemDiscoveryOverride = "/etc/google-cloud-sap-agent/system.json"
+
+	metricURL                 = "workload.googleapis.com"
+	discoverySinceSuccessPath = "/sap/agent/discovery/seconds_since_success"
+	discoveryFailedPassesPath = "/sap/agent/discovery/failed_passes"
+	discoveryResourceCapPath  = "/sap/agent/discovery/resource_cap_reached"
+)
+
+// SAP roles tagged on the discovered resources.
+const (
+	roleASCS      = "ascs"
+	roleERS       = "ers"
+	roleAppServer = "app-server"
+	roleDatabase  = "database"
+	roleHANode    = "ha-node"
+	roleHAVIP     = "ha-vip"
+)
+
+// instanceRoles maps the bits of an instance role bitmask to SAP roles.
+var instanceRoles = []struct {
+	bit  spb.SapDiscovery_Resource_InstanceProperties_InstanceRole
+	role string
+}{
+	{spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_ASCS, roleASCS},
+	{spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_ERS, roleERS},
+	{spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_APP_SERVER, roleAppServer},
+	{spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_DATABASE, roleDatabase},
+}
+
+// systemIDRegex matches the system IDs which can be pinned in the configuration.
+var systemIDRegex = regexp.MustCompile(`^[A-Za-z0-9][-_A-Za-z0-9]{0,62}$`)
 
 // Discovery is a type used to perform SAP System discovery operations.
+// When TimeSeriesCreator is set the discovery health metrics are sent after every pass which
+// writes to the WLM API. When Archiver is set the JSON of the discovered systems is archived
+// after every pass.
 type Discovery struct {
 	WlmService              WlmInterface
 	CloudLogInterface       CloudLogInterface
+	Archiver                ArchiveInterface
 	CloudDiscoveryInterface CloudDiscoveryInterface
 	HostDiscoveryInterface  HostDiscoveryInterface
 	SapDiscoveryInterface   SapDiscoveryInterface
 	AppsDiscovery           func(context.Context) *sappb.SAPInstances
 	OSStatReader            workloadmanager.OSStatReader
 	FileReader              workloadmanager.ConfigFileReader
+	TimeSeriesCreator       cloudmonitoring.TimeSeriesCreator
+	BackOffs                *cloudmonitoring.BackOffIntervals
 	systems                 []*spb.SapDiscovery
 	systemMu                sync.Mutex
 	sapInstances            *sappb.SAPInstances
 	sapMu                   sync.Mutex
 	sapInstancesRoutine     *recovery.RecoverableRoutine
 	systemDiscoveryRoutine  *recovery.RecoverableRoutine
+	// lastSuccess is the time of the last pass where every system was written to WLM, it starts
+	// at the time discovery started. failedPasses counts the passes since then.
+	lastSuccess  time.Time
+	failedPasses int64
 }
 
 // GetSAPSystems returns the current list of SAP Systems discovered on the current host.
@@ -80,6 +126,19 @@ func (d *Discovery) GetSAPInstances() *sappb.SAPInstances {
 	return d.sapInstances
 }
 
+// DiscoverSAPInstancesOnce discovers the SAP instances of the current host once and stores them
+// for GetSAPInstances, without starting the discovery routines. Nothing is written to the WLM API.
+func (d *Discovery) DiscoverSAPInstancesOnce(ctx context.Context, config *cpb.Configuration) *sappb.SAPInstances {
+	sapInst := &sappb.SAPInstances{}
+	if fileInfo, err := d.OSStatReader(systemDiscoveryOverride); fileInfo == nil || err != nil {
+		sapInst = filterSAPInstances(ctx, d.AppsDiscovery(ctx), config.GetCollectionConfiguration())
+	}
+	d.sapMu.Lock()
+	defer d.sapMu.Unlock()
+	d.sapInstances = sapInst
+	return sapInst
+}
+
 // StartSAPSystemDiscovery Initializes the discovery object and starts the discovery subroutine.
 // Returns true if the discovery goroutine is started, and false otherwise.
 func StartSAPSystemDiscovery(ctx context.Context, config *cpb.Configuration, d *Discovery) bool {
@@ -135,11 +194,13 @@ type WlmInterface interface {
 // CloudDiscoveryInterface is exported to be used by the system discovery OTE.
 type CloudDiscoveryInterface interface {
 	DiscoverComputeResources(context.Context, *spb.SapDiscovery_Resource, string, []string, *ipb.CloudProperties) []*spb.SapDiscovery_Resource
+	EndPass() (resources int, capReached bool)
 }
 
 // HostDiscoveryInterface is exported to be used by the system discovery OTE.
 type HostDiscoveryInterface interface {
 	DiscoverCurrentHost(context.Context) []string
+	DiscoverClusterResources(context.Context) []*spb.SapDiscovery_Resource_InstanceProperties_ClusterResource
 }
 
 // SapDiscoveryInterface is exported to be used by the system discovery OTE.
@@ -161,6 +222,9 @@ func removeDuplicates(res []*spb.SapDiscovery_Resource) []*spb.SapDiscovery_Reso
 					outRes.RelatedResources = append(outRes.RelatedResources, rel)
 				}
 			}
+			for _, role := range r.SapRoles {
+				addSAPRole(outRes, role)
+			}
 			if r.GetInstanceProperties() != nil {
 				log.Logger.Debugw("Stored instance properties", "properties", outRes.GetInstanceProperties().String())
 				log.Logger.Debugw("Duplicate instance properties", "properties", r.InstanceProperties.String())
@@ -185,6 +249,9 @@ func removeDuplicates(res []*spb.SapDiscovery_Resource) []*spb.SapDiscovery_Reso
 							log.Logger.Debugw("Duplicate app instance", "app", app.String())
 						}
 					}
+					if len(outRes.InstanceProperties.ClusterResources) == 0 {
+						outRes.InstanceProperties.ClusterResources = r.InstanceProperties.ClusterResources
+					}
 				}
 				log.Logger.Debugw("Merged properties", "properties", outRes.InstanceProperties.String())
 			}
@@ -218,8 +285,12 @@ func updateSAPInstances(ctx context.Context, a any) {
 	log.CtxLogger(ctx).Info("Starting SAP Instances update")
 	updateTicker := time.NewTicker(args.config.GetDiscoveryConfiguration().GetSapInstancesUpdateFrequency().AsDuration())
 	for {
+		if !drain.Wait(ctx) {
+			log.CtxLogger(ctx).Info("SAP Discovery cancellation requested")
+			return
+		}
 		log.CtxLogger(ctx).Info("Updating SAP Instances")
-		sapInst := args.d.AppsDiscovery(ctx)
+		sapInst := filterSAPInstances(ctx, args.d.AppsDiscovery(ctx), args.config.GetCollectionConfiguration())
 		args.d.sapMu.Lock()
 		args.d.sapInstances = sapInst
 		args.d.sapMu.Unlock()
@@ -234,6 +305,30 @@ func updateSAPInstances(ctx context.Context, a any) {
 	}
 }
 
+// filterSAPInstances removes the instances whose SID is not in the include_sids list, when one
+// is configured, or is in the exclude_sids list of the collection configuration. Since both
+// process metrics and system discovery consume the filtered list, excluded SIDs are neither
+// monitored nor discovered.
+func filterSAPInstances(ctx context.Context, instances *sappb.SAPInstances, config *cpb.CollectionConfiguration) *sappb.SAPInstances {
+	include, exclude := config.GetIncludeSids(), config.GetExcludeSids()
+	if instances == nil || (len(include) == 0 && len(exclude) == 0) {
+		return instances
+	}
+	containsSID := func(sids []string, sid string) bool {
+		return slices.ContainsFunc(sids, func(s string) bool { return strings.EqualFold(s, sid) })
+	}
+	filtered := &sappb.SAPInstances{LinuxClusterMember: instances.GetLinuxClusterMember()}
+	for _, instance := range instances.GetInstances() {
+		sid := instance.GetSapsid()
+		if (len(include) != 0 && !containsSID(include, sid)) || containsSID(exclude, sid) {
+			log.CtxLogger(ctx).Debugw("Skipping SAP instance filtered out by configuration", "sid", sid, "instanceid", instance.GetInstanceId())
+			continue
+		}
+		filtered.Instances = append(filtered.Instances, instance)
+	}
+	return filtered
+}
+
 func runDiscovery(ctx context.Context, a any) {
 	log.CtxLogger(ctx).Info("Starting SAP System Discovery")
 	var args runDiscoveryArgs
@@ -248,10 +343,17 @@ func runDiscovery(ctx context.Context, a any) {
 		return
 	}
 
+	args.d.lastSuccess = time.Now()
 	updateTicker := time.NewTicker(args.config.GetDiscoveryConfiguration().GetSystemDiscoveryUpdateFrequency().AsDuration())
 	for {
+		// Passes are not started in drain mode, the pass runs as soon as drain mode is exited.
+		if !drain.Wait(ctx) {
+			log.CtxLogger(ctx).Info("SAP Discovery cancellation requested")
+			return
+		}
 		sapSystems := args.d.discoverSAPSystems(ctx, cp, args.config)
-		log.CtxLogger(ctx).Debugw("Discovered SAP Systems", "systems", sapSystems)
+		resources, capReached := args.d.CloudDiscoveryInterface.EndPass()
+		log.CtxLogger(ctx).Debugw("Discovered SAP Systems", "systems", sapSystems, "resources", resources, "capReached", capReached)
 
 		locationParts := strings.Split(cp.GetZone(), "-")
 		region := strings.Join([]string{locationParts[0], locationParts[1]}, "-")
@@ -259,6 +361,7 @@ func runDiscovery(ctx context.Context, a any) {
 		// Write SAP system discovery data only if sap_system_discovery is enabled.
 		if args.config.GetDiscoveryConfiguration().GetEnableDiscovery().GetValue() {
 			log.CtxLogger(ctx).Info("Sending systems to WLM API")
+			passSucceeded := true
 			for _, sys := range sapSystems {
 				sys.ProjectNumber = cp.GetNumericProjectId()
 				sys.UpdateTime = timestamppb.Now()
@@ -275,6 +378,7 @@ func runDiscovery(ctx context.Context, a any) {
 				err := args.d.WlmService.WriteInsight(cp.ProjectId, region, insightRequest)
 				if err != nil {
 					log.CtxLogger(ctx).Infow("Encountered error writing to WLM", "error", err)
+					passSucceeded = false
 				}
 
 				if args.d.CloudLogInterface == nil {
@@ -285,6 +389,14 @@ func runDiscovery(ctx context.Context, a any) {
 					log.CtxLogger(ctx).Infow("Encountered error writing to cloud logging", "error", err)
 				}
 			}
+			now := time.Now()
+			health := append(args.d.discoveryHealthTimeSeries(args.config, passSucceeded, now), resourceCapTimeSeries(args.config, capReached, now))
+			args.d.sendDiscoveryHealth(ctx, args.config, health)
+		}
+
+		// Archived after the WLM writes so that a slow or failing bucket does not delay them.
+		if args.d.Archiver != nil {
+			args.d.archiveSystems(ctx, cp.GetInstanceName(), sapSystems, time.Now())
 		}
 
 		log.CtxLogger(ctx).Info("Done SAP System Discovery")
@@ -303,6 +415,49 @@ func runDiscovery(ctx context.Context, a any) {
 	}
 }
 
+// discoveryHealthTimeSeries records the outcome of a pass and returns a gauge of the seconds since
+// the last pass where all systems were written to WLM and a gauge of the consecutive failed passes.
+func (d *Discovery) discoveryHealthTimeSeries(config *cpb.Configuration, passSucceeded bool, now time.Time) []*mrpb.TimeSeries {
+	if passSucceeded {
+		d.lastSuccess = now
+		d.failedPasses = 0
+	} else {
+		d.failedPasses++
+	}
+	params := timeseries.Params{
+		BareMetal:  config.GetBareMetal(),
+		CloudProp:  timeseries.ConvertCloudProperties(config.GetCloudProperties()),
+		MetricType: metricURL + discoverySinceSuccessPath,
+		Int64Value: int64(now.Sub(d.lastSuccess).Seconds()),
+		Timestamp:  timestamppb.New(now),
+	}
+	sinceSuccess := timeseries.BuildInt(params)
+	params.MetricType = metricURL + discoveryFailedPassesPath
+	params.Int64Value = d.failedPasses
+	return []*mrpb.TimeSeries{sinceSuccess, timeseries.BuildInt(params)}
+}
+
+// resourceCapTimeSeries returns a gauge of whether the pass stopped expanding related resources
+// at the configured cap, in which case the discovered systems are incomplete.
+func resourceCapTimeSeries(config *cpb.Configuration, capReached bool, now time.Time) *mrpb.TimeSeries {
+	return timeseries.BuildBool(timeseries.Params{
+		BareMetal:  config.GetBareMetal(),
+		CloudProp:  timeseries.ConvertCloudProperties(config.GetCloudProperties()),
+		MetricType: metricURL + discoveryResourceCapPath,
+		BoolValue:  capReached,
+		Timestamp:  timestamppb.New(now),
+	})
+}
+
+func (d *Discovery) sendDiscoveryHealth(ctx context.Context, config *cpb.Configuration, ts []*mrpb.TimeSeries) {
+	if d.TimeSeriesCreator == nil {
+		return
+	}
+	if _, _, err := cloudmonitoring.SendTimeSeriesForSubsystem(ctx, cloudmonitoring.SubsystemDiscovery, ts, d.TimeSeriesCreator, d.BackOffs, config.GetCloudProperties().GetProjectId()); err != nil {
+		log.CtxLogger(ctx).Infow("Encountered error sending discovery health metrics", "error", err)
+	}
+}
+
 func (d *Discovery) discoverOverrideSystem(ctx context.Context, overrideFile string, instanceResource *spb.SapDiscovery_Resource) []*spb.SapDiscovery {
 	file, err := d.FileReader(overrideFile)
 	if err != nil {
@@ -366,6 +521,13 @@ func (d *Discovery) discoverSAPSystems(ctx context.Context, cp *ipb.CloudPropert
 	log.CtxLogger(ctx).Info("Starting host discovery")
 	hostResourceNames := d.HostDiscoveryInterface.DiscoverCurrentHost(ctx)
 	log.CtxLogger(ctx).Debugw("Host Resource Names", "names", hostResourceNames)
+	if clusterResources := d.HostDiscoveryInterface.DiscoverClusterResources(ctx); len(clusterResources) > 0 && instanceResource != nil {
+		log.CtxLogger(ctx).Debugw("Cluster Resources", "clusterResources", clusterResources)
+		if instanceResource.InstanceProperties == nil {
+			instanceResource.InstanceProperties = &spb.SapDiscovery_Resource_InstanceProperties{}
+		}
+		instanceResource.InstanceProperties.ClusterResources = clusterResources
+	}
 	log.CtxLogger(ctx).Infow("Discovering other host resources")
 	hostResources := d.CloudDiscoveryInterface.DiscoverComputeResources(ctx, instanceResource, instanceSubnetwork, hostResourceNames, cp)
 	hostResources = removeDuplicates(append(hostResources, hostInstanceResources...))
@@ -417,6 +579,7 @@ func (d *Discovery) discoverSAPSystems(ctx context.Context, cp *ipb.CloudPropert
 				}
 				appRes = append(appRes, haRes...)
 				s.AppComponent.HaHosts = haURIs
+				tagHAResources(appRes)
 			}
 			s.AppComponent.HostProject = cp.GetNumericProjectId()
 			s.AppComponent.Resources = removeDuplicates(appRes)
@@ -449,6 +612,7 @@ func (d *Discovery) discoverSAPSystems(ctx context.Context, cp *ipb.CloudPropert
 				}
 				dbRes = append(dbRes, haRes...)
 				s.DBComponent.HaHosts = haURIs
+				tagHAResources(dbRes)
 			}
 			for _, r := range dbRes {
 				if r.GetResourceKind() == spb.SapDiscovery_Resource_RESOURCE_KIND_INSTANCE {
@@ -508,8 +672,12 @@ func (d *Discovery) discoverSAPSystems(ctx context.Context, cp *ipb.CloudPropert
 				}
 			}
 		}
+		tagInstanceRoles(append(system.GetApplicationLayer().GetResources(), system.GetDatabaseLayer().GetResources()...))
 		system.WorkloadProperties = s.WorkloadProperties
 		system.ProjectNumber = cp.GetNumericProjectId()
+		if id := pinnedSystemID(ctx, config.GetDiscoveryConfiguration(), system); id != "" {
+			system.SystemId = id
+		}
 		system.UpdateTime = timestamppb.Now()
 		sapSystems = append(sapSystems, system)
 	}
@@ -517,6 +685,55 @@ func (d *Discovery) discoverSAPSystems(ctx context.Context, cp *ipb.CloudPropert
 	return sapSystems
 }
 
+// addSAPRole tags the resource with the SAP role unless it already has it.
+func addSAPRole(r *spb.SapDiscovery_Resource, role string) {
+	if !slices.Contains(r.SapRoles, role) {
+		r.SapRoles = append(r.SapRoles, role)
+	}
+}
+
+// tagHAResources tags the instances of a highly available component as HA nodes and its
+// forwarding rules as the virtual IPs of the cluster.
+func tagHAResources(res []*spb.SapDiscovery_Resource) {
+	for _, r := range res {
+		switch r.GetResourceKind() {
+		case spb.SapDiscovery_Resource_RESOURCE_KIND_INSTANCE:
+			addSAPRole(r, roleHANode)
+		case spb.SapDiscovery_Resource_RESOURCE_KIND_FORWARDING_RULE:
+			addSAPRole(r, roleHAVIP)
+		}
+	}
+}
+
+// tagInstanceRoles tags the instances with the SAP roles set in their instance role bitmask.
+func tagInstanceRoles(res []*spb.SapDiscovery_Resource) {
+	for _, r := range res {
+		instanceRole := r.GetInstanceProperties().GetInstanceRole()
+		for _, ir := range instanceRoles {
+			if instanceRole&ir.bit != 0 {
+				addSAPRole(r, ir.role)
+			}
+		}
+	}
+}
+
+// pinnedSystemID returns the system ID pinned in the configuration for the application SID of
+// the system, or else for its database SID. Invalid IDs are ignored.
+func pinnedSystemID(ctx context.Context, config *cpb.DiscoveryConfiguration, system *spb.SapDiscovery) string {
+	for _, sid := range []string{system.GetApplicationLayer().GetSid(), system.GetDatabaseLayer().GetSid()} {
+		id, ok := config.GetPinnedSystemIds()[sid]
+		if sid == "" || !ok {
+			continue
+		}
+		if !systemIDRegex.MatchString(id) {
+			log.CtxLogger(ctx).Warnw("Ignoring invalid pinned system ID, it must be 1 to 63 letters, digits, hyphens or underscores starting with a letter or digit", "sid", sid, "systemId", id)
+			continue
+		}
+		return id
+	}
+	return ""
+}
+
 func (d *Discovery) writeToCloudLogging(sys *spb.SapDiscovery) error {
 	s, err := protojson.Marshal(sys)
 	if err != nil {
@@ -39,6 +39,7 @@ import (
 	clouddiscoveryfake "github.com/GoogleCloudPlatform/sapagent/internal/system/clouddiscovery/fake"
 	hostdiscoveryfake "github.com/GoogleCloudPlatform/sapagent/internal/system/hostdiscovery/fake"
 	"github.com/GoogleCloudPlatform/sapagent/internal/workloadmanager"
+	cmfake "github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring/fake"
 
 	cpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
 	dwpb "github.com/GoogleCloudPlatform/sapagent/protos/datawarehouse"
@@ -118,6 +119,23 @@ var (
 		ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_INSTANCE,
 		ResourceUri:  defaultInstanceURI,
 	}
+	defaultClusterResources = []*spb.SapDiscovery_Resource_InstanceProperties_ClusterResource{{
+		Name:  "rsc_SAPHana_ABC_HDB00",
+		Type:  spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_SAP_HANA,
+		Agent: "ocf:suse:SAPHana",
+	}, {
+		Name:  "STONITH-some-db-host",
+		Type:  spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_FENCING,
+		Agent: "stonith:fence_gce",
+	}}
+	clusterInstanceResource = &spb.SapDiscovery_Resource{
+		ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+		ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_INSTANCE,
+		ResourceUri:  defaultInstanceURI,
+		InstanceProperties: &spb.SapDiscovery_Resource_InstanceProperties{
+			ClusterResources: defaultClusterResources,
+		},
+	}
 )
 
 func TestMain(t *testing.M) {
@@ -281,6 +299,104 @@ func TestDiscoverSAPSystems(t *testing.T) {
 						InstanceRole:    spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_DATABASE,
 						VirtualHostname: "some-db-host",
 					},
+					SapRoles: []string{"database"},
+				}, {
+					ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_STORAGE,
+					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FILESTORE,
+					ResourceUri:  "some-shared-nfs-uri",
+				}},
+				HostProject: "12345",
+			},
+			ProjectNumber: "12345",
+		}},
+	}, {
+		name:   "hanaWithClusterResources",
+		config: &cpb.Configuration{CloudProperties: defaultCloudProperties},
+		testSapDiscovery: &appsdiscoveryfake.SapDiscovery{
+			DiscoverSapAppsResp: [][]appsdiscovery.SapSystemDetails{{{
+				DBComponent: &spb.SapDiscovery_Component{
+					Sid: "ABC",
+					Properties: &spb.SapDiscovery_Component_DatabaseProperties_{
+						DatabaseProperties: &spb.SapDiscovery_Component_DatabaseProperties{
+							SharedNfsUri:   "some-shared-nfs-uri",
+							InstanceNumber: "00",
+						},
+					},
+				},
+				DBHosts:  []string{"some-db-host"},
+				DBOnHost: true,
+				InstanceProperties: []*spb.SapDiscovery_Resource_InstanceProperties{{
+					InstanceRole:    spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_DATABASE,
+					VirtualHostname: "some-db-host",
+				}},
+			}}},
+		},
+		testCloudDiscovery: &clouddiscoveryfake.CloudDiscovery{
+			DiscoverComputeResourcesResp: [][]*spb.SapDiscovery_Resource{{{
+				ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+				ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_INSTANCE,
+				ResourceUri:  defaultInstanceURI,
+			}}, {},
+				{{
+					ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_INSTANCE,
+					ResourceUri:  defaultInstanceURI,
+				}}, {{
+					ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_STORAGE,
+					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FILESTORE,
+					ResourceUri:  "some-shared-nfs-uri",
+				}}, {{
+					ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_INSTANCE,
+					ResourceUri:  defaultInstanceURI,
+				}}},
+			DiscoverComputeResourcesArgs: []clouddiscoveryfake.DiscoverComputeResourcesArgs{{
+				Parent:   nil,
+				HostList: []string{defaultInstanceURI},
+				CP:       defaultCloudProperties,
+			}, {
+				Parent:   clusterInstanceResource,
+				HostList: []string{},
+				CP:       defaultCloudProperties,
+			}, {
+				Parent:   clusterInstanceResource,
+				HostList: []string{"some-db-host"},
+				CP:       defaultCloudProperties,
+			}, {
+				Parent:   clusterInstanceResource,
+				HostList: []string{"some-shared-nfs-uri"},
+				CP:       defaultCloudProperties,
+			}, {
+				Parent:   clusterInstanceResource,
+				HostList: []string{"some-db-host"},
+				CP:       defaultCloudProperties,
+			}},
+		},
+		testHostDiscovery: &hostdiscoveryfake.HostDiscovery{
+			DiscoverCurrentHostResp: [][]string{{}},
+			DiscoverClusterResourcesResp: [][]*spb.SapDiscovery_Resource_InstanceProperties_ClusterResource{
+				defaultClusterResources,
+			},
+		},
+		want: []*spb.SapDiscovery{{
+			DatabaseLayer: &spb.SapDiscovery_Component{
+				Sid: "ABC",
+				Properties: &spb.SapDiscovery_Component_DatabaseProperties_{
+					DatabaseProperties: &spb.SapDiscovery_Component_DatabaseProperties{
+						SharedNfsUri:   "some-shared-nfs-uri",
+						InstanceNumber: "00",
+					},
+				},
+				Resources: []*spb.SapDiscovery_Resource{{
+					ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
+					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_INSTANCE,
+					ResourceUri:  defaultInstanceURI,
+					InstanceProperties: &spb.SapDiscovery_Resource_InstanceProperties{
+						InstanceRole:     spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_DATABASE,
+						VirtualHostname:  "some-db-host",
+						ClusterResources: defaultClusterResources,
+					},
+					SapRoles: []string{"database"},
 				}, {
 					ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_STORAGE,
 					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FILESTORE,
@@ -403,6 +519,7 @@ func TestDiscoverSAPSystems(t *testing.T) {
 							Number: "99",
 						}},
 					},
+					SapRoles: []string{"app-server"},
 				}, {
 					ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_STORAGE,
 					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FILESTORE,
@@ -741,6 +858,7 @@ func TestDiscoverSAPSystems(t *testing.T) {
 					InstanceProperties: &spb.SapDiscovery_Resource_InstanceProperties{
 						InstanceRole: spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_DATABASE,
 					},
+					SapRoles: []string{"database"},
 				}, {
 					ResourceUri:  "some-db-resource",
 					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_ADDRESS,
@@ -824,6 +942,7 @@ func TestDiscoverSAPSystems(t *testing.T) {
 					InstanceProperties: &spb.SapDiscovery_Resource_InstanceProperties{
 						InstanceRole: spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_DATABASE,
 					},
+					SapRoles: []string{"database"},
 				}, {
 					ResourceUri:  "some-app-resource",
 					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_ADDRESS,
@@ -846,6 +965,7 @@ func TestDiscoverSAPSystems(t *testing.T) {
 					InstanceProperties: &spb.SapDiscovery_Resource_InstanceProperties{
 						InstanceRole: spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_DATABASE,
 					},
+					SapRoles: []string{"database"},
 				}, {
 					ResourceUri:  "some-db-resource",
 					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_ADDRESS,
@@ -1027,6 +1147,7 @@ func TestDiscoverSAPSystems(t *testing.T) {
 					InstanceProperties: &spb.SapDiscovery_Resource_InstanceProperties{
 						InstanceRole: spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_DATABASE,
 					},
+					SapRoles: []string{"database"},
 				}, {
 					ResourceUri:  "some-db-resource",
 					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_ADDRESS,
@@ -1119,6 +1240,7 @@ func TestDiscoverSAPSystems(t *testing.T) {
 					InstanceProperties: &spb.SapDiscovery_Resource_InstanceProperties{
 						InstanceRole: spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_DATABASE,
 					},
+					SapRoles: []string{"database"},
 				}, {
 					ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_COMPUTE,
 					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_INSTANCE,
@@ -1127,6 +1249,7 @@ func TestDiscoverSAPSystems(t *testing.T) {
 						InstanceRole:    spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_DATABASE,
 						VirtualHostname: "some-other-db-host",
 					},
+					SapRoles: []string{"database"},
 				}, {
 					ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_STORAGE,
 					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FILESTORE,
@@ -1224,6 +1347,7 @@ func TestDiscoverSAPSystems(t *testing.T) {
 						InstanceRole:    spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_APP_SERVER | spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_DATABASE,
 						VirtualHostname: "some-db-host",
 					},
+					SapRoles: []string{"app-server", "database"},
 				}, {
 					ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_STORAGE,
 					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FILESTORE,
@@ -1370,6 +1494,7 @@ func TestDiscoverSAPSystems(t *testing.T) {
 							Number: "99",
 						}},
 					},
+					SapRoles: []string{"app-server"},
 				}},
 				HostProject: "12345",
 			},
@@ -1534,6 +1659,7 @@ func TestDiscoverSAPSystems(t *testing.T) {
 							Number: "12",
 						}},
 					},
+					SapRoles: []string{"ers", "app-server"},
 				}, {
 					ResourceType: spb.SapDiscovery_Resource_RESOURCE_TYPE_STORAGE,
 					ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FILESTORE,
@@ -1721,6 +1847,22 @@ func TestUpdateSAPInstances(t *testing.T) {
 		}}}, {Instances: []*sappb.SAPInstance{{
 			Sapsid: "def",
 		}}}},
+	}, {
+		name: "excludedSIDFiltered",
+		config: &cpb.Configuration{
+			DiscoveryConfiguration: &cpb.DiscoveryConfiguration{
+				SapInstancesUpdateFrequency: &dpb.Duration{Seconds: 5},
+			},
+			CollectionConfiguration: &cpb.CollectionConfiguration{ExcludeSids: []string{"def"}},
+		},
+		discoverResponses: []*sappb.SAPInstances{{Instances: []*sappb.SAPInstance{{
+			Sapsid: "abc",
+		}, {
+			Sapsid: "def",
+		}}}},
+		wantInstances: []*sappb.SAPInstances{{Instances: []*sappb.SAPInstance{{
+			Sapsid: "abc",
+		}}}},
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -1760,6 +1902,119 @@ func TestUpdateSAPInstances(t *testing.T) {
 	}
 }
 
+func TestDiscoverSAPInstancesOnce(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *cpb.Configuration
+		overrideFile bool
+		want         *sappb.SAPInstances
+	}{{
+		name: "discovered",
+		want: &sappb.SAPInstances{Instances: []*sappb.SAPInstance{{Sapsid: "abc"}, {Sapsid: "def"}}},
+	}, {
+		name:   "excludedSIDFiltered",
+		config: &cpb.Configuration{CollectionConfiguration: &cpb.CollectionConfiguration{ExcludeSids: []string{"def"}}},
+		want:   &sappb.SAPInstances{Instances: []*sappb.SAPInstance{{Sapsid: "abc"}}},
+	}, {
+		name:         "overrideFile",
+		overrideFile: true,
+		want:         &sappb.SAPInstances{},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := &Discovery{
+				AppsDiscovery: func(context.Context) *sappb.SAPInstances {
+					return &sappb.SAPInstances{Instances: []*sappb.SAPInstance{{Sapsid: "abc"}, {Sapsid: "def"}}}
+				},
+				OSStatReader: func(string) (os.FileInfo, error) {
+					if test.overrideFile {
+						return MockFileInfo{}, nil
+					}
+					return nil, errors.New("No file")
+				},
+			}
+			got := d.DiscoverSAPInstancesOnce(context.Background(), test.config)
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("DiscoverSAPInstancesOnce() mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.want, d.GetSAPInstances(), protocmp.Transform()); diff != "" {
+				t.Errorf("GetSAPInstances() after DiscoverSAPInstancesOnce() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFilterSAPInstances(t *testing.T) {
+	instances := &sappb.SAPInstances{
+		LinuxClusterMember: true,
+		Instances: []*sappb.SAPInstance{
+			{Sapsid: "AAA", InstanceId: "HDB00"},
+			{Sapsid: "BBB", InstanceId: "ASCS01"},
+			{Sapsid: "CCC", InstanceId: "D02"},
+		},
+	}
+	tests := []struct {
+		name      string
+		instances *sappb.SAPInstances
+		config    *cpb.CollectionConfiguration
+		want      *sappb.SAPInstances
+	}{{
+		name:      "noFilter",
+		instances: instances,
+		want:      instances,
+	}, {
+		name:      "nilInstances",
+		instances: nil,
+		config:    &cpb.CollectionConfiguration{IncludeSids: []string{"AAA"}},
+		want:      nil,
+	}, {
+		name:      "includeOnly",
+		instances: instances,
+		config:    &cpb.CollectionConfiguration{IncludeSids: []string{"AAA", "ccc"}},
+		want: &sappb.SAPInstances{
+			LinuxClusterMember: true,
+			Instances: []*sappb.SAPInstance{
+				{Sapsid: "AAA", InstanceId: "HDB00"},
+				{Sapsid: "CCC", InstanceId: "D02"},
+			},
+		},
+	}, {
+		name:      "excludeOnly",
+		instances: instances,
+		config:    &cpb.CollectionConfiguration{ExcludeSids: []string{"BBB"}},
+		want: &sappb.SAPInstances{
+			LinuxClusterMember: true,
+			Instances: []*sappb.SAPInstance{
+				{Sapsid: "AAA", InstanceId: "HDB00"},
+				{Sapsid: "CCC", InstanceId: "D02"},
+			},
+		},
+	}, {
+		name:      "excludeWinsOverInclude",
+		instances: instances,
+		config:    &cpb.CollectionConfiguration{IncludeSids: []string{"AAA", "BBB"}, ExcludeSids: []string{"AAA"}},
+		want: &sappb.SAPInstances{
+			LinuxClusterMember: true,
+			Instances: []*sappb.SAPInstance{
+				{Sapsid: "BBB", InstanceId: "ASCS01"},
+			},
+		},
+	}, {
+		name:      "allFiltered",
+		instances: instances,
+		config:    &cpb.CollectionConfiguration{IncludeSids: []string{"ZZZ"}},
+		want:      &sappb.SAPInstances{LinuxClusterMember: true},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := filterSAPInstances(context.Background(), test.instances, test.config)
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("filterSAPInstances() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestRunDiscovery(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -2011,6 +2266,59 @@ func TestRunDiscovery(t *testing.T) {
 	}
 }
 
+func TestDiscoveryHealthTimeSeries(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := &cpb.Configuration{CloudProperties: defaultCloudProperties}
+	passes := []struct {
+		succeeded        bool
+		offset           time.Duration
+		wantSinceSuccess int64
+		wantFailed       int64
+	}{
+		{succeeded: false, offset: 4 * time.Hour, wantSinceSuccess: 14400, wantFailed: 1},
+		{succeeded: false, offset: 8 * time.Hour, wantSinceSuccess: 28800, wantFailed: 2},
+		{succeeded: true, offset: 12 * time.Hour, wantSinceSuccess: 0, wantFailed: 0},
+		{succeeded: false, offset: 16 * time.Hour, wantSinceSuccess: 14400, wantFailed: 1},
+	}
+
+	tsc := &cmfake.TimeSeriesCreator{}
+	d := &Discovery{TimeSeriesCreator: tsc, lastSuccess: start}
+	for i, p := range passes {
+		ts := d.discoveryHealthTimeSeries(config, p.succeeded, start.Add(p.offset))
+		if len(ts) != 2 {
+			t.Fatalf("discoveryHealthTimeSeries() pass %d returned %d time series, want: 2", i, len(ts))
+		}
+		got := map[string]int64{
+			ts[0].GetMetric().GetType(): ts[0].GetPoints()[0].GetValue().GetInt64Value(),
+			ts[1].GetMetric().GetType(): ts[1].GetPoints()[0].GetValue().GetInt64Value(),
+		}
+		want := map[string]int64{
+			metricURL + discoverySinceSuccessPath: p.wantSinceSuccess,
+			metricURL + discoveryFailedPassesPath: p.wantFailed,
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("discoveryHealthTimeSeries() pass %d returned unexpected diff (-want +got):\n%s", i, diff)
+		}
+		d.sendDiscoveryHealth(context.Background(), config, ts)
+	}
+	if len(tsc.Calls) != len(passes) {
+		t.Errorf("sendDiscoveryHealth() sent %d requests, want: %d", len(tsc.Calls), len(passes))
+	}
+}
+
+func TestResourceCapTimeSeries(t *testing.T) {
+	config := &cpb.Configuration{CloudProperties: defaultCloudProperties}
+	for _, capReached := range []bool{true, false} {
+		ts := resourceCapTimeSeries(config, capReached, time.Now())
+		if got := ts.GetMetric().GetType(); got != metricURL+discoveryResourceCapPath {
+			t.Errorf("resourceCapTimeSeries(%t) metric type = %q, want: %q", capReached, got, metricURL+discoveryResourceCapPath)
+		}
+		if got := ts.GetPoints()[0].GetValue().GetBoolValue(); got != capReached {
+			t.Errorf("resourceCapTimeSeries(%t) value = %t, want: %t", capReached, got, capReached)
+		}
+	}
+}
+
 type fakeReadCloser struct {
 	fileContents string
 	readError    error
@@ -2210,3 +2518,100 @@ func TestDiscoverOverrideSystem(t *testing.T) {
 		})
 	}
 }
+
+func TestPinnedSystemID(t *testing.T) {
+	appAndDB := &spb.SapDiscovery{
+		ApplicationLayer: &spb.SapDiscovery_Component{Sid: "ABC"},
+		DatabaseLayer:    &spb.SapDiscovery_Component{Sid: "DEH"},
+	}
+	dbOnly := &spb.SapDiscovery{
+		DatabaseLayer: &spb.SapDiscovery_Component{Sid: "DEH"},
+	}
+	tests := []struct {
+		name   string
+		config *cpb.DiscoveryConfiguration
+		system *spb.SapDiscovery
+		want   string
+	}{
+		{
+			name:   "NotConfigured",
+			system: appAndDB,
+		},
+		{
+			name:   "ApplicationSID",
+			config: &cpb.DiscoveryConfiguration{PinnedSystemIds: map[string]string{"ABC": "erp-prod", "DEH": "hana-prod"}},
+			system: appAndDB,
+			want:   "erp-prod",
+		},
+		{
+			name:   "DatabaseSID",
+			config: &cpb.DiscoveryConfiguration{PinnedSystemIds: map[string]string{"DEH": "hana-prod"}},
+			system: dbOnly,
+			want:   "hana-prod",
+		},
+		{
+			name:   "OtherSID",
+			config: &cpb.DiscoveryConfiguration{PinnedSystemIds: map[string]string{"XYZ": "hana-prod"}},
+			system: appAndDB,
+		},
+		{
+			name:   "InvalidIDFallsBackToDatabaseSID",
+			config: &cpb.DiscoveryConfiguration{PinnedSystemIds: map[string]string{"ABC": "erp prod", "DEH": "hana_prod"}},
+			system: appAndDB,
+			want:   "hana_prod",
+		},
+		{
+			name:   "EmptyID",
+			config: &cpb.DiscoveryConfiguration{PinnedSystemIds: map[string]string{"DEH": ""}},
+			system: dbOnly,
+		},
+		{
+			name:   "IDTooLong",
+			config: &cpb.DiscoveryConfiguration{PinnedSystemIds: map[string]string{"DEH": "a234567890123456789012345678901234567890123456789012345678901234"}},
+			system: dbOnly,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := pinnedSystemID(context.Background(), tc.config, tc.system)
+			if got != tc.want {
+				t.Errorf("pinnedSystemID(%v, %v) = %q, want: %q", tc.config, tc.system, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTagSAPRoles(t *testing.T) {
+	instance := &spb.SapDiscovery_Resource{
+		ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_INSTANCE,
+		ResourceUri:  defaultInstanceURI,
+		InstanceProperties: &spb.SapDiscovery_Resource_InstanceProperties{
+			InstanceRole: spb.SapDiscovery_Resource_InstanceProperties_INSTANCE_ROLE_ASCS_ERS,
+		},
+	}
+	forwardingRule := &spb.SapDiscovery_Resource{
+		ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_FORWARDING_RULE,
+		ResourceUri:  "some-forwarding-rule",
+	}
+	disk := &spb.SapDiscovery_Resource{
+		ResourceKind: spb.SapDiscovery_Resource_RESOURCE_KIND_DISK,
+		ResourceUri:  "some-disk",
+	}
+	res := []*spb.SapDiscovery_Resource{instance, forwardingRule, disk}
+
+	tagHAResources(res)
+	tagInstanceRoles(res)
+	// Tagging again does not duplicate the roles.
+	tagInstanceRoles(res)
+
+	want := map[*spb.SapDiscovery_Resource][]string{
+		instance:       {"ha-node", "ascs", "ers"},
+		forwardingRule: {"ha-vip"},
+		disk:           nil,
+	}
+	for r, wantRoles := range want {
+		if diff := cmp.Diff(wantRoles, r.GetSapRoles()); diff != "" {
+			t.Errorf("SapRoles of %s mismatch (-want, +got):\n%s", r.GetResourceUri(), diff)
+		}
+	}
+}
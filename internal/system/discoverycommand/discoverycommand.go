@@ -0,0 +1,45 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package discoverycommand runs the commands of the host and SAP application discovery with a
+// timeout, so that a hung command does not block the whole discovery pass.
+package discoverycommand
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+// DefaultTimeout bounds each discovery command when no timeout is configured.
+const DefaultTimeout = 2 * time.Minute
+
+// Execute runs the command bounded by timeout, or by DefaultTimeout when timeout is not positive.
+// A command exceeding it is logged and its result has an error wrapping context.DeadlineExceeded,
+// the caller skips its discovery step.
+func Execute(ctx context.Context, execute commandlineexecutor.Execute, params commandlineexecutor.Params, timeout time.Duration) commandlineexecutor.Result {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	result := commandlineexecutor.ExecuteWithTimeout(ctx, execute, params, timeout)
+	if errors.Is(result.Error, context.DeadlineExceeded) {
+		log.CtxLogger(ctx).Warnw("Command timed out, skipping discovery step", "executable", params.Executable, "timeout", timeout, "error", result.Error)
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discoverycommand
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
+)
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name        string
+		execute     commandlineexecutor.Execute
+		timeout     time.Duration
+		wantStdOut  string
+		wantTimeout bool
+	}{
+		{
+			name: "Success",
+			execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: "out"}
+			},
+			timeout:    time.Second,
+			wantStdOut: "out",
+		},
+		{
+			name: "DefaultTimeout",
+			execute: func(ctx context.Context, _ commandlineexecutor.Params) commandlineexecutor.Result {
+				if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > DefaultTimeout {
+					return commandlineexecutor.Result{Error: errors.New("command not bounded by the default timeout")}
+				}
+				return commandlineexecutor.Result{StdOut: "out"}
+			},
+			wantStdOut: "out",
+		},
+		{
+			name: "Hangs",
+			execute: func(ctx context.Context, _ commandlineexecutor.Params) commandlineexecutor.Result {
+				<-ctx.Done()
+				time.Sleep(time.Second)
+				return commandlineexecutor.Result{StdOut: "out"}
+			},
+			timeout:     10 * time.Millisecond,
+			wantTimeout: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Execute(context.Background(), test.execute, commandlineexecutor.Params{Executable: "crm"}, test.timeout)
+			if gotTimeout := errors.Is(got.Error, context.DeadlineExceeded); gotTimeout != test.wantTimeout {
+				t.Errorf("Execute() returned error: %v, want timeout: %t", got.Error, test.wantTimeout)
+			}
+			if !test.wantTimeout && got.Error != nil {
+				t.Errorf("Execute() returned error: %v, want nil", got.Error)
+			}
+			if got.StdOut != test.wantStdOut {
+				t.Errorf("Execute() StdOut = %q, want: %q", got.StdOut, test.wantStdOut)
+			}
+		})
+	}
+}
@@ -19,12 +19,17 @@ package fake
 
 import (
 	"context"
+
+	spb "github.com/GoogleCloudPlatform/sapagent/protos/system"
 )
 
 // HostDiscovery provides a fake implementation of the public HostDiscovery interface.
 type HostDiscovery struct {
 	DiscoverCurrentHostResp      [][]string
 	discoverCurrentHostCallCount int
+
+	DiscoverClusterResourcesResp      [][]*spb.SapDiscovery_Resource_InstanceProperties_ClusterResource
+	discoverClusterResourcesCallCount int
 }
 
 // DiscoverCurrentHost is a fake implementation of the HostDiscovery DiscoverCurrentHost method.
@@ -35,3 +40,16 @@ func (d *HostDiscovery) DiscoverCurrentHost(ctx context.Context) []string {
 
 	return d.DiscoverCurrentHostResp[d.discoverCurrentHostCallCount]
 }
+
+// DiscoverClusterResources is a fake implementation of the HostDiscovery DiscoverClusterResources
+// method. Returns nil when no responses are set.
+func (d *HostDiscovery) DiscoverClusterResources(ctx context.Context) []*spb.SapDiscovery_Resource_InstanceProperties_ClusterResource {
+	if len(d.DiscoverClusterResourcesResp) == 0 {
+		return nil
+	}
+	defer func() {
+		d.discoverClusterResourcesCallCount++
+	}()
+
+	return d.DiscoverClusterResourcesResp[d.discoverClusterResourcesCallCount]
+}
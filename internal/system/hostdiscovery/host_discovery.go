@@ -22,21 +22,42 @@ import (
 	"errors"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/GoogleCloudPlatform/sapagent/internal/system/discoverycommand"
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	spb "github.com/GoogleCloudPlatform/sapagent/protos/system"
 )
 
+type clusterResource = spb.SapDiscovery_Resource_InstanceProperties_ClusterResource
+
 var (
-	fsMountRegex = regexp.MustCompile(`([0-9]+\.[0-9]+\.[0-9]+\.[0-9]+):(/[a-zA-Z0-9]+)`)
-	crmIPRegex   = regexp.MustCompile(`params ip=([0-9]+\.[0-9]+\.[0-9]+\.[0-9]+)`)
-	pcsIPRegex   = regexp.MustCompile(`ip=([0-9]+\.[0-9]+\.[0-9]+\.[0-9]+)`)
+	// fsMountRegex matches NFS mounts identified by either an IP address or a hostname.
+	fsMountRegex = regexp.MustCompile(`^([a-zA-Z0-9][a-zA-Z0-9.\-]*):(/[a-zA-Z0-9]+)`)
+	// vipAddressRegex matches the ip parameter of an IPaddr2 resource, as "ip=<address>" in both
+	// the crm primitive params and the pcs resource attributes.
+	vipAddressRegex = regexp.MustCompile(`(?:^|\s)ip=([0-9]+\.[0-9]+\.[0-9]+\.[0-9]+)`)
+
+	// crmPrimitiveRegex matches "primitive <id> <agent>" lines of crm config show.
+	crmPrimitiveRegex = regexp.MustCompile(`(?m)^\s*primitive\s+(\S+)\s+([^\s\\]+)`)
+	// crmConstraintRegex matches "<kind> <id> ..." constraint lines of crm config show.
+	crmConstraintRegex = regexp.MustCompile(`(?m)^\s*(location|colocation|order)\s+(\S+)`)
+	// pcsResourceRegex matches "Resource: <id> (class=... provider=... type=...)" lines of pcs config show.
+	pcsResourceRegex = regexp.MustCompile(`^\s*Resource:\s+(\S+)\s+\(([^)]*)\)`)
+	// pcsConstraintSectionRegex matches the headers of the pcs config show constraint sections.
+	pcsConstraintSectionRegex = regexp.MustCompile(`^\s*(Location|Ordering|Colocation|Ticket) Constraints:`)
+	// pcsConstraintIDRegex matches constraint IDs which are printed as "(id:<id>)" or "(id: <id>)".
+	pcsConstraintIDRegex = regexp.MustCompile(`\(id:\s*([^)\s]+)\)`)
 )
 
 // HostDiscovery is for discovering details that can only be performed on the host running the agent.
 type HostDiscovery struct {
 	Exists  commandlineexecutor.Exists
 	Execute commandlineexecutor.Execute
+	// CommandTimeout bounds each command, discoverycommand.DefaultTimeout when it is not set.
+	CommandTimeout time.Duration
 }
 
 // DiscoverCurrentHost invokes the necessary commands to discover the resources visible only
@@ -53,55 +74,248 @@ func (d *HostDiscovery) DiscoverCurrentHost(ctx context.Context) []string {
 	return append(fs, addrs...)
 }
 
-func (d *HostDiscovery) discoverClusterAddresses(ctx context.Context) ([]string, error) {
-	if d.Exists("crm") {
-		return d.discoverClustersCRM(ctx)
+// execute runs the command bounded by the command timeout, see discoverycommand.Execute.
+func (d *HostDiscovery) execute(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+	return discoverycommand.Execute(ctx, d.Execute, params, d.CommandTimeout)
+}
+
+// clusterManager reads the pacemaker configuration with the command line tool of the
+// distribution, crm on SLES and pcs on RHEL, which print the configuration in different formats.
+type clusterManager struct {
+	executable     string
+	parseAddresses func(config string) []string
+	parseResources func(config string) []*clusterResource
+}
+
+var (
+	slesClusterManager = clusterManager{
+		executable:     "crm",
+		parseAddresses: parseCRMClusterAddresses,
+		parseResources: parseCRMClusterResources,
 	}
-	if d.Exists("pcs") {
-		return d.discoverClustersPCS(ctx)
+	rhelClusterManager = clusterManager{
+		executable:     "pcs",
+		parseAddresses: parsePCSClusterAddresses,
+		parseResources: parsePCSClusterResources,
 	}
-	return nil, errors.New("no cluster command found")
+)
+
+// clusterManager selects the cluster manager by the cluster command installed on the host.
+func (d *HostDiscovery) clusterManager() (clusterManager, error) {
+	switch {
+	case d.Exists("crm"):
+		return slesClusterManager, nil
+	case d.Exists("pcs"):
+		return rhelClusterManager, nil
+	}
+	return clusterManager{}, errors.New("no cluster command found")
 }
 
-func (d *HostDiscovery) discoverClustersCRM(ctx context.Context) ([]string, error) {
-	result := d.Execute(ctx, commandlineexecutor.Params{
-		Executable:  "crm",
+// readClusterConfig returns the output of the config show command of the cluster manager.
+func (d *HostDiscovery) readClusterConfig(ctx context.Context, m clusterManager) (string, error) {
+	result := d.execute(ctx, commandlineexecutor.Params{
+		Executable:  m.executable,
 		ArgsToSplit: "config show",
 	})
 	if result.Error != nil {
-		log.CtxLogger(ctx).Infow("running crm", "error", result.Error, "stdOut", result.StdOut, "stdErr", result.StdErr)
-		return nil, result.Error
+		log.CtxLogger(ctx).Infow("Error reading cluster configuration", "executable", m.executable, "error", result.Error, "stdOut", result.StdOut, "stdErr", result.StdErr)
+		return "", result.Error
+	}
+	return result.StdOut, nil
+}
+
+// DiscoverClusterResources returns the SAP, fencing and virtual IP resources and the constraints
+// of the pacemaker cluster the current host is part of.
+func (d *HostDiscovery) DiscoverClusterResources(ctx context.Context) []*clusterResource {
+	m, err := d.clusterManager()
+	if err != nil {
+		return nil
 	}
+	config, err := d.readClusterConfig(ctx, m)
+	if err != nil {
+		return nil
+	}
+	return m.parseResources(config)
+}
 
+// discoverClusterAddresses returns the virtual IP addresses of the pacemaker cluster.
+func (d *HostDiscovery) discoverClusterAddresses(ctx context.Context) ([]string, error) {
+	m, err := d.clusterManager()
+	if err != nil {
+		return nil, err
+	}
+	config, err := d.readClusterConfig(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	return m.parseAddresses(config), nil
+}
+
+// parseCRMClusterAddresses returns the addresses of the IPaddr2 primitives in crm config show
+// output. A primitive is printed as one statement continued over lines ending in a backslash:
+//
+//	primitive rsc_vip_int-primary IPaddr2 \
+//		params ip=10.0.0.10 cidr_netmask=32 \
+//		op monitor interval=3600s timeout=60s
+func parseCRMClusterAddresses(config string) []string {
 	var addrs []string
-	matches := crmIPRegex.FindAllStringSubmatch(result.StdOut, -1)
-	for _, match := range matches {
-		if match == nil {
+	for _, statement := range crmStatements(config) {
+		match := crmPrimitiveRegex.FindStringSubmatch(statement)
+		if match == nil || primitiveType(match[2]) != spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_VIRTUAL_IP {
 			continue
 		}
-		addrs = append(addrs, match[1])
+		if ip := vipAddressRegex.FindStringSubmatch(statement); ip != nil {
+			addrs = append(addrs, ip[1])
+		}
 	}
-	return addrs, nil
+	return addrs
 }
 
-func (d *HostDiscovery) discoverClustersPCS(ctx context.Context) ([]string, error) {
-	result := d.Execute(ctx, commandlineexecutor.Params{
-		Executable:  "pcs",
-		ArgsToSplit: "config show",
-	})
-	if result.Error != nil {
-		return nil, result.Error
+// crmStatements joins the continuation lines of crm config show output into statements.
+func crmStatements(config string) []string {
+	var statements []string
+	var current strings.Builder
+	for _, line := range strings.Split(config, "\n") {
+		trimmed := strings.TrimSpace(line)
+		continued := strings.HasSuffix(trimmed, "\\")
+		current.WriteString(strings.TrimSuffix(trimmed, "\\"))
+		current.WriteString(" ")
+		if !continued {
+			statements = append(statements, current.String())
+			current.Reset()
+		}
 	}
+	if current.Len() > 0 {
+		statements = append(statements, current.String())
+	}
+	return statements
+}
 
+// parsePCSClusterAddresses returns the addresses of the IPaddr2 resources in pcs config show
+// output. The attributes follow the resource line, on one line in older pcs versions and one
+// attribute per line in newer versions:
+//
+//	Resource: rsc_vip_HA1_00 (class=ocf provider=heartbeat type=IPaddr2)
+//	 Attributes: cidr_netmask=32 ip=10.0.0.10
+func parsePCSClusterAddresses(config string) []string {
 	var addrs []string
-	matches := pcsIPRegex.FindAllStringSubmatch(result.StdOut, -1)
-	for _, match := range matches {
+	inVIP := false
+	for _, line := range strings.Split(config, "\n") {
+		if match := pcsResourceRegex.FindStringSubmatch(line); match != nil {
+			inVIP = primitiveType(pcsAgent(match[2])) == spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_VIRTUAL_IP
+			continue
+		}
+		// Any other section of the resource, group or clone ends the attributes.
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "Attributes:") && (strings.HasSuffix(trimmed, ":") || strings.HasPrefix(trimmed, "Group:") || strings.HasPrefix(trimmed, "Clone:")) {
+			inVIP = false
+			continue
+		}
+		if !inVIP {
+			continue
+		}
+		if ip := vipAddressRegex.FindStringSubmatch(line); ip != nil {
+			addrs = append(addrs, ip[1])
+			inVIP = false
+		}
+	}
+	return addrs
+}
+
+// parseCRMClusterResources parses the primitives and constraints from crm config show output.
+func parseCRMClusterResources(config string) []*clusterResource {
+	var resources []*clusterResource
+	for _, match := range crmPrimitiveRegex.FindAllStringSubmatch(config, -1) {
+		if t := primitiveType(match[2]); t != spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_CLUSTER_RESOURCE_TYPE_UNSPECIFIED {
+			resources = append(resources, &clusterResource{Name: match[1], Type: t, Agent: match[2]})
+		}
+	}
+	for _, match := range crmConstraintRegex.FindAllStringSubmatch(config, -1) {
+		resources = append(resources, &clusterResource{
+			Name:  match[2],
+			Type:  spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_CONSTRAINT,
+			Agent: match[1],
+		})
+	}
+	return resources
+}
+
+// parsePCSClusterResources parses the resources, stonith devices and constraints from
+// pcs config show output.
+func parsePCSClusterResources(config string) []*clusterResource {
+	var resources []*clusterResource
+	constraintKind := ""
+	for _, line := range strings.Split(config, "\n") {
+		if match := pcsConstraintSectionRegex.FindStringSubmatch(line); match != nil {
+			constraintKind = strings.ToLower(match[1])
+			continue
+		}
+		// Any other top level section ends the constraints.
+		if line != "" && line == strings.TrimLeft(line, " \t") && strings.HasSuffix(line, ":") {
+			constraintKind = ""
+		}
+		if constraintKind != "" {
+			if match := pcsConstraintIDRegex.FindStringSubmatch(line); match != nil {
+				resources = append(resources, &clusterResource{
+					Name:  match[1],
+					Type:  spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_CONSTRAINT,
+					Agent: constraintKind,
+				})
+			}
+			continue
+		}
+		match := pcsResourceRegex.FindStringSubmatch(line)
 		if match == nil {
 			continue
 		}
-		addrs = append(addrs, match[1])
+		agent := pcsAgent(match[2])
+		if t := primitiveType(agent); t != spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_CLUSTER_RESOURCE_TYPE_UNSPECIFIED {
+			resources = append(resources, &clusterResource{Name: match[1], Type: t, Agent: agent})
+		}
+	}
+	return resources
+}
+
+// pcsAgent converts "class=ocf provider=heartbeat type=IPaddr2" to ocf:heartbeat:IPaddr2.
+func pcsAgent(attributes string) string {
+	var class, provider, agentType string
+	for _, field := range strings.Fields(attributes) {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "class":
+			class = value
+		case "provider":
+			provider = value
+		case "type":
+			agentType = value
+		}
+	}
+	parts := []string{}
+	for _, p := range []string{class, provider, agentType} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// primitiveType classifies a resource by its agent, returning unspecified for resources
+// which are not relevant to the SAP system topology.
+func primitiveType(agent string) spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_ClusterResourceType {
+	if strings.HasPrefix(agent, "stonith:") {
+		return spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_FENCING
+	}
+	switch agent[strings.LastIndex(agent, ":")+1:] {
+	case "SAPInstance":
+		return spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_SAP_INSTANCE
+	case "SAPHana", "SAPHanaController":
+		return spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_SAP_HANA
+	case "SAPHanaTopology":
+		return spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_SAP_HANA_TOPOLOGY
+	case "IPaddr2":
+		return spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_VIRTUAL_IP
 	}
-	return addrs, nil
+	return spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_CLUSTER_RESOURCE_TYPE_UNSPECIFIED
 }
 
 func (d *HostDiscovery) discoverFilestores(ctx context.Context) []string {
@@ -109,7 +323,7 @@ func (d *HostDiscovery) discoverFilestores(ctx context.Context) []string {
 		return nil
 	}
 
-	result := d.Execute(ctx, commandlineexecutor.Params{
+	result := d.execute(ctx, commandlineexecutor.Params{
 		Executable: "df",
 		Args:       []string{"-h"},
 	})
@@ -122,7 +336,8 @@ func (d *HostDiscovery) discoverFilestores(ctx context.Context) []string {
 		if len(matches) < 2 {
 			continue
 		}
-		// The first match is the fully matched string, we only need the first submatch, the IP address.
+		// The first match is the fully matched string, we only need the first submatch, the IP address
+		// or hostname. Hostnames are resolved during cloud discovery.
 		address := matches[1]
 		fs = append(fs, address)
 	}
@@ -21,11 +21,15 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	spb "github.com/GoogleCloudPlatform/sapagent/protos/system"
 )
 
 func TestMain(t *testing.M) {
@@ -34,25 +38,30 @@ func TestMain(t *testing.M) {
 }
 
 const (
-	defaultClusterOutput = `
-	line1
-	line2
-	rsc_vip_int-primary IPaddr2
-	anotherline
-	params ip=127.0.0.1 other text
-	line3
-	line4
-	`
-	defaultMultiClusterOutput = `
-	line1
-	line2
-	rsc_vip_int-primary IPaddr2
-	anotherline
-	params ip=127.0.0.1 other text
-	line3
-	params ip=127.0.0.2 other text
-	line4
-	`
+	defaultClusterOutput = `node 1: node-1
+primitive rsc_vip_int-primary IPaddr2 \\
+	params ip=127.0.0.1 cidr_netmask=32 \\
+	op monitor interval=3600s timeout=60s
+primitive rsc_vip_hc-primary anything \\
+	params binfile="/usr/bin/socat"
+`
+	defaultMultiClusterOutput = `node 1: node-1
+primitive rsc_vip_int-primary IPaddr2 \\
+	params ip=127.0.0.1 cidr_netmask=32 \\
+	op monitor interval=3600s timeout=60s
+primitive rsc_vip_int-secondary ocf:heartbeat:IPaddr2 \\
+	params cidr_netmask=32 ip=127.0.0.2
+primitive rsc_dummy anything \\
+	params ip=127.0.0.9
+`
+	defaultPCSClusterOutput = `Cluster Name: hacluster
+Resources:
+ Group: g-primary
+  Resource: rsc_vip_HA1_00 (class=ocf provider=heartbeat type=IPaddr2)
+   Attributes: cidr_netmask=32 ip=127.0.0.1
+   Operations: monitor interval=10s (rsc_vip_HA1_00-monitor-interval-10s)
+  Resource: rsc_healthcheck_HA1 (class=service type=haproxy@HA1)
+`
 	defaultFilestoreOutput = `
 Filesystem                        Size  Used Avail Use% Mounted on
 udev                               48G     0   48G   0% /dev
@@ -62,141 +71,92 @@ tmpfs                              48G  2.0M   48G   1% /dev/shm
 	`
 )
 
-func TestDiscoverClustersCRM(t *testing.T) {
+func TestParseCRMClusterAddresses(t *testing.T) {
 	tests := []struct {
-		name        string
-		testExecute commandlineexecutor.Execute
-		wantAddrs   []string
-		wantErr     error
+		name   string
+		config string
+		want   []string
 	}{{
-		name: "CRM Success",
-		testExecute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-			return commandlineexecutor.Result{
-				StdOut: defaultClusterOutput,
-				StdErr: "",
-			}
-		},
-		wantAddrs: []string{"127.0.0.1"},
-		wantErr:   nil,
+		name:   "SingleAddress",
+		config: defaultClusterOutput,
+		want:   []string{"127.0.0.1"},
 	}, {
-		name: "CRM Success MultipleAddresses",
-		testExecute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-			return commandlineexecutor.Result{
-				StdOut: defaultMultiClusterOutput,
-				StdErr: "",
-			}
-		},
-		wantAddrs: []string{"127.0.0.1", "127.0.0.2"},
-		wantErr:   nil,
+		name:   "MultipleAddresses",
+		config: defaultMultiClusterOutput,
+		want:   []string{"127.0.0.1", "127.0.0.2"},
 	}, {
-		name: "CRM execute error",
-		testExecute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-			return commandlineexecutor.Result{
-				StdOut: "",
-				StdErr: "error",
-				Error:  errors.New("exit status 1"),
-			}
-		},
-		wantErr: cmpopts.AnyError,
+		name:   "SingleLinePrimitive",
+		config: "primitive rsc_vip_HA1 ocf:heartbeat:IPaddr2 params ip=127.0.0.3",
+		want:   []string{"127.0.0.3"},
 	}, {
-		name: "CRM no params ip",
-		testExecute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-			return commandlineexecutor.Result{
-				StdOut: "rsc_cip_int-primary IPAddr2",
-				StdErr: "",
-			}
-		},
+		name: "NoIPParam",
+		config: `primitive rsc_vip_int-primary IPaddr2 \\
+	params cidr_netmask=32
+`,
 	}, {
-		name: "CRM no output",
-		testExecute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-			return commandlineexecutor.Result{
-				StdOut: "",
-				StdErr: "",
-			}
-		},
-	},
-	}
+		name: "NonVIPResource",
+		config: `primitive rsc_dummy anything \\
+	params ip=127.0.0.9
+`,
+	}, {
+		name: "NoOutput",
+	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			d := HostDiscovery{
-				Execute: test.testExecute,
-			}
-			got, err := d.discoverClustersCRM(context.Background())
-			if diff := cmp.Diff(got, test.wantAddrs); diff != "" {
-				t.Errorf("discoverClustersCRM mismatch (-want, +got):\n%s", diff)
-			}
-			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
-				t.Error("discoverClustersCRM error mismatch")
+			got := parseCRMClusterAddresses(test.config)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("parseCRMClusterAddresses() mismatch (-want, +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestDiscoverClustersPCS(t *testing.T) {
+func TestParsePCSClusterAddresses(t *testing.T) {
 	tests := []struct {
-		name        string
-		testExecute commandlineexecutor.Execute
-		wantAddrs   []string
-		wantErr     error
+		name   string
+		config string
+		want   []string
 	}{{
-		name: "PCS Success",
-		testExecute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-			return commandlineexecutor.Result{
-				StdOut: defaultClusterOutput,
-				StdErr: "",
-			}
-		},
-		wantAddrs: []string{"127.0.0.1"},
-		wantErr:   nil,
+		name:   "SingleLineAttributes",
+		config: defaultPCSClusterOutput,
+		want:   []string{"127.0.0.1"},
 	}, {
-		name: "PCS Multi Cluster Success",
-		testExecute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-			return commandlineexecutor.Result{
-				StdOut: defaultMultiClusterOutput,
-				StdErr: "",
-			}
-		},
-		wantAddrs: []string{"127.0.0.1", "127.0.0.2"},
-		wantErr:   nil,
+		name: "AttributePerLine",
+		config: `Resources:
+  Group: g-primary
+    Resource: rsc_vip_HA1_00 (class=ocf provider=heartbeat type=IPaddr2)
+      Attributes: rsc_vip_HA1_00-instance_attributes
+        cidr_netmask=32
+        ip=127.0.0.1
+      Operations:
+        monitor: rsc_vip_HA1_00-monitor-interval-10s
+          interval=10s
+    Resource: rsc_vip_HA1_01 (class=ocf provider=heartbeat type=IPaddr2)
+      Attributes: rsc_vip_HA1_01-instance_attributes
+        ip=127.0.0.2
+`,
+		want: []string{"127.0.0.1", "127.0.0.2"},
 	}, {
-		name: "PCS execute error",
-		testExecute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-			return commandlineexecutor.Result{
-				StdOut: "",
-				StdErr: "error",
-				Error:  errors.New("exit status 1"),
-			}
-		},
-		wantErr: cmpopts.AnyError,
+		name: "NonVIPResource",
+		config: ` Resource: rsc_dummy (class=ocf provider=heartbeat type=anything)
+  Attributes: ip=127.0.0.9
+`,
 	}, {
-		name: "PCS no params ip",
-		testExecute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-			return commandlineexecutor.Result{
-				StdOut: "rsc_cip_int-primary IPAddr2",
-				StdErr: "",
-			}
-		},
+		name: "NoIPAttribute",
+		config: ` Resource: rsc_vip_HA1_00 (class=ocf provider=heartbeat type=IPaddr2)
+  Attributes: cidr_netmask=32
+  Operations: monitor interval=10s (rsc_vip_HA1_00-monitor-interval-10s)
+ Resource: rsc_dummy (class=ocf provider=heartbeat type=anything)
+  Attributes: ip=127.0.0.9
+`,
 	}, {
-		name: "PCS no output",
-		testExecute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-			return commandlineexecutor.Result{
-				StdOut: "",
-				StdErr: "",
-			}
-		},
-	},
-	}
+		name: "NoOutput",
+	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			d := HostDiscovery{
-				Execute: test.testExecute,
-			}
-			got, err := d.discoverClustersPCS(context.Background())
-			if diff := cmp.Diff(got, test.wantAddrs); diff != "" {
-				t.Errorf("discoverClustersPCS mismatch (-want, +got):\n%s", diff)
-			}
-			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
-				t.Error("discoverClustersPCS error mismatch")
+			got := parsePCSClusterAddresses(test.config)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("parsePCSClusterAddresses() mismatch (-want, +got):\n%s", diff)
 			}
 		})
 	}
@@ -204,11 +164,12 @@ func TestDiscoverClustersPCS(t *testing.T) {
 
 func TestDiscoverClusterAddresses(t *testing.T) {
 	tests := []struct {
-		name        string
-		testExists  commandlineexecutor.Exists
-		testExecute commandlineexecutor.Execute
-		wantAddrs   []string
-		wantErr     error
+		name           string
+		testExists     commandlineexecutor.Exists
+		testExecute    commandlineexecutor.Execute
+		commandTimeout time.Duration
+		wantAddrs      []string
+		wantErr        error
 	}{{
 		name:       "Address from CRM",
 		testExists: func(cmd string) bool { return cmd == "crm" },
@@ -239,7 +200,7 @@ func TestDiscoverClusterAddresses(t *testing.T) {
 				}
 			}
 			return commandlineexecutor.Result{
-				StdOut: defaultClusterOutput,
+				StdOut: defaultPCSClusterOutput,
 				StdErr: "",
 			}
 		},
@@ -291,12 +252,23 @@ func TestDiscoverClusterAddresses(t *testing.T) {
 			}
 		},
 		wantErr: cmpopts.AnyError,
+	}, {
+		name:       "CRM command hangs",
+		testExists: func(cmd string) bool { return cmd == "crm" },
+		testExecute: func(ctx context.Context, _ commandlineexecutor.Params) commandlineexecutor.Result {
+			<-ctx.Done()
+			time.Sleep(time.Second)
+			return commandlineexecutor.Result{StdOut: defaultClusterOutput}
+		},
+		commandTimeout: 10 * time.Millisecond,
+		wantErr:        context.DeadlineExceeded,
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			d := HostDiscovery{
-				Exists:  test.testExists,
-				Execute: test.testExecute,
+				Exists:         test.testExists,
+				Execute:        test.testExecute,
+				CommandTimeout: test.commandTimeout,
 			}
 			got, err := d.discoverClusterAddresses(context.Background())
 			if diff := cmp.Diff(got, test.wantAddrs); diff != "" {
@@ -339,6 +311,21 @@ tmpfs                              48G  2.0M   48G   1% /dev/shm`,
 			}
 		},
 		want: []string{"1.2.3.4", "5.6.7.8"},
+	}, {
+		name:   "NFS by hostname",
+		exists: func(cmd string) bool { return true },
+		execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+			return commandlineexecutor.Result{
+				StdOut: `
+Filesystem                        Size  Used Avail Use% Mounted on
+filestore.example.com:/vol          8G     0    8G   0% /vol
+filestore-2:/vol2                   8G     0    8G   0% /vol2
+1.2.3.4:/vol3                       8G     0    8G   0% /vol3
+tmpfs                              48G  2.0M   48G   1% /dev/shm`,
+				StdErr: "",
+			}
+		},
+		want: []string{"filestore.example.com", "filestore-2", "1.2.3.4"},
 	}, {
 		name:   "df does not exist",
 		exists: func(cmd string) bool { return false },
@@ -474,3 +461,112 @@ func TestDiscoverCurrentHost(t *testing.T) {
 		})
 	}
 }
+
+const (
+	crmClusterResourcesOutput = `node 1: node-1
+primitive STONITH-node-1 stonith:fence_gce \
+	params port=node-1 zone="us-central1-a" project=test-project
+primitive rsc_SAPHanaTopology_HA1_HDB00 ocf:suse:SAPHanaTopology \
+	params SID=HA1 InstanceNumber=00
+primitive rsc_SAPHana_HA1_HDB00 ocf:suse:SAPHana \
+	params SID=HA1 InstanceNumber=00
+primitive rsc_sap_HA1_ASCS10 SAPInstance \
+	params InstanceName=HA1_ASCS10_alidascs
+primitive rsc_vip_int-primary IPaddr2 \
+	params ip=127.0.0.1 cidr_netmask=32
+primitive rsc_vip_hc-primary anything \
+	params binfile="/usr/bin/socat"
+location LOC_STONITH_node-1 STONITH-node-1 -inf: node-1
+colocation col_saphana_ip_HA1_HDB00 4000: g-primary:Started msl_SAPHana_HA1_HDB00:Master
+order ord_SAPHana_HA1_HDB00 Optional: cln_SAPHanaTopology_HA1_HDB00 msl_SAPHana_HA1_HDB00
+`
+	pcsClusterResourcesOutput = `Cluster Name: hacluster
+Resources:
+ Resource: rsc_vip_int-primary (class=ocf provider=heartbeat type=IPaddr2)
+  Attributes: cidr_netmask=32 ip=127.0.0.1
+ Resource: rsc_healthcheck_primary (class=service type=haproxy@primary)
+ Clone: SAPHanaTopology_HA1_00-clone
+  Resource: SAPHanaTopology_HA1_00 (class=ocf provider=heartbeat type=SAPHanaTopology)
+ Clone: SAPHana_HA1_00-clone
+  Resource: SAPHana_HA1_00 (class=ocf provider=heartbeat type=SAPHana)
+
+Stonith Devices:
+ Resource: STONITH-node-1 (class=stonith type=fence_gce)
+  Attributes: port=node-1 project=test-project zone=us-central1-a
+Fencing Levels:
+
+Location Constraints:
+  Resource: STONITH-node-1
+    Disabled on: node-1 (score:-INFINITY) (id:location-STONITH-node-1-node-1--INFINITY)
+Ordering Constraints:
+  start SAPHanaTopology_HA1_00-clone then start SAPHana_HA1_00-clone (kind:Mandatory) (non-symmetrical) (id:order-SAPHanaTopology_HA1_00-clone-SAPHana_HA1_00-clone-mandatory)
+Colocation Constraints:
+  g-primary with SAPHana_HA1_00-clone (score:4000) (rsc-role:Started) (with-rsc-role:Promoted) (id: colocation-g-primary-SAPHana_HA1_00-clone-4000)
+
+Resources Defaults:
+  Meta Attrs: rsc_defaults-meta_attributes
+    resource-stickiness=1000
+`
+)
+
+func TestDiscoverClusterResources(t *testing.T) {
+	tests := []struct {
+		name    string
+		exists  commandlineexecutor.Exists
+		execute commandlineexecutor.Execute
+		want    []*clusterResource
+	}{{
+		name:   "CRM",
+		exists: func(cmd string) bool { return cmd == "crm" },
+		execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+			return commandlineexecutor.Result{StdOut: crmClusterResourcesOutput}
+		},
+		want: []*clusterResource{
+			{Name: "STONITH-node-1", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_FENCING, Agent: "stonith:fence_gce"},
+			{Name: "rsc_SAPHanaTopology_HA1_HDB00", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_SAP_HANA_TOPOLOGY, Agent: "ocf:suse:SAPHanaTopology"},
+			{Name: "rsc_SAPHana_HA1_HDB00", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_SAP_HANA, Agent: "ocf:suse:SAPHana"},
+			{Name: "rsc_sap_HA1_ASCS10", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_SAP_INSTANCE, Agent: "SAPInstance"},
+			{Name: "rsc_vip_int-primary", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_VIRTUAL_IP, Agent: "IPaddr2"},
+			{Name: "LOC_STONITH_node-1", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_CONSTRAINT, Agent: "location"},
+			{Name: "col_saphana_ip_HA1_HDB00", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_CONSTRAINT, Agent: "colocation"},
+			{Name: "ord_SAPHana_HA1_HDB00", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_CONSTRAINT, Agent: "order"},
+		},
+	}, {
+		name:   "PCS",
+		exists: func(cmd string) bool { return cmd == "pcs" },
+		execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+			return commandlineexecutor.Result{StdOut: pcsClusterResourcesOutput}
+		},
+		want: []*clusterResource{
+			{Name: "rsc_vip_int-primary", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_VIRTUAL_IP, Agent: "ocf:heartbeat:IPaddr2"},
+			{Name: "SAPHanaTopology_HA1_00", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_SAP_HANA_TOPOLOGY, Agent: "ocf:heartbeat:SAPHanaTopology"},
+			{Name: "SAPHana_HA1_00", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_SAP_HANA, Agent: "ocf:heartbeat:SAPHana"},
+			{Name: "STONITH-node-1", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_FENCING, Agent: "stonith:fence_gce"},
+			{Name: "location-STONITH-node-1-node-1--INFINITY", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_CONSTRAINT, Agent: "location"},
+			{Name: "order-SAPHanaTopology_HA1_00-clone-SAPHana_HA1_00-clone-mandatory", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_CONSTRAINT, Agent: "ordering"},
+			{Name: "colocation-g-primary-SAPHana_HA1_00-clone-4000", Type: spb.SapDiscovery_Resource_InstanceProperties_ClusterResource_CONSTRAINT, Agent: "colocation"},
+		},
+	}, {
+		name:   "NoClusterCommand",
+		exists: func(string) bool { return false },
+	}, {
+		name:   "CommandError",
+		exists: func(string) bool { return true },
+		execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+			return commandlineexecutor.Result{StdErr: "error", Error: errors.New("exit status 1")}
+		},
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := HostDiscovery{
+				Exists:  tc.exists,
+				Execute: tc.execute,
+			}
+			got := d.DiscoverClusterResources(context.Background())
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("DiscoverClusterResources() returned an unexpected diff (-want +got): %v", diff)
+			}
+		})
+	}
+}
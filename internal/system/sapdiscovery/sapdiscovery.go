@@ -19,13 +19,18 @@ package sapdiscovery
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	backoff "github.com/cenkalti/backoff/v4"
 	"google.golang.org/protobuf/encoding/prototext"
 	"github.com/GoogleCloudPlatform/sapagent/internal/pacemaker"
+	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 
@@ -60,6 +65,14 @@ var (
 	// is parsed as "/usr/sap/DEV/SYS/profile/DEV_ASCS01_dnwh75ldbci".
 	sapServicesProfilePattern = regexp.MustCompile(`pf=(\S*)?`)
 
+	// sapServicesNotFoundLogged makes sure the usage metric for a host without
+	// /usr/sap/sapservices is logged once rather than on every discovery.
+	sapServicesNotFoundLogged sync.Once
+
+	// clusterStatusUnknownLogged makes sure the usage metric for a host whose pacemaker data
+	// cannot be read is logged once rather than on every discovery.
+	clusterStatusUnknownLogged sync.Once
+
 	// libraryPathPattern captures the LD_LIBRARY_PATH from /usr/sap/sapservices.
 	// Example: "LD_LIBRARY_PATH=/usr/sap/DEV/ASCS01/exe:$LD_LIBRARY_PATH;export LD_LIBRARY_PATH" is parsed as
 	// "/usr/sap/DEV/ASCS01/exe".
@@ -78,6 +91,13 @@ var (
 		15: "Active: Initialization or sync with the primary is complete and the secondary is continuously replicating. No data loss will occur in SYNC mode.",
 	}
 
+	// replicationStatusOverrides holds the configured HDBSettings.sh path and systemReplicationStatus.py
+	// invocation, the defaults are used when they are empty.
+	replicationStatusOverrides struct {
+		sync.RWMutex
+		settingsPath, statusCommand string
+	}
+
 	siteMapPattern = regexp.MustCompile(`((\s+\|)*)(---)?([a-zA-Z0-9_\-]+)\s\([^\)]+\)`)
 	depthPattern   = regexp.MustCompile(`\s+\|`)
 	modePattern    = regexp.MustCompile(`mode: (primary|syncmem|async|sync)\n`)
@@ -102,15 +122,28 @@ type (
 //	Returns a sapb.SAPInstances which is an array of SAP instances running on the given machine.
 func SAPApplications(ctx context.Context) *sapb.SAPInstances {
 	data, err := pacemaker.Data(ctx)
-	if err != nil {
-		// could not collect data from crm_mon
-		log.CtxLogger(ctx).Debugw("Failure in reading crm_mon data from pacemaker", "err", err)
-	}
-	return instances(ctx, HANAReplicationConfig, listSAPInstances, commandlineexecutor.ExecuteCommand, data)
+	return instances(ctx, HANAReplicationConfig, listSAPInstances, commandlineexecutor.ExecuteCommand, clusterMember(ctx, data, err))
+}
+
+// clusterMember reports whether the instance is a member of a pacemaker cluster, given the
+// crm_mon data and the error reading it. A host without crm_mon is not a cluster member. When
+// crm_mon is installed but its data cannot be read the cluster status is unknown, which is logged
+// distinctly and reported as not a cluster member.
+func clusterMember(ctx context.Context, crmdata *pacemaker.CRMMon, err error) bool {
+	switch {
+	case errors.Is(err, pacemaker.ErrCRMMonNotFound):
+		log.CtxLogger(ctx).Debug("crm_mon is not installed, the instance is not a Linux cluster member")
+		return false
+	case err != nil:
+		log.CtxLogger(ctx).Warnw("Linux cluster status is unknown, failure in reading crm_mon data from pacemaker. The instance is reported as not a cluster member", "error", err)
+		clusterStatusUnknownLogged.Do(func() { usagemetrics.Error(usagemetrics.PacemakerDataUnavailable) })
+		return false
+	}
+	return pacemaker.Enabled(ctx, crmdata)
 }
 
 // instances is a testable version of SAPApplications.
-func instances(ctx context.Context, hrc ReplicationConfig, list listInstances, exec commandlineexecutor.Execute, crmdata *pacemaker.CRMMon) *sapb.SAPInstances {
+func instances(ctx context.Context, hrc ReplicationConfig, list listInstances, exec commandlineexecutor.Execute, linuxClusterMember bool) *sapb.SAPInstances {
 	log.CtxLogger(ctx).Debug("Discovering SAP Applications.")
 	var sapInstances []*sapb.SAPInstance
 
@@ -130,7 +163,7 @@ func instances(ctx context.Context, hrc ReplicationConfig, list listInstances, e
 
 	return &sapb.SAPInstances{
 		Instances:          sapInstances,
-		LinuxClusterMember: pacemaker.Enabled(ctx, crmdata),
+		LinuxClusterMember: linuxClusterMember,
 	}
 }
 
@@ -196,18 +229,49 @@ func HANAReplicationConfig(ctx context.Context, user, sid, instID string) (site
 	return readReplicationConfig(ctx, user, sid, instID, commandlineexecutor.ExecuteCommand)
 }
 
+// SetReplicationStatusCommand overrides the HDBSettings.sh path and the systemReplicationStatus.py
+// invocation used to read the HANA replication status, for installations where they are not under
+// /usr/sap/<SID>/<instance>. The placeholders {SID} and {INSTANCE} are replaced by the SID and
+// instance of the HANA system. Empty values keep the defaults.
+func SetReplicationStatusCommand(settingsPath, statusCommand string) {
+	replicationStatusOverrides.Lock()
+	defer replicationStatusOverrides.Unlock()
+	replicationStatusOverrides.settingsPath = settingsPath
+	replicationStatusOverrides.statusCommand = statusCommand
+}
+
+// replicationStatusArgs returns the sudo arguments running systemReplicationStatus.py for the
+// HANA instance, using the overrides set by SetReplicationStatusCommand.
+func replicationStatusArgs(sid, instID string) string {
+	replicationStatusOverrides.RLock()
+	settingsPath := replicationStatusOverrides.settingsPath
+	statusCommand := replicationStatusOverrides.statusCommand
+	replicationStatusOverrides.RUnlock()
+
+	if settingsPath == "" {
+		settingsPath = "/usr/sap/{SID}/{INSTANCE}/HDBSettings.sh"
+	}
+	if statusCommand == "" {
+		statusCommand = "/usr/sap/{SID}/{INSTANCE}/exe/python_support/systemReplicationStatus.py --sapcontrol=1"
+	}
+	r := strings.NewReplacer("{SID}", sid, "{INSTANCE}", instID)
+	return fmt.Sprintf("-i -u %sadm %s %s", strings.ToLower(sid), r.Replace(settingsPath), r.Replace(statusCommand))
+}
+
 // readReplicationConfig is a testable version of HANAReplicationConfig.
 func readReplicationConfig(ctx context.Context, user, sid, instID string, exec commandlineexecutor.Execute) (mode int, HAMembers []string, exitStatus int64, replicationSites *sapb.HANAReplicaSite, err error) {
 	cmd := "sudo"
-	args := "" +
-		fmt.Sprintf("-i -u %sadm /usr/sap/%s/%s/HDBSettings.sh ", strings.ToLower(sid), sid, instID) +
-		fmt.Sprintf(" /usr/sap/%s/%s/exe/python_support/systemReplicationStatus.py --sapcontrol=1", sid, instID)
+	args := replicationStatusArgs(sid, instID)
+	log.CtxLogger(ctx).Debugw("Running systemReplicationStatus.py", "command", cmd+" "+args)
 	result := exec(ctx, commandlineexecutor.Params{
 		Executable:  cmd,
 		ArgsToSplit: args,
 	})
 	exitStatus = int64(result.ExitCode)
 	log.CtxLogger(ctx).Debugw("systemReplicationStatus.py returned", "result", result)
+	if _, ok := systemReplicationStatus[exitStatus]; !ok {
+		log.CtxLogger(ctx).Infow("systemReplicationStatus.py returned an invalid return code, set hana_settings_path and hana_replication_status_command if HANA is not installed under /usr/sap", "command", cmd+" "+args, "exitstatus", exitStatus, "stderr", result.StdErr)
+	}
 
 	cmd = "sudo"
 	args = fmt.Sprintf("-i -u %sadm hdbnsutil -sr_state", strings.ToLower(sid))
@@ -360,8 +424,15 @@ func HANASite(mode int) sapb.InstanceSite {
 	return sapb.InstanceSite_INSTANCE_SITE_UNDEFINED
 }
 
+// sapServicesMissing reports whether grep failed because /usr/sap/sapservices does not exist,
+// grep exits with status 2 when a file cannot be read.
+func sapServicesMissing(result commandlineexecutor.Result) bool {
+	return result.ExitCode == 2 && strings.Contains(result.StdErr, "No such file or directory")
+}
+
 // listSAPInstances returns list of SAP Instances present on the machine.
-// The list is derived from '/usr/sap/sapservices' file.
+// The list is derived from '/usr/sap/sapservices' file, an empty list is returned
+// without an error when the file does not exist as no SAP is installed on the machine.
 func listSAPInstances(ctx context.Context, exec commandlineexecutor.Execute) ([]*instanceInfo, error) {
 	var sapServicesEntries []*instanceInfo
 	result := exec(ctx, commandlineexecutor.Params{
@@ -369,6 +440,11 @@ func listSAPInstances(ctx context.Context, exec commandlineexecutor.Execute) ([]
 		ArgsToSplit: "'pf=' /usr/sap/sapservices",
 	})
 	log.CtxLogger(ctx).Debugw("`grep 'pf=' /usr/sap/sapservices` returned", "stdout", result.StdOut, "stderr", result.StdErr, "error", result.Error)
+	if sapServicesMissing(result) {
+		log.CtxLogger(ctx).Info("No SAP installed on this host, /usr/sap/sapservices does not exist")
+		sapServicesNotFoundLogged.Do(func() { usagemetrics.Action(usagemetrics.SAPServicesNotFound) })
+		return nil, nil
+	}
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -566,9 +642,9 @@ func buildURLAndServiceName(instanceName, HTTPPort string) (url, serviceName str
 	return url, serviceName, nil
 }
 
-// sapInitRunning returns a bool indicating if sapinit is running.
+// SAPInitRunning returns a bool indicating if sapinit is running.
 // Returns an error in case of failures.
-func sapInitRunning(ctx context.Context, exec commandlineexecutor.Execute) (bool, error) {
+func SAPInitRunning(ctx context.Context, exec commandlineexecutor.Execute) (bool, error) {
 	result := exec(ctx, commandlineexecutor.Params{
 		Executable: "/usr/sap/hostctrl/exe/sapinit",
 		Args:       []string{"status"},
@@ -609,9 +685,39 @@ func ReadHANACredentials(ctx context.Context, projectID string, hanaConfig *cpb.
 		return user, hanaConfig.GetHanaDbPassword(), "", nil
 	}
 
-	password, err = gceService.GetSecret(ctx, projectID, hanaConfig.GetHanaDbPasswordSecretName())
+	password, err = getSecretWithRetry(ctx, projectID, hanaConfig.GetHanaDbPasswordSecretName(), gceService, secretRetryPolicy())
 	if err != nil {
 		return "", "", "", err
 	}
 	return user, password, "", nil
 }
+
+// secretRetryPolicy bounds the attempts to read a secret so that a transient Secret Manager
+// outage at startup does not permanently disable the metrics which depend on the secret.
+var secretRetryPolicy = func() backoff.BackOff {
+	exp := backoff.NewExponentialBackOff()
+	exp.InitialInterval = 2 * time.Second
+	exp.MaxInterval = 30 * time.Second
+	return backoff.WithMaxRetries(exp, 4) // 4 retries (5 total attempts)
+}
+
+// getSecretWithRetry reads the secret from Secret Manager, retrying failures as per the policy.
+func getSecretWithRetry(ctx context.Context, projectID, secretName string, gceService GCEInterface, bo backoff.BackOff) (string, error) {
+	var secret string
+	attempt := 1
+	err := backoff.Retry(func() error {
+		var err error
+		if secret, err = gceService.GetSecret(ctx, projectID, secretName); err != nil {
+			log.CtxLogger(ctx).Infow("Failed to read secret from Secret Manager", "secretName", secretName, "attempt", attempt, "error", err)
+			attempt++
+			return err
+		}
+		return nil
+	}, backoff.WithContext(bo, ctx))
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Could not read secret from Secret Manager", "secretName", secretName, "attempts", attempt-1, "error", err)
+		return "", err
+	}
+	log.CtxLogger(ctx).Debugw("Read secret from Secret Manager", "secretName", secretName, "attempts", attempt)
+	return secret, nil
+}
@@ -25,9 +25,11 @@ import (
 	"strings"
 	"testing"
 
+	backoff "github.com/cenkalti/backoff/v4"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/sapagent/internal/pacemaker"
 	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/sapagent/shared/gce/fake"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
@@ -38,6 +40,9 @@ import (
 
 func TestMain(t *testing.M) {
 	log.SetupLoggingForTest()
+	secretRetryPolicy = func() backoff.BackOff {
+		return backoff.WithMaxRetries(&backoff.ZeroBackOff{}, 2)
+	}
 	os.Exit(t.Run())
 }
 
@@ -313,7 +318,7 @@ func TestInstances(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := instances(context.Background(), test.fakeReplicationConfig, test.fakeList, test.fakeExec, nil)
+			got := instances(context.Background(), test.fakeReplicationConfig, test.fakeList, test.fakeExec, false)
 			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
 				t.Errorf("instances() unexpected diff: (-want +got):\n%s", diff)
 			}
@@ -321,6 +326,43 @@ func TestInstances(t *testing.T) {
 	}
 }
 
+func TestClusterMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		crmdata *pacemaker.CRMMon
+		err     error
+		want    bool
+	}{
+		{
+			name:    "ClusterMember",
+			crmdata: &pacemaker.CRMMon{Nodes: []pacemaker.CRMNode{{Name: "test-node-1"}, {Name: "test-node-2"}}},
+			want:    true,
+		},
+		{
+			name:    "ZeroNodes",
+			crmdata: &pacemaker.CRMMon{},
+		},
+		{
+			name: "CRMMonNotFound",
+			err:  pacemaker.ErrCRMMonNotFound,
+		},
+		{
+			name: "NilDataWithError",
+			err:  errors.New("crm_mon failed"),
+		},
+		{
+			name: "NilDataWithoutError",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := clusterMember(context.Background(), test.crmdata, test.err); got != test.want {
+				t.Errorf("clusterMember(%v, %v) = %t, want %t", test.crmdata, test.err, got, test.want)
+			}
+		})
+	}
+}
+
 func TestReadReplicationConfig(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -715,6 +757,39 @@ func TestReadReplicationConfig(t *testing.T) {
 	}
 }
 
+func TestReplicationStatusArgs(t *testing.T) {
+	tests := []struct {
+		name          string
+		settingsPath  string
+		statusCommand string
+		want          string
+	}{
+		{
+			name: "Defaults",
+			want: "-i -u hdbadm /usr/sap/HDB/HDB00/HDBSettings.sh /usr/sap/HDB/HDB00/exe/python_support/systemReplicationStatus.py --sapcontrol=1",
+		},
+		{
+			name:         "SettingsPathOverride",
+			settingsPath: "/hana/shared/{SID}/{INSTANCE}/HDBSettings.sh",
+			want:         "-i -u hdbadm /hana/shared/HDB/HDB00/HDBSettings.sh /usr/sap/HDB/HDB00/exe/python_support/systemReplicationStatus.py --sapcontrol=1",
+		},
+		{
+			name:          "StatusCommandOverride",
+			statusCommand: "/opt/hana/systemReplicationStatus.py --sapcontrol=1 --localhost",
+			want:          "-i -u hdbadm /usr/sap/HDB/HDB00/HDBSettings.sh /opt/hana/systemReplicationStatus.py --sapcontrol=1 --localhost",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			SetReplicationStatusCommand(test.settingsPath, test.statusCommand)
+			defer SetReplicationStatusCommand("", "")
+			if got := replicationStatusArgs("HDB", "HDB00"); got != test.want {
+				t.Errorf("replicationStatusArgs(%q, %q) = %q, want %q", "HDB", "HDB00", got, test.want)
+			}
+		})
+	}
+}
+
 func TestListSAPInstances(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -801,6 +876,25 @@ func TestListSAPInstances(t *testing.T) {
 			}
 		},
 		wantErr: cmpopts.AnyError,
+	}, {
+		name: "SapservicesMissing",
+		fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+			return commandlineexecutor.Result{
+				StdErr:   "grep: /usr/sap/sapservices: No such file or directory",
+				ExitCode: 2,
+				Error:    cmpopts.AnyError,
+			}
+		},
+	}, {
+		name: "SapservicesUnreadable",
+		fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+			return commandlineexecutor.Result{
+				StdErr:   "grep: /usr/sap/sapservices: Permission denied",
+				ExitCode: 2,
+				Error:    cmpopts.AnyError,
+			}
+		},
+		wantErr: cmpopts.AnyError,
 	}, {
 		name: "InvalidSapservicesEntry",
 		fakeExec: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
@@ -1044,13 +1138,13 @@ func TestSAPInitRunning(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got, gotErr := sapInitRunning(context.Background(), test.fakeExec)
+			got, gotErr := SAPInitRunning(context.Background(), test.fakeExec)
 
 			if !cmp.Equal(gotErr, test.wantErr, cmpopts.EquateErrors()) {
-				t.Errorf("Unexpected return from sapInitRunning(), got(%v), want(%v)", got, test.wantErr)
+				t.Errorf("Unexpected return from SAPInitRunning(), got(%v), want(%v)", got, test.wantErr)
 			}
 			if got != test.want {
-				t.Errorf("sapInitRunning() = %t, want %t", got, test.want)
+				t.Errorf("SAPInitRunning() = %t, want %t", got, test.want)
 			}
 		})
 	}
@@ -1272,6 +1366,19 @@ func TestReadHANACredentials(t *testing.T) {
 			},
 			wantErr: cmpopts.AnyError,
 		},
+		{
+			name: "SecretManagerTransientError",
+			hanaConfig: &cpb.HANAMetricsConfig{
+				HanaDbUser:               "hdbadm",
+				HanaDbPasswordSecretName: "TESTSECRET",
+			},
+			gceService: &fake.TestGCE{
+				GetSecretResp: []string{"", "Dummy"},
+				GetSecretErr:  []error{errors.New("error"), nil},
+			},
+			wantUser:     "hdbadm",
+			wantPassword: "Dummy",
+		},
 		{
 			name: "HDBUserstoreKeyinConfigFile",
 			hanaConfig: &cpb.HANAMetricsConfig{
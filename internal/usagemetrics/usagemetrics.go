@@ -118,6 +118,9 @@ const (
 	GCBDRBackupFailure                             = 77 //	GCBDRBackupFailure
 	GCBDRDiscoveryFailure                          = 78 //	GCBDRDiscoveryFailure
 	HANAInsightsOTEFailure                         = 79 //	HANAInsightsOTEFailure
+	XFSFreezeTimeoutFailure                        = 80 //	XFSFreezeTimeoutFailure
+	ReloadSecretsFailure                           = 81 //	ReloadSecretsFailure
+	PacemakerDataUnavailable                       = 82 //	PacemakerDataUnavailable
 )
 
 // Agent wide action mappings - Only append the action codes at the end of the list.
@@ -205,6 +208,9 @@ const (
 	GCBDRDiscoveryFinished                  = 78 //	GCBDRDiscoveryFinished
 	HANAInsightsOTEStarted                  = 79 //	HANAInsightsOTEStarted
 	HANAInsightsOTEFinished                 = 80 //	HANAInsightsOTEFinished
+	SAPServicesNotFound                     = 81 //	SAPServicesNotFound
+	ReloadSecretsStarted                    = 82 //	ReloadSecretsStarted
+	ReloadSecretsFinished                   = 83 //	ReloadSecretsFinished
 )
 
 // LINT.ThenChange("//depot/github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics/usagemetrics_test.go")
@@ -259,6 +259,12 @@ func TestErrorConstants(t *testing.T) {
 	if HANAInsightsOTEFailure != 79 {
 		t.Errorf("HANAInsightsOTEFailure = %v, want 79", HANAInsightsOTEFailure)
 	}
+	if XFSFreezeTimeoutFailure != 80 {
+		t.Errorf("XFSFreezeTimeoutFailure = %v, want 80", XFSFreezeTimeoutFailure)
+	}
+	if ReloadSecretsFailure != 81 {
+		t.Errorf("ReloadSecretsFailure = %v, want 81", ReloadSecretsFailure)
+	}
 }
 
 func TestActionConstants(t *testing.T) {
@@ -505,4 +511,13 @@ func TestActionConstants(t *testing.T) {
 	if HANAInsightsOTEFinished != 80 {
 		t.Errorf("HANAInsightsOTEFinished = %v, want 80", HANAInsightsOTEFinished)
 	}
+	if SAPServicesNotFound != 81 {
+		t.Errorf("SAPServicesNotFound = %v, want 81", SAPServicesNotFound)
+	}
+	if ReloadSecretsStarted != 82 {
+		t.Errorf("ReloadSecretsStarted = %v, want 82", ReloadSecretsStarted)
+	}
+	if ReloadSecretsFinished != 83 {
+		t.Errorf("ReloadSecretsFinished = %v, want 83", ReloadSecretsFinished)
+	}
 }
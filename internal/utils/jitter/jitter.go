@@ -0,0 +1,61 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jitter computes startup delays which spread the metric collection loops of a fleet
+// of hosts over the collection interval.
+package jitter
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+// Delay returns a delay between 0 and fraction * interval.
+// The delay is derived from the seed, typically the instance ID, so a host always starts its
+// collection at the same offset. An empty seed yields a random delay.
+// A fraction outside of (0, 1] is clamped, 0 disables the delay.
+func Delay(seed string, interval time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || interval <= 0 {
+		return 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	r := rand.Float64()
+	if seed != "" {
+		h := fnv.New64a()
+		h.Write([]byte(seed))
+		r = rand.New(rand.NewSource(int64(h.Sum64()))).Float64()
+	}
+	return time.Duration(r * fraction * float64(interval))
+}
+
+// Wait blocks for the delay and returns true, or returns false as soon as the context is done.
+func Wait(ctx context.Context, delay time.Duration) bool {
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jitter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		seed     string
+		interval time.Duration
+		fraction float64
+		wantMax  time.Duration
+	}{
+		{
+			name:     "Disabled",
+			seed:     "123",
+			interval: time.Minute,
+			wantMax:  0,
+		},
+		{
+			name:     "NegativeFraction",
+			interval: time.Minute,
+			fraction: -1,
+			wantMax:  0,
+		},
+		{
+			name:     "HalfInterval",
+			seed:     "123",
+			interval: time.Minute,
+			fraction: 0.5,
+			wantMax:  30 * time.Second,
+		},
+		{
+			name:     "FractionClamped",
+			seed:     "123",
+			interval: time.Minute,
+			fraction: 5,
+			wantMax:  time.Minute,
+		},
+		{
+			name:     "NoSeed",
+			interval: time.Minute,
+			fraction: 1,
+			wantMax:  time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Delay(test.seed, test.interval, test.fraction)
+			if got < 0 || got > test.wantMax {
+				t.Errorf("Delay(%q, %v, %v)=%v, want between 0 and %v", test.seed, test.interval, test.fraction, got, test.wantMax)
+			}
+		})
+	}
+}
+
+func TestDelayIsDeterministic(t *testing.T) {
+	first := Delay("1234567890", time.Minute, 1)
+	for i := 0; i < 5; i++ {
+		if got := Delay("1234567890", time.Minute, 1); got != first {
+			t.Errorf("Delay() for the same seed=%v, want: %v", got, first)
+		}
+	}
+	if other := Delay("0987654321", time.Minute, 1); other == first {
+		t.Errorf("Delay() for different seeds both returned %v, want different delays", first)
+	}
+}
+
+func TestWait(t *testing.T) {
+	if got := Wait(context.Background(), time.Millisecond); !got {
+		t.Errorf("Wait(%v)=%v, want: true", time.Millisecond, got)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := Wait(ctx, time.Hour); got {
+		t.Errorf("Wait(cancelled, %v)=%v, want: false", time.Hour, got)
+	}
+	if got := Wait(ctx, 0); got {
+		t.Errorf("Wait(cancelled, 0)=%v, want: false", got)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdnotify
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNotify(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("net.ListenUnixgram(%s) returned error: %v", socket, err)
+	}
+	defer conn.Close()
+	t.Setenv("NOTIFY_SOCKET", socket)
+
+	sent, err := Notify(Ready)
+	if !sent || err != nil {
+		t.Fatalf("Notify(%q) = %t, %v, want: true, nil", Ready, sent, err)
+	}
+	buf := make([]byte, 64)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Read() returned error: %v", err)
+	}
+	if got := string(buf[:n]); got != Ready {
+		t.Errorf("Notify(%q) sent %q, want: %q", Ready, got, Ready)
+	}
+}
+
+func TestNotifyNoSocket(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	if sent, err := Notify(Ready); sent || err != nil {
+		t.Errorf("Notify(%q) = %t, %v, want: false, nil", Ready, sent, err)
+	}
+}
+
+func TestNotifyMissingSocket(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", filepath.Join(t.TempDir(), "missing.sock"))
+	if _, err := Notify(Ready); err == nil {
+		t.Errorf("Notify(%q) succeeded for a missing socket, want error", Ready)
+	}
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	tests := []struct {
+		name   string
+		usec   string
+		pid    string
+		want   time.Duration
+		wantOK bool
+	}{
+		{
+			name:   "Enabled",
+			usec:   "30000000",
+			want:   30 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "EnabledForThisProcess",
+			usec:   "1000",
+			pid:    strconv.Itoa(os.Getpid()),
+			want:   time.Millisecond,
+			wantOK: true,
+		},
+		{
+			name: "OtherProcess",
+			usec: "1000",
+			pid:  "1",
+		},
+		{
+			name: "Disabled",
+		},
+		{
+			name: "Invalid",
+			usec: "soon",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("WATCHDOG_USEC", tc.usec)
+			t.Setenv("WATCHDOG_PID", tc.pid)
+			got, ok := WatchdogInterval()
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("WatchdogInterval() = %v, %t, want: %v, %t", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
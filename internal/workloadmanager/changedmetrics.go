@@ -0,0 +1,106 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	cnfpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+const defaultFullRefreshFrequency = time.Hour
+
+type (
+	// timeSeriesKey uniquely identifies a time series and can be used as a map key.
+	timeSeriesKey struct {
+		MetricType   string
+		MetricLabels string
+	}
+
+	// changedMetrics drops the time series whose value is unchanged since it was last sent.
+	// Every time series is kept on a full refresh, which happens on the first cycle and then
+	// every fullRefresh.
+	changedMetrics struct {
+		fullRefresh     time.Duration
+		lastFullRefresh time.Time
+		prevValues      map[timeSeriesKey]*mrpb.TypedValue
+	}
+)
+
+// newChangedMetrics returns the changed metrics filter configured for the collection,
+// or nil when all workload validation metrics are sent on every cycle.
+func newChangedMetrics(config *cnfpb.CollectionConfiguration) *changedMetrics {
+	if !config.GetSendChangedWorkloadValidationMetricsOnly() {
+		return nil
+	}
+	fullRefresh := time.Duration(config.GetWorkloadValidationFullRefreshFrequency()) * time.Second
+	if fullRefresh <= 0 {
+		fullRefresh = defaultFullRefreshFrequency
+	}
+	return &changedMetrics{fullRefresh: fullRefresh, prevValues: make(map[timeSeriesKey]*mrpb.TypedValue)}
+}
+
+// filter returns the time series to send at now and records their values.
+// A nil filter returns all time series.
+func (c *changedMetrics) filter(ctx context.Context, metrics []*mrpb.TimeSeries, now time.Time) []*mrpb.TimeSeries {
+	if c == nil {
+		return metrics
+	}
+	fullRefresh := now.Sub(c.lastFullRefresh) >= c.fullRefresh
+	if fullRefresh {
+		c.lastFullRefresh = now
+	}
+	var changed []*mrpb.TimeSeries
+	for _, m := range metrics {
+		key := prepareKey(m)
+		var value *mrpb.TypedValue
+		if len(m.GetPoints()) > 0 {
+			value = m.GetPoints()[0].GetValue()
+		}
+		if prev, ok := c.prevValues[key]; fullRefresh || !ok || !proto.Equal(prev, value) {
+			changed = append(changed, m)
+		}
+		c.prevValues[key] = value
+	}
+	log.CtxLogger(ctx).Debugw("Filtered unchanged Workload Manager metrics", "fullRefresh", fullRefresh, "collected", len(metrics), "changed", len(changed))
+	return changed
+}
+
+// reset forces a full refresh on the next cycle, used when the changed time series could not
+// be sent.
+func (c *changedMetrics) reset() {
+	if c == nil {
+		return
+	}
+	c.lastFullRefresh = time.Time{}
+}
+
+func prepareKey(m *mrpb.TimeSeries) timeSeriesKey {
+	var labels []string
+	for k, v := range m.GetMetric().GetLabels() {
+		labels = append(labels, k+":"+v)
+	}
+	sort.Strings(labels)
+	return timeSeriesKey{MetricType: m.GetMetric().GetType(), MetricLabels: strings.Join(labels, ",")}
+}
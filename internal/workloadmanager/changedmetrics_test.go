@@ -0,0 +1,158 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	metricpb "google.golang.org/genproto/googleapis/api/metric"
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	cfgpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
+)
+
+func validationTimeSeries(metricType string, labels map[string]string, value float64) *mrpb.TimeSeries {
+	return &mrpb.TimeSeries{
+		Metric: &metricpb.Metric{Type: metricType, Labels: labels},
+		Points: []*mrpb.Point{{
+			Value: &mrpb.TypedValue{Value: &mrpb.TypedValue_DoubleValue{DoubleValue: value}},
+		}},
+	}
+}
+
+func TestNewChangedMetrics(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *cfgpb.CollectionConfiguration
+		want   *changedMetrics
+	}{
+		{
+			name:   "Disabled",
+			config: &cfgpb.CollectionConfiguration{},
+			want:   nil,
+		},
+		{
+			name: "DefaultFullRefresh",
+			config: &cfgpb.CollectionConfiguration{
+				SendChangedWorkloadValidationMetricsOnly: true,
+			},
+			want: &changedMetrics{fullRefresh: defaultFullRefreshFrequency, prevValues: map[timeSeriesKey]*mrpb.TypedValue{}},
+		},
+		{
+			name: "ConfiguredFullRefresh",
+			config: &cfgpb.CollectionConfiguration{
+				SendChangedWorkloadValidationMetricsOnly: true,
+				WorkloadValidationFullRefreshFrequency:   600,
+			},
+			want: &changedMetrics{fullRefresh: 10 * time.Minute, prevValues: map[timeSeriesKey]*mrpb.TypedValue{}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := newChangedMetrics(tc.config)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(changedMetrics{}), protocmp.Transform()); diff != "" {
+				t.Errorf("newChangedMetrics(%v) returned unexpected diff (-want +got):\n%s", tc.config, diff)
+			}
+		})
+	}
+}
+
+// filterCycle is a previous collection cycle, followed by a wait of after.
+type filterCycle struct {
+	metrics []*mrpb.TimeSeries
+	after   time.Duration
+}
+
+func TestChangedMetricsFilter(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	osA := validationTimeSeries("workload.googleapis.com/sap/validation/os", map[string]string{"os": "rhel-8"}, 1)
+	osB := validationTimeSeries("workload.googleapis.com/sap/validation/os", map[string]string{"os": "rhel-9"}, 1)
+	hana := validationTimeSeries("workload.googleapis.com/sap/validation/hana", map[string]string{"numa_balancing": "false"}, 1)
+	hanaChanged := validationTimeSeries("workload.googleapis.com/sap/validation/hana", map[string]string{"numa_balancing": "false"}, 0)
+
+	tests := []struct {
+		name   string
+		cycles []filterCycle
+		reset  bool
+		want   []*mrpb.TimeSeries
+	}{
+		{
+			name: "FirstCycleSendsAll",
+			want: []*mrpb.TimeSeries{osA, hana},
+		},
+		{
+			name:   "UnchangedMetricsDropped",
+			cycles: []filterCycle{{metrics: []*mrpb.TimeSeries{osA, hana}, after: time.Minute}},
+			want:   nil,
+		},
+		{
+			name:   "ChangedValueSent",
+			cycles: []filterCycle{{metrics: []*mrpb.TimeSeries{osA, hanaChanged}, after: time.Minute}},
+			want:   []*mrpb.TimeSeries{hana},
+		},
+		{
+			name:   "ChangedLabelsSent",
+			cycles: []filterCycle{{metrics: []*mrpb.TimeSeries{osB, hana}, after: time.Minute}},
+			want:   []*mrpb.TimeSeries{osA},
+		},
+		{
+			name:   "FullRefreshSendsAll",
+			cycles: []filterCycle{{metrics: []*mrpb.TimeSeries{osA, hana}, after: time.Hour}},
+			want:   []*mrpb.TimeSeries{osA, hana},
+		},
+		{
+			name:   "ResetSendsAll",
+			cycles: []filterCycle{{metrics: []*mrpb.TimeSeries{osA, hana}, after: time.Minute}},
+			reset:  true,
+			want:   []*mrpb.TimeSeries{osA, hana},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := newChangedMetrics(&cfgpb.CollectionConfiguration{SendChangedWorkloadValidationMetricsOnly: true})
+			now := start
+			for _, cycle := range tc.cycles {
+				c.filter(ctx, cycle.metrics, now)
+				now = now.Add(cycle.after)
+			}
+			if tc.reset {
+				c.reset()
+			}
+			got := c.filter(ctx, []*mrpb.TimeSeries{osA, hana}, now)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("filter() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestChangedMetricsFilterNil(t *testing.T) {
+	var c *changedMetrics
+	metrics := []*mrpb.TimeSeries{validationTimeSeries("workload.googleapis.com/sap/validation/os", nil, 1)}
+	got := c.filter(context.Background(), metrics, time.Now())
+	if diff := cmp.Diff(metrics, got, protocmp.Transform()); diff != "" {
+		t.Errorf("filter() on a nil filter returned unexpected diff (-want +got):\n%s", diff)
+	}
+	c.reset()
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/sapagent/internal/configurablemetrics"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+
+	cmpb "github.com/GoogleCloudPlatform/sapagent/protos/configurablemetrics"
+)
+
+// RuleExplanation holds the explanation of a configurable rule of the WorkloadValidation config.
+type RuleExplanation struct {
+	// Validation is the metric type the rule is reported under.
+	Validation string
+	configurablemetrics.Explanation
+}
+
+// ExplainRules evaluates the configurable rules of the WorkloadValidation config
+// and returns, per rule, the command output or file contents that were read and
+// the result of the evaluation. No metrics are sent.
+func ExplainRules(ctx context.Context, params Parameters) []RuleExplanation {
+	log.CtxLogger(ctx).Debugw("Explaining Workload Manager rules...", "definitionVersion", params.WorkloadConfig.GetVersion())
+	wc := params.WorkloadConfig
+	var explanations []RuleExplanation
+	explainCommands := func(validation string, metrics []*cmpb.OSCommandMetric) {
+		for _, m := range metrics {
+			explanations = append(explanations, RuleExplanation{
+				Validation:  validation,
+				Explanation: configurablemetrics.ExplainOSCommandMetric(ctx, m, params.Execute, params.osVendorID),
+			})
+		}
+	}
+	explainFile := func(validation, path string, metrics []*cmpb.EvalMetric) {
+		for _, e := range configurablemetrics.ExplainMetricsFromFile(ctx, configurablemetrics.FileReader(params.ConfigFileReader), path, metrics) {
+			explanations = append(explanations, RuleExplanation{Validation: validation, Explanation: e})
+		}
+	}
+
+	explainCommands(sapValidationSystem, wc.GetValidationSystem().GetOsCommandMetrics())
+	explainFile(sapValidationCorosync, wc.GetValidationCorosync().GetConfigPath(), wc.GetValidationCorosync().GetConfigMetrics())
+	explainCommands(sapValidationCorosync, wc.GetValidationCorosync().GetOsCommandMetrics())
+
+	hana := wc.GetValidationHana()
+	if dir := hanaSystemConfigFromSAPSID(ctx, params); dir != "" {
+		explainFile(sapValidationHANA, dir+"/global.ini", hana.GetGlobalIniMetrics())
+		explainFile(sapValidationHANA, dir+"/indexserver.ini", hana.GetIndexserverIniMetrics())
+	} else {
+		for _, metrics := range [][]*cmpb.EvalMetric{hana.GetGlobalIniMetrics(), hana.GetIndexserverIniMetrics()} {
+			for _, m := range metrics {
+				explanations = append(explanations, RuleExplanation{
+					Validation: sapValidationHANA,
+					Explanation: configurablemetrics.Explanation{
+						Label:   m.GetMetricInfo().GetLabel(),
+						Skipped: "HANA not active on instance",
+					},
+				})
+			}
+		}
+	}
+	explainCommands(sapValidationHANA, hana.GetOsCommandMetrics())
+	explainCommands(sapValidationNetweaver, wc.GetValidationNetweaver().GetOsCommandMetrics())
+	explainCommands(sapValidationPacemaker, wc.GetValidationPacemaker().GetOsCommandMetrics())
+	explainCommands(sapValidationCustom, wc.GetValidationCustom().GetOsCommandMetrics())
+	return explanations
+}
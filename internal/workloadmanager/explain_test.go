@@ -0,0 +1,191 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/sapagent/internal/configurablemetrics"
+	"github.com/GoogleCloudPlatform/sapagent/shared/commandlineexecutor"
+
+	cmpb "github.com/GoogleCloudPlatform/sapagent/protos/configurablemetrics"
+	sapb "github.com/GoogleCloudPlatform/sapagent/protos/sapapp"
+	wlmpb "github.com/GoogleCloudPlatform/sapagent/protos/wlmvalidation"
+)
+
+func explainEvalRule(contains string) *cmpb.EvalMetricRule {
+	return &cmpb.EvalMetricRule{
+		EvalRules: []*cmpb.EvalRule{{
+			OutputSource:  cmpb.OutputSource_STDOUT,
+			EvalRuleTypes: &cmpb.EvalRule_OutputContains{OutputContains: contains},
+		}},
+		IfTrue: &cmpb.EvalResult{
+			EvalResultTypes: &cmpb.EvalResult_ValueFromLiteral{ValueFromLiteral: "true"},
+		},
+		IfFalse: &cmpb.EvalResult{
+			EvalResultTypes: &cmpb.EvalResult_ValueFromLiteral{ValueFromLiteral: "false"},
+		},
+	}
+}
+
+func TestExplainRules(t *testing.T) {
+	workloadConfig := &wlmpb.WorkloadValidation{
+		ValidationSystem: &wlmpb.ValidationSystem{
+			OsCommandMetrics: []*cmpb.OSCommandMetric{{
+				MetricInfo:    &cmpb.MetricInfo{Label: "kernel"},
+				Command:       "uname",
+				Args:          []string{"-r"},
+				EvalRuleTypes: &cmpb.OSCommandMetric_AndEvalRules{AndEvalRules: explainEvalRule("default")},
+			}},
+		},
+		ValidationCorosync: &wlmpb.ValidationCorosync{
+			ConfigPath: "token: 20000",
+			ConfigMetrics: []*cmpb.EvalMetric{{
+				MetricInfo:    &cmpb.MetricInfo{Label: "token"},
+				EvalRuleTypes: &cmpb.EvalMetric_AndEvalRules{AndEvalRules: explainEvalRule("token")},
+			}},
+		},
+		ValidationHana: &wlmpb.ValidationHANA{
+			GlobalIniMetrics: []*cmpb.EvalMetric{{
+				MetricInfo:    &cmpb.MetricInfo{Label: "fast_restart"},
+				EvalRuleTypes: &cmpb.EvalMetric_AndEvalRules{AndEvalRules: explainEvalRule("basepath_persistent_memory_volumes")},
+			}},
+		},
+		ValidationCustom: &wlmpb.ValidationCustom{
+			OsCommandMetrics: []*cmpb.OSCommandMetric{{
+				MetricInfo:    &cmpb.MetricInfo{Label: "sles_only"},
+				OsVendor:      cmpb.OSVendor_SLES,
+				Command:       "zypper",
+				EvalRuleTypes: &cmpb.OSCommandMetric_AndEvalRules{AndEvalRules: explainEvalRule("")},
+			}},
+		},
+	}
+	execute := func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
+		return commandlineexecutor.Result{StdOut: "5.14.21-default\n"}
+	}
+
+	tests := []struct {
+		name      string
+		discovery discoveryInterface
+		want      []RuleExplanation
+	}{
+		{
+			name:      "HANANotActive",
+			discovery: &fakeDiscoveryInterface{},
+			want: []RuleExplanation{
+				{
+					Validation: sapValidationSystem,
+					Explanation: configurablemetrics.Explanation{
+						Label:  "kernel",
+						Source: "uname -r",
+						Input:  configurablemetrics.Output{StdOut: "5.14.21-default", ExitCode: "0"},
+						Value:  "true",
+						Result: true,
+					},
+				},
+				{
+					Validation: sapValidationCorosync,
+					Explanation: configurablemetrics.Explanation{
+						Label:  "token",
+						Source: "token: 20000",
+						Input:  configurablemetrics.Output{StdOut: "token: 20000"},
+						Value:  "true",
+						Result: true,
+					},
+				},
+				{
+					Validation: sapValidationHANA,
+					Explanation: configurablemetrics.Explanation{
+						Label:   "fast_restart",
+						Skipped: "HANA not active on instance",
+					},
+				},
+				{
+					Validation: sapValidationCustom,
+					Explanation: configurablemetrics.Explanation{
+						Label:   "sles_only",
+						Source:  "zypper",
+						Skipped: `OS vendor of "SLES" not detected for this system`,
+					},
+				},
+			},
+		},
+		{
+			name: "HANAActive",
+			discovery: &fakeDiscoveryInterface{
+				instances: &sapb.SAPInstances{
+					Instances: []*sapb.SAPInstance{{Type: sapb.InstanceType_HANA, Sapsid: "HDB"}},
+				},
+			},
+			want: []RuleExplanation{
+				{
+					Validation: sapValidationSystem,
+					Explanation: configurablemetrics.Explanation{
+						Label:  "kernel",
+						Source: "uname -r",
+						Input:  configurablemetrics.Output{StdOut: "5.14.21-default", ExitCode: "0"},
+						Value:  "true",
+						Result: true,
+					},
+				},
+				{
+					Validation: sapValidationCorosync,
+					Explanation: configurablemetrics.Explanation{
+						Label:  "token",
+						Source: "token: 20000",
+						Input:  configurablemetrics.Output{StdOut: "token: 20000"},
+						Value:  "true",
+						Result: true,
+					},
+				},
+				{
+					Validation: sapValidationHANA,
+					Explanation: configurablemetrics.Explanation{
+						Label:  "fast_restart",
+						Source: "/usr/sap/HDB/SYS/global/hdb/custom/config/global.ini",
+						Value:  "false",
+					},
+				},
+				{
+					Validation: sapValidationCustom,
+					Explanation: configurablemetrics.Explanation{
+						Label:   "sles_only",
+						Source:  "zypper",
+						Skipped: `OS vendor of "SLES" not detected for this system`,
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := Parameters{
+				WorkloadConfig:   workloadConfig,
+				ConfigFileReader: defaultFileReader,
+				Execute:          execute,
+				Discovery:        test.discovery,
+				osVendorID:       "rhel",
+			}
+			got := ExplainRules(context.Background(), params)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ExplainRules() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
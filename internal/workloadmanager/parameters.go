@@ -85,11 +85,15 @@ type Parameters struct {
 	GCEService            gceInterface
 	WLMService            wlmInterface
 	Discovery             discoveryInterface
+	// ReadyFunc is called after each completed local collection, it may be nil.
+	ReadyFunc func()
 	// fields derived from parsing the file specified by OSReleaseFilePath
 	osVendorID string
 	osVersion  string
 	// fields derived from reading HANA Insights rules
 	hanaInsightRules []*rpb.Rule
+	// changedMetrics drops unchanged metrics between full refreshes when configured
+	changedMetrics *changedMetrics
 }
 
 // Init runs additional setup that is a prerequisite for WLM metric collection.
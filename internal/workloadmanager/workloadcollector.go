@@ -33,6 +33,7 @@ import (
 	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring"
 
 	"github.com/GoogleCloudPlatform/sapagent/internal/configuration"
+	"github.com/GoogleCloudPlatform/sapagent/internal/drain"
 	"github.com/GoogleCloudPlatform/sapagent/internal/instanceinfo"
 	"github.com/GoogleCloudPlatform/sapagent/internal/usagemetrics"
 	cnfpb "github.com/GoogleCloudPlatform/sapagent/protos/configuration"
@@ -78,6 +79,7 @@ type sendMetricsParams struct {
 	timeSeriesCreator     cloudmonitoring.TimeSeriesCreator
 	backOffIntervals      *cloudmonitoring.BackOffIntervals
 	wlmService            wlmInterface
+	changedMetrics        *changedMetrics
 }
 
 var (
@@ -106,6 +108,7 @@ func start(ctx context.Context, a any) {
 		usagemetrics.Action(usagemetrics.HDBUserstoreKeyConfigured)
 	}
 	log.CtxLogger(ctx).Infow("Starting collection of Workload Manager metrics", "definitionVersion", params.WorkloadConfig.GetVersion())
+	params.changedMetrics = newChangedMetrics(params.Config.GetCollectionConfiguration())
 	cmf := time.Duration(params.Config.GetCollectionConfiguration().GetWorkloadValidationMetricsFrequency()) * time.Second
 	if cmf <= 0 {
 		// default it to 5 minutes
@@ -148,8 +151,16 @@ func start(ctx context.Context, a any) {
 		case <-heartbeatTicker.C:
 			params.HeartbeatSpec.Beat()
 		case <-configurableMetricsTicker.C:
+			if drain.Active() {
+				log.CtxLogger(ctx).Debug("Agent is in drain mode, skipping Workload Manager metrics collection")
+				continue
+			}
 			collectWorkloadMetricsOnce(ctx, params)
 		case <-databaseMetricTicker.C:
+			if drain.Active() {
+				log.CtxLogger(ctx).Debug("Agent is in drain mode, skipping Workload Manager database metrics collection")
+				continue
+			}
 			if err := collectDBMetricsOnce(ctx, params); err != nil {
 				log.CtxLogger(ctx).Warn(err)
 			}
@@ -175,7 +186,11 @@ func collectWorkloadMetricsOnce(ctx context.Context, params Parameters) {
 		timeSeriesCreator:     params.TimeSeriesCreator,
 		backOffIntervals:      params.BackOffs,
 		wlmService:            params.WLMService,
+		changedMetrics:        params.changedMetrics,
 	})
+	if params.ReadyFunc != nil {
+		params.ReadyFunc()
+	}
 }
 
 // StartMetricsCollection continuously collects Workload Manager metrics for SAP workloads.
@@ -426,18 +441,25 @@ func sendMetrics(ctx context.Context, params sendMetricsParams) int {
 	logMetricData(ctx, params.wm.Metrics)
 
 	if params.sendToCloudMonitoring {
-		sentMetrics := len(params.wm.Metrics)
-		log.CtxLogger(ctx).Infow("Sending metrics to Cloud Monitoring...", "number", len(params.wm.Metrics))
+		timeSeries := params.changedMetrics.filter(ctx, params.wm.Metrics, time.Now())
+		if len(timeSeries) == 0 {
+			log.CtxLogger(ctx).Info("No changed Workload Manager metrics to send to Cloud Monitoring")
+			sendMetricsToDataWarehouseSecondary(ctx, params)
+			return 0
+		}
+		sentMetrics := len(timeSeries)
+		log.CtxLogger(ctx).Infow("Sending metrics to Cloud Monitoring...", "number", len(timeSeries))
 		request := mpb.CreateTimeSeriesRequest{
 			Name:       fmt.Sprintf("projects/%s", params.cp.GetProjectId()),
-			TimeSeries: params.wm.Metrics,
+			TimeSeries: timeSeries,
 		}
 		if err := cloudmonitoring.CreateTimeSeriesWithRetry(ctx, params.timeSeriesCreator, &request, params.backOffIntervals); err != nil {
 			log.CtxLogger(ctx).Errorw("Failed to send metrics to Cloud Monitoring", "error", err)
 			usagemetrics.Error(usagemetrics.WLMMetricCollectionFailure)
+			params.changedMetrics.reset()
 			sentMetrics = 0
 		} else {
-			log.CtxLogger(ctx).Infow("Sent metrics to Cloud Monitoring", "number", len(params.wm.Metrics))
+			log.CtxLogger(ctx).Infow("Sent metrics to Cloud Monitoring", "number", len(timeSeries))
 		}
 
 		sendMetricsToDataWarehouseSecondary(ctx, params)
@@ -102,20 +102,24 @@ const (
 	EvalNode_GTE       EvalNode_EvalType = 6
 	EvalNode_EQSTR     EvalNode_EvalType = 7
 	EvalNode_SUBSTR    EvalNode_EvalType = 8
+	EvalNode_EQSTR_CI  EvalNode_EvalType = 9
+	EvalNode_SUBSTR_CI EvalNode_EvalType = 10
 )
 
 // Enum value maps for EvalNode_EvalType.
 var (
 	EvalNode_EvalType_name = map[int32]string{
-		0: "UNDEFINED",
-		1: "EQ",
-		2: "NEQ",
-		3: "LT",
-		4: "LTE",
-		5: "GT",
-		6: "GTE",
-		7: "EQSTR",
-		8: "SUBSTR",
+		0:  "UNDEFINED",
+		1:  "EQ",
+		2:  "NEQ",
+		3:  "LT",
+		4:  "LTE",
+		5:  "GT",
+		6:  "GTE",
+		7:  "EQSTR",
+		8:  "SUBSTR",
+		9:  "EQSTR_CI",
+		10: "SUBSTR_CI",
 	}
 	EvalNode_EvalType_value = map[string]int32{
 		"UNDEFINED": 0,
@@ -127,6 +131,8 @@ var (
 		"GTE":       6,
 		"EQSTR":     7,
 		"SUBSTR":    8,
+		"EQSTR_CI":  9,
+		"SUBSTR_CI": 10,
 	}
 )
 
@@ -154,7 +160,7 @@ func (x EvalNode_EvalType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use EvalNode_EvalType.Descriptor instead.
 func (EvalNode_EvalType) EnumDescriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{3, 0}
+	return file_events_events_proto_rawDescGZIP(), []int{5, 0}
 }
 
 type Rule struct {
@@ -383,7 +389,18 @@ type EventTarget struct {
 	//
 	//	*EventTarget_HttpEndpoint
 	//	*EventTarget_FileEndpoint
+	//	*EventTarget_CloudLogging
 	Target isEventTarget_Target `protobuf_oneof:"target"`
+	// Optional - rotation settings used when writing to a file_endpoint.
+	FileRotation *FileRotation `protobuf:"bytes,3,opt,name=file_rotation,json=fileRotation,proto3" json:"file_rotation,omitempty"`
+	// Optional - authentication of the requests sent to an http_endpoint, at most
+	// one of these is set.
+	HttpBearerToken string `protobuf:"bytes,5,opt,name=http_bearer_token,json=httpBearerToken,proto3" json:"http_bearer_token,omitempty"` // Static bearer token.
+	// Name of the Secret Manager secret holding the bearer token.
+	HttpBearerTokenSecret string `protobuf:"bytes,6,opt,name=http_bearer_token_secret,json=httpBearerTokenSecret,proto3" json:"http_bearer_token_secret,omitempty"`
+	// Audience of the OIDC identity token minted for the requests, ex: the URL of
+	// a Cloud Run service.
+	HttpOidcAudience string `protobuf:"bytes,7,opt,name=http_oidc_audience,json=httpOidcAudience,proto3" json:"http_oidc_audience,omitempty"`
 }
 
 func (x *EventTarget) Reset() {
@@ -439,6 +456,41 @@ func (x *EventTarget) GetFileEndpoint() string {
 	return ""
 }
 
+func (x *EventTarget) GetCloudLogging() *CloudLoggingTarget {
+	if x, ok := x.GetTarget().(*EventTarget_CloudLogging); ok {
+		return x.CloudLogging
+	}
+	return nil
+}
+
+func (x *EventTarget) GetFileRotation() *FileRotation {
+	if x != nil {
+		return x.FileRotation
+	}
+	return nil
+}
+
+func (x *EventTarget) GetHttpBearerToken() string {
+	if x != nil {
+		return x.HttpBearerToken
+	}
+	return ""
+}
+
+func (x *EventTarget) GetHttpBearerTokenSecret() string {
+	if x != nil {
+		return x.HttpBearerTokenSecret
+	}
+	return ""
+}
+
+func (x *EventTarget) GetHttpOidcAudience() string {
+	if x != nil {
+		return x.HttpOidcAudience
+	}
+	return ""
+}
+
 type isEventTarget_Target interface {
 	isEventTarget_Target()
 }
@@ -451,10 +503,127 @@ type EventTarget_FileEndpoint struct {
 	FileEndpoint string `protobuf:"bytes,2,opt,name=file_endpoint,json=fileEndpoint,proto3,oneof"`
 }
 
+type EventTarget_CloudLogging struct {
+	CloudLogging *CloudLoggingTarget `protobuf:"bytes,4,opt,name=cloud_logging,json=cloudLogging,proto3,oneof"`
+}
+
 func (*EventTarget_HttpEndpoint) isEventTarget_Target() {}
 
 func (*EventTarget_FileEndpoint) isEventTarget_Target() {}
 
+func (*EventTarget_CloudLogging) isEventTarget_Target() {}
+
+type CloudLoggingTarget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the log the events are written to, ex: sap-agent-events.
+	LogName string `protobuf:"bytes,1,opt,name=log_name,json=logName,proto3" json:"log_name,omitempty"`
+}
+
+func (x *CloudLoggingTarget) Reset() {
+	*x = CloudLoggingTarget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_events_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloudLoggingTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloudLoggingTarget) ProtoMessage() {}
+
+func (x *CloudLoggingTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloudLoggingTarget.ProtoReflect.Descriptor instead.
+func (*CloudLoggingTarget) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CloudLoggingTarget) GetLogName() string {
+	if x != nil {
+		return x.LogName
+	}
+	return ""
+}
+
+type FileRotation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxSizeMb  int64 `protobuf:"varint,1,opt,name=max_size_mb,json=maxSizeMb,proto3" json:"max_size_mb,omitempty"`  // Size in megabytes at which the file is rotated.
+	MaxBackups int64 `protobuf:"varint,2,opt,name=max_backups,json=maxBackups,proto3" json:"max_backups,omitempty"` // Number of rotated files to retain.
+	Compress   bool  `protobuf:"varint,3,opt,name=compress,proto3" json:"compress,omitempty"`                       // Compress the rotated files using gzip.
+}
+
+func (x *FileRotation) Reset() {
+	*x = FileRotation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_events_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileRotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileRotation) ProtoMessage() {}
+
+func (x *FileRotation) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileRotation.ProtoReflect.Descriptor instead.
+func (*FileRotation) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FileRotation) GetMaxSizeMb() int64 {
+	if x != nil {
+		return x.MaxSizeMb
+	}
+	return 0
+}
+
+func (x *FileRotation) GetMaxBackups() int64 {
+	if x != nil {
+		return x.MaxBackups
+	}
+	return 0
+}
+
+func (x *FileRotation) GetCompress() bool {
+	if x != nil {
+		return x.Compress
+	}
+	return false
+}
+
 type EvalNode struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -462,12 +631,16 @@ type EvalNode struct {
 
 	Rhs       string            `protobuf:"bytes,2,opt,name=rhs,proto3" json:"rhs,omitempty"`
 	Operation EvalNode_EvalType `protobuf:"varint,3,opt,name=operation,proto3,enum=sapagent.protos.events.EvalNode_EvalType" json:"operation,omitempty"`
+	// Optional - when set, the value compared against is read from this source
+	// at evaluation time instead of the literal rhs, ex: a threshold kept in
+	// the instance metadata.
+	RhsSource *EventSource `protobuf:"bytes,4,opt,name=rhs_source,json=rhsSource,proto3" json:"rhs_source,omitempty"`
 }
 
 func (x *EvalNode) Reset() {
 	*x = EvalNode{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_events_events_proto_msgTypes[3]
+		mi := &file_events_events_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -480,7 +653,7 @@ func (x *EvalNode) String() string {
 func (*EvalNode) ProtoMessage() {}
 
 func (x *EvalNode) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[3]
+	mi := &file_events_events_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -493,7 +666,7 @@ func (x *EvalNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EvalNode.ProtoReflect.Descriptor instead.
 func (*EvalNode) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{3}
+	return file_events_events_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *EvalNode) GetRhs() string {
@@ -510,6 +683,13 @@ func (x *EvalNode) GetOperation() EvalNode_EvalType {
 	return EvalNode_UNDEFINED
 }
 
+func (x *EvalNode) GetRhsSource() *EventSource {
+	if x != nil {
+		return x.RhsSource
+	}
+	return nil
+}
+
 type EventSource_CloudMonitoringMetric struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -528,7 +708,7 @@ type EventSource_CloudMonitoringMetric struct {
 func (x *EventSource_CloudMonitoringMetric) Reset() {
 	*x = EventSource_CloudMonitoringMetric{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_events_events_proto_msgTypes[4]
+		mi := &file_events_events_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -541,7 +721,7 @@ func (x *EventSource_CloudMonitoringMetric) String() string {
 func (*EventSource_CloudMonitoringMetric) ProtoMessage() {}
 
 func (x *EventSource_CloudMonitoringMetric) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[4]
+	mi := &file_events_events_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -611,14 +791,22 @@ type EventSource_CloudLogging struct {
 	// Logging query written in
 	// https://cloud.google.com/logging/docs/view/logging-query-language
 	LogQuery string `protobuf:"bytes,1,opt,name=log_query,json=logQuery,proto3" json:"log_query,omitempty"`
-	// Value type returned by the cloud logging query.
+	// Value type returned by the cloud logging query. INT64 without a
+	// field_path returns the number of matching entries.
 	ValueType EventSource_ValueType `protobuf:"varint,2,opt,name=value_type,json=valueType,proto3,enum=sapagent.protos.events.EventSource_ValueType" json:"value_type,omitempty"`
+	// Optional - only entries at or above this severity are read, ex: ERROR.
+	MinSeverity string `protobuf:"bytes,3,opt,name=min_severity,json=minSeverity,proto3" json:"min_severity,omitempty"`
+	// Optional - only entries logged in the last window_minutes are read.
+	WindowMinutes int64 `protobuf:"varint,4,opt,name=window_minutes,json=windowMinutes,proto3" json:"window_minutes,omitempty"`
+	// Optional - slash separated path of the field of the newest matching
+	// entry returned as the value, ex: jsonPayload/status.
+	FieldPath string `protobuf:"bytes,5,opt,name=field_path,json=fieldPath,proto3" json:"field_path,omitempty"`
 }
 
 func (x *EventSource_CloudLogging) Reset() {
 	*x = EventSource_CloudLogging{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_events_events_proto_msgTypes[5]
+		mi := &file_events_events_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -631,7 +819,7 @@ func (x *EventSource_CloudLogging) String() string {
 func (*EventSource_CloudLogging) ProtoMessage() {}
 
 func (x *EventSource_CloudLogging) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[5]
+	mi := &file_events_events_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -661,6 +849,27 @@ func (x *EventSource_CloudLogging) GetValueType() EventSource_ValueType {
 	return EventSource_UNSPECIFIED
 }
 
+func (x *EventSource_CloudLogging) GetMinSeverity() string {
+	if x != nil {
+		return x.MinSeverity
+	}
+	return ""
+}
+
+func (x *EventSource_CloudLogging) GetWindowMinutes() int64 {
+	if x != nil {
+		return x.WindowMinutes
+	}
+	return 0
+}
+
+func (x *EventSource_CloudLogging) GetFieldPath() string {
+	if x != nil {
+		return x.FieldPath
+	}
+	return ""
+}
+
 type EventSource_Metadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -668,12 +877,15 @@ type EventSource_Metadata struct {
 
 	Url       string                `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
 	ValueType EventSource_ValueType `protobuf:"varint,2,opt,name=value_type,json=valueType,proto3,enum=sapagent.protos.events.EventSource_ValueType" json:"value_type,omitempty"` // Value type returned by the GCP METADATA.
+	// Optional - slash separated path of the value to extract from a JSON
+	// response, ex: attributes/some-key. Array elements are selected by index.
+	JsonPath string `protobuf:"bytes,3,opt,name=json_path,json=jsonPath,proto3" json:"json_path,omitempty"`
 }
 
 func (x *EventSource_Metadata) Reset() {
 	*x = EventSource_Metadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_events_events_proto_msgTypes[6]
+		mi := &file_events_events_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -686,7 +898,7 @@ func (x *EventSource_Metadata) String() string {
 func (*EventSource_Metadata) ProtoMessage() {}
 
 func (x *EventSource_Metadata) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[6]
+	mi := &file_events_events_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -716,6 +928,13 @@ func (x *EventSource_Metadata) GetValueType() EventSource_ValueType {
 	return EventSource_UNSPECIFIED
 }
 
+func (x *EventSource_Metadata) GetJsonPath() string {
+	if x != nil {
+		return x.JsonPath
+	}
+	return ""
+}
+
 type EventSource_GuestLog struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -725,12 +944,17 @@ type EventSource_GuestLog struct {
 	// grep "ERROR" /var/log/google-cloud-sap-agent.log
 	Command   string                `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
 	ValueType EventSource_ValueType `protobuf:"varint,2,opt,name=value_type,json=valueType,proto3,enum=sapagent.protos.events.EventSource_ValueType" json:"value_type,omitempty"` // Value type returned by the command.
+	// Optional - when set, the log file is read incrementally instead of
+	// running the command. Only content appended since the previous poll is
+	// scanned and the number of lines matching match_pattern is the value.
+	LogFilePath  string `protobuf:"bytes,3,opt,name=log_file_path,json=logFilePath,proto3" json:"log_file_path,omitempty"`
+	MatchPattern string `protobuf:"bytes,4,opt,name=match_pattern,json=matchPattern,proto3" json:"match_pattern,omitempty"` // Regular expression matched against each line.
 }
 
 func (x *EventSource_GuestLog) Reset() {
 	*x = EventSource_GuestLog{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_events_events_proto_msgTypes[7]
+		mi := &file_events_events_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -743,7 +967,7 @@ func (x *EventSource_GuestLog) String() string {
 func (*EventSource_GuestLog) ProtoMessage() {}
 
 func (x *EventSource_GuestLog) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[7]
+	mi := &file_events_events_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -773,6 +997,20 @@ func (x *EventSource_GuestLog) GetValueType() EventSource_ValueType {
 	return EventSource_UNSPECIFIED
 }
 
+func (x *EventSource_GuestLog) GetLogFilePath() string {
+	if x != nil {
+		return x.LogFilePath
+	}
+	return ""
+}
+
+func (x *EventSource_GuestLog) GetMatchPattern() string {
+	if x != nil {
+		return x.MatchPattern
+	}
+	return ""
+}
+
 var File_events_events_proto protoreflect.FileDescriptor
 
 var file_events_events_proto_rawDesc = []byte{
@@ -799,7 +1037,7 @@ var file_events_events_proto_rawDesc = []byte{
 	0x0c, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x65, 0x63, 0x12, 0x23, 0x0a,
 	0x0d, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x18, 0x08,
 	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x54, 0x72, 0x69, 0x67, 0x67,
-	0x65, 0x72, 0x22, 0xe5, 0x07, 0x0a, 0x0b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72,
+	0x65, 0x72, 0x22, 0xb7, 0x09, 0x0a, 0x0b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72,
 	0x63, 0x65, 0x12, 0x73, 0x0a, 0x17, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x6d, 0x6f, 0x6e, 0x69,
 	0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70,
@@ -834,54 +1072,102 @@ var file_events_events_proto_rawDesc = []byte{
 	0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x6f, 0x75,
 	0x72, 0x63, 0x65, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x48, 0x00, 0x52,
 	0x0f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65,
-	0x42, 0x08, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x1a, 0x79, 0x0a, 0x0c, 0x43, 0x6c,
-	0x6f, 0x75, 0x64, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f,
-	0x67, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
-	0x6f, 0x67, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x4c, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x73, 0x61,
-	0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x76,
-	0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x6a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x75, 0x72, 0x6c, 0x12, 0x4c, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65,
-	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
-	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x1a, 0x72, 0x0a, 0x08, 0x47, 0x75, 0x65, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x18, 0x0a,
-	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x4c, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x73, 0x61,
-	0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x76,
-	0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x49, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
-	0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x01, 0x12, 0x09, 0x0a,
-	0x05, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49,
-	0x4e, 0x47, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x10, 0x04,
-	0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x65, 0x0a, 0x0b, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x25, 0x0a, 0x0d, 0x68, 0x74, 0x74,
-	0x70, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x48, 0x00, 0x52, 0x0c, 0x68, 0x74, 0x74, 0x70, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x12, 0x25, 0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x45,
-	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x22, 0xca, 0x01, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x10,
-	0x0a, 0x03, 0x72, 0x68, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x68, 0x73,
-	0x12, 0x47, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x76, 0x61,
-	0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09,
-	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x63, 0x0a, 0x08, 0x45, 0x76, 0x61,
-	0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e,
-	0x45, 0x44, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x45, 0x51, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03,
-	0x4e, 0x45, 0x51, 0x10, 0x02, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x54, 0x10, 0x03, 0x12, 0x07, 0x0a,
-	0x03, 0x4c, 0x54, 0x45, 0x10, 0x04, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x54, 0x10, 0x05, 0x12, 0x07,
-	0x0a, 0x03, 0x47, 0x54, 0x45, 0x10, 0x06, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x51, 0x53, 0x54, 0x52,
-	0x10, 0x07, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x55, 0x42, 0x53, 0x54, 0x52, 0x10, 0x08, 0x42, 0x02,
-	0x50, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x42, 0x08, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x1a, 0xe2, 0x01, 0x0a, 0x0c, 0x43,
+	0x6c, 0x6f, 0x75, 0x64, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x6c,
+	0x6f, 0x67, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x6f, 0x67, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x4c, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x73,
+	0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x65,
+	0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x69,
+	0x6e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x77, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x74, 0x68, 0x1a,
+	0x87, 0x01, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x4c,
+	0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6a, 0x73, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x1a, 0xbb, 0x01, 0x0a, 0x08, 0x47, 0x75,
+	0x65, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x12, 0x4c, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x22,
+	0x0a, 0x0d, 0x6c, 0x6f, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61,
+	0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x70, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0x49, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x01, 0x12,
+	0x09, 0x0a, 0x05, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54,
+	0x52, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45,
+	0x10, 0x04, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x96, 0x03, 0x0a,
+	0x0b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x25, 0x0a, 0x0d,
+	0x68, 0x74, 0x74, 0x70, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x68, 0x74, 0x74, 0x70, 0x45, 0x6e, 0x64, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x69,
+	0x6c, 0x65, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x51, 0x0a, 0x0d, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x5f, 0x6c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2a, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64,
+	0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x48, 0x00, 0x52,
+	0x0c, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x49, 0x0a,
+	0x0d, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x66, 0x69, 0x6c, 0x65,
+	0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x68, 0x74, 0x74, 0x70,
+	0x5f, 0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x68, 0x74, 0x74, 0x70, 0x42, 0x65, 0x61, 0x72, 0x65, 0x72, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x37, 0x0a, 0x18, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x62, 0x65, 0x61,
+	0x72, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x68, 0x74, 0x74, 0x70, 0x42, 0x65, 0x61, 0x72,
+	0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x2c, 0x0a,
+	0x12, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x6f, 0x69, 0x64, 0x63, 0x5f, 0x61, 0x75, 0x64, 0x69, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x68, 0x74, 0x74, 0x70, 0x4f,
+	0x69, 0x64, 0x63, 0x41, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x2f, 0x0a, 0x12, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x4c, 0x6f,
+	0x67, 0x67, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6c,
+	0x6f, 0x67, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c,
+	0x6f, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x6b, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x5f, 0x6d, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x61, 0x78,
+	0x53, 0x69, 0x7a, 0x65, 0x4d, 0x62, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61,
+	0x63, 0x6b, 0x75, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61, 0x78,
+	0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x22, 0xac, 0x02, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x72, 0x68, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72,
+	0x68, 0x73, 0x12, 0x47, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45,
+	0x76, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x42, 0x0a, 0x0a, 0x72,
+	0x68, 0x73, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x73, 0x61, 0x70, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x09, 0x72, 0x68, 0x73, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22,
+	0x80, 0x01, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09,
+	0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x45,
+	0x51, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x45, 0x51, 0x10, 0x02, 0x12, 0x06, 0x0a, 0x02,
+	0x4c, 0x54, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x54, 0x45, 0x10, 0x04, 0x12, 0x06, 0x0a,
+	0x02, 0x47, 0x54, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x54, 0x45, 0x10, 0x06, 0x12, 0x09,
+	0x0a, 0x05, 0x45, 0x51, 0x53, 0x54, 0x52, 0x10, 0x07, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x55, 0x42,
+	0x53, 0x54, 0x52, 0x10, 0x08, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x51, 0x53, 0x54, 0x52, 0x5f, 0x43,
+	0x49, 0x10, 0x09, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55, 0x42, 0x53, 0x54, 0x52, 0x5f, 0x43, 0x49,
+	0x10, 0x0a, 0x42, 0x02, 0x50, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -897,37 +1183,42 @@ func file_events_events_proto_rawDescGZIP() []byte {
 }
 
 var file_events_events_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_events_events_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_events_events_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_events_events_proto_goTypes = []any{
 	(EventSource_ValueType)(0),                // 0: sapagent.protos.events.EventSource.ValueType
 	(EvalNode_EvalType)(0),                    // 1: sapagent.protos.events.EvalNode.EvalType
 	(*Rule)(nil),                              // 2: sapagent.protos.events.Rule
 	(*EventSource)(nil),                       // 3: sapagent.protos.events.EventSource
 	(*EventTarget)(nil),                       // 4: sapagent.protos.events.EventTarget
-	(*EvalNode)(nil),                          // 5: sapagent.protos.events.EvalNode
-	(*EventSource_CloudMonitoringMetric)(nil), // 6: sapagent.protos.events.EventSource.CloudMonitoringMetric
-	(*EventSource_CloudLogging)(nil),          // 7: sapagent.protos.events.EventSource.CloudLogging
-	(*EventSource_Metadata)(nil),              // 8: sapagent.protos.events.EventSource.Metadata
-	(*EventSource_GuestLog)(nil),              // 9: sapagent.protos.events.EventSource.GuestLog
+	(*CloudLoggingTarget)(nil),                // 5: sapagent.protos.events.CloudLoggingTarget
+	(*FileRotation)(nil),                      // 6: sapagent.protos.events.FileRotation
+	(*EvalNode)(nil),                          // 7: sapagent.protos.events.EvalNode
+	(*EventSource_CloudMonitoringMetric)(nil), // 8: sapagent.protos.events.EventSource.CloudMonitoringMetric
+	(*EventSource_CloudLogging)(nil),          // 9: sapagent.protos.events.EventSource.CloudLogging
+	(*EventSource_Metadata)(nil),              // 10: sapagent.protos.events.EventSource.Metadata
+	(*EventSource_GuestLog)(nil),              // 11: sapagent.protos.events.EventSource.GuestLog
 }
 var file_events_events_proto_depIdxs = []int32{
 	3,  // 0: sapagent.protos.events.Rule.source:type_name -> sapagent.protos.events.EventSource
-	5,  // 1: sapagent.protos.events.Rule.trigger:type_name -> sapagent.protos.events.EvalNode
+	7,  // 1: sapagent.protos.events.Rule.trigger:type_name -> sapagent.protos.events.EvalNode
 	4,  // 2: sapagent.protos.events.Rule.target:type_name -> sapagent.protos.events.EventTarget
-	6,  // 3: sapagent.protos.events.EventSource.cloud_monitoring_metric:type_name -> sapagent.protos.events.EventSource.CloudMonitoringMetric
-	7,  // 4: sapagent.protos.events.EventSource.cloud_logging:type_name -> sapagent.protos.events.EventSource.CloudLogging
-	8,  // 5: sapagent.protos.events.EventSource.metadata:type_name -> sapagent.protos.events.EventSource.Metadata
-	9,  // 6: sapagent.protos.events.EventSource.guest_log:type_name -> sapagent.protos.events.EventSource.GuestLog
-	1,  // 7: sapagent.protos.events.EvalNode.operation:type_name -> sapagent.protos.events.EvalNode.EvalType
-	0,  // 8: sapagent.protos.events.EventSource.CloudMonitoringMetric.metric_value_type:type_name -> sapagent.protos.events.EventSource.ValueType
-	0,  // 9: sapagent.protos.events.EventSource.CloudLogging.value_type:type_name -> sapagent.protos.events.EventSource.ValueType
-	0,  // 10: sapagent.protos.events.EventSource.Metadata.value_type:type_name -> sapagent.protos.events.EventSource.ValueType
-	0,  // 11: sapagent.protos.events.EventSource.GuestLog.value_type:type_name -> sapagent.protos.events.EventSource.ValueType
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	8,  // 3: sapagent.protos.events.EventSource.cloud_monitoring_metric:type_name -> sapagent.protos.events.EventSource.CloudMonitoringMetric
+	9,  // 4: sapagent.protos.events.EventSource.cloud_logging:type_name -> sapagent.protos.events.EventSource.CloudLogging
+	10, // 5: sapagent.protos.events.EventSource.metadata:type_name -> sapagent.protos.events.EventSource.Metadata
+	11, // 6: sapagent.protos.events.EventSource.guest_log:type_name -> sapagent.protos.events.EventSource.GuestLog
+	5,  // 7: sapagent.protos.events.EventTarget.cloud_logging:type_name -> sapagent.protos.events.CloudLoggingTarget
+	6,  // 8: sapagent.protos.events.EventTarget.file_rotation:type_name -> sapagent.protos.events.FileRotation
+	1,  // 9: sapagent.protos.events.EvalNode.operation:type_name -> sapagent.protos.events.EvalNode.EvalType
+	3,  // 10: sapagent.protos.events.EvalNode.rhs_source:type_name -> sapagent.protos.events.EventSource
+	0,  // 11: sapagent.protos.events.EventSource.CloudMonitoringMetric.metric_value_type:type_name -> sapagent.protos.events.EventSource.ValueType
+	0,  // 12: sapagent.protos.events.EventSource.CloudLogging.value_type:type_name -> sapagent.protos.events.EventSource.ValueType
+	0,  // 13: sapagent.protos.events.EventSource.Metadata.value_type:type_name -> sapagent.protos.events.EventSource.ValueType
+	0,  // 14: sapagent.protos.events.EventSource.GuestLog.value_type:type_name -> sapagent.protos.events.EventSource.ValueType
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_events_events_proto_init() }
@@ -973,7 +1264,7 @@ func file_events_events_proto_init() {
 			}
 		}
 		file_events_events_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*EvalNode); i {
+			switch v := v.(*CloudLoggingTarget); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -985,7 +1276,7 @@ func file_events_events_proto_init() {
 			}
 		}
 		file_events_events_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*EventSource_CloudMonitoringMetric); i {
+			switch v := v.(*FileRotation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -997,7 +1288,7 @@ func file_events_events_proto_init() {
 			}
 		}
 		file_events_events_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*EventSource_CloudLogging); i {
+			switch v := v.(*EvalNode); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1009,7 +1300,7 @@ func file_events_events_proto_init() {
 			}
 		}
 		file_events_events_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*EventSource_Metadata); i {
+			switch v := v.(*EventSource_CloudMonitoringMetric); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1021,6 +1312,30 @@ func file_events_events_proto_init() {
 			}
 		}
 		file_events_events_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*EventSource_CloudLogging); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_events_events_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*EventSource_Metadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_events_events_proto_msgTypes[9].Exporter = func(v any, i int) any {
 			switch v := v.(*EventSource_GuestLog); i {
 			case 0:
 				return &v.state
@@ -1042,8 +1357,9 @@ func file_events_events_proto_init() {
 	file_events_events_proto_msgTypes[2].OneofWrappers = []any{
 		(*EventTarget_HttpEndpoint)(nil),
 		(*EventTarget_FileEndpoint)(nil),
+		(*EventTarget_CloudLogging)(nil),
 	}
-	file_events_events_proto_msgTypes[4].OneofWrappers = []any{
+	file_events_events_proto_msgTypes[6].OneofWrappers = []any{
 		(*EventSource_CloudMonitoringMetric_LabelName)(nil),
 		(*EventSource_CloudMonitoringMetric_MetricValueType)(nil),
 	}
@@ -1053,7 +1369,7 @@ func file_events_events_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_events_events_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   8,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
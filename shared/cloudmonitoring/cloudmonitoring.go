@@ -20,12 +20,17 @@ package cloudmonitoring
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	backoff "github.com/cenkalti/backoff/v4"
 	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 
 	mpb "google.golang.org/genproto/googleapis/monitoring/v3"
@@ -56,6 +61,15 @@ const (
 
 const maxTSPerRequest = 200 // Reference: https://cloud.google.com/monitoring/quotas
 
+// droppedTimeSeries counts the time series rejected as invalid by cloud monitoring.
+var droppedTimeSeries atomic.Int64
+
+// DroppedTimeSeries returns the number of time series dropped by SendTimeSeries since the
+// process started because cloud monitoring rejected them as invalid.
+func DroppedTimeSeries() int64 {
+	return droppedTimeSeries.Load()
+}
+
 // NewBackOffIntervals is a constructor for the back off intervals.
 func NewBackOffIntervals(longExponential, shortConstant time.Duration) *BackOffIntervals {
 	return &BackOffIntervals{
@@ -103,6 +117,11 @@ func CreateTimeSeriesWithRetry(ctx context.Context, client TimeSeriesCreator, re
 
 	err := backoff.Retry(func() error {
 		if err := client.CreateTimeSeries(ctx, req); err != nil {
+			if status.Code(err) == codes.InvalidArgument {
+				// The request is rejected again on retry, the caller decides what to drop.
+				log.CtxLogger(ctx).Warnw("Error in CreateTimeSeries, invalid request", "attempt", attempt, "error", err)
+				return backoff.Permanent(err)
+			}
 			if strings.Contains(err.Error(), "PermissionDenied") {
 				log.CtxLogger(ctx).Warnw("Error in CreateTimeSeries, Permission denied - Enable the Monitoring Metrics Writer IAM role for the Service Account", "attempt", attempt, "error", err)
 			} else {
@@ -208,6 +227,9 @@ func prepareKey(t *mrpb.TimeSeries) timeSeriesKey {
 
 // SendTimeSeries sends all the time series objects to cloud monitoring.
 // maxTSPerRequest is used as an upper limit to batch send time series values per request.
+// Batches wait for the rate limit set by SetRequestsPerMinute before they are sent.
+// Cloud monitoring writes the valid time series of a batch rejected as invalid, the invalid ones
+// are dropped, counted by DroppedTimeSeries and not included in sent.
 // If a cloud monitoring API call fails even after retries, the remaining measurements are discarded.
 func SendTimeSeries(ctx context.Context, timeSeries []*mrpb.TimeSeries, timeSeriesCreator TimeSeriesCreator, bo *BackOffIntervals, projectID string) (sent, batchCount int, err error) {
 	var batchTimeSeries []*mrpb.TimeSeries
@@ -218,10 +240,11 @@ func SendTimeSeries(ctx context.Context, timeSeries []*mrpb.TimeSeries, timeSeri
 		if len(batchTimeSeries) == maxTSPerRequest {
 			log.CtxLogger(ctx).Debug("Maximum batch size has been reached, sending the batch.")
 			batchCount++
-			if err := sendBatch(ctx, batchTimeSeries, timeSeriesCreator, bo, projectID); err != nil {
+			dropped, err := sendBatch(ctx, batchTimeSeries, timeSeriesCreator, bo, projectID)
+			if err != nil {
 				return sent, batchCount, err
 			}
-			sent += len(batchTimeSeries)
+			sent += len(batchTimeSeries) - dropped
 			batchTimeSeries = nil
 		}
 	}
@@ -229,20 +252,94 @@ func SendTimeSeries(ctx context.Context, timeSeries []*mrpb.TimeSeries, timeSeri
 		return sent, batchCount, nil
 	}
 	batchCount++
-	if err := sendBatch(ctx, batchTimeSeries, timeSeriesCreator, bo, projectID); err != nil {
+	dropped, err := sendBatch(ctx, batchTimeSeries, timeSeriesCreator, bo, projectID)
+	if err != nil {
 		return sent, batchCount, err
 	}
-	return sent + len(batchTimeSeries), batchCount, nil
+	return sent + len(batchTimeSeries) - dropped, batchCount, nil
 }
 
-// sendBatch sends one batch of metrics to cloud monitoring using an API call with retries. Returns an error in case of failures.
-func sendBatch(ctx context.Context, batchTimeSeries []*mrpb.TimeSeries, timeSeriesCreator TimeSeriesCreator, bo *BackOffIntervals, projectID string) error {
-	log.CtxLogger(ctx).Debugw("Sending a batch of metrics to cloud monitoring.", "numberofmetrics", len(batchTimeSeries), "metrics", batchTimeSeries)
+// sendBatch sends one batch of metrics to cloud monitoring using an API call with retries.
+// Returns the number of time series dropped as invalid and an error in case of failures.
+func sendBatch(ctx context.Context, batchTimeSeries []*mrpb.TimeSeries, timeSeriesCreator TimeSeriesCreator, bo *BackOffIntervals, projectID string) (dropped int, err error) {
+	batch := pruneBatch(batchTimeSeries)
+	err = createBatch(ctx, batch, timeSeriesCreator, bo, projectID)
+	if err == nil || status.Code(err) != codes.InvalidArgument {
+		return 0, err
+	}
+	// Cloud monitoring writes the valid time series of a rejected request, the batch is not sent
+	// again as the points already written would be rejected as duplicates.
+	dropped = failedTimeSeries(ctx, err, batch)
+	droppedTimeSeries.Add(int64(dropped))
+	return dropped, nil
+}
+
+// timeSeriesIndexes matches the indexes of the failed time series in a CreateTimeSeries error,
+// ex: "timeSeries[3]" or "timeSeries[0-5]".
+var timeSeriesIndexes = regexp.MustCompile(`timeSeries\[(\d+)(?:-(\d+))?\]`)
+
+// failedTimeSeries returns the number of time series of the batch which were not written because
+// of an InvalidArgument error. The failed time series are identified by their indexes in the
+// status messages, otherwise their number is read from the CreateTimeSeriesSummary in the status
+// details. The agent writes one point per time series, so failed points are failed time series.
+// Without either, the whole batch is considered rejected.
+func failedTimeSeries(ctx context.Context, err error, batch []*mrpb.TimeSeries) int {
+	st := status.Convert(err)
+	messages := []string{st.Message()}
+	summaryFailed := -1
+	for _, detail := range st.Details() {
+		summary, ok := detail.(*mpb.CreateTimeSeriesSummary)
+		if !ok {
+			continue
+		}
+		summaryFailed = int(summary.GetTotalPointCount() - summary.GetSuccessPointCount())
+		for _, e := range summary.GetErrors() {
+			messages = append(messages, e.GetStatus().GetMessage())
+		}
+	}
+
+	failed := make(map[int]bool)
+	for _, m := range messages {
+		for _, match := range timeSeriesIndexes.FindAllStringSubmatch(m, -1) {
+			first, _ := strconv.Atoi(match[1])
+			last := first
+			if match[2] != "" {
+				last, _ = strconv.Atoi(match[2])
+			}
+			for i := first; i <= last && i < len(batch); i++ {
+				failed[i] = true
+			}
+		}
+	}
+	for i := range batch {
+		if !failed[i] {
+			continue
+		}
+		log.CtxLogger(ctx).Warnw("Dropping time series rejected by cloud monitoring", "metric", batch[i].GetMetric().GetType(), "labels", batch[i].GetMetric().GetLabels(), "error", err)
+	}
+	switch {
+	case len(failed) > 0:
+		return len(failed)
+	case summaryFailed >= 0 && summaryFailed <= len(batch):
+		log.CtxLogger(ctx).Warnw("Dropping time series rejected by cloud monitoring", "numberofmetrics", summaryFailed, "error", err)
+		return summaryFailed
+	default:
+		log.CtxLogger(ctx).Warnw("Dropping batch rejected by cloud monitoring", "numberofmetrics", len(batch), "error", err)
+		return len(batch)
+	}
+}
+
+// createBatch makes the API call for one batch, waiting for the rate limit first.
+func createBatch(ctx context.Context, batch []*mrpb.TimeSeries, timeSeriesCreator TimeSeriesCreator, bo *BackOffIntervals, projectID string) error {
+	log.CtxLogger(ctx).Debugw("Sending a batch of metrics to cloud monitoring.", "numberofmetrics", len(batch), "metrics", batch)
 	req := &mpb.CreateTimeSeriesRequest{
 		Name:       fmt.Sprintf("projects/%s", projectID),
-		TimeSeries: pruneBatch(batchTimeSeries),
+		TimeSeries: batch,
 	}
 
+	if err := requestLimiter.wait(ctx); err != nil {
+		return err
+	}
 	if bo != nil && bo.Retries > 0 {
 		return CreateTimeSeriesWithRetry(ctx, timeSeriesCreator, req, bo)
 	}
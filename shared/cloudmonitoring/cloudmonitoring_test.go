@@ -19,12 +19,17 @@ package cloudmonitoring
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/gax-go/v2"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
 	"github.com/GoogleCloudPlatform/sapagent/shared/cloudmonitoring/fake"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
@@ -249,6 +254,194 @@ func TestSendTimeSeries(t *testing.T) {
 	}
 }
 
+// invalidMetricCreator writes the time series of a request like cloud monitoring: the valid time
+// series are written, the time series of the invalid metric type are reported in an
+// InvalidArgument error, and a point written again is rejected as a duplicate.
+type invalidMetricCreator struct {
+	invalid string
+	calls   int
+	written map[string]bool
+}
+
+func (f *invalidMetricCreator) CreateTimeSeries(ctx context.Context, req *mpb.CreateTimeSeriesRequest, opts ...gax.CallOption) error {
+	f.calls++
+	if f.written == nil {
+		f.written = make(map[string]bool)
+	}
+	var failed []string
+	for i, ts := range req.GetTimeSeries() {
+		switch {
+		case ts.GetMetric().GetType() == f.invalid:
+			failed = append(failed, fmt.Sprintf("Field timeSeries[%d].metric.type had an invalid value", i))
+		case f.written[ts.GetMetric().GetType()]:
+			failed = append(failed, fmt.Sprintf("Points must be written in order. One or more of the points specified had an older start time than the most recent point.: timeSeries[%d]", i))
+		default:
+			f.written[ts.GetMetric().GetType()] = true
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	total := int32(len(req.GetTimeSeries()))
+	st, err := status.New(codes.InvalidArgument, "One or more TimeSeries could not be written: "+strings.Join(failed, "; ")).WithDetails(&mpb.CreateTimeSeriesSummary{
+		TotalPointCount:   total,
+		SuccessPointCount: total - int32(len(failed)),
+	})
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+func createNamedTimeSeries(types ...string) []*mrpb.TimeSeries {
+	var metrics []*mrpb.TimeSeries
+	for _, t := range types {
+		metrics = append(metrics, &mrpb.TimeSeries{Metric: &metricpb.Metric{Type: t}})
+	}
+	return metrics
+}
+
+func TestSendTimeSeriesDropsInvalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		timeSeries    []*mrpb.TimeSeries
+		invalid       string
+		wantSentCount int
+		wantDropped   int64
+	}{
+		{
+			name:          "NoInvalidTimeSeries",
+			timeSeries:    createNamedTimeSeries("a", "b", "c"),
+			invalid:       "misconfiguredCol",
+			wantSentCount: 3,
+		},
+		{
+			name:          "OneInvalidTimeSeries",
+			timeSeries:    createNamedTimeSeries("a", "b", "misconfiguredCol", "c", "d"),
+			invalid:       "misconfiguredCol",
+			wantSentCount: 4,
+			wantDropped:   1,
+		},
+		{
+			name:          "AllInvalid",
+			timeSeries:    createNamedTimeSeries("misconfiguredCol"),
+			invalid:       "misconfiguredCol",
+			wantSentCount: 0,
+			wantDropped:   1,
+		},
+		{
+			name: "MultipleInvalid",
+			// The invalid time series differ by label, so that they are not pruned as duplicates.
+			timeSeries: append(createNamedTimeSeries("misconfiguredCol", "a", "b"),
+				&mrpb.TimeSeries{Metric: &metricpb.Metric{Type: "misconfiguredCol", Labels: map[string]string{"sid": "DEF"}}}),
+			invalid:       "misconfiguredCol",
+			wantSentCount: 2,
+			wantDropped:   2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before := DroppedTimeSeries()
+			creator := &invalidMetricCreator{invalid: test.invalid}
+			gotSentCount, _, err := SendTimeSeries(context.Background(), test.timeSeries, creator, defaultBackOffIntervals, "test-project")
+			if err != nil {
+				t.Fatalf("SendTimeSeries() returned error: %v", err)
+			}
+			if gotSentCount != test.wantSentCount {
+				t.Errorf("SendTimeSeries() sent = %d, want: %d", gotSentCount, test.wantSentCount)
+			}
+			if gotDropped := DroppedTimeSeries() - before; gotDropped != test.wantDropped {
+				t.Errorf("DroppedTimeSeries() increased by %d, want: %d", gotDropped, test.wantDropped)
+			}
+		})
+	}
+}
+
+func TestSendTimeSeriesPartialWriteNotResent(t *testing.T) {
+	before := DroppedTimeSeries()
+	creator := &invalidMetricCreator{invalid: "misconfiguredCol"}
+	gotSentCount, _, err := SendTimeSeries(context.Background(), createNamedTimeSeries("a", "b", "misconfiguredCol", "c"), creator, defaultBackOffIntervals, "test-project")
+	if err != nil {
+		t.Fatalf("SendTimeSeries() returned error: %v", err)
+	}
+	if gotSentCount != 3 {
+		t.Errorf("SendTimeSeries() sent = %d, want: 3", gotSentCount)
+	}
+	if creator.calls != 1 {
+		t.Errorf("SendTimeSeries() made %d calls, want 1 as the valid time series of a partial write are not resent", creator.calls)
+	}
+
+	// The points of a partial write sent again are rejected as duplicates, only they are dropped.
+	gotSentCount, _, err = SendTimeSeries(context.Background(), createNamedTimeSeries("a", "d"), creator, defaultBackOffIntervals, "test-project")
+	if err != nil {
+		t.Fatalf("SendTimeSeries() returned error: %v", err)
+	}
+	if gotSentCount != 1 {
+		t.Errorf("SendTimeSeries() of a duplicate point sent = %d, want: 1", gotSentCount)
+	}
+	if gotDropped := DroppedTimeSeries() - before; gotDropped != 2 {
+		t.Errorf("DroppedTimeSeries() increased by %d, want: 2", gotDropped)
+	}
+}
+
+func TestFailedTimeSeries(t *testing.T) {
+	batch := createNamedTimeSeries("a", "b", "c", "d", "e", "f")
+	withSummary := func(msg string, total, success int32) error {
+		st, err := status.New(codes.InvalidArgument, msg).WithDetails(&mpb.CreateTimeSeriesSummary{TotalPointCount: total, SuccessPointCount: success})
+		if err != nil {
+			t.Fatalf("WithDetails() failed: %v", err)
+		}
+		return st.Err()
+	}
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "IndexesInMessage",
+			err:  status.Error(codes.InvalidArgument, "One or more TimeSeries could not be written: timeSeries[1]; timeSeries[4]"),
+			want: 2,
+		},
+		{
+			name: "IndexRange",
+			err:  status.Error(codes.InvalidArgument, "One or more TimeSeries could not be written: timeSeries[0-2]; timeSeries[2].points[0]"),
+			want: 3,
+		},
+		{
+			name: "SummaryOnly",
+			err:  withSummary("One or more TimeSeries could not be written", 6, 2),
+			want: 4,
+		},
+		{
+			name: "NoDetails",
+			err:  status.Error(codes.InvalidArgument, "Request was invalid"),
+			want: 6,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := failedTimeSeries(context.Background(), test.err, batch); got != test.want {
+				t.Errorf("failedTimeSeries(%v) = %d, want: %d", test.err, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCreateTimeSeriesWithRetryInvalidArgument(t *testing.T) {
+	creator := &invalidMetricCreator{invalid: "misconfiguredCol"}
+	req := &mpb.CreateTimeSeriesRequest{TimeSeries: createNamedTimeSeries("misconfiguredCol")}
+	err := CreateTimeSeriesWithRetry(context.Background(), creator, req, defaultBackOffIntervals)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateTimeSeriesWithRetry() returned error: %v, want code: %v", err, codes.InvalidArgument)
+	}
+	if creator.calls != 1 {
+		t.Errorf("CreateTimeSeriesWithRetry() made %d calls, want 1 for an invalid request", creator.calls)
+	}
+}
+
 func TestPrepareKey(t *testing.T) {
 	tests := []struct {
 		name string
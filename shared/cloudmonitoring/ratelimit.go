@@ -0,0 +1,75 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudmonitoring
+
+import (
+	"context"
+	"sync/atomic"
+
+	"golang.org/x/time/rate"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+// rateLimiter is a token bucket limiting the CreateTimeSeries requests sent by SendTimeSeries.
+// Requests over the limit wait for a token rather than being dropped.
+type rateLimiter struct {
+	limiter atomic.Pointer[rate.Limiter]
+	waiting atomic.Int64
+}
+
+// requestLimiter is shared by all collectors of the process so that together they stay below
+// the Cloud Monitoring write quota. Requests are not limited until SetRequestsPerMinute is called.
+var requestLimiter = newRateLimiter()
+
+func newRateLimiter() *rateLimiter {
+	r := &rateLimiter{}
+	r.limiter.Store(rate.NewLimiter(rate.Inf, 0))
+	return r
+}
+
+// SetRequestsPerMinute limits the requests sent by SendTimeSeries to requestsPerMinute across
+// the process. Bursts of up to a tenth of the requests per minute are sent without waiting.
+// A value of 0 or less removes the limit.
+func SetRequestsPerMinute(requestsPerMinute int64) {
+	requestLimiter.setRequestsPerMinute(requestsPerMinute)
+}
+
+// ThrottledRequests returns the number of requests currently waiting for the rate limit.
+func ThrottledRequests() int64 {
+	return requestLimiter.waiting.Load()
+}
+
+// setRequestsPerMinute replaces the limiter, the new limiter starts with a full bucket.
+func (r *rateLimiter) setRequestsPerMinute(requestsPerMinute int64) {
+	if requestsPerMinute <= 0 {
+		r.limiter.Store(rate.NewLimiter(rate.Inf, 0))
+		return
+	}
+	r.limiter.Store(rate.NewLimiter(rate.Limit(float64(requestsPerMinute)/60), max(1, int(requestsPerMinute/10))))
+}
+
+// wait blocks until the request can be sent or the context is done.
+func (r *rateLimiter) wait(ctx context.Context) error {
+	limiter := r.limiter.Load()
+	if limiter.Allow() {
+		return nil
+	}
+	r.waiting.Add(1)
+	defer r.waiting.Add(-1)
+	log.CtxLogger(ctx).Debugw("Throttling request to cloud monitoring", "waitingrequests", r.waiting.Load())
+	return limiter.Wait(ctx)
+}
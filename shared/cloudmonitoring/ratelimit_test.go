@@ -0,0 +1,89 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudmonitoring
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	tests := []struct {
+		name              string
+		requestsPerMinute int64
+		wantAllowed       int
+	}{
+		{
+			name:              "Unlimited",
+			requestsPerMinute: 0,
+			wantAllowed:       100,
+		},
+		{
+			name:              "BurstOfATenth",
+			requestsPerMinute: 60,
+			wantAllowed:       6,
+		},
+		{
+			name:              "MinimumBurst",
+			requestsPerMinute: 5,
+			wantAllowed:       1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newRateLimiter()
+			r.setRequestsPerMinute(tc.requestsPerMinute)
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			allowed := 0
+			for i := 0; i < 100; i++ {
+				if err := r.wait(ctx); err != nil {
+					break
+				}
+				allowed++
+			}
+			if allowed != tc.wantAllowed {
+				t.Errorf("wait() allowed %d requests without throttling, want: %d", allowed, tc.wantAllowed)
+			}
+			if got := r.waiting.Load(); got != 0 {
+				t.Errorf("wait() left %d requests waiting, want: 0", got)
+			}
+		})
+	}
+}
+
+func TestRateLimiterThrottledRequests(t *testing.T) {
+	r := newRateLimiter()
+	r.setRequestsPerMinute(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := r.wait(ctx); err != nil {
+		t.Fatalf("wait() returned error for the first request: %v", err)
+	}
+	done := make(chan error)
+	go func() { done <- r.wait(ctx) }()
+	for r.waiting.Load() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err == nil {
+		t.Error("wait() returned nil after the context was cancelled, want error")
+	}
+	if got := r.waiting.Load(); got != 0 {
+		t.Errorf("wait() left %d requests waiting, want: 0", got)
+	}
+}
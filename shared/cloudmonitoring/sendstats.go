@@ -0,0 +1,89 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudmonitoring
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+)
+
+// Subsystems identifying the callers of SendTimeSeriesForSubsystem.
+const (
+	SubsystemProcess        = "process"
+	SubsystemHANAMonitoring = "hanamonitoring"
+	SubsystemDiscovery      = "discovery"
+)
+
+// SendStats holds the outcome of the SendTimeSeriesForSubsystem calls made by one subsystem
+// since the process started.
+type SendStats struct {
+	Successes, Errors int64
+	// Latency is the total time spent in the calls, including retries and rate limit waits.
+	Latency time.Duration
+}
+
+// sendStats is shared by all collectors of the process, keyed by subsystem.
+var sendStats = newSendStatsRecorder()
+
+type sendStatsRecorder struct {
+	mu    sync.Mutex
+	stats map[string]SendStats
+}
+
+func newSendStatsRecorder() *sendStatsRecorder {
+	return &sendStatsRecorder{stats: make(map[string]SendStats)}
+}
+
+// SendTimeSeriesForSubsystem calls SendTimeSeries and records its latency and outcome for the
+// subsystem, these are returned by SendStatsBySubsystem.
+func SendTimeSeriesForSubsystem(ctx context.Context, subsystem string, timeSeries []*mrpb.TimeSeries, timeSeriesCreator TimeSeriesCreator, bo *BackOffIntervals, projectID string) (sent, batchCount int, err error) {
+	start := time.Now()
+	sent, batchCount, err = SendTimeSeries(ctx, timeSeries, timeSeriesCreator, bo, projectID)
+	sendStats.record(subsystem, time.Since(start), err)
+	return sent, batchCount, err
+}
+
+// SendStatsBySubsystem returns a copy of the send statistics recorded for each subsystem.
+func SendStatsBySubsystem() map[string]SendStats {
+	return sendStats.snapshot()
+}
+
+func (r *sendStatsRecorder) record(subsystem string, latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.stats[subsystem]
+	if err != nil {
+		s.Errors++
+	} else {
+		s.Successes++
+	}
+	s.Latency += latency
+	r.stats[subsystem] = s
+}
+
+func (r *sendStatsRecorder) snapshot() map[string]SendStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := make(map[string]SendStats, len(r.stats))
+	for k, v := range r.stats {
+		stats[k] = v
+	}
+	return stats
+}
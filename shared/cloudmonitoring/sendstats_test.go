@@ -0,0 +1,50 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudmonitoring
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSendStatsRecorder(t *testing.T) {
+	r := newSendStatsRecorder()
+	r.record(SubsystemProcess, time.Second, nil)
+	r.record(SubsystemProcess, 2*time.Second, errors.New("send failed"))
+	r.record(SubsystemHANAMonitoring, time.Second, nil)
+
+	got := r.snapshot()
+	want := map[string]SendStats{
+		SubsystemProcess:        {Successes: 1, Errors: 1, Latency: 3 * time.Second},
+		SubsystemHANAMonitoring: {Successes: 1, Latency: time.Second},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("snapshot() returned %d subsystems, want %d", len(got), len(want))
+	}
+	for subsystem, w := range want {
+		if got[subsystem] != w {
+			t.Errorf("snapshot()[%s] = %+v, want %+v", subsystem, got[subsystem], w)
+		}
+	}
+
+	// The snapshot must not change with later sends.
+	r.record(SubsystemProcess, time.Second, nil)
+	if got[SubsystemProcess].Successes != 1 {
+		t.Errorf("snapshot()[%s].Successes = %d after a later send, want 1", SubsystemProcess, got[SubsystemProcess].Successes)
+	}
+}
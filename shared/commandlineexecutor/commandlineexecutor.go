@@ -205,6 +205,37 @@ func ExecuteCommand(ctx context.Context, params Params) Result {
 	return Result{stdout.String(), stderr.String(), 0, nil, true, false}
 }
 
+/*
+ExecuteWithTimeout runs the command with the provided execute function and returns once the command
+completes or the timeout elapses, whichever happens first.
+
+The context passed to execute carries the timeout so the process is terminated when it elapses.
+Commands which do not return even after their process is terminated, for example when a child
+process keeps the output pipes open, are abandoned and a Result with an error wrapping
+context.DeadlineExceeded is returned so callers are never blocked past the timeout.
+*/
+func ExecuteWithTimeout(ctx context.Context, execute Execute, params Params, timeout time.Duration) Result {
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	done := make(chan Result, 1)
+	go func() {
+		done <- execute(tctx, params)
+	}()
+
+	select {
+	case result := <-done:
+		if result.Error != nil && errors.Is(tctx.Err(), context.DeadlineExceeded) {
+			result.Error = fmt.Errorf("command %s timed out after %v: %w", params.Executable, timeout, context.DeadlineExceeded)
+		}
+		return result
+	case <-tctx.Done():
+		return Result{
+			Error:           fmt.Errorf("command %s did not complete within %v: %w", params.Executable, timeout, tctx.Err()),
+			ExecutableFound: true,
+		}
+	}
+}
+
 /*
 CommandExists returns whether or not an executable command exists within the current os runtime
 environment.
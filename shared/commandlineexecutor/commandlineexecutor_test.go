@@ -18,9 +18,11 @@ package commandlineexecutor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -432,3 +434,57 @@ func TestExecuteCommandWithStdin(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteWithTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		execute     Execute
+		want        Result
+		wantTimeout bool
+	}{
+		{
+			name: "Completes",
+			execute: func(context.Context, Params) Result {
+				return Result{StdOut: "out", ExecutableFound: true}
+			},
+			want: Result{StdOut: "out", ExecutableFound: true},
+		},
+		{
+			name: "CommandError",
+			execute: func(context.Context, Params) Result {
+				return Result{ExitCode: 1, Error: errors.New("exit status 1"), ExecutableFound: true}
+			},
+			want: Result{ExitCode: 1, Error: cmpopts.AnyError, ExecutableFound: true},
+		},
+		{
+			name: "TerminatedOnTimeout",
+			execute: func(ctx context.Context, p Params) Result {
+				<-ctx.Done()
+				return Result{Error: errors.New("signal: killed"), ExecutableFound: true}
+			},
+			want:        Result{Error: cmpopts.AnyError, ExecutableFound: true},
+			wantTimeout: true,
+		},
+		{
+			name: "HungCommandAbandoned",
+			execute: func(ctx context.Context, p Params) Result {
+				time.Sleep(time.Second)
+				return Result{StdOut: "too late"}
+			},
+			want:        Result{Error: cmpopts.AnyError, ExecutableFound: true},
+			wantTimeout: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ExecuteWithTimeout(context.Background(), test.execute, Params{Executable: "test"}, 50*time.Millisecond)
+			if diff := cmp.Diff(test.want, got, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("ExecuteWithTimeout() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			if gotTimeout := errors.Is(got.Error, context.DeadlineExceeded); gotTimeout != test.wantTimeout {
+				t.Errorf("ExecuteWithTimeout() timed out: %t, want: %t", gotTimeout, test.wantTimeout)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ package fake
 import (
 	"context"
 	"testing"
+	"time"
 
 	compute "google.golang.org/api/compute/v1"
 	file "google.golang.org/api/file/v1"
@@ -58,6 +59,10 @@ type TestGCE struct {
 	ListDisksErr       []error
 	ListDisksCallCount int
 
+	ListDiskSnapshotsResp      []*compute.SnapshotList
+	ListDiskSnapshotsErr       []error
+	ListDiskSnapshotsCallCount int
+
 	ListZoneOperationsResp      []*compute.OperationList
 	ListZoneOperationsErr       []error
 	ListZoneOperationsCallCount int
@@ -123,9 +128,18 @@ type TestGCE struct {
 	CreateSnapshotOp  *compute.Operation
 	CreateSnapshotErr error
 
+	CreateDiskOp  *compute.Operation
+	CreateDiskErr error
+
+	DeleteDiskOp  *compute.Operation
+	DeleteDiskErr error
+
 	SnapshotList    *compute.SnapshotList
 	SnapshotListErr error
 
+	GetSnapshotResp *compute.Snapshot
+	GetSnapshotErr  error
+
 	AddResourcePoliciesOp  *compute.Operation
 	AddResourcePoliciesErr error
 
@@ -178,6 +192,17 @@ func (g *TestGCE) ListDisks(project, zone, filter string) (*compute.DiskList, er
 	return g.ListDisksResp[g.ListDisksCallCount], g.ListDisksErr[g.ListDisksCallCount]
 }
 
+// ListDiskSnapshots fakes a call to the compute API to retrieve the snapshots of a disk.
+func (g *TestGCE) ListDiskSnapshots(project, diskURI string) (*compute.SnapshotList, error) {
+	defer func() {
+		g.ListDiskSnapshotsCallCount++
+		if g.ListDiskSnapshotsCallCount >= len(g.ListDiskSnapshotsResp) || g.ListDiskSnapshotsCallCount >= len(g.ListDiskSnapshotsErr) {
+			g.ListDiskSnapshotsCallCount = 0
+		}
+	}()
+	return g.ListDiskSnapshotsResp[g.ListDiskSnapshotsCallCount], g.ListDiskSnapshotsErr[g.ListDiskSnapshotsCallCount]
+}
+
 // ListZoneOperations  fakes a call to the compute API to retrieve a list of Operations resources.
 func (g *TestGCE) ListZoneOperations(project, zone, filter string, maxResults int64) (*compute.OperationList, error) {
 	defer func() {
@@ -294,7 +319,7 @@ func (g *TestGCE) GetInstanceByIP(project, ip string) (*compute.Instance, error)
 }
 
 // GetURIForIP fakes calls to compute APIs to locate an object URI related to the IP address provided.
-func (g *TestGCE) GetURIForIP(project, ip, region, subnetwork string) (string, error) {
+func (g *TestGCE) GetURIForIP(project, ip, region, subnetwork string, filestoreLocations []string) (string, error) {
 	defer func() {
 		g.GetURIForIPCallCount++
 		if g.GetURIForIPCallCount >= len(g.GetURIForIPResp) || g.GetURIForIPCallCount >= len(g.GetURIForIPErr) {
@@ -355,12 +380,12 @@ func (g *TestGCE) DiskAttachedToInstance(project, zone, instanceName, diskName s
 }
 
 // WaitForSnapshotCreationCompletionWithRetry fakes calls to the cloud APIs to wait for a disk creation operation to complete.
-func (g *TestGCE) WaitForSnapshotCreationCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string) error {
+func (g *TestGCE) WaitForSnapshotCreationCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string, interval, maxWait time.Duration) error {
 	return g.CreationCompletionErr
 }
 
 // WaitForSnapshotUploadCompletionWithRetry fakes calls to the cloud APIs to wait for a disk upload operation to complete.
-func (g *TestGCE) WaitForSnapshotUploadCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string) error {
+func (g *TestGCE) WaitForSnapshotUploadCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string, interval, maxWait time.Duration) error {
 	return g.UploadCompletionErr
 }
 
@@ -384,6 +409,21 @@ func (g *TestGCE) CreateSnapshot(ctx context.Context, project string, snapshotRe
 	return g.CreateSnapshotOp, g.CreateSnapshotErr
 }
 
+// CreateDisk fakes calls to the cloud APIs to create a disk.
+func (g *TestGCE) CreateDisk(ctx context.Context, project, zone string, disk *compute.Disk) (*compute.Operation, error) {
+	return g.CreateDiskOp, g.CreateDiskErr
+}
+
+// DeleteDisk fakes calls to the cloud APIs to delete a disk.
+func (g *TestGCE) DeleteDisk(ctx context.Context, project, zone, name string) (*compute.Operation, error) {
+	return g.DeleteDiskOp, g.DeleteDiskErr
+}
+
+// GetSnapshot fakes calls to the cloud APIs to get a snapshot.
+func (g *TestGCE) GetSnapshot(ctx context.Context, project, name string) (*compute.Snapshot, error) {
+	return g.GetSnapshotResp, g.GetSnapshotErr
+}
+
 // ListSnapshots fakes calls to the cloud APIs to list snapshots.
 func (g *TestGCE) ListSnapshots(ctx context.Context, project string) (*compute.SnapshotList, error) {
 	return g.SnapshotList, g.SnapshotListErr
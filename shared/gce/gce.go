@@ -20,6 +20,7 @@ package gce
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -29,10 +30,50 @@ import (
 	smpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1"
 	compute "google.golang.org/api/compute/v1"
 	file "google.golang.org/api/file/v1"
+	"google.golang.org/api/googleapi"
 	ipb "github.com/GoogleCloudPlatform/sapagent/protos/instanceinfo"
 	"github.com/GoogleCloudPlatform/sapagent/shared/log"
 )
 
+// ErrorClass tells whether a failed API call is worth retrying.
+type ErrorClass int
+
+// ErrorClass values.
+const (
+	// ErrorUnclassified is an error which is not a googleapi.Error or has another status code.
+	ErrorUnclassified ErrorClass = iota
+	// ErrorPermanent is an error which is returned again on retry, e.g. a missing resource.
+	ErrorPermanent
+	// ErrorTransient is an error which may succeed on retry, e.g. quota exhaustion.
+	ErrorTransient
+)
+
+// ClassifyError classifies the googleapi.Error wrapped in err by its HTTP status code.
+// 403 and 404 are permanent, 429, 500 and 503 are transient.
+func ClassifyError(err error) ErrorClass {
+	var gErr *googleapi.Error
+	if !errors.As(err, &gErr) {
+		return ErrorUnclassified
+	}
+	switch gErr.Code {
+	case http.StatusForbidden, http.StatusNotFound:
+		return ErrorPermanent
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable:
+		return ErrorTransient
+	default:
+		return ErrorUnclassified
+	}
+}
+
+// RetryableError marks permanent errors with backoff.Permanent so backoff.Retry stops on them,
+// other errors are returned unchanged.
+func RetryableError(err error) error {
+	if ClassifyError(err) == ErrorPermanent {
+		return backoff.Permanent(err)
+	}
+	return err
+}
+
 // GCE is a wrapper for Google Compute Engine services.
 type GCE struct {
 	service *compute.Service
@@ -98,6 +139,20 @@ func (g *GCE) ListDisks(project, zone, filter string) (*compute.DiskList, error)
 	return g.service.Disks.List(project, zone).Filter(filter).Do()
 }
 
+// ListDiskSnapshots retrieves the snapshots in the project created from the disk with the URI provided.
+func (g *GCE) ListDiskSnapshots(project, diskURI string) (*compute.SnapshotList, error) {
+	snapshots := &compute.SnapshotList{}
+	call := g.service.Snapshots.List(project).Filter(fmt.Sprintf("sourceDisk = %q", diskURI))
+	err := call.Pages(context.Background(), func(page *compute.SnapshotList) error {
+		snapshots.Items = append(snapshots.Items, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
 // ListZoneOperations retrieves a list of Operations resources defined by the project, and zone provided.
 // Results will be filtered according to the provided filter string, and limit the number jof results to maxResults.
 func (g *GCE) ListZoneOperations(project, zone, filter string, maxResults int64) (*compute.OperationList, error) {
@@ -160,8 +215,12 @@ func (g *GCE) GetRegionalBackendService(project, region, service string) (*compu
 	return g.service.RegionBackendServices.Get(project, region, service).Do()
 }
 
-// GetForwardingRule retrieves a GCE Forwarding rule defined by the project, zone, and name provided.
+// GetForwardingRule retrieves a GCE Forwarding rule defined by the project, location, and name provided.
+// An empty location retrieves a global forwarding rule.
 func (g *GCE) GetForwardingRule(project, location, name string) (*compute.ForwardingRule, error) {
+	if location == "" {
+		return g.service.GlobalForwardingRules.Get(project, name).Do()
+	}
 	return g.service.ForwardingRules.Get(project, location, name).Do()
 }
 
@@ -206,7 +265,8 @@ func (g *GCE) GetFilestoreByIP(project, location, ip string) (*file.ListInstance
 }
 
 // GetURIForIP attempts to locate the URI for any object that is related to the IP address provided.
-func (g *GCE) GetURIForIP(project, ip, region, subnetwork string) (string, error) {
+// Filestore instances are searched in filestoreLocations, or in all locations when it is empty.
+func (g *GCE) GetURIForIP(project, ip, region, subnetwork string, filestoreLocations []string) (string, error) {
 	log.Logger.Debugw("GetURIForIP", "project", project, "ip", ip, "region", region, "subnetwork", subnetwork)
 	addr, _ := g.GetAddressByIP(project, "", subnetwork, ip)
 	if addr != nil {
@@ -222,10 +282,19 @@ func (g *GCE) GetURIForIP(project, ip, region, subnetwork string) (string, error
 		return fwr.SelfLink, nil
 	}
 
-	fs, err := g.GetFilestoreByIP(project, "-", ip)
-	if fs != nil && len(fs.Instances) > 0 {
-		fsURI := strings.Replace(fs.Instances[0].Name, "/instances/", "/filestores/", 1)
-		return fsURI, nil
+	locations := filestoreLocations
+	if len(locations) == 0 {
+		locations = []string{"-"}
+	}
+	log.Logger.Debugw("Searching for a Filestore by IP", "project", project, "ip", ip, "locations", locations)
+	var err error
+	for _, location := range locations {
+		var fs *file.ListInstancesResponse
+		fs, err = g.GetFilestoreByIP(project, location, ip)
+		if fs != nil && len(fs.Instances) > 0 {
+			fsURI := strings.Replace(fs.Instances[0].Name, "/instances/", "/filestores/", 1)
+			return fsURI, nil
+		}
 	}
 	return "", errors.Errorf("error locating object by IP: %v", err)
 }
@@ -281,11 +350,13 @@ func (g *GCE) waitForSnapshotCreationCompletion(ctx context.Context, op *compute
 }
 
 // WaitForSnapshotCreationCompletionWithRetry waits for the given compute operation to complete.
-// We sleep for 1s between retries a total 300 times => max_wait_duration = 5 minutes
-func (g *GCE) WaitForSnapshotCreationCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string) error {
-	constantBackoff := backoff.NewConstantBackOff(1 * time.Second)
-	bo := backoff.WithContext(backoff.WithMaxRetries(constantBackoff, 300), ctx)
-	return backoff.Retry(func() error { return g.waitForSnapshotCreationCompletion(ctx, op, project, snapshotName) }, bo)
+// The snapshot is polled every interval for at most maxWait, zero values default to polling
+// every 1s for 5 minutes.
+func (g *GCE) WaitForSnapshotCreationCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string, interval, maxWait time.Duration) error {
+	bo := pollBackOff(ctx, interval, maxWait, time.Second, 5*time.Minute)
+	return backoff.Retry(func() error {
+		return RetryableError(g.waitForSnapshotCreationCompletion(ctx, op, project, snapshotName))
+	}, bo)
 }
 
 // waitForUploadCompletion waits for the given snapshot upload operation to complete.
@@ -314,11 +385,26 @@ func (g *GCE) waitForUploadCompletion(ctx context.Context, op *compute.Operation
 }
 
 // WaitForSnapshotUploadCompletionWithRetry waits for the given compute operation to complete.
-// We sleep for 30s between retries a total 480 times => max_wait_duration = 30*480 = 4 Hours
-func (g *GCE) WaitForSnapshotUploadCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string) error {
-	constantBackoff := backoff.NewConstantBackOff(30 * time.Second)
-	bo := backoff.WithContext(backoff.WithMaxRetries(constantBackoff, 480), ctx)
-	return backoff.Retry(func() error { return g.waitForUploadCompletion(ctx, op, project, diskZone, snapshotName) }, bo)
+// The operation is polled every interval for at most maxWait, zero values default to polling
+// every 30s for 4 hours.
+func (g *GCE) WaitForSnapshotUploadCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string, interval, maxWait time.Duration) error {
+	bo := pollBackOff(ctx, interval, maxWait, 30*time.Second, 4*time.Hour)
+	return backoff.Retry(func() error {
+		return RetryableError(g.waitForUploadCompletion(ctx, op, project, diskZone, snapshotName))
+	}, bo)
+}
+
+// pollBackOff returns a constant backoff polling every interval for at most maxWait, the
+// defaults are used for the values which are not positive.
+func pollBackOff(ctx context.Context, interval, maxWait, defaultInterval, defaultMaxWait time.Duration) backoff.BackOff {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	if maxWait <= 0 {
+		maxWait = defaultMaxWait
+	}
+	retries := uint64(maxWait / interval)
+	return backoff.WithContext(backoff.WithMaxRetries(backoff.NewConstantBackOff(interval), retries), ctx)
 }
 
 // waitForDiskOpCompletion waits for the given disk operation to complete.
@@ -340,7 +426,9 @@ func (g *GCE) waitForDiskOpCompletion(ctx context.Context, op *compute.Operation
 func (g *GCE) WaitForDiskOpCompletionWithRetry(ctx context.Context, op *compute.Operation, project, dataDiskZone string) error {
 	constantBackoff := backoff.NewConstantBackOff(120 * time.Second)
 	bo := backoff.WithContext(backoff.WithMaxRetries(constantBackoff, 10), ctx)
-	return backoff.Retry(func() error { return g.waitForDiskOpCompletion(ctx, op, project, dataDiskZone) }, bo)
+	return backoff.Retry(func() error {
+		return RetryableError(g.waitForDiskOpCompletion(ctx, op, project, dataDiskZone))
+	}, bo)
 }
 
 // AttachDisk attaches the disk with the given name to the instance.
@@ -386,11 +474,34 @@ func (g *GCE) CreateSnapshot(ctx context.Context, project string, snapshotReq *c
 	snapshotsService := compute.NewSnapshotsService(g.service)
 	op, err := snapshotsService.Insert(project, snapshotReq).Do()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create snapshot: %v", err)
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
 	}
 	return op, nil
 }
 
+// CreateDisk creates a persistent disk in the zone.
+func (g *GCE) CreateDisk(ctx context.Context, project, zone string, disk *compute.Disk) (*compute.Operation, error) {
+	op, err := g.service.Disks.Insert(project, zone, disk).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create disk: %v", err)
+	}
+	return op, nil
+}
+
+// DeleteDisk deletes the persistent disk from the zone.
+func (g *GCE) DeleteDisk(ctx context.Context, project, zone, name string) (*compute.Operation, error) {
+	op, err := g.service.Disks.Delete(project, zone, name).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete disk: %v", err)
+	}
+	return op, nil
+}
+
+// GetSnapshot retrieves a snapshot of the project.
+func (g *GCE) GetSnapshot(ctx context.Context, project, name string) (*compute.Snapshot, error) {
+	return g.service.Snapshots.Get(project, name).Context(ctx).Do()
+}
+
 // ListSnapshots lists the snapshots for a given project.
 func (g *GCE) ListSnapshots(ctx context.Context, project string) (*compute.SnapshotList, error) {
 	snapshotService := compute.NewSnapshotsService(g.service)
@@ -482,5 +593,7 @@ func (g *GCE) waitForGlobalUploadCompletion(ctx context.Context, op *compute.Ope
 func (g *GCE) WaitForInstantSnapshotConversionCompletionWithRetry(ctx context.Context, op *compute.Operation, project, diskZone, snapshotName string) error {
 	constantBackoff := backoff.NewConstantBackOff(30 * time.Second)
 	bo := backoff.WithContext(backoff.WithMaxRetries(constantBackoff, 480), ctx)
-	return backoff.Retry(func() error { return g.waitForGlobalUploadCompletion(ctx, op, project, diskZone, snapshotName) }, bo)
+	return backoff.Retry(func() error {
+		return RetryableError(g.waitForGlobalUploadCompletion(ctx, op, project, diskZone, snapshotName))
+	}, bo)
 }
@@ -0,0 +1,190 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadataserver
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	backoff "github.com/cenkalti/backoff/v4"
+	"github.com/GoogleCloudPlatform/sapagent/shared/log"
+)
+
+const (
+	// RetriesEnv overrides the number of retries when fetching the cloud properties.
+	RetriesEnv = "GOOGLE_CLOUD_SAP_AGENT_METADATA_RETRIES"
+	// RetryIntervalEnv sets a fixed interval between the retries, ex: 5s.
+	// The retries use an exponential backoff when it is not set.
+	RetryIntervalEnv = "GOOGLE_CLOUD_SAP_AGENT_METADATA_RETRY_INTERVAL"
+	// SecondaryURLEnv overrides the metadata server endpoint tried when the primary one cannot be reached.
+	SecondaryURLEnv = "GOOGLE_CLOUD_SAP_AGENT_METADATA_SECONDARY_URL"
+
+	// SecondaryMetadataServerURL reaches the metadata server by its link-local address,
+	// which does not depend on name resolution being available.
+	SecondaryMetadataServerURL = "http://169.254.169.254/computeMetadata/v1"
+
+	linuxCloudPropertiesCachePath   = `/var/lib/google-cloud-sap-agent/cloud-properties.json`
+	windowsCloudPropertiesCachePath = `C:\Program Files\Google\google-cloud-sap-agent\conf\cloud-properties.json`
+
+	defaultRetries = 1
+)
+
+// hostname is replaced in tests.
+var hostname = os.Hostname
+
+// cachedCloudProperties is the content of the cloud properties cache. The hostname ties the cache
+// to the host which wrote it, so that a cache copied to another instance with a disk image is not
+// used there.
+type cachedCloudProperties struct {
+	Hostname        string
+	CloudProperties *CloudProperties
+}
+
+// FetchOptions configure how the cloud properties are fetched when the agent starts.
+type FetchOptions struct {
+	Retries       int
+	RetryInterval time.Duration
+	SecondaryURL  string
+	// BareMetal skips the secondary endpoint, its link-local address does not reach a metadata
+	// server on bare metal.
+	BareMetal bool
+	// CachePath is the local file the cloud properties are written to after a successful fetch,
+	// and read from when the metadata server cannot be reached.
+	CachePath string
+}
+
+// FetchOptionsFromEnv returns the default fetch options for the OS, overridden by the
+// RetriesEnv, RetryIntervalEnv and SecondaryURLEnv environment variables.
+func FetchOptionsFromEnv(goos string, getenv func(string) string) FetchOptions {
+	opts := FetchOptions{
+		Retries:      defaultRetries,
+		SecondaryURL: SecondaryMetadataServerURL,
+		CachePath:    linuxCloudPropertiesCachePath,
+	}
+	if goos == "windows" {
+		opts.CachePath = windowsCloudPropertiesCachePath
+	}
+	if v := getenv(RetriesEnv); v != "" {
+		if retries, err := strconv.Atoi(v); err == nil && retries >= 0 {
+			opts.Retries = retries
+		} else {
+			log.Logger.Warnw("Ignoring invalid metadata server retries", "variable", RetriesEnv, "value", v)
+		}
+	}
+	if v := getenv(RetryIntervalEnv); v != "" {
+		if interval, err := time.ParseDuration(v); err == nil && interval > 0 {
+			opts.RetryInterval = interval
+		} else {
+			log.Logger.Warnw("Ignoring invalid metadata server retry interval", "variable", RetryIntervalEnv, "value", v)
+		}
+	}
+	if v := getenv(SecondaryURLEnv); v != "" {
+		opts.SecondaryURL = v
+	}
+	return opts
+}
+
+// backOff returns the retry policy of the options.
+func (o FetchOptions) backOff() backoff.BackOff {
+	var bo backoff.BackOff = backoff.NewExponentialBackOff()
+	if o.RetryInterval > 0 {
+		bo = backoff.NewConstantBackOff(o.RetryInterval)
+	}
+	return backoff.WithMaxRetries(bo, uint64(o.Retries))
+}
+
+// FetchCloudPropertiesWithOptions retrieves the cloud properties, trying the secondary endpoint
+// on each attempt the primary one fails unless on bare metal. The properties are cached after a
+// successful fetch and the cached properties are returned when all of the attempts fail, if they
+// were cached by this host.
+func FetchCloudPropertiesWithOptions(opts FetchOptions) *CloudProperties {
+	var (
+		attempt = 1
+		cp      *CloudProperties
+	)
+	err := backoff.Retry(func() error {
+		var err error
+		cp, err = requestProperties(metadataServerURL)
+		if err != nil && opts.SecondaryURL != "" && !opts.BareMetal {
+			log.Logger.Debugw("Trying the secondary metadata server endpoint", "url", opts.SecondaryURL, "error", err)
+			cp, err = requestProperties(opts.SecondaryURL)
+		}
+		if err != nil {
+			log.Logger.Warnw("Error in requestCloudProperties", "attempt", attempt, "error", err)
+			attempt++
+		}
+		return err
+	}, opts.backOff())
+	if err != nil {
+		log.Logger.Errorw("CloudProperties request retry limit exceeded", log.Error(err))
+		return readCachedCloudProperties(opts.CachePath)
+	}
+	writeCachedCloudProperties(opts.CachePath, cp)
+	return cp
+}
+
+// readCachedCloudProperties returns the cloud properties cached at path, or nil if there are none
+// or they were cached by another host.
+func readCachedCloudProperties(path string) *CloudProperties {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Logger.Warnw("Could not read cached cloud properties", "path", path, "error", err)
+		return nil
+	}
+	cached := &cachedCloudProperties{}
+	if err := json.Unmarshal(data, cached); err != nil || cached.CloudProperties == nil {
+		log.Logger.Warnw("Could not parse cached cloud properties", "path", path, "error", err)
+		return nil
+	}
+	host, err := hostname()
+	if err != nil || host != cached.Hostname {
+		log.Logger.Warnw("Ignoring the cloud properties cached by another host", "path", path, "cachedHostname", cached.Hostname, "hostname", host, "error", err)
+		return nil
+	}
+	log.Logger.Warnw("Using the cloud properties cached from a previous successful fetch", "path", path)
+	return cached.CloudProperties
+}
+
+// writeCachedCloudProperties caches the cloud properties at path.
+func writeCachedCloudProperties(path string, cp *CloudProperties) {
+	if path == "" {
+		return
+	}
+	host, err := hostname()
+	if err != nil {
+		log.Logger.Debugw("Could not read the hostname, not caching cloud properties", "error", err)
+		return
+	}
+	data, err := json.Marshal(cachedCloudProperties{Hostname: host, CloudProperties: cp})
+	if err != nil {
+		log.Logger.Debugw("Could not marshal cloud properties", "error", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Logger.Debugw("Could not create the cloud properties cache directory", "path", path, "error", err)
+		return
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		log.Logger.Debugw("Could not cache cloud properties", "path", path, "error", err)
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadataserver
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFetchOptionsFromEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		goos string
+		env  map[string]string
+		want FetchOptions
+	}{
+		{
+			name: "Defaults",
+			goos: "linux",
+			want: FetchOptions{
+				Retries:      defaultRetries,
+				SecondaryURL: SecondaryMetadataServerURL,
+				CachePath:    linuxCloudPropertiesCachePath,
+			},
+		},
+		{
+			name: "WindowsDefaults",
+			goos: "windows",
+			want: FetchOptions{
+				Retries:      defaultRetries,
+				SecondaryURL: SecondaryMetadataServerURL,
+				CachePath:    windowsCloudPropertiesCachePath,
+			},
+		},
+		{
+			name: "Overrides",
+			goos: "linux",
+			env: map[string]string{
+				RetriesEnv:       "5",
+				RetryIntervalEnv: "10s",
+				SecondaryURLEnv:  "http://metadata.internal/computeMetadata/v1",
+			},
+			want: FetchOptions{
+				Retries:       5,
+				RetryInterval: 10 * time.Second,
+				SecondaryURL:  "http://metadata.internal/computeMetadata/v1",
+				CachePath:     linuxCloudPropertiesCachePath,
+			},
+		},
+		{
+			name: "InvalidOverridesIgnored",
+			goos: "linux",
+			env: map[string]string{
+				RetriesEnv:       "-1",
+				RetryIntervalEnv: "ten seconds",
+			},
+			want: FetchOptions{
+				Retries:      defaultRetries,
+				SecondaryURL: SecondaryMetadataServerURL,
+				CachePath:    linuxCloudPropertiesCachePath,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := FetchOptionsFromEnv(test.goos, func(key string) string { return test.env[key] })
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("FetchOptionsFromEnv() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFetchCloudPropertiesWithOptions(t *testing.T) {
+	ts := mockMetadataServer(t, endpoint{
+		uri: cloudPropertiesURI,
+		responseBody: marshalResponse(t, metadataServerResponse{
+			Project: projectInfo{ProjectID: "test-project", NumericProjectID: 1},
+			Instance: instanceInfo{
+				ID:          101,
+				Zone:        "projects/test-project/zones/test-zone",
+				Name:        "test-instance-name",
+				Image:       "test-image",
+				MachineType: "projects/test-project/machineTypes/test-machine-type",
+			},
+		}),
+	})
+	defer ts.Close()
+	want := &CloudProperties{
+		ProjectID:        "test-project",
+		NumericProjectID: "1",
+		InstanceID:       "101",
+		Zone:             "test-zone",
+		InstanceName:     "test-instance-name",
+		Image:            "test-image",
+		MachineType:      "test-machine-type",
+	}
+	unreachable := "http://localhost:0"
+	hostname = func() (string, error) { return "test-host", nil }
+	defer func() { hostname = os.Hostname }()
+
+	tests := []struct {
+		name         string
+		primaryURL   string
+		secondaryURL string
+		bareMetal    bool
+		cached       string
+		want         *CloudProperties
+	}{
+		{
+			name:       "PrimarySuccess",
+			primaryURL: ts.URL,
+			want:       want,
+		},
+		{
+			name:         "SecondarySuccess",
+			primaryURL:   unreachable,
+			secondaryURL: ts.URL,
+			want:         want,
+		},
+		{
+			name:         "BareMetalSkipsSecondary",
+			primaryURL:   unreachable,
+			secondaryURL: ts.URL,
+			bareMetal:    true,
+		},
+		{
+			name:       "CachedProperties",
+			primaryURL: unreachable,
+			cached:     `{"Hostname": "test-host", "CloudProperties": {"ProjectID": "cached-project", "InstanceID": "102"}}`,
+			want:       &CloudProperties{ProjectID: "cached-project", InstanceID: "102"},
+		},
+		{
+			name:       "CachedByAnotherHost",
+			primaryURL: unreachable,
+			cached:     `{"Hostname": "other-host", "CloudProperties": {"ProjectID": "cached-project", "InstanceID": "102"}}`,
+		},
+		{
+			name:       "CachedWithoutHostname",
+			primaryURL: unreachable,
+			cached:     `{"ProjectID": "cached-project", "InstanceID": "102"}`,
+		},
+		{
+			name:       "InvalidCachedProperties",
+			primaryURL: unreachable,
+			cached:     "not json",
+		},
+		{
+			name:       "NoCachedProperties",
+			primaryURL: unreachable,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cachePath := path.Join(t.TempDir(), "state", "cloud-properties.json")
+			if test.cached != "" {
+				if err := os.MkdirAll(path.Dir(cachePath), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(cachePath, []byte(test.cached), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			metadataServerURL = test.primaryURL
+			opts := FetchOptions{SecondaryURL: test.secondaryURL, BareMetal: test.bareMetal, CachePath: cachePath, RetryInterval: time.Millisecond}
+
+			got := FetchCloudPropertiesWithOptions(opts)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("FetchCloudPropertiesWithOptions() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			if test.cached != "" || got == nil {
+				return
+			}
+			// Fetched properties are cached for the next start.
+			if diff := cmp.Diff(got, readCachedCloudProperties(cachePath)); diff != "" {
+				t.Errorf("readCachedCloudProperties() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ package metadataserver
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -50,6 +51,9 @@ var (
 	// not a const so we can override in test suite.
 	metadataServerURL                     = "http://metadata.google.internal/computeMetadata/v1"
 	metadataNoUpcomingMaintenanceResponse = `{ "error": "no notifications have been received yet, try again later" }`
+
+	// errNotFound is returned by get when the requested metadata key does not exist.
+	errNotFound = errors.New("metadata key not found")
 )
 
 const (
@@ -57,6 +61,11 @@ const (
 	maintenanceEventURI    = "/instance/maintenance-event"
 	upcomingMaintenanceURI = "/instance/upcoming-maintenance"
 	diskType               = "/instance/disks/"
+	instanceAttributesURI  = "/instance/attributes/"
+	agentConfigURI         = instanceAttributesURI + AgentConfigKey
+
+	// AgentConfigKey is the instance metadata key holding agent configuration overrides.
+	AgentConfigKey = "google-cloud-sap-agent-config"
 
 	helpString = `For information on permissions needed to access metadata refer: https://cloud.google.com/compute/docs/metadata/querying-metadata#permissions. Restart the agent after adding necessary permissions.`
 )
@@ -122,7 +131,7 @@ func ReadCloudPropertiesWithRetry(bo backoff.BackOff) *CloudProperties {
 	)
 	err := backoff.Retry(func() error {
 		var err error
-		cp, err = requestProperties()
+		cp, err = requestProperties(metadataServerURL)
 		if err != nil {
 			log.Logger.Warnw("Error in requestCloudProperties", "attempt", attempt, "error", err)
 			attempt++
@@ -161,7 +170,12 @@ func DiskTypeWithRetry(bo backoff.BackOff, disk string) string {
 
 // get performs a get request to the metadata server and returns the response body.
 func get(uri, queryString string) ([]byte, error) {
-	metadataURL, err := url.Parse(metadataServerURL)
+	return getFrom(metadataServerURL, uri, queryString)
+}
+
+// getFrom performs a get request to the metadata server at baseURL and returns the response body.
+func getFrom(baseURL, uri, queryString string) ([]byte, error) {
+	metadataURL, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse metadata server url: %v, %s", err, helpString)
 	}
@@ -186,6 +200,9 @@ func get(uri, queryString string) ([]byte, error) {
 			}
 			return body, nil
 		}
+		if res.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("unsuccessful response from metadata server: %s, %w", res.Status, errNotFound)
+		}
 		return nil, fmt.Errorf("unsuccessful response from metadata server: %s, %s", res.Status, helpString)
 	}
 	body, err := io.ReadAll(res.Body)
@@ -242,9 +259,9 @@ func requestCloudProperties() (*instancepb.CloudProperties, error) {
 	}, nil
 }
 
-// requestProperties attempts to fetch information from the GCE metadata server.
-func requestProperties() (*CloudProperties, error) {
-	body, err := get(cloudPropertiesURI, "recursive=true")
+// requestProperties attempts to fetch information from the GCE metadata server at baseURL.
+func requestProperties(baseURL string) (*CloudProperties, error) {
+	body, err := getFrom(baseURL, cloudPropertiesURI, "recursive=true")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cloud properties from metadata server: %v", err)
 	}
@@ -347,3 +364,66 @@ func FetchGCEUpcomingMaintenance() (string, error) {
 	}
 	return string(body), nil
 }
+
+// FetchAgentConfigOverride retrieves the agent configuration overrides stored in the instance
+// metadata under AgentConfigKey. An empty string is returned when the key is not set.
+func FetchAgentConfigOverride() (string, error) {
+	body, err := get(agentConfigURI, "")
+	if errors.Is(err, errNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// FetchClockSkew returns the difference between the local clock and the clock of the metadata
+// server, a positive skew means the local clock is ahead. The server time is read from the Date
+// header of a response, which has a resolution of one second, so the skew is accurate to about a
+// second.
+func FetchClockSkew() (time.Duration, error) {
+	return clockSkew(metadataServerURL, time.Now)
+}
+
+// clockSkew is a testable version of FetchClockSkew.
+func clockSkew(baseURL string, now func() time.Time) (time.Duration, error) {
+	req, err := http.NewRequest("GET", baseURL+cloudPropertiesURI, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to make request to metadata server: %v, %s", err, helpString)
+	}
+	req.Header.Add("Metadata-Flavor", "Google")
+	client := &http.Client{Timeout: 2 * time.Second}
+	sent := now()
+	res, err := client.Do(req)
+	received := now()
+	if err != nil {
+		return 0, fmt.Errorf("failed to receive response from metadata server: %v, %s", err, helpString)
+	}
+	defer res.Body.Close()
+	if !isStatusSuccess(res.StatusCode) {
+		return 0, fmt.Errorf("unsuccessful response from metadata server: %s, %s", res.Status, helpString)
+	}
+	date, err := http.ParseTime(res.Header.Get("Date"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the Date header of the metadata server response: %v", err)
+	}
+	// The Date header is truncated to the second, and the local time is taken halfway through the
+	// request to cancel out the network latency.
+	serverTime := date.Add(500 * time.Millisecond)
+	localTime := sent.Add(received.Sub(sent) / 2)
+	return localTime.Sub(serverTime), nil
+}
+
+// FetchInstanceAttribute retrieves the value of a custom attribute from the instance metadata.
+// An empty string is returned when the attribute is not set.
+func FetchInstanceAttribute(key string) (string, error) {
+	body, err := get(instanceAttributesURI+key, "")
+	if errors.Is(err, errNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
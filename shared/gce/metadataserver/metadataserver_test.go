@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	backoff "github.com/cenkalti/backoff/v4"
 	"github.com/google/go-cmp/cmp"
@@ -47,7 +48,7 @@ func mockMetadataServer(t *testing.T, handler endpoint) *httptest.Server {
 			w.WriteHeader(403)
 			fmt.Fprint(w, "Metadata-flavor header missing")
 		}
-		if r.URL.Path != cloudPropertiesURI && r.URL.Path != maintenanceEventURI && r.URL.Path != upcomingMaintenanceURI && !strings.HasPrefix(r.URL.Path, diskType) {
+		if r.URL.Path != cloudPropertiesURI && r.URL.Path != maintenanceEventURI && r.URL.Path != upcomingMaintenanceURI && !strings.HasPrefix(r.URL.Path, instanceAttributesURI) && !strings.HasPrefix(r.URL.Path, diskType) {
 			w.WriteHeader(404)
 			fmt.Fprint(w, "404 Page not found")
 		}
@@ -580,3 +581,158 @@ window_start_time 2024-07-29T14:19:57+00:00`
 		}
 	}
 }
+
+func TestFetchAgentConfigOverride(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint endpoint
+		url      string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "Success",
+			endpoint: endpoint{uri: agentConfigURI, responseBody: `{"log_level": "DEBUG"}`},
+			want:     `{"log_level": "DEBUG"}`,
+		},
+		{
+			name:     "KeyNotSet",
+			endpoint: endpoint{uri: agentConfigURI, responseBody: "error"},
+			want:     "",
+		},
+		{
+			name:     "Error",
+			endpoint: endpoint{uri: agentConfigURI},
+			url:      "http://localhost:0",
+			wantErr:  cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := mockMetadataServer(t, test.endpoint)
+			defer ts.Close()
+			metadataServerURL = ts.URL
+			if test.url != "" {
+				metadataServerURL = test.url
+			}
+
+			got, err := FetchAgentConfigOverride()
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("FetchAgentConfigOverride() returned error: %v, want: %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("FetchAgentConfigOverride()=%q, want: %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFetchInstanceAttribute(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint endpoint
+		url      string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "Success",
+			endpoint: endpoint{uri: instanceAttributesURI + "cost-center", responseBody: "finance"},
+			want:     "finance",
+		},
+		{
+			name:     "KeyNotSet",
+			endpoint: endpoint{uri: instanceAttributesURI + "cost-center", responseBody: "error"},
+			want:     "",
+		},
+		{
+			name:     "Error",
+			endpoint: endpoint{uri: instanceAttributesURI + "cost-center"},
+			url:      "http://localhost:0",
+			wantErr:  cmpopts.AnyError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := mockMetadataServer(t, test.endpoint)
+			defer ts.Close()
+			metadataServerURL = ts.URL
+			if test.url != "" {
+				metadataServerURL = test.url
+			}
+
+			got, err := FetchInstanceAttribute("cost-center")
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("FetchInstanceAttribute() returned error: %v, want: %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("FetchInstanceAttribute()=%q, want: %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestClockSkew(t *testing.T) {
+	serverTime := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	tests := []struct {
+		name      string
+		date      string
+		status    int
+		localTime time.Time
+		want      time.Duration
+		wantErr   error
+	}{
+		{
+			name:      "NoSkew",
+			date:      serverTime.Format(http.TimeFormat),
+			status:    http.StatusOK,
+			localTime: serverTime.Add(500 * time.Millisecond),
+			want:      0,
+		},
+		{
+			name:      "LocalClockAhead",
+			date:      serverTime.Format(http.TimeFormat),
+			status:    http.StatusOK,
+			localTime: serverTime.Add(3500 * time.Millisecond),
+			want:      3 * time.Second,
+		},
+		{
+			name:      "LocalClockBehind",
+			date:      serverTime.Format(http.TimeFormat),
+			status:    http.StatusOK,
+			localTime: serverTime.Add(-2 * time.Second),
+			want:      -2500 * time.Millisecond,
+		},
+		{
+			name:    "InvalidDate",
+			date:    "yesterday",
+			status:  http.StatusOK,
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			name:    "UnsuccessfulResponse",
+			date:    serverTime.Format(http.TimeFormat),
+			status:  http.StatusInternalServerError,
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Date", test.date)
+				w.WriteHeader(test.status)
+			}))
+			defer ts.Close()
+
+			got, err := clockSkew(ts.URL, func() time.Time { return test.localTime })
+			if !cmp.Equal(err, test.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("clockSkew() returned error: %v, want: %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("clockSkew() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
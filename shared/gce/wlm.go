@@ -41,6 +41,12 @@ func NewWLMClient(ctx context.Context, basePath string) (*WLM, error) {
 	return &WLM{s}, nil
 }
 
+// WithCompression returns a WLM wrapper sending gzip compressed WriteInsight requests
+// through the same client.
+func (w *WLM) WithCompression() *WLM {
+	return &WLM{w.service.WithCompression()}
+}
+
 // WriteInsight wraps a call to the WLM insights:write API.
 func (w *WLM) WriteInsight(project string, location string, writeInsightRequest *dwpb.WriteInsightRequest) error {
 	res, err := w.service.WriteInsight(project, location, writeInsightRequest)
@@ -90,12 +90,23 @@ type (
 		LogFileName        string
 		LogFilePath        string
 		CloudLogName       string
+		// Rotation policy of the log file, zero values use the default limits.
+		MaxSizeMB  int
+		MaxAgeDays int
+		MaxBackups int
 	}
 	cloudWriter struct {
 		w io.Writer
 	}
 )
 
+// Default rotation limits of the log files.
+const (
+	defaultMaxSizeMB  = 25
+	defaultMaxAgeDays = 30
+	defaultMaxBackups = 3
+)
+
 // contextKeyType represents context key Service
 type contextKeyType string
 
@@ -150,11 +161,7 @@ func SetupLogging(params Parameters) {
 	config.EncodeTime = zapcore.ISO8601TimeEncoder
 	config.TimeKey = "timestamp"
 	logEncoder := zapcore.NewJSONEncoder(config)
-	fileOrPrintLogger := &lumberjack.Logger{
-		Filename:   params.LogFileName,
-		MaxSize:    25, // megabytes
-		MaxBackups: 3,
-	}
+	fileOrPrintLogger := newFileLogger(params)
 	_, err := fileOrPrintLogger.Write(make([]byte, 0))
 	fileOrPrintLogWriter := zapcore.AddSync(fileOrPrintLogger)
 	if err != nil {
@@ -185,6 +192,27 @@ func SetupLogging(params Parameters) {
 	Logger = coreLogger.Sugar()
 }
 
+// newFileLogger returns the rotating writer of the log file, applying the default limits to the
+// rotation settings which are not set.
+func newFileLogger(params Parameters) *lumberjack.Logger {
+	l := &lumberjack.Logger{
+		Filename:   params.LogFileName,
+		MaxSize:    params.MaxSizeMB, // megabytes
+		MaxAge:     params.MaxAgeDays,
+		MaxBackups: params.MaxBackups,
+	}
+	if l.MaxSize <= 0 {
+		l.MaxSize = defaultMaxSizeMB
+	}
+	if l.MaxAge <= 0 {
+		l.MaxAge = defaultMaxAgeDays
+	}
+	if l.MaxBackups <= 0 {
+		l.MaxBackups = defaultMaxBackups
+	}
+	return l
+}
+
 // StringLevelToZapcore returns the equivalent of the string log level. It defaults to info level
 // in case unknown log level is identified.
 func StringLevelToZapcore(level string) zapcore.Level {
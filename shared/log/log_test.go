@@ -21,6 +21,8 @@ import (
 
 	logging "cloud.google.com/go/logging"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/natefinch/lumberjack"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -117,6 +119,63 @@ func TestSetupLogging(t *testing.T) {
 	}
 }
 
+func TestNewFileLogger(t *testing.T) {
+	tests := []struct {
+		name   string
+		params Parameters
+		want   *lumberjack.Logger
+	}{
+		{
+			name:   "Defaults",
+			params: Parameters{LogFileName: "log-file"},
+			want: &lumberjack.Logger{
+				Filename:   "log-file",
+				MaxSize:    25,
+				MaxAge:     30,
+				MaxBackups: 3,
+			},
+		},
+		{
+			name: "Configured",
+			params: Parameters{
+				LogFileName: "log-file",
+				MaxSizeMB:   100,
+				MaxAgeDays:  7,
+				MaxBackups:  10,
+			},
+			want: &lumberjack.Logger{
+				Filename:   "log-file",
+				MaxSize:    100,
+				MaxAge:     7,
+				MaxBackups: 10,
+			},
+		},
+		{
+			name: "NegativeUsesDefaults",
+			params: Parameters{
+				LogFileName: "log-file",
+				MaxSizeMB:   -1,
+				MaxAgeDays:  -1,
+				MaxBackups:  -1,
+			},
+			want: &lumberjack.Logger{
+				Filename:   "log-file",
+				MaxSize:    25,
+				MaxAge:     30,
+				MaxBackups: 3,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := newFileLogger(tc.params)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreUnexported(lumberjack.Logger{})); diff != "" {
+				t.Errorf("newFileLogger(%v) returned an unexpected diff (-want +got): %v", tc.params, diff)
+			}
+		})
+	}
+}
+
 func TestSetupLoggingForOTE(t *testing.T) {
 	tests := []struct {
 		name           string
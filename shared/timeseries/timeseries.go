@@ -18,6 +18,8 @@ limitations under the License.
 package timeseries
 
 import (
+	"sync"
+
 	mpb "google.golang.org/genproto/googleapis/api/metric"
 	mrespb "google.golang.org/genproto/googleapis/api/monitoredres"
 	cpb "google.golang.org/genproto/googleapis/monitoring/v3"
@@ -51,6 +53,36 @@ type Params struct {
 	BoolValue    bool
 }
 
+var (
+	commonLabelsMu sync.RWMutex
+	commonLabels   map[string]string
+)
+
+// SetCommonLabels sets the labels added to the metric labels of every time series built by this
+// package. Labels already set on a time series are not overridden.
+func SetCommonLabels(labels map[string]string) {
+	commonLabelsMu.Lock()
+	defer commonLabelsMu.Unlock()
+	commonLabels = labels
+}
+
+// withCommonLabels returns the metric labels merged with the common labels.
+func withCommonLabels(labels map[string]string) map[string]string {
+	commonLabelsMu.RLock()
+	defer commonLabelsMu.RUnlock()
+	if len(commonLabels) == 0 {
+		return labels
+	}
+	merged := make(map[string]string, len(labels)+len(commonLabels))
+	for k, v := range commonLabels {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	return merged
+}
+
 // ConvertCloudProperties converts Cloud Properties proto to CloudProperties struct.
 func ConvertCloudProperties(cp *ipb.CloudProperties) *CloudProperties {
 	return &CloudProperties{
@@ -131,7 +163,7 @@ func buildTimeSeries(p Params) *mrpb.TimeSeries {
 	return &mrpb.TimeSeries{
 		Metric: &mpb.Metric{
 			Type:   p.MetricType,
-			Labels: p.MetricLabels,
+			Labels: withCommonLabels(p.MetricLabels),
 		},
 		MetricKind: p.MetricKind,
 		Resource:   monitoredResource(p.CloudProp, p.BareMetal),
@@ -181,6 +181,51 @@ func TestBuildFloat64(t *testing.T) {
 	}
 }
 
+func TestCommonLabels(t *testing.T) {
+	tests := []struct {
+		name         string
+		commonLabels map[string]string
+		metricLabels map[string]string
+		want         map[string]string
+	}{
+		{
+			name:         "NoCommonLabels",
+			metricLabels: mLabels,
+			want:         mLabels,
+		},
+		{
+			name:         "CommonLabelsAdded",
+			commonLabels: map[string]string{"cost_center": "finance"},
+			metricLabels: mLabels,
+			want:         map[string]string{"key1": "value1", "key2": "value2", "cost_center": "finance"},
+		},
+		{
+			name:         "MetricLabelsTakePrecedence",
+			commonLabels: map[string]string{"key1": "common"},
+			metricLabels: mLabels,
+			want:         mLabels,
+		},
+		{
+			name:         "NoMetricLabels",
+			commonLabels: map[string]string{"cost_center": "finance"},
+			want:         map[string]string{"cost_center": "finance"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			SetCommonLabels(test.commonLabels)
+			defer SetCommonLabels(nil)
+			got := BuildInt(Params{CloudProp: defaultCloudProperties, MetricType: mType, MetricLabels: test.metricLabels, Timestamp: now})
+			if diff := cmp.Diff(test.want, got.GetMetric().GetLabels()); diff != "" {
+				t.Errorf("BuildInt() returned unexpected metric labels (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(map[string]string{"key1": "value1", "key2": "value2"}, mLabels); diff != "" {
+				t.Errorf("BuildInt() modified the metric labels passed in (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestMonitoredResource(t *testing.T) {
 	tests := []struct {
 		name       string